@@ -0,0 +1,251 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary compat_checker reports the changes between two revisions of a set
+// of YANG modules that are breaking or non-breaking for clients of the YANG
+// schema, and for users of the Go code generated for it by ygen.
+//
+// Each revision is specified either by a set of YANG modules, or by a schema
+// snapshot that was previously written by compat_checker using the
+// write_schema flag. For example:
+//
+//	compat_checker -old_modules=old/a.yang -old_path=old \
+//	  -new_modules=new/a.yang -new_path=new
+//
+// compat_checker exits with a non-zero status if a breaking change is found.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	log "github.com/golang/glog"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/ycompat"
+	"github.com/openconfig/ygot/ygen"
+)
+
+var (
+	oldModules   = flag.String("old_modules", "", "Comma separated set of YANG files of the old revision.")
+	oldPath      = flag.String("old_path", "", "Comma separated list of paths to be recursively searched for modules included by the old revision.")
+	oldSchema    = flag.String("old_schema", "", "A schema snapshot, written using write_schema, of the old revision. Used instead of old_modules.")
+	newModules   = flag.String("new_modules", "", "Comma separated set of YANG files of the new revision.")
+	newPath      = flag.String("new_path", "", "Comma separated list of paths to be recursively searched for modules included by the new revision.")
+	newSchema    = flag.String("new_schema", "", "A schema snapshot, written using write_schema, of the new revision. Used instead of new_modules.")
+	writeSchema  = flag.String("write_schema", "", "If set, the schema snapshot of the modules specified as arguments, with included modules searched for in path, is written to the specified file, rather than comparing two revisions. Specify \"-\" for stdout.")
+	yangPaths    = flag.String("path", "", "Comma separated list of paths to be recursively searched for included modules when write_schema is specified.")
+	breakingOnly = flag.Bool("breaking_only", false, "If set to true, only breaking changes are reported.")
+
+	// Flags that determine the Go code whose API is compared, which have the
+	// same meaning as those of the generator binary.
+	compressPaths                        = flag.Bool("compress_paths", true, "If set to true, the schema's paths are compressed, according to OpenConfig YANG module conventions. Only compressed paths are currently supported.")
+	excludeState                         = flag.Bool("exclude_state", false, "If set to true, state (config false) fields in the YANG schema are not included in the generated Go code.")
+	preferOperationalState               = flag.Bool("prefer_operational_state", false, "If set to true, state (config false) fields in the YANG schema are preferred over intended config leaves in the generated Go code with compressed schema paths.")
+	excludeModules                       = flag.String("exclude_modules", "", "Comma separated set of module names that should be excluded from code generation.")
+	ignoreCircDeps                       = flag.Bool("ignore_circdeps", false, "If set to true, circular dependencies between submodules are ignored.")
+	generateFakeRoot                     = flag.Bool("generate_fakeroot", false, "If set to true, a fake element at the root of the data tree is generated.")
+	fakeRootName                         = flag.String("fakeroot_name", "", "The name of the fake root entity.")
+	skipEnumDedup                        = flag.Bool("skip_enum_deduplication", false, "If set to true, all leaves of type enumeration will have a unique enum output for them, rather than sharing a common type.")
+	shortenEnumLeafNames                 = flag.Bool("shorten_enum_leaf_names", false, "If set to true, all leaves of type enumeration will by default not be prefixed with the name of its residing module.")
+	useDefiningModuleForTypedefEnumNames = flag.Bool("typedef_enum_with_defmod", false, "If set to true, all typedefs of type enumeration or identity will be prefixed with the name of its module of definition instead of its residing module.")
+	appendEnumSuffixForSimpleUnionEnums  = flag.Bool("enum_suffix_for_simple_union_enums", false, "If set to true when typedef_enum_with_defmod is also true, all inlined enumerations within unions will be suffixed with \"Enum\".")
+	trimEnumOpenConfigPrefix             = flag.Bool("trim_enum_openconfig_prefix", false, `If set to true, the organizational prefix "openconfig-" is trimmed from the module part of the name of enumerated names.`)
+)
+
+// compareFlags is the set of flags that are specific to comparing two
+// revisions, and are hence not passed to the process that loads the old
+// revision.
+var compareFlags = map[string]bool{
+	"old_modules":   true,
+	"old_path":      true,
+	"old_schema":    true,
+	"new_modules":   true,
+	"new_path":      true,
+	"new_schema":    true,
+	"write_schema":  true,
+	"path":          true,
+	"breaking_only": true,
+}
+
+// splitList splits the comma-separated list s, returning nil if s is empty.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// moduleSet returns the ModuleSet for the supplied comma-separated set of
+// module files and include paths, where each include path is searched
+// recursively.
+func moduleSet(files []string, paths string) ycompat.ModuleSet {
+	ms := ycompat.ModuleSet{Files: files}
+	for _, p := range splitList(paths) {
+		ms.IncludePaths = append(ms.IncludePaths, filepath.Join(p, "..."))
+	}
+	return ms
+}
+
+// generatorConfig returns the ygen configuration specified by the flags.
+func generatorConfig() (*ygen.DirectoryGenConfig, error) {
+	compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
+	if err != nil {
+		return nil, err
+	}
+	var enumOrgPrefixesToTrim []string
+	if *compressPaths && *trimEnumOpenConfigPrefix {
+		enumOrgPrefixesToTrim = []string{"openconfig"}
+	}
+	return &ygen.DirectoryGenConfig{
+		ParseOptions: ygen.ParseOpts{
+			ExcludeModules:        splitList(*excludeModules),
+			SkipEnumDeduplication: *skipEnumDedup,
+			YANGParseOptions: yang.Options{
+				IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
+			},
+		},
+		TransformationOptions: ygen.TransformationOpts{
+			CompressBehaviour:                    compressBehaviour,
+			GenerateFakeRoot:                     *generateFakeRoot,
+			FakeRootName:                         *fakeRootName,
+			ShortenEnumLeafNames:                 *shortenEnumLeafNames,
+			EnumOrgPrefixesToTrim:                enumOrgPrefixesToTrim,
+			UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
+		},
+		GoOptions: ygen.GoOpts{
+			AppendEnumSuffixForSimpleUnionEnums: *appendEnumSuffixForSimpleUnionEnums,
+		},
+	}, nil
+}
+
+// readSchema reads the schema snapshot in the file fn.
+func readSchema(fn string) (*ycompat.Schema, error) {
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	s := &ycompat.Schema{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("invalid schema snapshot %s: %v", fn, err)
+	}
+	return s, nil
+}
+
+// loadInSubprocess loads the schema for the supplied modules by running the
+// current binary with the write_schema flag. Since goyang retains the
+// identities of the modules that it has parsed, this ensures that the schema
+// is not affected by the modules of the other revision.
+func loadInSubprocess(modules []string, paths string) (*ycompat.Schema, error) {
+	bin, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("cannot find compat_checker binary: %v", err)
+	}
+	f, err := ioutil.TempFile("", "compat_checker")
+	if err != nil {
+		return nil, err
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	args := []string{fmt.Sprintf("-write_schema=%s", f.Name()), fmt.Sprintf("-path=%s", paths)}
+	flag.Visit(func(fl *flag.Flag) {
+		if !compareFlags[fl.Name] {
+			args = append(args, fmt.Sprintf("-%s=%s", fl.Name, fl.Value))
+		}
+	})
+	cmd := exec.Command(bin, append(args, modules...)...)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("cannot load modules %v: %v", modules, err)
+	}
+	return readSchema(f.Name())
+}
+
+// main parses command-line flags to determine the revisions of the YANG
+// modules that are to be compared, and writes the changes between them to
+// stdout.
+func main() {
+	flag.Parse()
+	cfg, err := generatorConfig()
+	if err != nil {
+		log.Exitf("Error: %v", err)
+	}
+
+	if *writeSchema != "" {
+		if len(flag.Args()) == 0 {
+			log.Exitln("Error: no input modules specified")
+		}
+		s, err := ycompat.Load(moduleSet(flag.Args(), *yangPaths), cfg)
+		if err != nil {
+			log.Exitf("Error: cannot load modules: %v", err)
+		}
+		b, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			log.Exitf("Error: cannot marshal schema: %v", err)
+		}
+		outfh := os.Stdout
+		if *writeSchema != "-" {
+			outfh = genutil.OpenFile(*writeSchema)
+			defer genutil.SyncFile(outfh)
+		}
+		outfh.Write(b)
+		return
+	}
+
+	var oldS, newS *ycompat.Schema
+	switch {
+	case *oldSchema != "":
+		oldS, err = readSchema(*oldSchema)
+	case *oldModules != "":
+		// The new revision may be loaded within this process, hence the
+		// old revision is loaded in a separate process.
+		oldS, err = loadInSubprocess(splitList(*oldModules), *oldPath)
+	default:
+		log.Exitln("Error: one of old_modules or old_schema must be specified")
+	}
+	if err != nil {
+		log.Exitf("Error: cannot load old revision: %v", err)
+	}
+
+	switch {
+	case *newSchema != "":
+		newS, err = readSchema(*newSchema)
+	case *newModules != "":
+		newS, err = ycompat.Load(moduleSet(splitList(*newModules), *newPath), cfg)
+	default:
+		log.Exitln("Error: one of new_modules or new_schema must be specified")
+	}
+	if err != nil {
+		log.Exitf("Error: cannot load new revision: %v", err)
+	}
+
+	report := ycompat.Compare(oldS, newS)
+	changes := report.Changes
+	if *breakingOnly {
+		changes = report.Breaking()
+	}
+	for _, c := range changes {
+		fmt.Println(c)
+	}
+	if report.HasBreaking() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,417 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/ygen"
+	"github.com/openconfig/ygot/ypathgen"
+)
+
+// config is the configuration of a run of the generator. It can be read from
+// a YAML or JSON file specified by the config_file flag, whose keys are the
+// names of the fields of config. The Generator and PathGenerator fields map
+// one-to-one to the ygen and ypathgen configuration structs.
+type config struct {
+	// Modules is the set of YANG modules for which code is generated.
+	// Modules that are specified as arguments to the generator are
+	// appended to this set.
+	Modules []string
+	// IncludePaths is the set of paths that are recursively searched for
+	// included modules or submodules within the YANG modules.
+	IncludePaths []string
+	// GenerateStructs specifies whether schema structs are generated.
+	GenerateStructs bool
+	// GeneratePathStructs specifies whether path structs are generated.
+	GeneratePathStructs bool
+	// OutputFile is the file that schema structs are written to, "-"
+	// specifies stdout.
+	OutputFile string
+	// StructsSplitFilesCount is the number of files that schema structs
+	// are split into when OutputDir is specified.
+	StructsSplitFilesCount int
+	// PathStructsOutputFile is the file that path structs are written to,
+	// "-" specifies stdout.
+	PathStructsOutputFile string
+	// PathStructsSplitFilesCount is the number of files that path structs
+	// are split into when OutputDir is specified.
+	PathStructsSplitFilesCount int
+	// OutputDir is the directory that generated code is written to.
+	OutputDir string
+	// Generator is the configuration used for schema struct generation.
+	Generator ygen.GeneratorConfig
+	// PathGenerator is the configuration used for path struct generation.
+	PathGenerator ypathgen.GenConfig
+}
+
+// configFromFlags returns the configuration that is specified by the values
+// of the generator's flags, where set is the set of names of the flags that
+// were explicitly specified.
+func configFromFlags(set map[string]bool) (*config, error) {
+	compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
+	if err != nil {
+		return nil, err
+	}
+
+	var enumOrgPrefixesToTrim []string
+	if *compressPaths && *trimEnumOpenConfigPrefix {
+		// No organization name is trimmed if compress paths is false.
+		enumOrgPrefixesToTrim = []string{"openconfig"}
+	}
+
+	// The enabled features are only filtered when the flag is explicitly
+	// specified, such that an empty value can be used to disable all
+	// features.
+	var featuresEnabled []string
+	if set["enabled_features"] {
+		featuresEnabled = []string{}
+		if *enabledFeatures != "" {
+			featuresEnabled = strings.Split(*enabledFeatures, ",")
+		}
+	}
+
+	modsExcluded := genutil.SplitList(*excludeModules)
+	deviationFiles := genutil.SplitList(*deviationModules)
+
+	return &config{
+		IncludePaths:               genutil.SplitList(*yangPaths),
+		GenerateStructs:            *generateGoStructs,
+		GeneratePathStructs:        *generatePathStructs,
+		OutputFile:                 *ocStructsOutputFile,
+		StructsSplitFilesCount:     *structsFileN,
+		PathStructsOutputFile:      *ocPathStructsOutputFile,
+		PathStructsSplitFilesCount: *pathStructsFileN,
+		OutputDir:                  *outputDir,
+		Generator: ygen.GeneratorConfig{
+			ParseOptions: ygen.ParseOpts{
+				ExcludeModules:            modsExcluded,
+				SkipEnumDeduplication:     *skipEnumDedup,
+				GenerateRPCTypes:          *generateRPCTypes,
+				GenerateNotificationTypes: *generateNotifTypes,
+				EnabledFeatures:           featuresEnabled,
+				DeviationModules:          deviationFiles,
+				YANGParseOptions: yang.Options{
+					IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
+				},
+			},
+			TransformationOptions: ygen.TransformationOpts{
+				CompressBehaviour:                    compressBehaviour,
+				IgnoreShadowSchemaPaths:              *ignoreShadowSchemaPaths,
+				GenerateFakeRoot:                     *generateFakeRoot,
+				FakeRootName:                         *fakeRootName,
+				ShortenEnumLeafNames:                 *shortenEnumLeafNames,
+				EnumOrgPrefixesToTrim:                enumOrgPrefixesToTrim,
+				UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
+			},
+			PackageName:         *packageName,
+			GenerateJSONSchema:  *generateSchema,
+			IncludeDescriptions: *includeDescriptions,
+			GoOptions: ygen.GoOpts{
+				YgotImportPath:                      *ygotImportPath,
+				YtypesImportPath:                    *ytypesImportPath,
+				GoyangImportPath:                    *goyangImportPath,
+				GenerateRenameMethod:                *generateRename,
+				AddAnnotationFields:                 *addAnnotations,
+				AnnotationPrefix:                    *annotationPrefix,
+				GenerateGetters:                     *generateGetters,
+				GenerateDeleteMethod:                *generateDelete,
+				GenerateAppendMethod:                *generateAppend,
+				GenerateLeafGetters:                 *generateLeafGetters,
+				GenerateLeafSetters:                 *generateLeafSetters,
+				GenerateListEntryConstructors:       *generateListConstructors,
+				GenerateSimpleUnions:                *generateSimpleUnions,
+				GenerateGenericUnions:               *generateGenericUnions,
+				IncludeModelData:                    *includeModelData,
+				AppendEnumSuffixForSimpleUnionEnums: *appendEnumSuffixForSimpleUnionEnums,
+				GenerateDeepCopyMethod:              *generateDeepCopy,
+				GenerateEqualMethod:                 *generateEqual,
+				GenerateOrderedMaps:                 *generateOrderedMaps,
+			},
+		},
+		PathGenerator: ypathgen.GenConfig{
+			PackageName: *packageName,
+			GoImports: ypathgen.GoImports{
+				SchemaStructPkgPath: *schemaStructPath,
+				YgotImportPath:      *ygotImportPath,
+				YtypesImportPath:    *ytypesImportPath,
+			},
+			PreferOperationalState:               *preferOperationalState,
+			ExcludeState:                         *excludeState,
+			SkipEnumDeduplication:                *skipEnumDedup,
+			ShortenEnumLeafNames:                 *shortenEnumLeafNames,
+			EnumOrgPrefixesToTrim:                enumOrgPrefixesToTrim,
+			UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
+			AppendEnumSuffixForSimpleUnionEnums:  *appendEnumSuffixForSimpleUnionEnums,
+			FakeRootName:                         *fakeRootName,
+			PathStructSuffix:                     *pathStructSuffix,
+			ExcludeModules:                       modsExcluded,
+			EnabledFeatures:                      featuresEnabled,
+			DeviationModules:                     deviationFiles,
+			YANGParseOptions: yang.Options{
+				IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
+			},
+			GeneratingBinary:         genutil.CallerName(),
+			ListBuilderKeyThreshold:  *listBuilderKeyThreshold,
+			GenerateWildcardPaths:    *generateWildcardPaths,
+			GenerateTypedLeafPaths:   *generateTypedLeafPaths,
+			GenerateParsePath:        *generateParsePath,
+			GenerateDescendantPaths:  *generateDescendantPaths,
+			GenerateConfigStatePaths: *generateConfigStatePaths,
+			GenerateOperationPaths:   *generateOperationPaths,
+			SimplifyWildcardPaths:    *simplifyWildcardPaths,
+			TrimOCPackage:            *trimOCPackage,
+			SplitByModule:            *splitByModule,
+			BaseImportPath:           *baseImportPath,
+			PackageSuffix:            *packageSuffix,
+		},
+	}, nil
+}
+
+// flagFields maps the name of each flag to a function which copies the
+// fields of the configuration that the flag specifies from src to dst. It is
+// used to override the values of a configuration file with those of the
+// flags that are explicitly specified. The flags that determine the
+// compression behaviour, and the trimming of enumeration names, are handled
+// by overrideConfig since they depend on one another.
+var flagFields = genutil.FlagFields[config]{
+	"generate_structs": func(d, s *config) {
+		d.GenerateStructs = s.GenerateStructs
+	},
+	"generate_path_structs": func(d, s *config) {
+		d.GeneratePathStructs = s.GeneratePathStructs
+	},
+	"output_file": func(d, s *config) {
+		d.OutputFile = s.OutputFile
+	},
+	"structs_split_files_count": func(d, s *config) {
+		d.StructsSplitFilesCount = s.StructsSplitFilesCount
+	},
+	"path_structs_output_file": func(d, s *config) {
+		d.PathStructsOutputFile = s.PathStructsOutputFile
+	},
+	"path_structs_split_files_count": func(d, s *config) {
+		d.PathStructsSplitFilesCount = s.PathStructsSplitFilesCount
+	},
+	"output_dir": func(d, s *config) {
+		d.OutputDir = s.OutputDir
+	},
+	"path": func(d, s *config) {
+		d.IncludePaths = s.IncludePaths
+	},
+	"exclude_modules": func(d, s *config) {
+		d.Generator.ParseOptions.ExcludeModules = s.Generator.ParseOptions.ExcludeModules
+		d.PathGenerator.ExcludeModules = s.PathGenerator.ExcludeModules
+	},
+	"package_name": func(d, s *config) {
+		d.Generator.PackageName = s.Generator.PackageName
+		d.PathGenerator.PackageName = s.PathGenerator.PackageName
+	},
+	"ignore_circdeps": func(d, s *config) {
+		d.Generator.ParseOptions.YANGParseOptions.IgnoreSubmoduleCircularDependencies = s.Generator.ParseOptions.YANGParseOptions.IgnoreSubmoduleCircularDependencies
+		d.PathGenerator.YANGParseOptions.IgnoreSubmoduleCircularDependencies = s.PathGenerator.YANGParseOptions.IgnoreSubmoduleCircularDependencies
+	},
+	"fakeroot_name": func(d, s *config) {
+		d.Generator.TransformationOptions.FakeRootName = s.Generator.TransformationOptions.FakeRootName
+		d.PathGenerator.FakeRootName = s.PathGenerator.FakeRootName
+	},
+	"skip_enum_deduplication": func(d, s *config) {
+		d.Generator.ParseOptions.SkipEnumDeduplication = s.Generator.ParseOptions.SkipEnumDeduplication
+		d.PathGenerator.SkipEnumDeduplication = s.PathGenerator.SkipEnumDeduplication
+	},
+	"ignore_shadow_schema_paths": func(d, s *config) {
+		d.Generator.TransformationOptions.IgnoreShadowSchemaPaths = s.Generator.TransformationOptions.IgnoreShadowSchemaPaths
+	},
+	"shorten_enum_leaf_names": func(d, s *config) {
+		d.Generator.TransformationOptions.ShortenEnumLeafNames = s.Generator.TransformationOptions.ShortenEnumLeafNames
+		d.PathGenerator.ShortenEnumLeafNames = s.PathGenerator.ShortenEnumLeafNames
+	},
+	"typedef_enum_with_defmod": func(d, s *config) {
+		d.Generator.TransformationOptions.UseDefiningModuleForTypedefEnumNames = s.Generator.TransformationOptions.UseDefiningModuleForTypedefEnumNames
+		d.PathGenerator.UseDefiningModuleForTypedefEnumNames = s.PathGenerator.UseDefiningModuleForTypedefEnumNames
+	},
+	"enum_suffix_for_simple_union_enums": func(d, s *config) {
+		d.Generator.GoOptions.AppendEnumSuffixForSimpleUnionEnums = s.Generator.GoOptions.AppendEnumSuffixForSimpleUnionEnums
+		d.PathGenerator.AppendEnumSuffixForSimpleUnionEnums = s.PathGenerator.AppendEnumSuffixForSimpleUnionEnums
+	},
+	"ygot_path": func(d, s *config) {
+		d.Generator.GoOptions.YgotImportPath = s.Generator.GoOptions.YgotImportPath
+		d.PathGenerator.GoImports.YgotImportPath = s.PathGenerator.GoImports.YgotImportPath
+	},
+	"include_descriptions": func(d, s *config) {
+		d.Generator.IncludeDescriptions = s.Generator.IncludeDescriptions
+	},
+	"enabled_features": func(d, s *config) {
+		d.Generator.ParseOptions.EnabledFeatures = s.Generator.ParseOptions.EnabledFeatures
+		d.PathGenerator.EnabledFeatures = s.PathGenerator.EnabledFeatures
+	},
+	"deviation_modules": func(d, s *config) {
+		d.Generator.ParseOptions.DeviationModules = s.Generator.ParseOptions.DeviationModules
+		d.PathGenerator.DeviationModules = s.PathGenerator.DeviationModules
+	},
+	"generate_fakeroot": func(d, s *config) {
+		d.Generator.TransformationOptions.GenerateFakeRoot = s.Generator.TransformationOptions.GenerateFakeRoot
+	},
+	"include_schema": func(d, s *config) {
+		d.Generator.GenerateJSONSchema = s.Generator.GenerateJSONSchema
+	},
+	"ytypes_path": func(d, s *config) {
+		d.Generator.GoOptions.YtypesImportPath = s.Generator.GoOptions.YtypesImportPath
+		d.PathGenerator.GoImports.YtypesImportPath = s.PathGenerator.GoImports.YtypesImportPath
+	},
+	"goyang_path": func(d, s *config) {
+		d.Generator.GoOptions.GoyangImportPath = s.Generator.GoOptions.GoyangImportPath
+	},
+	"generate_rename": func(d, s *config) {
+		d.Generator.GoOptions.GenerateRenameMethod = s.Generator.GoOptions.GenerateRenameMethod
+	},
+	"annotations": func(d, s *config) {
+		d.Generator.GoOptions.AddAnnotationFields = s.Generator.GoOptions.AddAnnotationFields
+	},
+	"annotation_prefix": func(d, s *config) {
+		d.Generator.GoOptions.AnnotationPrefix = s.Generator.GoOptions.AnnotationPrefix
+	},
+	"generate_append": func(d, s *config) {
+		d.Generator.GoOptions.GenerateAppendMethod = s.Generator.GoOptions.GenerateAppendMethod
+	},
+	"generate_getters": func(d, s *config) {
+		d.Generator.GoOptions.GenerateGetters = s.Generator.GoOptions.GenerateGetters
+	},
+	"generate_delete": func(d, s *config) {
+		d.Generator.GoOptions.GenerateDeleteMethod = s.Generator.GoOptions.GenerateDeleteMethod
+	},
+	"generate_leaf_getters": func(d, s *config) {
+		d.Generator.GoOptions.GenerateLeafGetters = s.Generator.GoOptions.GenerateLeafGetters
+	},
+	"generate_leaf_setters": func(d, s *config) {
+		d.Generator.GoOptions.GenerateLeafSetters = s.Generator.GoOptions.GenerateLeafSetters
+	},
+	"generate_list_constructors": func(d, s *config) {
+		d.Generator.GoOptions.GenerateListEntryConstructors = s.Generator.GoOptions.GenerateListEntryConstructors
+	},
+	"generate_simple_unions": func(d, s *config) {
+		d.Generator.GoOptions.GenerateSimpleUnions = s.Generator.GoOptions.GenerateSimpleUnions
+	},
+	"generate_generic_unions": func(d, s *config) {
+		d.Generator.GoOptions.GenerateGenericUnions = s.Generator.GoOptions.GenerateGenericUnions
+	},
+	"generate_deepcopy": func(d, s *config) {
+		d.Generator.GoOptions.GenerateDeepCopyMethod = s.Generator.GoOptions.GenerateDeepCopyMethod
+	},
+	"generate_equal": func(d, s *config) {
+		d.Generator.GoOptions.GenerateEqualMethod = s.Generator.GoOptions.GenerateEqualMethod
+	},
+	"generate_ordered_maps": func(d, s *config) {
+		d.Generator.GoOptions.GenerateOrderedMaps = s.Generator.GoOptions.GenerateOrderedMaps
+	},
+	"generate_rpc_types": func(d, s *config) {
+		d.Generator.ParseOptions.GenerateRPCTypes = s.Generator.ParseOptions.GenerateRPCTypes
+	},
+	"generate_notification_types": func(d, s *config) {
+		d.Generator.ParseOptions.GenerateNotificationTypes = s.Generator.ParseOptions.GenerateNotificationTypes
+	},
+	"include_model_data": func(d, s *config) {
+		d.Generator.GoOptions.IncludeModelData = s.Generator.GoOptions.IncludeModelData
+	},
+	"schema_struct_path": func(d, s *config) {
+		d.PathGenerator.GoImports.SchemaStructPkgPath = s.PathGenerator.GoImports.SchemaStructPkgPath
+	},
+	"generate_wildcard_paths": func(d, s *config) {
+		d.PathGenerator.GenerateWildcardPaths = s.PathGenerator.GenerateWildcardPaths
+	},
+	"generate_typed_leaf_paths": func(d, s *config) {
+		d.PathGenerator.GenerateTypedLeafPaths = s.PathGenerator.GenerateTypedLeafPaths
+	},
+	"generate_parse_path": func(d, s *config) {
+		d.PathGenerator.GenerateParsePath = s.PathGenerator.GenerateParsePath
+	},
+	"generate_descendant_paths": func(d, s *config) {
+		d.PathGenerator.GenerateDescendantPaths = s.PathGenerator.GenerateDescendantPaths
+	},
+	"generate_config_state_paths": func(d, s *config) {
+		d.PathGenerator.GenerateConfigStatePaths = s.PathGenerator.GenerateConfigStatePaths
+	},
+	"generate_operation_paths": func(d, s *config) {
+		d.PathGenerator.GenerateOperationPaths = s.PathGenerator.GenerateOperationPaths
+	},
+	"simplify_wildcard_paths": func(d, s *config) {
+		d.PathGenerator.SimplifyWildcardPaths = s.PathGenerator.SimplifyWildcardPaths
+	},
+	"list_builder_key_threshold": func(d, s *config) {
+		d.PathGenerator.ListBuilderKeyThreshold = s.PathGenerator.ListBuilderKeyThreshold
+	},
+	"path_struct_suffix": func(d, s *config) {
+		d.PathGenerator.PathStructSuffix = s.PathGenerator.PathStructSuffix
+	},
+	"split_pathstructs_by_module": func(d, s *config) {
+		d.PathGenerator.SplitByModule = s.PathGenerator.SplitByModule
+	},
+	"trim_path_package_oc_prefix": func(d, s *config) {
+		d.PathGenerator.TrimOCPackage = s.PathGenerator.TrimOCPackage
+	},
+	"base_import_path": func(d, s *config) {
+		d.PathGenerator.BaseImportPath = s.PathGenerator.BaseImportPath
+	},
+	"path_struct_package_suffix": func(d, s *config) {
+		d.PathGenerator.PackageSuffix = s.PathGenerator.PackageSuffix
+	},
+}
+
+// overrideConfig overrides the values of dst, which is typically read from a
+// configuration file, with the values in src, which is derived from flags,
+// for each flag whose name is in set.
+func overrideConfig(dst, src *config, set map[string]bool) error {
+	flagFields.Override(dst, src, set)
+
+	cb, err := genutil.OverrideCompressBehaviour(dst.Generator.TransformationOptions.CompressBehaviour, set, *compressPaths, *excludeState, *preferOperationalState)
+	if err != nil {
+		return err
+	}
+	dst.Generator.TransformationOptions.CompressBehaviour = cb
+	if set["compress_paths"] || set["exclude_state"] || set["prefer_operational_state"] {
+		dst.PathGenerator.ExcludeState = cb.StateExcluded()
+		dst.PathGenerator.PreferOperationalState = cb == genutil.PreferOperationalState
+	}
+
+	if set["trim_enum_openconfig_prefix"] {
+		var prefixes []string
+		if *trimEnumOpenConfigPrefix && dst.Generator.TransformationOptions.CompressBehaviour.CompressEnabled() {
+			prefixes = []string{"openconfig"}
+		}
+		dst.Generator.TransformationOptions.EnumOrgPrefixesToTrim = prefixes
+		dst.PathGenerator.EnumOrgPrefixesToTrim = prefixes
+	}
+	return nil
+}
+
+// loadConfig returns the effective configuration of the generator. If
+// configFile is non-empty, the configuration file is read, with defaults
+// taken from the flags, and the flags in set then override its values.
+// Otherwise, the configuration is specified by the flags alone. The supplied
+// args are appended to the configuration's modules.
+func loadConfig(configFile string, set map[string]bool, args []string) (*config, error) {
+	cfg, err := genutil.LoadConfig(configFile, func() (*config, error) {
+		return configFromFlags(set)
+	}, func(dst, src *config) error {
+		return overrideConfig(dst, src, set)
+	})
+	if err != nil {
+		return nil, err
+	}
+	cfg.Modules = append(cfg.Modules, args...)
+	return cfg, nil
+}
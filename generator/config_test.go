@@ -0,0 +1,257 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/genutil"
+)
+
+// setTestFlags sets the named flags to the supplied values for the duration of
+// the test, returning the set of names of the flags that were set.
+func setTestFlags(t *testing.T, flags map[string]string) map[string]bool {
+	t.Helper()
+	set := map[string]bool{}
+	for name, value := range flags {
+		f := flag.Lookup(name)
+		if f == nil {
+			t.Fatalf("unknown flag %s", name)
+		}
+		def := f.DefValue
+		if err := flag.Set(name, value); err != nil {
+			t.Fatalf("cannot set flag %s to %s: %v", name, value, err)
+		}
+		t.Cleanup(func() { flag.Set(name, def) })
+		set[name] = true
+	}
+	return set
+}
+
+// TestFlagFields checks that each flag that specifies a field of the
+// configuration can override the value of a configuration file.
+func TestFlagFields(t *testing.T) {
+	notConfig := map[string]bool{
+		// Flags that control how the configuration is read.
+		"config_file":  true,
+		"print_config": true,
+		// Flags that are handled by overrideConfig.
+		"compress_paths":              true,
+		"exclude_state":               true,
+		"prefer_operational_state":    true,
+		"trim_enum_openconfig_prefix": true,
+		// Flags defined by glog.
+		"alsologtostderr":  true,
+		"log_backtrace_at": true,
+		"log_dir":          true,
+		"logtostderr":      true,
+		"stderrthreshold":  true,
+		"v":                true,
+		"vmodule":          true,
+	}
+	flag.VisitAll(func(f *flag.Flag) {
+		if notConfig[f.Name] || strings.HasPrefix(f.Name, "test.") {
+			return
+		}
+		if _, ok := flagFields[f.Name]; !ok {
+			t.Errorf("flag %s does not override any field of the configuration", f.Name)
+		}
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "generator-config")
+	if err != nil {
+		t.Fatalf("cannot create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	configFile := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(configFile, []byte(`
+Modules: [a.yang]
+IncludePaths: [models]
+OutputFile: file.go
+Generator:
+  PackageName: fromfile
+  TransformationOptions:
+    CompressBehaviour: ExcludeDerivedState
+  GoOptions:
+    GenerateGetters: true
+PathGenerator:
+  PackageName: fromfile
+  ExcludeState: true
+`), 0644); err != nil {
+		t.Fatalf("cannot write config file: %v", err)
+	}
+
+	tests := []struct {
+		desc             string
+		inConfigFile     string
+		inFlags          map[string]string
+		inArgs           []string
+		check            func(t *testing.T, c *config)
+		wantErrSubstring string
+	}{{
+		desc:    "flags only",
+		inFlags: map[string]string{"compress_paths": "true", "trim_enum_openconfig_prefix": "true", "enabled_features": ""},
+		inArgs:  []string{"b.yang"},
+		check: func(t *testing.T, c *config) {
+			if diff := cmp.Diff([]string{"b.yang"}, c.Modules); diff != "" {
+				t.Errorf("Modules: (-want, +got):\n%s", diff)
+			}
+			if got, want := c.Generator.TransformationOptions.CompressBehaviour, genutil.PreferIntendedConfig; got != want {
+				t.Errorf("CompressBehaviour: got %v, want %v", got, want)
+			}
+			if diff := cmp.Diff([]string{"openconfig"}, c.PathGenerator.EnumOrgPrefixesToTrim); diff != "" {
+				t.Errorf("EnumOrgPrefixesToTrim: (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]string{}, c.Generator.ParseOptions.EnabledFeatures); diff != "" {
+				t.Errorf("EnabledFeatures: (-want, +got):\n%s", diff)
+			}
+		},
+	}, {
+		desc:         "config file with defaults from flags",
+		inConfigFile: configFile,
+		inArgs:       []string{"b.yang"},
+		check: func(t *testing.T, c *config) {
+			if diff := cmp.Diff([]string{"a.yang", "b.yang"}, c.Modules); diff != "" {
+				t.Errorf("Modules: (-want, +got):\n%s", diff)
+			}
+			if got, want := c.Generator.PackageName, "fromfile"; got != want {
+				t.Errorf("PackageName: got %s, want %s", got, want)
+			}
+			if got, want := c.Generator.TransformationOptions.CompressBehaviour, genutil.ExcludeDerivedState; got != want {
+				t.Errorf("CompressBehaviour: got %v, want %v", got, want)
+			}
+			if !c.Generator.GoOptions.GenerateGetters {
+				t.Errorf("GenerateGetters: got false, want true")
+			}
+			// Values not specified in the file are the defaults of the flags.
+			if got, want := c.Generator.GoOptions.YgotImportPath, genutil.GoDefaultYgotImportPath; got != want {
+				t.Errorf("YgotImportPath: got %s, want %s", got, want)
+			}
+			if !c.GenerateStructs || !c.Generator.GenerateJSONSchema {
+				t.Errorf("GenerateStructs, GenerateJSONSchema: got false, want true")
+			}
+		},
+	}, {
+		desc:         "flags override config file",
+		inConfigFile: configFile,
+		inFlags: map[string]string{
+			"package_name":     "fromflag",
+			"exclude_state":    "false",
+			"path":             "x,y",
+			"generate_getters": "false",
+		},
+		check: func(t *testing.T, c *config) {
+			if got, want := c.Generator.PackageName, "fromflag"; got != want {
+				t.Errorf("Generator.PackageName: got %s, want %s", got, want)
+			}
+			if got, want := c.PathGenerator.PackageName, "fromflag"; got != want {
+				t.Errorf("PathGenerator.PackageName: got %s, want %s", got, want)
+			}
+			if got, want := c.Generator.TransformationOptions.CompressBehaviour, genutil.PreferIntendedConfig; got != want {
+				t.Errorf("CompressBehaviour: got %v, want %v", got, want)
+			}
+			if c.PathGenerator.ExcludeState {
+				t.Errorf("PathGenerator.ExcludeState: got true, want false")
+			}
+			if diff := cmp.Diff([]string{"x", "y"}, c.IncludePaths); diff != "" {
+				t.Errorf("IncludePaths: (-want, +got):\n%s", diff)
+			}
+			if c.Generator.GoOptions.GenerateGetters {
+				t.Errorf("GenerateGetters: got true, want false")
+			}
+			if got, want := c.OutputFile, "file.go"; got != want {
+				t.Errorf("OutputFile: got %s, want %s", got, want)
+			}
+		},
+	}, {
+		desc:             "invalid compression flags",
+		inConfigFile:     configFile,
+		inFlags:          map[string]string{"prefer_operational_state": "true"},
+		wantErrSubstring: "preferOperationalState is only compatible",
+	}, {
+		desc:             "missing config file",
+		inConfigFile:     filepath.Join(dir, "missing.yaml"),
+		wantErrSubstring: "cannot read config file",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			set := setTestFlags(t, tt.inFlags)
+			got, err := loadConfig(tt.inConfigFile, set, tt.inArgs)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("loadConfig: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+func TestPrintConfigRoundTrip(t *testing.T) {
+	set := setTestFlags(t, map[string]string{
+		"compress_paths":    "true",
+		"generate_getters":  "true",
+		"exclude_modules":   "ietf-interfaces",
+		"deviation_modules": "dev.yang",
+	})
+	want, err := loadConfig("", set, []string{"a.yang"})
+	if err != nil {
+		t.Fatalf("loadConfig: unexpected error: %v", err)
+	}
+
+	dir, err := ioutil.TempDir("", "generator-config")
+	if err != nil {
+		t.Fatalf("cannot create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, format := range []genutil.ConfigFormat{genutil.YAMLConfig, genutil.JSONConfig} {
+		t.Run(string(format), func(t *testing.T) {
+			b, err := genutil.MarshalConfig(want, format)
+			if err != nil {
+				t.Fatalf("MarshalConfig: unexpected error: %v", err)
+			}
+			fn := filepath.Join(dir, "config."+string(format))
+			if err := ioutil.WriteFile(fn, b, 0644); err != nil {
+				t.Fatalf("cannot write config file: %v", err)
+			}
+			// The printed configuration is read without any flags being
+			// set, such that it alone determines the configuration.
+			flag.Set("compress_paths", "false")
+			flag.Set("generate_getters", "false")
+			flag.Set("exclude_modules", "")
+			flag.Set("deviation_modules", "")
+			got, err := loadConfig(fn, nil, nil)
+			if err != nil {
+				t.Fatalf("loadConfig: unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("did not get same config after printing, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
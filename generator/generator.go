@@ -27,7 +27,6 @@ import (
 	"strings"
 
 	log "github.com/golang/glog"
-	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/genutil"
 	"github.com/openconfig/ygot/ygen"
 	"github.com/openconfig/ygot/ypathgen"
@@ -51,6 +50,9 @@ const (
 )
 
 var (
+	configFile  = flag.String("config_file", "", "A YAML (.yaml or .yml) or JSON (.json) file specifying the configuration of the generator, whose keys are the names of the fields of the generator's configuration. Flags that are explicitly specified override the values within the file.")
+	printConfig = flag.String("print_config", "", "If set to yaml or json, the effective configuration of the generator is written to stdout in the specified format, and no code is generated. The output can be used as the config_file of a later run.")
+
 	generateGoStructs       = flag.Bool("generate_structs", true, "If true, then Go code for YANG path construction (schema/Go structs) will be generated.")
 	generatePathStructs     = flag.Bool("generate_path_structs", false, "If true, then Go code for YANG path construction (path structs) will be generated.")
 	ocStructsOutputFile     = flag.String("output_file", "", "The file that the generated Go code for manipulating YANG data (schema/Go structs) should be written to. Specify \"-\" for stdout.")
@@ -58,7 +60,7 @@ var (
 	ocPathStructsOutputFile = flag.String("path_structs_output_file", "", "The file that the generated Go code for YANG path construction (path structs) will be generated. If split_pathstructs_by_module=true, this file contains the fake root path struct. Specify \"-\" for stdout.")
 	pathStructsFileN        = flag.Int("path_structs_split_files_count", 0, "The number of files to split the generated path structs into when output_file is specified for generating path structs")
 	outputDir               = flag.String("output_dir", "", "The directory that the generated Go code should be written to. This is common between schema structs and path structs. For path struct generation, if split_pathstructs_by_module=true, this directory is the base of the generated module packages.")
-	compressPaths           = flag.Bool("compress_paths", false, "If set to true, the schema's paths are compressed, according to OpenConfig YANG module conventions. Path structs generation currently only supports compressed paths.")
+	compressPaths           = flag.Bool("compress_paths", false, "If set to true, the schema's paths are compressed, according to OpenConfig YANG module conventions.")
 
 	// Common flags used for GoStruct and PathStruct generation.
 	yangPaths                            = flag.String("path", "", "Comma separated list of paths to be recursively searched for included modules or submodules within the defined YANG modules.")
@@ -76,33 +78,47 @@ var (
 	ygotImportPath                       = flag.String("ygot_path", genutil.GoDefaultYgotImportPath, "The import path to use for ygot.")
 	trimEnumOpenConfigPrefix             = flag.Bool("trim_enum_openconfig_prefix", false, `If set to true when compressPaths=true, the organizational prefix "openconfig-" is trimmed from the module part of the name of enumerated names in the generated code`)
 	includeDescriptions                  = flag.Bool("include_descriptions", false, "If set to true when generateSchema=true, the YANG descriptions will be included in the generated code artefact.")
-	enumOrgPrefixesToTrim                []string
+	enabledFeatures                      = flag.String("enabled_features", "", `Comma separated set of module:feature pairs specifying the YANG features that are enabled, where module:all enables every feature of a module. If this flag is specified, schema nodes whose if-feature statements are not satisfied are excluded from the generated code; specifying an empty value disables all features.`)
+	deviationModules                     = flag.String("deviation_modules", "", "Comma separated set of paths to YANG modules containing deviations that should be applied to the input modules. Code is not generated for the deviation modules themselves.")
 
 	// Flags used for GoStruct generation only.
-	generateFakeRoot     = flag.Bool("generate_fakeroot", false, "If set to true, a fake element at the root of the data tree is generated. By default the fake root entity is named Device, its name can be controlled with the fakeroot_name flag.")
-	generateSchema       = flag.Bool("include_schema", true, "If set to true, the YANG schema will be encoded as JSON and stored in the generated code artefact.")
-	ytypesImportPath     = flag.String("ytypes_path", genutil.GoDefaultYtypesImportPath, "The import path to use for ytypes.")
-	goyangImportPath     = flag.String("goyang_path", genutil.GoDefaultGoyangImportPath, "The import path to use for goyang's yang package.")
-	generateRename       = flag.Bool("generate_rename", false, "If set to true, rename methods are generated for lists within the Go code.")
-	addAnnotations       = flag.Bool("annotations", false, "If set to true, metadata annotations are added within the generated structs.")
-	annotationPrefix     = flag.String("annotation_prefix", ygen.DefaultAnnotationPrefix, "String to be appended to each metadata field within the generated structs if annoations is set to true.")
-	generateAppend       = flag.Bool("generate_append", false, "If set to true, append methods are generated for YANG lists (Go maps) within the Go code.")
-	generateGetters      = flag.Bool("generate_getters", false, "If set to true, getter methdos that retrieve or create an element are generated for YANG container (Go struct pointer) or list (Go map) fields within the generated code.")
-	generateDelete       = flag.Bool("generate_delete", false, "If set to true, delete methods are generated for YANG lists (Go maps) within the Go code.")
-	generateLeafGetters  = flag.Bool("generate_leaf_getters", false, "If set to true, getters for YANG leaves are generated within the Go code. Caution should be exercised when using leaf getters, since values that are explicitly set to the Go default/zero value are not distinguishable from those that are unset when retrieved via the GetXXX method.")
-	generateSimpleUnions = flag.Bool("generate_simple_unions", false, "If set to true, then generated typedefs will be used to represent union subtypes within Go code instead of wrapper struct types.")
-	includeModelData     = flag.Bool("include_model_data", false, "If set to true, a slice of gNMI ModelData messages are included in the generated Go code containing the details of the input schemas from which the code was generated.")
+	generateFakeRoot         = flag.Bool("generate_fakeroot", false, "If set to true, a fake element at the root of the data tree is generated. By default the fake root entity is named Device, its name can be controlled with the fakeroot_name flag.")
+	generateSchema           = flag.Bool("include_schema", true, "If set to true, the YANG schema will be encoded as JSON and stored in the generated code artefact.")
+	ytypesImportPath         = flag.String("ytypes_path", genutil.GoDefaultYtypesImportPath, "The import path to use for ytypes.")
+	goyangImportPath         = flag.String("goyang_path", genutil.GoDefaultGoyangImportPath, "The import path to use for goyang's yang package.")
+	generateRename           = flag.Bool("generate_rename", false, "If set to true, rename methods are generated for lists within the Go code.")
+	addAnnotations           = flag.Bool("annotations", false, "If set to true, metadata annotations are added within the generated structs.")
+	annotationPrefix         = flag.String("annotation_prefix", ygen.DefaultAnnotationPrefix, "String to be appended to each metadata field within the generated structs if annoations is set to true.")
+	generateAppend           = flag.Bool("generate_append", false, "If set to true, append methods are generated for YANG lists (Go maps) within the Go code.")
+	generateGetters          = flag.Bool("generate_getters", false, "If set to true, getter methdos that retrieve or create an element are generated for YANG container (Go struct pointer) or list (Go map) fields within the generated code.")
+	generateDelete           = flag.Bool("generate_delete", false, "If set to true, delete methods are generated for YANG lists (Go maps) within the Go code.")
+	generateLeafGetters      = flag.Bool("generate_leaf_getters", false, "If set to true, getters for YANG leaves are generated within the Go code. Caution should be exercised when using leaf getters, since values that are explicitly set to the Go default/zero value are not distinguishable from those that are unset when retrieved via the GetXXX method.")
+	generateLeafSetters      = flag.Bool("generate_leaf_setters", false, "If set to true, chainable setters for YANG leaves are generated within the Go code. Setters are not generated for the key leaves of a list.")
+	generateListConstructors = flag.Bool("generate_list_constructors", false, "If set to true, a NewXXX function is generated for each keyed list struct, which returns a new list entry with its key fields populated from its arguments.")
+	generateSimpleUnions     = flag.Bool("generate_simple_unions", false, "If set to true, then generated typedefs will be used to represent union subtypes within Go code instead of wrapper struct types.")
+	generateGenericUnions    = flag.Bool("generate_generic_unions", false, "If set to true, then aliases of the generic union types of the ygot package will be used to represent multi-type unions within Go code. Takes precedence over generate_simple_unions.")
+	generateDeepCopy         = flag.Bool("generate_deepcopy", false, "If set to true, ΛDeepCopy and ΛMerge methods are generated for each struct, allowing structs to be copied and merged without the use of reflection.")
+	generateEqual            = flag.Bool("generate_equal", false, "If set to true, a ΛEqual method is generated for each struct, allowing structs to be compared without the use of reflection.")
+	generateOrderedMaps      = flag.Bool("generate_ordered_maps", false, "If set to true, keyed lists that are ordered-by user are represented by generated ordered map types that retain the order of the list's entries, rather than Go maps.")
+	generateRPCTypes         = flag.Bool("generate_rpc_types", false, "If set to true, structs are generated for the input and output of YANG rpc and action statements, along with a map from the qualified name of each operation to its types.")
+	generateNotifTypes       = flag.Bool("generate_notification_types", false, "If set to true, structs are generated for YANG notification statements, along with a map from the path of each notification to its type.")
+	includeModelData         = flag.Bool("include_model_data", false, "If set to true, a slice of gNMI ModelData messages are included in the generated Go code containing the details of the input schemas from which the code was generated.")
 
 	// Flags used for PathStruct generation only.
-	schemaStructPath        = flag.String("schema_struct_path", "", "The Go import path for the schema structs package. This should be specified if and only if schema structs are not being generated at the same time as path structs.")
-	generateWildcardPaths   = flag.Bool("generate_wildcard_paths", true, "Whether to generate methods for constructing wildcard paths.")
-	simplifyWildcardPaths   = flag.Bool("simplify_wildcard_paths", false, "Whether to omit the keys in the generated paths if all keys for a list node are wildcards.")
-	listBuilderKeyThreshold = flag.Uint("list_builder_key_threshold", 0, "The threshold equal or over which the path structs' builder API is used for key population. 0 means infinity. This flag is only meaningful when wildcard paths are generated.")
-	pathStructSuffix        = flag.String("path_struct_suffix", "Path", "The suffix string appended to each generated path struct in order to differentiate their names from their corresponding schema struct names.")
-	splitByModule           = flag.Bool("split_pathstructs_by_module", false, "Whether to split path struct generation by module.")
-	trimOCPackage           = flag.Bool("trim_path_package_oc_prefix", false, "Whether to trim openconfig- from generated package names, when split_pathstructs_by_module=true.")
-	baseImportPath          = flag.String("base_import_path", "", "Base import path used to concatenate with module package relative paths for path struct imports when split_pathstructs_by_module=true.")
-	packageSuffix           = flag.String("path_struct_package_suffix", "path", "Suffix to append to generated Go package names, when split_pathstructs_by_module=true.")
+	schemaStructPath         = flag.String("schema_struct_path", "", "The Go import path for the schema structs package. This should be specified if and only if schema structs are not being generated at the same time as path structs.")
+	generateWildcardPaths    = flag.Bool("generate_wildcard_paths", true, "Whether to generate methods for constructing wildcard paths.")
+	generateTypedLeafPaths   = flag.Bool("generate_typed_leaf_paths", false, "If set to true, the path structs of leaves are typed according to the Go type of the leaf's value, and the GoStruct that the leaf is a field of.")
+	generateParsePath        = flag.Bool("generate_parse_path", false, "If set to true, a ParsePath function is generated that returns the path struct corresponding to a gNMI path.")
+	generateDescendantPaths  = flag.Bool("generate_descendant_paths", false, "If set to true, a Descendants method is generated for each non-leaf path struct that returns the path of all of its descendants using the multilevel wildcard \"...\".")
+	generateConfigStatePaths = flag.Bool("generate_config_state_paths", false, "If set to true, Config and State methods are generated for each path struct whose node has leaves under both its config and state containers, which allow both paths of each such leaf to be constructed.")
+	generateOperationPaths   = flag.Bool("generate_operation_paths", false, "If set to true, path structs are generated for the input and output trees of YANG rpc and action statements, and for YANG notification trees, each of which has its own root path struct. The GoStructs should be generated with generate_rpc_types and generate_notification_types.")
+	simplifyWildcardPaths    = flag.Bool("simplify_wildcard_paths", false, "Whether to omit the keys in the generated paths if all keys for a list node are wildcards.")
+	listBuilderKeyThreshold  = flag.Uint("list_builder_key_threshold", 0, "The threshold equal or over which the path structs' builder API is used for key population. 0 means infinity. This flag is only meaningful when wildcard paths are generated.")
+	pathStructSuffix         = flag.String("path_struct_suffix", "Path", "The suffix string appended to each generated path struct in order to differentiate their names from their corresponding schema struct names.")
+	splitByModule            = flag.Bool("split_pathstructs_by_module", false, "Whether to split path struct generation by module.")
+	trimOCPackage            = flag.Bool("trim_path_package_oc_prefix", false, "Whether to trim openconfig- from generated package names, when split_pathstructs_by_module=true.")
+	baseImportPath           = flag.String("base_import_path", "", "Base import path used to concatenate with module package relative paths for path struct imports when split_pathstructs_by_module=true.")
+	packageSuffix            = flag.String("path_struct_package_suffix", "path", "Suffix to append to generated Go package names, when split_pathstructs_by_module=true.")
 )
 
 // writeGoCodeSingleFile takes a ygen.GeneratedGoCode struct and writes the Go code
@@ -135,6 +151,14 @@ func writeGoCodeSingleFile(w io.Writer, goCode *ygen.GeneratedGoCode) error {
 		fmt.Fprintln(w, goCode.EnumTypeMap)
 	}
 
+	if len(goCode.RPCTypeMap) > 0 {
+		fmt.Fprintln(w, goCode.RPCTypeMap)
+	}
+
+	if len(goCode.NotificationTypeMap) > 0 {
+		fmt.Fprintln(w, goCode.NotificationTypeMap)
+	}
+
 	return nil
 }
 
@@ -207,6 +231,14 @@ func splitCodeByFileN(goCode *ygen.GeneratedGoCode, fileN int) (map[string]strin
 		code.WriteString("\n")
 	}
 	code.WriteString(goCode.EnumTypeMap)
+	if goCode.RPCTypeMap != "" {
+		code.WriteString("\n")
+		code.WriteString(goCode.RPCTypeMap)
+	}
+	if goCode.NotificationTypeMap != "" {
+		code.WriteString("\n")
+		code.WriteString(goCode.NotificationTypeMap)
+	}
 
 	out[enumMapFn] = code.String()
 	out[interfaceFn] = interfaceCode.String()
@@ -243,120 +275,70 @@ func writeFiles(dir string, out map[string]string) error {
 	return nil
 }
 
-// processFlags does some minimal processing of flags where otherwise
-// inconvenient before they're passed to the code generators.
-func processFlags() {
-	if *compressPaths && *trimEnumOpenConfigPrefix {
-		// No organization name is trimmed if compress paths is false.
-		enumOrgPrefixesToTrim = []string{"openconfig"}
-	}
-}
-
-// main parses command-line flags to determine the set of YANG modules for
-// which code generation should be performed, and calls the codegen library
-// to generate Go code corresponding to their schema. The output is written
-// to the specified file.
+// main parses command-line flags, and the configuration file if one is
+// specified, to determine the set of YANG modules for which code generation
+// should be performed, and calls the codegen library to generate Go code
+// corresponding to their schema. The output is written to the specified file.
 func main() {
 	flag.Parse()
-	processFlags()
+	cfg, err := loadConfig(*configFile, genutil.SetFlags(flag.CommandLine), flag.Args())
+	if err != nil {
+		log.Exitf("Error: %v", err)
+	}
+
+	if *printConfig != "" {
+		b, err := genutil.MarshalConfig(cfg, genutil.ConfigFormat(*printConfig))
+		if err != nil {
+			log.Exitf("Error: cannot print config: %v", err)
+		}
+		os.Stdout.Write(b)
+		return
+	}
+
 	// Extract the set of modules that code is to be generated for,
 	// throwing an error if the set is empty.
-	generateModules := flag.Args()
+	generateModules := cfg.Modules
 	if len(generateModules) == 0 {
 		log.Exitln("Error: no input modules specified")
 	}
 
-	if !*generateGoStructs && !*generatePathStructs {
+	if !cfg.GenerateStructs && !cfg.GeneratePathStructs {
 		log.Exitf("Error: Neither schema structs nor path structs generation is enabled.")
 	}
 
-	if *generatePathStructs {
-		if *generateGoStructs && *schemaStructPath != "" {
+	pcg := &cfg.PathGenerator
+	if cfg.GeneratePathStructs {
+		if cfg.GenerateStructs && pcg.GoImports.SchemaStructPkgPath != "" {
 			log.Exitf("Error: provided non-empty schema_struct_path for import by path structs file(s), but schema structs are also to be generated within the same package.")
 		}
-		if !*generateGoStructs && *schemaStructPath == "" {
+		if !cfg.GenerateStructs && pcg.GoImports.SchemaStructPkgPath == "" {
 			log.Exitf("Error: need to provide schema_struct_path for import by path structs file(s) when schema structs are not being generated at the same time.")
 		}
-		if *splitByModule && *baseImportPath == "" {
+		if pcg.SplitByModule && pcg.BaseImportPath == "" {
 			log.Exitf("Error: when splitting path structs by module, base_import_path needs to be set.")
 		}
 	}
 
 	// Determine the set of paths that should be searched for included
-	// modules. This is supplied by the user as a set of comma-separated
-	// paths, so we split the string. Additionally, for each path
-	// specified, we append "..." to ensure that the directory is
-	// recursively searched.
+	// modules. For each path specified, we append "..." to ensure that the
+	// directory is recursively searched.
 	includePaths := []string{}
-	if len(*yangPaths) > 0 {
-		pathParts := strings.Split(*yangPaths, ",")
-		for _, path := range pathParts {
-			includePaths = append(includePaths, filepath.Join(path, "..."))
-		}
+	for _, path := range cfg.IncludePaths {
+		includePaths = append(includePaths, filepath.Join(path, "..."))
 	}
 
-	// Determine which modules the user has requested to be excluded from
-	// code generation.
-	modsExcluded := []string{}
-	if len(*excludeModules) > 0 {
-		modParts := strings.Split(*excludeModules, ",")
-		for _, mod := range modParts {
-			modsExcluded = append(modsExcluded, mod)
-		}
-	}
-
-	if *generateGoStructs {
-		generateGoStructsSingleFile := *ocStructsOutputFile != ""
-		generateGoStructsMultipleFiles := *outputDir != ""
+	if cfg.GenerateStructs {
+		generateGoStructsSingleFile := cfg.OutputFile != ""
+		generateGoStructsMultipleFiles := cfg.OutputDir != ""
 		if generateGoStructsSingleFile && generateGoStructsMultipleFiles {
-			log.Exitf("Error: cannot specify both output_file (%s) and output_dir (%s)", *ocStructsOutputFile, *outputDir)
+			log.Exitf("Error: cannot specify both output_file (%s) and output_dir (%s)", cfg.OutputFile, cfg.OutputDir)
 		}
 		if !generateGoStructsSingleFile && !generateGoStructsMultipleFiles {
 			log.Exitf("Error: Go struct generation requires a specified output file or output directory.")
 		}
 
-		compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
-		if err != nil {
-			log.Exitf("ERROR Generating Code: %v\n", err)
-		}
-
 		// Perform the code generation.
-		cg := ygen.NewYANGCodeGenerator(&ygen.GeneratorConfig{
-			ParseOptions: ygen.ParseOpts{
-				ExcludeModules:        modsExcluded,
-				SkipEnumDeduplication: *skipEnumDedup,
-				YANGParseOptions: yang.Options{
-					IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
-				},
-			},
-			TransformationOptions: ygen.TransformationOpts{
-				CompressBehaviour:                    compressBehaviour,
-				IgnoreShadowSchemaPaths:              *ignoreShadowSchemaPaths,
-				GenerateFakeRoot:                     *generateFakeRoot,
-				FakeRootName:                         *fakeRootName,
-				ShortenEnumLeafNames:                 *shortenEnumLeafNames,
-				EnumOrgPrefixesToTrim:                enumOrgPrefixesToTrim,
-				UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
-			},
-			PackageName:         *packageName,
-			GenerateJSONSchema:  *generateSchema,
-			IncludeDescriptions: *includeDescriptions,
-			GoOptions: ygen.GoOpts{
-				YgotImportPath:                      *ygotImportPath,
-				YtypesImportPath:                    *ytypesImportPath,
-				GoyangImportPath:                    *goyangImportPath,
-				GenerateRenameMethod:                *generateRename,
-				AddAnnotationFields:                 *addAnnotations,
-				AnnotationPrefix:                    *annotationPrefix,
-				GenerateGetters:                     *generateGetters,
-				GenerateDeleteMethod:                *generateDelete,
-				GenerateAppendMethod:                *generateAppend,
-				GenerateLeafGetters:                 *generateLeafGetters,
-				GenerateSimpleUnions:                *generateSimpleUnions,
-				IncludeModelData:                    *includeModelData,
-				AppendEnumSuffixForSimpleUnionEnums: *appendEnumSuffixForSimpleUnionEnums,
-			},
-		})
+		cg := ygen.NewYANGCodeGenerator(&cfg.Generator)
 
 		generatedGoCode, errs := cg.GenerateGoCode(generateModules, includePaths)
 		if errs != nil {
@@ -366,7 +348,7 @@ func main() {
 		switch {
 		case generateGoStructsSingleFile:
 			var outfh *os.File
-			switch *ocStructsOutputFile {
+			switch cfg.OutputFile {
 			case "-":
 				// If "-" is the output file name, we output to os.Stdout, otherwise
 				// we write to the specified file.
@@ -374,89 +356,60 @@ func main() {
 			default:
 				// Assign the newly created filehandle to the outfh, and ensure
 				// that it is synced and closed before exit of main.
-				outfh = genutil.OpenFile(*ocStructsOutputFile)
+				outfh = genutil.OpenFile(cfg.OutputFile)
 				defer genutil.SyncFile(outfh)
 			}
 
 			writeGoCodeSingleFile(outfh, generatedGoCode)
 		case generateGoStructsMultipleFiles:
 			// Write the Go code to a series of output files.
-			out, err := splitCodeByFileN(generatedGoCode, *structsFileN)
+			out, err := splitCodeByFileN(generatedGoCode, cfg.StructsSplitFilesCount)
 			if err != nil {
 				log.Exitf("ERROR writing split GoStruct Code: %v\n", err)
 			}
-			if err := writeFiles(*outputDir, out); err != nil {
+			if err := writeFiles(cfg.OutputDir, out); err != nil {
 				log.Exitf("Error while writing schema struct files: %v", err)
 			}
 		}
 	}
 
 	// Generate PathStructs.
-	if !*generatePathStructs {
+	if !cfg.GeneratePathStructs {
 		return
 	}
-	if !*compressPaths {
-		log.Exitf("Error: path struct generation not supported for uncompressed paths. Please use compressed paths or remove output file flag for path struct generation.")
-	}
-
-	generatePathStructsSingleFile := *ocPathStructsOutputFile != ""
-	generatePathStructsMultipleFiles := *outputDir != ""
+	generatePathStructsSingleFile := cfg.PathStructsOutputFile != ""
+	generatePathStructsMultipleFiles := cfg.OutputDir != ""
 	if !generatePathStructsSingleFile && !generatePathStructsMultipleFiles {
 		log.Exitf("Error: path struct generation requires a specified output file or directory.")
 	}
-	if !*splitByModule && generatePathStructsSingleFile && generatePathStructsMultipleFiles {
-		log.Exitf("Error: cannot specify both path_structs_output_file (%s) and output_dir (%s)", *ocPathStructsOutputFile, *outputDir)
+	if !pcg.SplitByModule && generatePathStructsSingleFile && generatePathStructsMultipleFiles {
+		log.Exitf("Error: cannot specify both path_structs_output_file (%s) and output_dir (%s)", cfg.PathStructsOutputFile, cfg.OutputDir)
 	}
-	if *splitByModule && (!generatePathStructsSingleFile || !generatePathStructsMultipleFiles) {
+	if pcg.SplitByModule && (!generatePathStructsSingleFile || !generatePathStructsMultipleFiles) {
 		log.Exitf("Error: when splitting path structs by module, both output_dir and path_structs_output_file need to be set.")
 	}
 
-	// Perform the code generation.
-	pcg := &ypathgen.GenConfig{
-		PackageName: *packageName,
-		GoImports: ypathgen.GoImports{
-			SchemaStructPkgPath: *schemaStructPath,
-			YgotImportPath:      *ygotImportPath,
-		},
-		PreferOperationalState:               *preferOperationalState,
-		ExcludeState:                         *excludeState,
-		SkipEnumDeduplication:                *skipEnumDedup,
-		ShortenEnumLeafNames:                 *shortenEnumLeafNames,
-		EnumOrgPrefixesToTrim:                enumOrgPrefixesToTrim,
-		UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
-		AppendEnumSuffixForSimpleUnionEnums:  *appendEnumSuffixForSimpleUnionEnums,
-		FakeRootName:                         *fakeRootName,
-		PathStructSuffix:                     *pathStructSuffix,
-		ExcludeModules:                       modsExcluded,
-		YANGParseOptions: yang.Options{
-			IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
-		},
-		GeneratingBinary:        genutil.CallerName(),
-		ListBuilderKeyThreshold: *listBuilderKeyThreshold,
-		GenerateWildcardPaths:   *generateWildcardPaths,
-		SimplifyWildcardPaths:   *simplifyWildcardPaths,
-		TrimOCPackage:           *trimOCPackage,
-		SplitByModule:           *splitByModule,
-		BaseImportPath:          *baseImportPath,
-		PackageSuffix:           *packageSuffix,
-	}
+	// The path structs must be generated for the same form of the schema
+	// as the schema structs that they are used with.
+	pcg.UncompressedPaths = !cfg.Generator.TransformationOptions.CompressBehaviour.CompressEnabled()
 
+	// Perform the code generation.
 	pathCode, _, errs := pcg.GeneratePathCode(generateModules, includePaths)
 	if errs != nil {
 		log.Exitf("ERROR Generating PathStruct Code: %s\n", errs)
 	}
 
 	switch {
-	case *splitByModule:
+	case pcg.SplitByModule:
 		for packageName, code := range pathCode {
-			// The fake root package is written to ocPathStructsOutputFile.
-			// All other packages are written to outdir/<package>.
-			path := *ocPathStructsOutputFile
+			// The fake root package is written to the path structs output
+			// file. All other packages are written to outdir/<package>.
+			path := cfg.PathStructsOutputFile
 			if packageName != pcg.PackageName {
-				if err := os.MkdirAll(filepath.Join(*outputDir, packageName), 0755); err != nil {
+				if err := os.MkdirAll(filepath.Join(cfg.OutputDir, packageName), 0755); err != nil {
 					log.Exitf("failed to create directory for package %q: %v", packageName, err)
 				}
-				path = filepath.Join(*outputDir, packageName, fmt.Sprintf("%s.go", packageName))
+				path = filepath.Join(cfg.OutputDir, packageName, fmt.Sprintf("%s.go", packageName))
 			}
 			outfh := genutil.OpenFile(path)
 			defer genutil.SyncFile(outfh)
@@ -467,7 +420,7 @@ func main() {
 		}
 	case generatePathStructsSingleFile:
 		var outfh *os.File
-		switch *ocPathStructsOutputFile {
+		switch cfg.PathStructsOutputFile {
 		case "-":
 			// If "-" is the output file name, we output to os.Stdout, otherwise
 			// we write to the specified file.
@@ -475,21 +428,21 @@ func main() {
 		default:
 			// Assign the newly created filehandle to the outfh, and ensure
 			// that it is synced and closed before exit of main.
-			outfh = genutil.OpenFile(*ocPathStructsOutputFile)
+			outfh = genutil.OpenFile(cfg.PathStructsOutputFile)
 			defer genutil.SyncFile(outfh)
 		}
 		writeGoPathCodeSingleFile(outfh, pathCode[pcg.PackageName])
 	case generatePathStructsMultipleFiles:
 		out := map[string]string{}
 		// Split the path struct code into files.
-		files, err := pathCode[pcg.PackageName].SplitFiles(*pathStructsFileN)
+		files, err := pathCode[pcg.PackageName].SplitFiles(cfg.PathStructsSplitFilesCount)
 		if err != nil {
-			log.Exitf("Error while splitting path structs code into %d files: %v\n", pathStructsFileN, err)
+			log.Exitf("Error while splitting path structs code into %d files: %v\n", cfg.PathStructsSplitFilesCount, err)
 		}
 		for i, file := range files {
 			out[fmt.Sprintf(pathStructsFileFmt, i)] = file
 		}
-		if err := writeFiles(*outputDir, out); err != nil {
+		if err := writeFiles(cfg.OutputDir, out); err != nil {
 			log.Exitf("Error while writing path struct files: %v", err)
 		}
 	}
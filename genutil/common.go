@@ -77,7 +77,7 @@ func GetOrderedEntryKeys(entries map[string]*yang.Entry) []string {
 func findAllChildrenWithoutCompression(e *yang.Entry, excludeState bool) (map[string]*yang.Entry, []error) {
 	var errs []error
 	directChildren := map[string]*yang.Entry{}
-	for _, child := range util.Children(e) {
+	for _, child := range util.DataTreeChildren(e) {
 		// Exclude children that are config false if requested.
 		if excludeState && !util.IsConfig(child) {
 			continue
@@ -145,6 +145,27 @@ func (c CompressBehaviour) String() string {
 	return fmt.Sprintf("%d", c)
 }
 
+// MarshalText implements encoding.TextMarshaler, such that the
+// CompressBehaviour is represented by its name within configuration files.
+func (c CompressBehaviour) MarshalText() ([]byte, error) {
+	if c < Uncompressed || c > ExcludeDerivedState {
+		return nil, fmt.Errorf("invalid CompressBehaviour %d", c)
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, setting the
+// CompressBehaviour to the value with the name b.
+func (c *CompressBehaviour) UnmarshalText(b []byte) error {
+	for v := Uncompressed; v <= ExcludeDerivedState; v++ {
+		if v.String() == string(b) {
+			*c = v
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid CompressBehaviour %q", b)
+}
+
 // CompressEnabled is a helper to query whether compression is on.
 func (c CompressBehaviour) CompressEnabled() bool {
 	switch c {
@@ -322,7 +343,7 @@ func FindAllChildren(e *yang.Entry, compBehaviour CompressBehaviour) (map[string
 	}
 
 	// We now append all other entries in the directory to the orderedChildren list.
-	for _, child := range util.Children(e) {
+	for _, child := range util.DataTreeChildren(e) {
 		if child.Name != prioData {
 			orderedChildNames = append(orderedChildNames, child.Name)
 		}
@@ -357,7 +378,7 @@ func FindAllChildren(e *yang.Entry, compBehaviour CompressBehaviour) (map[string
 			// present them as being at a higher-layer. This allows the "config"
 			// and "state" container to be removed from the schema.
 			// For example, /foo/bar/config/{a,b,c} becomes /foo/bar/{a,b,c}.
-			for _, configStateChild := range util.Children(e.Dir[currChild]) {
+			for _, configStateChild := range util.DataTreeChildren(e.Dir[currChild]) {
 				// If we get an error for the deprioritized data container then we ignore it as we
 				// expect that there are some duplicates here for applied configuration leaves
 				// (those that appear both in the "config" and "state" container).
@@ -415,7 +436,7 @@ func FindAllChildren(e *yang.Entry, compBehaviour CompressBehaviour) (map[string
 			//
 			// eGrandChildren is a slice of the elements that are children of the
 			// directory that was a child of e.
-			eGrandChildren := util.Children(e.Dir[currChild])
+			eGrandChildren := util.DataTreeChildren(e.Dir[currChild])
 			switch {
 			// Implement rule 2 - remove surrounding containers for lists and consider
 			// the list under the surrounding container a direct child.
@@ -503,7 +524,7 @@ func TransformEntry(e *yang.Entry, compressBehaviour CompressBehaviour) util.Err
 	}
 
 	var errs util.Errors
-	for _, ch := range util.Children(e) {
+	for _, ch := range util.DataTreeChildren(e) {
 		switch {
 		case ch.IsLeaf(), ch.IsLeafList():
 			errs = util.AppendErr(errs, pointLeafrefToState(ch))
@@ -0,0 +1,204 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFormat is the format of a generator configuration file.
+type ConfigFormat string
+
+const (
+	// JSONConfig indicates that a configuration file is formatted as JSON.
+	JSONConfig ConfigFormat = "json"
+	// YAMLConfig indicates that a configuration file is formatted as YAML.
+	YAMLConfig ConfigFormat = "yaml"
+)
+
+// ConfigFormatForFile returns the format of the configuration file fn, which
+// is determined by its extension.
+func ConfigFormatForFile(fn string) (ConfigFormat, error) {
+	switch strings.ToLower(filepath.Ext(fn)) {
+	case ".json":
+		return JSONConfig, nil
+	case ".yaml", ".yml":
+		return YAMLConfig, nil
+	}
+	return "", fmt.Errorf("cannot determine format of config file %s, extension must be one of .json, .yaml or .yml", fn)
+}
+
+// ReadConfigFile reads the generator configuration file fn, which may be
+// formatted as YAML or JSON, into cfg, which must be a pointer to a struct.
+// The keys of the file are the names of the fields of cfg, which are matched
+// case-insensitively, such that the file maps one-to-one to the configuration
+// structs of the ygen and ypathgen packages. Fields of cfg that are not
+// specified in the file retain their existing values, allowing cfg to be
+// populated with defaults before the file is read. An error is returned if
+// the file specifies a key that does not correspond to a field of cfg.
+func ReadConfigFile(fn string, cfg interface{}) error {
+	format, err := ConfigFormatForFile(fn)
+	if err != nil {
+		return err
+	}
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return fmt.Errorf("cannot read config file: %v", err)
+	}
+	if err := UnmarshalConfig(b, format, cfg); err != nil {
+		return fmt.Errorf("invalid config file %s: %v", fn, err)
+	}
+	return nil
+}
+
+// UnmarshalConfig unmarshals the configuration b, which is formatted according
+// to format, into cfg using the semantics described in ReadConfigFile.
+func UnmarshalConfig(b []byte, format ConfigFormat, cfg interface{}) error {
+	if format == YAMLConfig {
+		// YAML is converted to JSON such that the same field names, and
+		// the same handling of types that implement encoding.TextUnmarshaler,
+		// are used for both formats.
+		var v interface{}
+		if err := yaml.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		if v == nil {
+			return nil
+		}
+		var err error
+		if b, err = json.Marshal(v); err != nil {
+			return err
+		}
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+	return d.Decode(cfg)
+}
+
+// MarshalConfig marshals cfg into the supplied format, such that the output
+// can be read using ReadConfigFile.
+func MarshalConfig(cfg interface{}, format ConfigFormat) ([]byte, error) {
+	b, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case JSONConfig:
+		return append(b, '\n'), nil
+	case YAMLConfig:
+		// The JSON is converted back to YAML to retain the field names
+		// and value representations used in JSON.
+		var v interface{}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(v)
+	}
+	return nil, fmt.Errorf("unknown config format %q", format)
+}
+
+// SetFlags returns the set of names of the flags within fs that were
+// explicitly specified on the command line.
+func SetFlags(fs *flag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return set
+}
+
+// SplitList splits the comma-separated list s, as specified by the value of a
+// generator flag, returning nil if s is empty.
+func SplitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// FlagFields maps the name of each flag of a generator binary to a function
+// which copies the fields of its configuration, of type T, that the flag
+// specifies from src to dst.
+type FlagFields[T any] map[string]func(dst, src *T)
+
+// Override copies the fields specified by each flag whose name is in set from
+// src, which is derived from flags, to dst, which is typically read from a
+// configuration file. Flags that are not within f are ignored.
+func (f FlagFields[T]) Override(dst, src *T, set map[string]bool) {
+	for name := range set {
+		if fn, ok := f[name]; ok {
+			fn(dst, src)
+		}
+	}
+}
+
+// OverrideCompressBehaviour returns the compression behaviour that results
+// from overriding cb with the values of the compress_paths, exclude_state and
+// prefer_operational_state flags that are within set. Since the compression
+// behaviour is specified by the three flags, each flag that is not in set
+// takes the value that is implied by cb.
+func OverrideCompressBehaviour(cb CompressBehaviour, set map[string]bool, compressPaths, excludeState, preferOperationalState bool) (CompressBehaviour, error) {
+	if !set["compress_paths"] && !set["exclude_state"] && !set["prefer_operational_state"] {
+		return cb, nil
+	}
+	compress, exclude, prefer := cb.CompressEnabled(), cb.StateExcluded(), cb == PreferOperationalState
+	if set["compress_paths"] {
+		compress = compressPaths
+	}
+	if set["exclude_state"] {
+		exclude = excludeState
+	}
+	if set["prefer_operational_state"] {
+		prefer = preferOperationalState
+	}
+	return TranslateToCompressBehaviour(compress, exclude, prefer)
+}
+
+// LoadConfig returns the effective configuration of a generator binary. The
+// configuration is initially that returned by fromFlags. If configFile is
+// non-empty, the configuration file is read over it, such that the flags
+// provide the defaults of fields that the file does not specify, and
+// override is then called to apply the values of the flags that were
+// explicitly specified.
+func LoadConfig[T any](configFile string, fromFlags func() (*T, error), override func(dst, src *T) error) (*T, error) {
+	cfg, err := fromFlags()
+	if err != nil {
+		return nil, err
+	}
+	if configFile == "" {
+		return cfg, nil
+	}
+	// The configuration file is read into a separate copy of the
+	// configuration derived from the flags, such that unspecified fields
+	// take their default values without the slices of the two copies
+	// being shared.
+	fc, err := fromFlags()
+	if err != nil {
+		return nil, err
+	}
+	if err := ReadConfigFile(configFile, cfg); err != nil {
+		return nil, err
+	}
+	if err := override(cfg, fc); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
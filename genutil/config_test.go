@@ -0,0 +1,332 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genutil
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+type testInnerConfig struct {
+	Compress CompressBehaviour
+	Prefixes []string
+}
+
+type testConfig struct {
+	Name    string
+	Count   int
+	Enabled bool
+	Inner   testInnerConfig
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	defaults := testConfig{
+		Name:  "default",
+		Count: 42,
+		Inner: testInnerConfig{Prefixes: []string{"openconfig"}},
+	}
+
+	tests := []struct {
+		desc             string
+		in               string
+		inFormat         ConfigFormat
+		want             testConfig
+		wantErrSubstring string
+	}{{
+		desc:     "yaml",
+		in:       "name: foo\nenabled: true\ninner:\n  compress: PreferOperationalState\n",
+		inFormat: YAMLConfig,
+		want: testConfig{
+			Name:    "foo",
+			Count:   42,
+			Enabled: true,
+			Inner: testInnerConfig{
+				Compress: PreferOperationalState,
+				Prefixes: []string{"openconfig"},
+			},
+		},
+	}, {
+		desc:     "json",
+		in:       `{"Count": 1, "Inner": {"Prefixes": []}}`,
+		inFormat: JSONConfig,
+		want: testConfig{
+			Name:  "default",
+			Count: 1,
+			Inner: testInnerConfig{Prefixes: []string{}},
+		},
+	}, {
+		desc:     "empty yaml",
+		inFormat: YAMLConfig,
+		want:     defaults,
+	}, {
+		desc:             "unknown field",
+		in:               "nmae: foo\n",
+		inFormat:         YAMLConfig,
+		wantErrSubstring: `unknown field "nmae"`,
+	}, {
+		desc:             "invalid compress behaviour",
+		in:               `{"Inner": {"Compress": "Compressed"}}`,
+		inFormat:         JSONConfig,
+		wantErrSubstring: "invalid CompressBehaviour",
+	}, {
+		desc:             "invalid yaml",
+		in:               "name: [foo",
+		inFormat:         YAMLConfig,
+		wantErrSubstring: "yaml",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := defaults
+			got.Inner.Prefixes = append([]string{}, defaults.Inner.Prefixes...)
+			err := UnmarshalConfig([]byte(tt.in), tt.inFormat, &got)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalConfig: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalConfig: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	in := testConfig{
+		Name:    "foo",
+		Count:   7,
+		Enabled: true,
+		Inner: testInnerConfig{
+			Compress: ExcludeDerivedState,
+			Prefixes: []string{"openconfig", "ietf"},
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "genutil-config")
+	if err != nil {
+		t.Fatalf("cannot create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, format := range []ConfigFormat{YAMLConfig, JSONConfig} {
+		t.Run(string(format), func(t *testing.T) {
+			b, err := MarshalConfig(in, format)
+			if err != nil {
+				t.Fatalf("MarshalConfig: unexpected error: %v", err)
+			}
+			fn := filepath.Join(dir, "config."+string(format))
+			if err := ioutil.WriteFile(fn, b, 0644); err != nil {
+				t.Fatalf("cannot write config file: %v", err)
+			}
+			var got testConfig
+			if err := ReadConfigFile(fn, &got); err != nil {
+				t.Fatalf("ReadConfigFile: unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(in, got); diff != "" {
+				t.Errorf("did not get same config after round trip, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := MarshalConfig(in, "toml"); err == nil {
+		t.Errorf("MarshalConfig: did not get expected error for unknown format")
+	}
+	if diff := errdiff.Substring(ReadConfigFile(filepath.Join(dir, "config.txt"), &testConfig{}), "cannot determine format"); diff != "" {
+		t.Errorf("ReadConfigFile: %s", diff)
+	}
+}
+
+func TestCompressBehaviourText(t *testing.T) {
+	for _, cb := range []CompressBehaviour{Uncompressed, PreferIntendedConfig, PreferOperationalState, UncompressedExcludeDerivedState, ExcludeDerivedState} {
+		b, err := cb.MarshalText()
+		if err != nil {
+			t.Fatalf("%v: MarshalText: unexpected error: %v", cb, err)
+		}
+		var got CompressBehaviour
+		if err := got.UnmarshalText(b); err != nil {
+			t.Fatalf("%v: UnmarshalText(%s): unexpected error: %v", cb, b, err)
+		}
+		if got != cb {
+			t.Errorf("UnmarshalText(%s): got %v, want %v", b, got, cb)
+		}
+	}
+	if _, err := CompressBehaviour(-1).MarshalText(); err == nil {
+		t.Errorf("MarshalText: did not get expected error for invalid compress behaviour")
+	}
+}
+
+func TestSetFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("foo", "", "")
+	fs.String("bar", "", "")
+	fs.Bool("baz", true, "")
+	if err := fs.Parse([]string{"-foo=x", "-baz=true"}); err != nil {
+		t.Fatalf("cannot parse flags: %v", err)
+	}
+	if diff := cmp.Diff(map[string]bool{"foo": true, "baz": true}, SetFlags(fs)); diff != "" {
+		t.Errorf("SetFlags: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{{
+		in:   "",
+		want: nil,
+	}, {
+		in:   "a",
+		want: []string{"a"},
+	}, {
+		in:   "a,b",
+		want: []string{"a", "b"},
+	}}
+
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, SplitList(tt.in)); diff != "" {
+			t.Errorf("SplitList(%q): (-want, +got):\n%s", tt.in, diff)
+		}
+	}
+}
+
+func TestFlagFieldsOverride(t *testing.T) {
+	fields := FlagFields[testConfig]{
+		"name":  func(d, s *testConfig) { d.Name = s.Name },
+		"count": func(d, s *testConfig) { d.Count = s.Count },
+	}
+	dst := &testConfig{Name: "file", Count: 1, Enabled: true}
+	src := &testConfig{Name: "flag", Count: 2}
+	fields.Override(dst, src, map[string]bool{"name": true, "unknown": true})
+	if diff := cmp.Diff(&testConfig{Name: "flag", Count: 1, Enabled: true}, dst); diff != "" {
+		t.Errorf("Override: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestOverrideCompressBehaviour(t *testing.T) {
+	tests := []struct {
+		desc                     string
+		inCompressBehaviour      CompressBehaviour
+		inSet                    map[string]bool
+		inCompressPaths          bool
+		inExcludeState           bool
+		inPreferOperationalState bool
+		want                     CompressBehaviour
+		wantErrSubstring         string
+	}{{
+		desc:                "no flags set",
+		inCompressBehaviour: ExcludeDerivedState,
+		inCompressPaths:     false,
+		want:                ExcludeDerivedState,
+	}, {
+		desc:                "exclude state unset, compression retained",
+		inCompressBehaviour: ExcludeDerivedState,
+		inSet:               map[string]bool{"exclude_state": true},
+		inExcludeState:      false,
+		want:                PreferIntendedConfig,
+	}, {
+		desc:                "compression disabled, state exclusion retained",
+		inCompressBehaviour: ExcludeDerivedState,
+		inSet:               map[string]bool{"compress_paths": true},
+		inCompressPaths:     false,
+		want:                UncompressedExcludeDerivedState,
+	}, {
+		desc:                     "prefer operational state with compression",
+		inCompressBehaviour:      PreferIntendedConfig,
+		inSet:                    map[string]bool{"prefer_operational_state": true},
+		inPreferOperationalState: true,
+		want:                     PreferOperationalState,
+	}, {
+		desc:                     "prefer operational state without compression",
+		inCompressBehaviour:      Uncompressed,
+		inSet:                    map[string]bool{"prefer_operational_state": true},
+		inPreferOperationalState: true,
+		wantErrSubstring:         "preferOperationalState is only compatible",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := OverrideCompressBehaviour(tt.inCompressBehaviour, tt.inSet, tt.inCompressPaths, tt.inExcludeState, tt.inPreferOperationalState)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("OverrideCompressBehaviour: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("OverrideCompressBehaviour: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genutil-config")
+	if err != nil {
+		t.Fatalf("cannot create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	configFile := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(configFile, []byte("Name: file\nInner:\n  Prefixes: [a]\n"), 0644); err != nil {
+		t.Fatalf("cannot write config file: %v", err)
+	}
+
+	fromFlags := func() (*testConfig, error) {
+		return &testConfig{Name: "flag", Count: 2, Inner: testInnerConfig{Prefixes: []string{"flag"}}}, nil
+	}
+	overrideCount := func(d, s *testConfig) error {
+		d.Count = s.Count * 10
+		return nil
+	}
+
+	tests := []struct {
+		desc             string
+		inConfigFile     string
+		want             *testConfig
+		wantErrSubstring string
+	}{{
+		desc: "flags only",
+		want: &testConfig{Name: "flag", Count: 2, Inner: testInnerConfig{Prefixes: []string{"flag"}}},
+	}, {
+		desc:         "config file overridden by flags",
+		inConfigFile: configFile,
+		want:         &testConfig{Name: "file", Count: 20, Inner: testInnerConfig{Prefixes: []string{"a"}}},
+	}, {
+		desc:             "missing config file",
+		inConfigFile:     filepath.Join(dir, "missing.yaml"),
+		wantErrSubstring: "cannot read config file",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := LoadConfig(tt.inConfigFile, fromFlags, overrideCount)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("LoadConfig: %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LoadConfig: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/openconfig/ygot
 
-go 1.14
+go 1.18
 
 require (
 	github.com/golang/glog v0.0.0-20160126235308-23def4e6c14b
@@ -14,4 +14,11 @@ require (
 	google.golang.org/genproto v0.0.0-20201214200347-8c77b98c765d
 	google.golang.org/grpc v1.37.0
 	google.golang.org/protobuf v1.26.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	golang.org/x/net v0.0.0-20210410081132-afb366fc7cd1 // indirect
+	golang.org/x/sys v0.0.0-20210330210617-4fbd30eecc44 // indirect
+	golang.org/x/text v0.3.6 // indirect
 )
@@ -0,0 +1,175 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// config is the configuration of a run of the jsonschema_generator. It can be
+// read from a YAML or JSON file specified by the config_file flag, whose keys
+// are the names of the fields of config. The Generator field maps one-to-one
+// to ygen.GeneratorConfig.
+type config struct {
+	// Modules is the set of YANG modules for which documents are
+	// generated. Modules that are specified as arguments to the
+	// jsonschema_generator are appended to this set.
+	Modules []string
+	// IncludePaths is the set of paths that are recursively searched for
+	// included modules or submodules within the YANG modules.
+	IncludePaths []string
+	// JSONSchemaOutputFile is the file that the JSON Schema document is
+	// written to.
+	JSONSchemaOutputFile string
+	// OpenAPIOutputFile is the file that the OpenAPI document is written
+	// to.
+	OpenAPIOutputFile string
+	// Generator is the configuration used for document generation.
+	Generator ygen.GeneratorConfig
+}
+
+// configFromFlags returns the configuration that is specified by the values
+// of the jsonschema_generator's flags, where set is the set of names of the
+// flags that were explicitly specified.
+func configFromFlags(set map[string]bool) (*config, error) {
+	compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
+	if err != nil {
+		return nil, err
+	}
+
+	// The enabled features are only filtered when the flag is explicitly
+	// specified, such that an empty value can be used to disable all
+	// features.
+	var featuresEnabled []string
+	if set["enabled_features"] {
+		featuresEnabled = []string{}
+		if *enabledFeatures != "" {
+			featuresEnabled = strings.Split(*enabledFeatures, ",")
+		}
+	}
+
+	return &config{
+		IncludePaths:         genutil.SplitList(*yangPaths),
+		JSONSchemaOutputFile: *jsonSchemaOutputFile,
+		OpenAPIOutputFile:    *openAPIOutputFile,
+		Generator: ygen.GeneratorConfig{
+			ParseOptions: ygen.ParseOpts{
+				ExcludeModules:        genutil.SplitList(*excludeModules),
+				SkipEnumDeduplication: *skipEnumDedup,
+				YANGParseOptions: yang.Options{
+					IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
+				},
+				EnabledFeatures:  featuresEnabled,
+				DeviationModules: genutil.SplitList(*deviationModules),
+			},
+			TransformationOptions: ygen.TransformationOpts{
+				CompressBehaviour: compressBehaviour,
+				GenerateFakeRoot:  *generateFakeRoot,
+				FakeRootName:      *fakeRootName,
+			},
+			IncludeDescriptions: *includeDescriptions,
+			JSONSchemaOptions: ygen.JSONSchemaOpts{
+				SchemaID:   *schemaID,
+				Title:      *title,
+				APIVersion: *apiVersion,
+			},
+		},
+	}, nil
+}
+
+// flagFields maps the name of each flag to a function which copies the
+// fields of the configuration that the flag specifies from src to dst. The
+// flags that determine the compression behaviour are handled by
+// overrideConfig since they depend on one another.
+var flagFields = genutil.FlagFields[config]{
+	"path": func(d, s *config) {
+		d.IncludePaths = s.IncludePaths
+	},
+	"json_schema_output_file": func(d, s *config) {
+		d.JSONSchemaOutputFile = s.JSONSchemaOutputFile
+	},
+	"openapi_output_file": func(d, s *config) {
+		d.OpenAPIOutputFile = s.OpenAPIOutputFile
+	},
+	"exclude_modules": func(d, s *config) {
+		d.Generator.ParseOptions.ExcludeModules = s.Generator.ParseOptions.ExcludeModules
+	},
+	"ignore_circdeps": func(d, s *config) {
+		d.Generator.ParseOptions.YANGParseOptions.IgnoreSubmoduleCircularDependencies = s.Generator.ParseOptions.YANGParseOptions.IgnoreSubmoduleCircularDependencies
+	},
+	"skip_enum_deduplication": func(d, s *config) {
+		d.Generator.ParseOptions.SkipEnumDeduplication = s.Generator.ParseOptions.SkipEnumDeduplication
+	},
+	"enabled_features": func(d, s *config) {
+		d.Generator.ParseOptions.EnabledFeatures = s.Generator.ParseOptions.EnabledFeatures
+	},
+	"deviation_modules": func(d, s *config) {
+		d.Generator.ParseOptions.DeviationModules = s.Generator.ParseOptions.DeviationModules
+	},
+	"generate_fakeroot": func(d, s *config) {
+		d.Generator.TransformationOptions.GenerateFakeRoot = s.Generator.TransformationOptions.GenerateFakeRoot
+	},
+	"fakeroot_name": func(d, s *config) {
+		d.Generator.TransformationOptions.FakeRootName = s.Generator.TransformationOptions.FakeRootName
+	},
+	"include_descriptions": func(d, s *config) {
+		d.Generator.IncludeDescriptions = s.Generator.IncludeDescriptions
+	},
+	"schema_id": func(d, s *config) {
+		d.Generator.JSONSchemaOptions.SchemaID = s.Generator.JSONSchemaOptions.SchemaID
+	},
+	"title": func(d, s *config) {
+		d.Generator.JSONSchemaOptions.Title = s.Generator.JSONSchemaOptions.Title
+	},
+	"api_version": func(d, s *config) {
+		d.Generator.JSONSchemaOptions.APIVersion = s.Generator.JSONSchemaOptions.APIVersion
+	},
+}
+
+// overrideConfig overrides the values of dst, which is typically read from a
+// configuration file, with the values in src, which is derived from flags,
+// for each flag whose name is in set.
+func overrideConfig(dst, src *config, set map[string]bool) error {
+	flagFields.Override(dst, src, set)
+
+	cb, err := genutil.OverrideCompressBehaviour(dst.Generator.TransformationOptions.CompressBehaviour, set, *compressPaths, *excludeState, *preferOperationalState)
+	if err != nil {
+		return err
+	}
+	dst.Generator.TransformationOptions.CompressBehaviour = cb
+	return nil
+}
+
+// loadConfig returns the effective configuration of the jsonschema_generator.
+// If configFile is non-empty, the configuration file is read, with defaults
+// taken from the flags, and the flags in set then override its values.
+// Otherwise, the configuration is specified by the flags alone. The supplied
+// args are appended to the configuration's modules.
+func loadConfig(configFile string, set map[string]bool, args []string) (*config, error) {
+	cfg, err := genutil.LoadConfig(configFile, func() (*config, error) {
+		return configFromFlags(set)
+	}, func(dst, src *config) error {
+		return overrideConfig(dst, src, set)
+	})
+	if err != nil {
+		return nil, err
+	}
+	cfg.Modules = append(cfg.Modules, args...)
+	return cfg, nil
+}
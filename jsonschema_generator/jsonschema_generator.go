@@ -0,0 +1,122 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary jsonschema_generator generates a JSON Schema (draft 2020-12)
+// document, and an OpenAPI 3.1 document containing the equivalent component
+// schemas, that describe the RFC7951 JSON encoding of the data tree of an
+// input YANG schema. The input set of modules are read, parsed using goyang,
+// and handled as input to the ygen package which generates the documents.
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+
+	log "github.com/golang/glog"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/ygen"
+)
+
+var (
+	configFile             = flag.String("config_file", "", "A YAML (.yaml or .yml) or JSON (.json) file specifying the configuration of the jsonschema_generator, whose keys are the names of the fields of its configuration. Flags that are explicitly specified override the values within the file.")
+	printConfig            = flag.String("print_config", "", "If set to yaml or json, the effective configuration of the jsonschema_generator is written to stdout in the specified format, and no documents are generated. The output can be used as the config_file of a later run.")
+	yangPaths              = flag.String("path", "", "Comma separated list of paths to be recursively searched for included modules or submodules within the defined YANG modules.")
+	compressPaths          = flag.Bool("compress_paths", false, "If set to true, the schema's paths are compressed, according to OpenConfig YANG module conventions, such that the documents contain a schema for each struct that is generated by the generator with compressed paths.")
+	excludeModules         = flag.String("exclude_modules", "", "Comma separated set of module names that should be excluded from generation. This can be used to ensure overlapping namespaces can be ignored.")
+	excludeState           = flag.Bool("exclude_state", false, "If set to true, state (config false) fields in the YANG schema are not included in the generated documents.")
+	preferOperationalState = flag.Bool("prefer_operational_state", false, "If set to true, state (config false) fields in the YANG schema are preferred over intended config leaves in the generated documents with compressed schema paths. This flag is only valid for compress_paths=true and exclude_state=false.")
+	ignoreCircDeps         = flag.Bool("ignore_circdeps", false, "If set to true, circular dependencies between submodules are ignored.")
+	skipEnumDedup          = flag.Bool("skip_enum_deduplication", false, "If set to true, all leaves of type enumeration will have a unique enum output for them, rather than sharing a common type (default behaviour).")
+	enabledFeatures        = flag.String("enabled_features", "", `Comma separated set of module:feature pairs specifying the YANG features that are enabled, where module:all enables every feature of a module. If this flag is specified, schema nodes whose if-feature statements are not satisfied are excluded from the generated documents; specifying an empty value disables all features.`)
+	deviationModules       = flag.String("deviation_modules", "", "Comma separated set of paths to YANG modules containing deviations that should be applied to the input modules.")
+	generateFakeRoot       = flag.Bool("generate_fakeroot", false, "If set to true, a schema is generated for a fake element at the root of the data tree, as for the generator. The fake root's name can be controlled with the fakeroot_name flag.")
+	fakeRootName           = flag.String("fakeroot_name", "", "The name of the schema for the root of the data tree.")
+	includeDescriptions    = flag.Bool("include_descriptions", false, "If set to true, the YANG descriptions are included in the generated schemas.")
+	schemaID               = flag.String("schema_id", "", "The URI that is used as the $id of the generated JSON Schema document.")
+	title                  = flag.String("title", "", "The title of the generated documents. If it is not specified, the names of the input modules are used.")
+	apiVersion             = flag.String("api_version", ygen.DefaultOpenAPIInfoVersion, "The version of the API specified in the info object of the generated OpenAPI document.")
+	jsonSchemaOutputFile   = flag.String("json_schema_output_file", "", "The file that the JSON Schema document should be written to. Specify \"-\" for stdout.")
+	openAPIOutputFile      = flag.String("openapi_output_file", "", "The file that the OpenAPI document should be written to. Specify \"-\" for stdout.")
+)
+
+// writeDocument writes the document b to the file fn, or to stdout if fn is
+// "-".
+func writeDocument(fn string, b []byte) {
+	outfh := os.Stdout
+	if fn != "-" {
+		outfh = genutil.OpenFile(fn)
+		defer genutil.SyncFile(outfh)
+	}
+	if _, err := outfh.Write(b); err != nil {
+		log.Exitf("Error: cannot write %s: %v", fn, err)
+	}
+}
+
+// main parses command-line flags, and the configuration file if one is
+// specified, to determine the set of YANG modules for which documents should
+// be generated, and calls the codegen library to generate JSON Schema and
+// OpenAPI documents corresponding to their schema. The output is written to
+// the specified files.
+func main() {
+	flag.Parse()
+	cfg, err := loadConfig(*configFile, genutil.SetFlags(flag.CommandLine), flag.Args())
+	if err != nil {
+		log.Exitf("Error: %v", err)
+	}
+
+	if *printConfig != "" {
+		b, err := genutil.MarshalConfig(cfg, genutil.ConfigFormat(*printConfig))
+		if err != nil {
+			log.Exitf("Error: cannot print config: %v", err)
+		}
+		os.Stdout.Write(b)
+		return
+	}
+
+	// Extract the set of modules that documents are to be generated for,
+	// throwing an error if the set is empty.
+	generateModules := cfg.Modules
+	if len(generateModules) == 0 {
+		log.Exitln("Error: no input modules specified")
+	}
+
+	if cfg.JSONSchemaOutputFile == "" && cfg.OpenAPIOutputFile == "" {
+		log.Exitln("Error: at least one of json_schema_output_file or openapi_output_file must be specified")
+	}
+	if cfg.JSONSchemaOutputFile == "-" && cfg.OpenAPIOutputFile == "-" {
+		log.Exitln("Error: only one of json_schema_output_file or openapi_output_file can be written to stdout")
+	}
+
+	// Determine the set of paths that should be searched for included
+	// modules. For each path specified, we append "..." to ensure that the
+	// directory is recursively searched.
+	includePaths := []string{}
+	for _, path := range cfg.IncludePaths {
+		includePaths = append(includePaths, filepath.Join(path, "..."))
+	}
+
+	cg := ygen.NewYANGCodeGenerator(&cfg.Generator)
+	docs, errs := cg.GenerateJSONSchemaDocuments(generateModules, includePaths)
+	if errs != nil {
+		log.Exitf("ERROR Generating JSON Schema: %v\n", errs)
+	}
+
+	if cfg.JSONSchemaOutputFile != "" {
+		writeDocument(cfg.JSONSchemaOutputFile, docs.JSONSchema)
+	}
+	if cfg.OpenAPIOutputFile != "" {
+		writeDocument(cfg.OpenAPIOutputFile, docs.OpenAPI)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// config is the configuration of a run of the proto_generator. It can be read
+// from a YAML or JSON file specified by the config_file flag, whose keys are
+// the names of the fields of config. The Generator field maps one-to-one to
+// ygen.GeneratorConfig.
+type config struct {
+	// Modules is the set of YANG modules for which protobufs are
+	// generated. Modules that are specified as arguments to the
+	// proto_generator are appended to this set.
+	Modules []string
+	// IncludePaths is the set of paths that are recursively searched for
+	// included modules or submodules within the YANG modules.
+	IncludePaths []string
+	// OutputDir is the directory that the generated protobufs are
+	// written to.
+	OutputDir string
+	// Generator is the configuration used for protobuf generation.
+	Generator ygen.GeneratorConfig
+}
+
+// configFromFlags returns the configuration that is specified by the values
+// of the proto_generator's flags.
+func configFromFlags() (*config, error) {
+	compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config{
+		IncludePaths: genutil.SplitList(*yangPaths),
+		OutputDir:    *outputDir,
+		Generator: ygen.GeneratorConfig{
+			ParseOptions: ygen.ParseOpts{
+				ExcludeModules:        genutil.SplitList(*excludeModules),
+				SkipEnumDeduplication: *skipEnumDedup,
+				YANGParseOptions: yang.Options{
+					IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
+				},
+			},
+			TransformationOptions: ygen.TransformationOpts{
+				CompressBehaviour:                    compressBehaviour,
+				GenerateFakeRoot:                     *generateFakeRoot,
+				FakeRootName:                         *fakeRootName,
+				UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
+			},
+			PackageName: *packageName,
+			Caller:      *callerName,
+			ProtoOptions: ygen.ProtoOpts{
+				BaseImportPath:                       *baseImportPath,
+				YwrapperPath:                         *ywrapperPath,
+				YextPath:                             *yextPath,
+				AnnotateSchemaPaths:                  *annotateSchemaPaths,
+				AnnotateEnumNames:                    *annotateEnumNames,
+				NestedMessages:                       !*packageHierarchy,
+				EnumPackageName:                      *enumPackageName,
+				UseConsistentNamesForProtoUnionEnums: *useConsistentNamesForProtoUnionEnums,
+				GoPackageBase:                        *goPackageBase,
+			},
+		},
+	}, nil
+}
+
+// flagFields maps the name of each flag to a function which copies the
+// fields of the configuration that the flag specifies from src to dst. The
+// flags that determine the compression behaviour are handled by
+// overrideConfig since they depend on one another.
+var flagFields = genutil.FlagFields[config]{
+	"path": func(d, s *config) {
+		d.IncludePaths = s.IncludePaths
+	},
+	"output_dir": func(d, s *config) {
+		d.OutputDir = s.OutputDir
+	},
+	"exclude_modules": func(d, s *config) {
+		d.Generator.ParseOptions.ExcludeModules = s.Generator.ParseOptions.ExcludeModules
+	},
+	"package_name": func(d, s *config) {
+		d.Generator.PackageName = s.Generator.PackageName
+	},
+	"enum_package_name": func(d, s *config) {
+		d.Generator.ProtoOptions.EnumPackageName = s.Generator.ProtoOptions.EnumPackageName
+	},
+	"ignore_circdeps": func(d, s *config) {
+		d.Generator.ParseOptions.YANGParseOptions.IgnoreSubmoduleCircularDependencies = s.Generator.ParseOptions.YANGParseOptions.IgnoreSubmoduleCircularDependencies
+	},
+	"base_import_path": func(d, s *config) {
+		d.Generator.ProtoOptions.BaseImportPath = s.Generator.ProtoOptions.BaseImportPath
+	},
+	"ywrapper_path": func(d, s *config) {
+		d.Generator.ProtoOptions.YwrapperPath = s.Generator.ProtoOptions.YwrapperPath
+	},
+	"yext_path": func(d, s *config) {
+		d.Generator.ProtoOptions.YextPath = s.Generator.ProtoOptions.YextPath
+	},
+	"generate_fakeroot": func(d, s *config) {
+		d.Generator.TransformationOptions.GenerateFakeRoot = s.Generator.TransformationOptions.GenerateFakeRoot
+	},
+	"fakeroot_name": func(d, s *config) {
+		d.Generator.TransformationOptions.FakeRootName = s.Generator.TransformationOptions.FakeRootName
+	},
+	"add_schemapaths": func(d, s *config) {
+		d.Generator.ProtoOptions.AnnotateSchemaPaths = s.Generator.ProtoOptions.AnnotateSchemaPaths
+	},
+	"add_enumnames": func(d, s *config) {
+		d.Generator.ProtoOptions.AnnotateEnumNames = s.Generator.ProtoOptions.AnnotateEnumNames
+	},
+	"package_hierarchy": func(d, s *config) {
+		d.Generator.ProtoOptions.NestedMessages = s.Generator.ProtoOptions.NestedMessages
+	},
+	"caller_name": func(d, s *config) {
+		d.Generator.Caller = s.Generator.Caller
+	},
+	"skip_enum_deduplication": func(d, s *config) {
+		d.Generator.ParseOptions.SkipEnumDeduplication = s.Generator.ParseOptions.SkipEnumDeduplication
+	},
+	"typedef_enum_with_defmod": func(d, s *config) {
+		d.Generator.TransformationOptions.UseDefiningModuleForTypedefEnumNames = s.Generator.TransformationOptions.UseDefiningModuleForTypedefEnumNames
+	},
+	"consistent_union_enum_names": func(d, s *config) {
+		d.Generator.ProtoOptions.UseConsistentNamesForProtoUnionEnums = s.Generator.ProtoOptions.UseConsistentNamesForProtoUnionEnums
+	},
+	"go_package_base": func(d, s *config) {
+		d.Generator.ProtoOptions.GoPackageBase = s.Generator.ProtoOptions.GoPackageBase
+	},
+}
+
+// overrideConfig overrides the values of dst, which is typically read from a
+// configuration file, with the values in src, which is derived from flags,
+// for each flag whose name is in set.
+func overrideConfig(dst, src *config, set map[string]bool) error {
+	flagFields.Override(dst, src, set)
+
+	cb, err := genutil.OverrideCompressBehaviour(dst.Generator.TransformationOptions.CompressBehaviour, set, *compressPaths, *excludeState, *preferOperationalState)
+	if err != nil {
+		return err
+	}
+	dst.Generator.TransformationOptions.CompressBehaviour = cb
+	return nil
+}
+
+// loadConfig returns the effective configuration of the proto_generator. If
+// configFile is non-empty, the configuration file is read, with defaults
+// taken from the flags, and the flags in set then override its values.
+// Otherwise, the configuration is specified by the flags alone. The supplied
+// args are appended to the configuration's modules.
+func loadConfig(configFile string, set map[string]bool, args []string) (*config, error) {
+	cfg, err := genutil.LoadConfig(configFile, configFromFlags, func(dst, src *config) error {
+		return overrideConfig(dst, src, set)
+	})
+	if err != nil {
+		return nil, err
+	}
+	cfg.Modules = append(cfg.Modules, args...)
+	return cfg, nil
+}
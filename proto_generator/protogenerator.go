@@ -23,15 +23,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	log "github.com/golang/glog"
-	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/genutil"
 	"github.com/openconfig/ygot/ygen"
 )
 
 var (
+	configFile                           = flag.String("config_file", "", "A YAML (.yaml or .yml) or JSON (.json) file specifying the configuration of the proto_generator, whose keys are the names of the fields of its configuration. Flags that are explicitly specified override the values within the file.")
+	printConfig                          = flag.String("print_config", "", "If set to yaml or json, the effective configuration of the proto_generator is written to stdout in the specified format, and no protobufs are generated. The output can be used as the config_file of a later run.")
 	yangPaths                            = flag.String("path", "", "Comma separated list of paths to be recursively searched for included modules or submodules within the defined YANG modules.")
 	compressPaths                        = flag.Bool("compress_paths", false, "If set to true, the schema's paths are compressed, according to OpenConfig YANG module conventions.")
 	excludeModules                       = flag.String("exclude_modules", "", "Comma separated set of module names that should be excluded from code generation. This can be used to ensure overlapping namespaces can be ignored.")
@@ -56,80 +56,48 @@ var (
 	goPackageBase                        = flag.String("go_package_base", "", "Base name for the Go packages that are to be generated - this value is included in the go_package option of the generated protobufs - and has generated packages' names appended to it.")
 )
 
-// main parses command-line flags to determine the set of YANG modules for
-// which code generation should be performed, and calls the codegen library
-// to generate Go code corresponding to their schema. The output is written
-// to the specified file.
+// main parses command-line flags, and the configuration file if one is
+// specified, to determine the set of YANG modules for which code generation
+// should be performed, and calls the codegen library to generate protobufs
+// corresponding to their schema. The output is written to the specified
+// directory.
 func main() {
 	flag.Parse()
+	cfg, err := loadConfig(*configFile, genutil.SetFlags(flag.CommandLine), flag.Args())
+	if err != nil {
+		log.Exitf("Error: %v", err)
+	}
+
+	if *printConfig != "" {
+		b, err := genutil.MarshalConfig(cfg, genutil.ConfigFormat(*printConfig))
+		if err != nil {
+			log.Exitf("Error: cannot print config: %v", err)
+		}
+		os.Stdout.Write(b)
+		return
+	}
+
 	// Extract the set of modules that code is to be generated for,
 	// throwing an error if the set is empty.
-	generateModules := flag.Args()
+	generateModules := cfg.Modules
 	if len(generateModules) == 0 {
 		log.Exitln("Error: no input modules specified")
 	}
 
-	if *outputDir == "" {
+	if cfg.OutputDir == "" {
 		log.Exitln("Error: an output directory must be specified")
 	}
 
 	// Determine the set of paths that should be searched for included
-	// modules. This is supplied by the user as a set of comma-separated
-	// paths, so we split the string. Additionally, for each path
-	// specified, we append "..." to ensure that the directory is
-	// recursively searched.
+	// modules. For each path specified, we append "..." to ensure that the
+	// directory is recursively searched.
 	includePaths := []string{}
-	if len(*yangPaths) > 0 {
-		pathParts := strings.Split(*yangPaths, ",")
-		for _, path := range pathParts {
-			includePaths = append(includePaths, filepath.Join(path, "..."))
-		}
-	}
-
-	// Determine which modules the user has requested to be excluded from
-	// code generation.
-	modsExcluded := []string{}
-	if len(*excludeModules) > 0 {
-		modParts := strings.Split(*excludeModules, ",")
-		for _, mod := range modParts {
-			modsExcluded = append(modsExcluded, mod)
-		}
-	}
-
-	compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
-	if err != nil {
-		log.Exitf("ERROR Generating Proto Code: %s\n", err)
+	for _, path := range cfg.IncludePaths {
+		includePaths = append(includePaths, filepath.Join(path, "..."))
 	}
 
 	// Perform the code generation.
-	cg := ygen.NewYANGCodeGenerator(&ygen.GeneratorConfig{
-		ParseOptions: ygen.ParseOpts{
-			ExcludeModules:        modsExcluded,
-			SkipEnumDeduplication: *skipEnumDedup,
-			YANGParseOptions: yang.Options{
-				IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
-			},
-		},
-		TransformationOptions: ygen.TransformationOpts{
-			CompressBehaviour:                    compressBehaviour,
-			GenerateFakeRoot:                     *generateFakeRoot,
-			FakeRootName:                         *fakeRootName,
-			UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
-		},
-		PackageName: *packageName,
-		Caller:      *callerName,
-		ProtoOptions: ygen.ProtoOpts{
-			BaseImportPath:                       *baseImportPath,
-			YwrapperPath:                         *ywrapperPath,
-			YextPath:                             *yextPath,
-			AnnotateSchemaPaths:                  *annotateSchemaPaths,
-			AnnotateEnumNames:                    *annotateEnumNames,
-			NestedMessages:                       !*packageHierarchy,
-			EnumPackageName:                      *enumPackageName,
-			UseConsistentNamesForProtoUnionEnums: *useConsistentNamesForProtoUnionEnums,
-			GoPackageBase:                        *goPackageBase,
-		},
-	})
+	cg := ygen.NewYANGCodeGenerator(&cfg.Generator)
 
 	generatedProtoCode, errs := cg.GenerateProto3(generateModules, includePaths)
 	if errs != nil {
@@ -137,7 +105,7 @@ func main() {
 	}
 
 	for _, p := range generatedProtoCode.Packages {
-		fp := filepath.Join(append([]string{*outputDir}, p.FilePath[:len(p.FilePath)-1]...)...)
+		fp := filepath.Join(append([]string{cfg.OutputDir}, p.FilePath[:len(p.FilePath)-1]...)...)
 		if err := os.MkdirAll(fp, 0755); err != nil {
 			log.Exitf("could not create directory %v, got error: %v", fp, err)
 		}
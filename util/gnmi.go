@@ -98,19 +98,49 @@ func PathMatchesPathElemPrefix(path, prefix *gpb.Path) bool {
 }
 
 // PathMatchesQuery returns whether query is prefix of path.
-// Only the query may contain wildcard name or keys.
-// TODO: Multilevel wildcards ("...") not supported.
+// Only the query may contain wildcard name or keys, including multilevel
+// wildcards ("..."), which match zero or more path elements.
 // If either path and query contain nil elements func returns false.
 // Both paths must use the gNMI >=0.4.0 PathElem path format.
 func PathMatchesQuery(path, query *gpb.Path) bool {
-	if len(path.GetElem()) < len(query.GetElem()) || path.Origin != query.Origin {
+	if path.Origin != query.Origin {
 		return false
 	}
-	for i, queryElem := range query.Elem {
-		pathElem := path.Elem[i]
-		if queryElem == nil || pathElem == nil {
+	return pathElemsMatchQuery(path.GetElem(), query.GetElem(), true)
+}
+
+// PathMatchesQueryExact returns whether path matches query in its entirety,
+// rather than only a prefix of path matching query as in PathMatchesQuery.
+// Wildcards are handled as by PathMatchesQuery.
+func PathMatchesQueryExact(path, query *gpb.Path) bool {
+	if path.Origin != query.Origin {
+		return false
+	}
+	return pathElemsMatchQuery(path.GetElem(), query.GetElem(), false)
+}
+
+// pathElemsMatchQuery returns whether the path elements of query match those
+// of path. If prefix is set, query needs only to match a prefix of path.
+func pathElemsMatchQuery(path, query []*gpb.PathElem, prefix bool) bool {
+	for i, queryElem := range query {
+		if queryElem == nil {
+			return false
+		}
+		if queryElem.Name == "..." {
+			// The multilevel wildcard matches any number of path
+			// elements, so attempt to match the rest of the query at
+			// each of the remaining positions of the path.
+			for j := i; j <= len(path); j++ {
+				if pathElemsMatchQuery(path[j:], query[i+1:], prefix) {
+					return true
+				}
+			}
+			return false
+		}
+		if i >= len(path) || path[i] == nil {
 			return false
 		}
+		pathElem := path[i]
 		if queryElem.Name != "*" && queryElem.Name != pathElem.Name {
 			return false
 		}
@@ -120,7 +150,18 @@ func PathMatchesQuery(path, query *gpb.Path) bool {
 			}
 		}
 	}
-	return true
+	return prefix || len(path) == len(query)
+}
+
+// PathHasMultiLevelWildcard returns whether path contains a multilevel
+// wildcard ("...") element.
+func PathHasMultiLevelWildcard(path *gpb.Path) bool {
+	for _, e := range path.GetElem() {
+		if e.GetName() == "..." {
+			return true
+		}
+	}
+	return false
 }
 
 // TrimGNMIPathPrefix returns path with the prefix trimmed. It returns the
@@ -710,6 +710,80 @@ func TestPathMatchesQuery(t *testing.T) {
 				Key:  map[string]string{"seven": "*"},
 			}},
 		},
+	}, {
+		desc: "valid multilevel wildcard matching several elements",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+				Key:  map[string]string{"three": "four"},
+			}, {
+				Name: "five",
+			}, {
+				Name: "six",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "...",
+			}, {
+				Name: "five",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "valid multilevel wildcard matching no elements",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "five",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "...",
+			}, {
+				Name: "five",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "valid trailing multilevel wildcard",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "...",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "invalid multilevel wildcard followed by unmatched element",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "...",
+			}, {
+				Name: "three",
+			}},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -720,6 +794,80 @@ func TestPathMatchesQuery(t *testing.T) {
 	}
 }
 
+func TestPathMatchesQueryExact(t *testing.T) {
+	path := &gpb.Path{
+		Elem: []*gpb.PathElem{{
+			Name: "one",
+		}, {
+			Name: "two",
+			Key:  map[string]string{"three": "four"},
+		}, {
+			Name: "five",
+		}},
+	}
+	tests := []struct {
+		desc    string
+		inQuery *gpb.Path
+		want    bool
+	}{{
+		desc: "exact match",
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+				Key:  map[string]string{"three": "*"},
+			}, {
+				Name: "*",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "prefix only",
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}},
+		},
+	}, {
+		desc: "multilevel wildcard",
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "...",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "multilevel wildcard followed by last element",
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "...",
+			}, {
+				Name: "five",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "multilevel wildcard followed by inner element",
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "...",
+			}, {
+				Name: "two",
+			}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := PathMatchesQueryExact(path, tt.inQuery); got != tt.want {
+				t.Fatalf("did not get expected result, got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTrimGNMIPathElemPrefix(t *testing.T) {
 	tests := []struct {
 		desc     string
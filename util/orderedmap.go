@@ -0,0 +1,122 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// orderedMap is the interface implemented by the ordered map types that are
+// generated to represent keyed YANG lists that are "ordered-by user". It
+// mirrors the ygot.GoOrderedMap interface, which cannot be referenced by this
+// package.
+type orderedMap interface {
+	IsYANGOrderedList()
+	Len() int
+}
+
+// orderedMapType is the reflect.Type of the orderedMap interface.
+var orderedMapType = reflect.TypeOf((*orderedMap)(nil)).Elem()
+
+// IsTypeOrderedMap reports whether t is a generated ordered map type, which
+// represents a keyed YANG list that is "ordered-by user".
+func IsTypeOrderedMap(t reflect.Type) bool {
+	if t == reflect.TypeOf(nil) {
+		return false
+	}
+	return t.Kind() == reflect.Ptr && t.Implements(orderedMapType)
+}
+
+// IsValueOrderedMap reports whether v is a generated ordered map.
+func IsValueOrderedMap(v reflect.Value) bool {
+	return v.IsValid() && IsTypeOrderedMap(v.Type())
+}
+
+// IsTypeKeyedList reports whether t is a type that represents a keyed YANG
+// list, i.e., a map or a generated ordered map.
+func IsTypeKeyedList(t reflect.Type) bool {
+	return IsTypeMap(t) || IsTypeOrderedMap(t)
+}
+
+// OrderedMapKeyType returns the type of the keys of the ordered map type t.
+func OrderedMapKeyType(t reflect.Type) reflect.Type {
+	m, _ := t.MethodByName("Keys")
+	return m.Type.Out(0).Elem()
+}
+
+// OrderedMapElemType returns the type of the values of the ordered map type
+// t, which is a pointer to the struct representing the list's entries.
+func OrderedMapElemType(t reflect.Type) reflect.Type {
+	m, _ := t.MethodByName("Values")
+	return m.Type.Out(0).Elem()
+}
+
+// OrderedMapKeys returns the keys of the ordered map v, in order.
+func OrderedMapKeys(v reflect.Value) []reflect.Value {
+	return sliceElems(v.MethodByName("Keys").Call(nil)[0])
+}
+
+// OrderedMapValues returns the values of the ordered map v, in order.
+func OrderedMapValues(v reflect.Value) []reflect.Value {
+	return sliceElems(v.MethodByName("Values").Call(nil)[0])
+}
+
+// OrderedMapGet returns the value with the key k within the ordered map v. A
+// nil pointer value is returned if the key does not exist.
+func OrderedMapGet(v, k reflect.Value) reflect.Value {
+	return v.MethodByName("Get").Call([]reflect.Value{k})[0]
+}
+
+// OrderedMapDelete deletes the value with the key k from the ordered map v.
+func OrderedMapDelete(v, k reflect.Value) {
+	v.MethodByName("Delete").Call([]reflect.Value{k})
+}
+
+// OrderedMapAppend appends the value e, which must be a pointer to the struct
+// representing the list's entries, to the end of the ordered map v. The
+// ordered map must be non-nil.
+func OrderedMapAppend(v, e reflect.Value) error {
+	if v.IsNil() {
+		return fmt.Errorf("cannot append to nil ordered map of type %v", v.Type())
+	}
+	if et := OrderedMapElemType(v.Type()); e.Type() != et {
+		return fmt.Errorf("cannot append value of type %v to ordered map of type %v, must be %v", e.Type(), v.Type(), et)
+	}
+	if err := v.MethodByName("Append").Call([]reflect.Value{e})[0].Interface(); err != nil {
+		return err.(error)
+	}
+	return nil
+}
+
+// OrderedMapAsMap returns a map, keyed by the keys of the ordered map v,
+// containing its values. The values are shared with v, such that changes to
+// them are reflected in v, whereas insertions and deletions are not.
+func OrderedMapAsMap(v reflect.Value) reflect.Value {
+	m := reflect.MakeMap(reflect.MapOf(OrderedMapKeyType(v.Type()), OrderedMapElemType(v.Type())))
+	for _, k := range OrderedMapKeys(v) {
+		m.SetMapIndex(k, OrderedMapGet(v, k))
+	}
+	return m
+}
+
+// sliceElems returns the elements of the slice v.
+func sliceElems(v reflect.Value) []reflect.Value {
+	var vs []reflect.Value
+	for i := 0; i < v.Len(); i++ {
+		vs = append(vs, v.Index(i))
+	}
+	return vs
+}
@@ -0,0 +1,225 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+// basicStructOrderedMap is a minimal hand-written equivalent of a generated
+// ordered map, whose entries are BasicStruct pointers keyed by StringField.
+type basicStructOrderedMap struct {
+	keys     []string
+	valueMap map[string]*BasicStruct
+}
+
+func (*basicStructOrderedMap) IsYANGOrderedList() {}
+
+func (o *basicStructOrderedMap) Keys() []string {
+	if o == nil {
+		return nil
+	}
+	return append([]string{}, o.keys...)
+}
+
+func (o *basicStructOrderedMap) Values() []*BasicStruct {
+	if o == nil {
+		return nil
+	}
+	var vs []*BasicStruct
+	for _, k := range o.keys {
+		vs = append(vs, o.valueMap[k])
+	}
+	return vs
+}
+
+func (o *basicStructOrderedMap) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.keys)
+}
+
+func (o *basicStructOrderedMap) Get(key string) *BasicStruct {
+	if o == nil {
+		return nil
+	}
+	return o.valueMap[key]
+}
+
+func (o *basicStructOrderedMap) Delete(key string) bool {
+	if _, ok := o.valueMap[key]; !ok {
+		return false
+	}
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+		}
+	}
+	delete(o.valueMap, key)
+	return true
+}
+
+func (o *basicStructOrderedMap) Append(v *BasicStruct) error {
+	if v == nil {
+		return fmt.Errorf("nil value")
+	}
+	if o.valueMap == nil {
+		o.valueMap = map[string]*BasicStruct{}
+	}
+	if _, ok := o.valueMap[v.StringField]; ok {
+		return fmt.Errorf("duplicate key %s", v.StringField)
+	}
+	o.keys = append(o.keys, v.StringField)
+	o.valueMap[v.StringField] = v
+	return nil
+}
+
+type StructOfOrderedMap struct {
+	BasicStructOrderedMapField *basicStructOrderedMap `path:"basic-struct"`
+}
+
+func newBasicStructOrderedMap(t *testing.T, vs ...*BasicStruct) *basicStructOrderedMap {
+	t.Helper()
+	om := &basicStructOrderedMap{}
+	for _, v := range vs {
+		if err := om.Append(v); err != nil {
+			t.Fatalf("cannot append %v: %v", v, err)
+		}
+	}
+	return om
+}
+
+func TestIsTypeOrderedMap(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   reflect.Type
+		want bool
+	}{{
+		desc: "nil",
+		in:   reflect.TypeOf(nil),
+	}, {
+		desc: "map",
+		in:   reflect.TypeOf(map[string]*BasicStruct{}),
+	}, {
+		desc: "struct ptr",
+		in:   reflect.TypeOf(&BasicStruct{}),
+	}, {
+		desc: "ordered map",
+		in:   reflect.TypeOf(&basicStructOrderedMap{}),
+		want: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := IsTypeOrderedMap(tt.in); got != tt.want {
+				t.Errorf("IsTypeOrderedMap(%v): got %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderedMapHelpers(t *testing.T) {
+	a, b, c := &BasicStruct{StringField: "a"}, &BasicStruct{StringField: "b"}, &BasicStruct{StringField: "c"}
+	om := newBasicStructOrderedMap(t, c, a)
+	v := reflect.ValueOf(om)
+
+	if got, want := OrderedMapKeyType(v.Type()), reflect.TypeOf(""); got != want {
+		t.Errorf("OrderedMapKeyType: got %v, want %v", got, want)
+	}
+	if got, want := OrderedMapElemType(v.Type()), reflect.TypeOf(&BasicStruct{}); got != want {
+		t.Errorf("OrderedMapElemType: got %v, want %v", got, want)
+	}
+
+	if err := OrderedMapAppend(v, reflect.ValueOf(b)); err != nil {
+		t.Fatalf("OrderedMapAppend: unexpected error: %v", err)
+	}
+	if err := OrderedMapAppend(v, reflect.ValueOf(b)); err == nil {
+		t.Errorf("OrderedMapAppend: did not get expected error for duplicate key")
+	}
+	if err := OrderedMapAppend(v, reflect.ValueOf("d")); err == nil {
+		t.Errorf("OrderedMapAppend: did not get expected error for wrong type")
+	}
+
+	var gotKeys []string
+	for _, k := range OrderedMapKeys(v) {
+		gotKeys = append(gotKeys, k.String())
+	}
+	if diff := cmp.Diff([]string{"c", "a", "b"}, gotKeys); diff != "" {
+		t.Errorf("OrderedMapKeys: (-want, +got):\n%s", diff)
+	}
+	var gotValues []*BasicStruct
+	for _, e := range OrderedMapValues(v) {
+		gotValues = append(gotValues, e.Interface().(*BasicStruct))
+	}
+	if diff := cmp.Diff([]*BasicStruct{c, a, b}, gotValues); diff != "" {
+		t.Errorf("OrderedMapValues: (-want, +got):\n%s", diff)
+	}
+
+	if got := OrderedMapGet(v, reflect.ValueOf("a")).Interface().(*BasicStruct); got != a {
+		t.Errorf("OrderedMapGet(a): got %v, want %v", got, a)
+	}
+	if got := OrderedMapGet(v, reflect.ValueOf("z")); !got.IsNil() {
+		t.Errorf("OrderedMapGet(z): got %v, want nil", got)
+	}
+
+	if diff := cmp.Diff(map[string]*BasicStruct{"a": a, "b": b, "c": c}, OrderedMapAsMap(v).Interface()); diff != "" {
+		t.Errorf("OrderedMapAsMap: (-want, +got):\n%s", diff)
+	}
+
+	OrderedMapDelete(v, reflect.ValueOf("a"))
+	if diff := cmp.Diff([]string{"c", "b"}, om.Keys()); diff != "" {
+		t.Errorf("OrderedMapDelete: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestOrderedMapAppendNil(t *testing.T) {
+	var om *basicStructOrderedMap
+	err := OrderedMapAppend(reflect.ValueOf(om), reflect.ValueOf(&BasicStruct{}))
+	if diff := errdiff.Substring(err, "cannot append to nil ordered map"); diff != "" {
+		t.Errorf("OrderedMapAppend: %s", diff)
+	}
+}
+
+func TestForEachDataFieldOrderedMap(t *testing.T) {
+	parent := &StructOfOrderedMap{
+		BasicStructOrderedMapField: newBasicStructOrderedMap(t,
+			&BasicStruct{StringField: "z", Int32Field: 1},
+			&BasicStruct{StringField: "a", Int32Field: 2},
+			&BasicStruct{StringField: "m", Int32Field: 3},
+		),
+	}
+
+	var got []string
+	errs := ForEachDataField(parent, nil, &got, func(ni *NodeInfo, in, out interface{}) Errors {
+		if ni.StructField.Name == "Int32Field" {
+			o := out.(*[]string)
+			*o = append(*o, fmt.Sprintf("%v=%v", ni.PathFromParent, ni.FieldValue.Interface()))
+		}
+		return nil
+	})
+	if errs != nil {
+		t.Fatalf("ForEachDataField: unexpected errors: %v", errs)
+	}
+	want := []string{"[int32]=1", "[int32]=2", "[int32]=3"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ForEachDataField did not iterate ordered map in order, (-want, +got):\n%s", diff)
+	}
+}
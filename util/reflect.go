@@ -645,6 +645,47 @@ func forEachFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction FieldI
 	t := v.Type()
 
 	switch {
+	case IsTypeOrderedMap(t):
+		// An ordered map is handled in the same way as a map, with its
+		// entries being iterated in order.
+		schema := *(ni.Schema)
+		schema.ListAttr = nil
+		if IsNilOrInvalidValue(v) {
+			nn := &NodeInfo{
+				Parent:         ni,
+				PathFromParent: []string{schema.Name},
+				Schema:         &schema,
+				FieldValue:     reflect.Zero(OrderedMapElemType(t)),
+			}
+			switch in.(type) {
+			case *PathQueryNodeMemo: // Memoization of path queries requested.
+				errs = AppendErrs(errs, forEachFieldInternal(nn, newPathQueryMemo(), out, iterFunction))
+			default:
+				errs = AppendErrs(errs, forEachFieldInternal(nn, in, out, iterFunction))
+			}
+		} else {
+			keys := OrderedMapKeys(v)
+			for _, key := range keys {
+				nn := *ni
+				nn.Schema = &schema
+				nn.Parent = ni
+				nn.PathFromParent = []string{schema.Name}
+				nn.FieldValue = OrderedMapGet(v, key)
+				nn.FieldKey = key
+				nn.FieldKeys = keys
+				switch in.(type) {
+				case *PathQueryNodeMemo: // Memoization of path queries requested.
+					errs = AppendErrs(errs, forEachFieldInternal(&nn, newPathQueryMemo(), out, iterFunction))
+				default:
+					errs = AppendErrs(errs, forEachFieldInternal(&nn, in, out, iterFunction))
+				}
+			}
+		}
+
+	case IsTypeGenericUnion(t):
+		// A generic union represents a leaf, and hence is not recursed
+		// into.
+
 	case IsTypeStructPtr(t):
 		t = t.Elem()
 		if !IsNilOrInvalidValue(v) {
@@ -685,7 +726,7 @@ func forEachFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction FieldI
 				// In the case of a map/slice, the path is of the form
 				// "container/element" in the compressed schema, so trim off
 				// any extra path elements in this case.
-				if IsTypeSlice(sf.Type) || IsTypeMap(sf.Type) {
+				if IsTypeSlice(sf.Type) || IsTypeKeyedList(sf.Type) {
 					nn.PathFromParent = p[0:1]
 				}
 				switch in.(type) {
@@ -810,6 +851,21 @@ func forEachDataFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction Fi
 	// a leaf or leaf-list, which are not recursed into when traversing the
 	// data tree.
 	switch {
+	case IsTypeOrderedMap(t):
+		// Handle the case of an ordered map, which is a YANG list that is
+		// ordered-by user, iterating its entries in order.
+		keys := OrderedMapKeys(v)
+		for _, key := range keys {
+			nn := *ni
+			nn.Parent = ni
+			nn.FieldValue = OrderedMapGet(v, key)
+			nn.FieldKey = key
+			nn.FieldKeys = keys
+			errs = AppendErrs(errs, forEachDataFieldInternal(&nn, in, out, iterFunction))
+		}
+	case IsTypeGenericUnion(t):
+		// A generic union is the value of a leaf, and hence is not
+		// recursed into.
 	case IsTypeStructPtr(t):
 		// A struct pointer in a GoStruct is a pointer to another container within
 		// the YANG, therefore we dereference the pointer and then recurse. If the
@@ -840,7 +896,7 @@ func forEachDataFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction Fi
 			// fields.
 			for _, p := range ps {
 				nn.PathFromParent = p
-				if IsTypeSlice(sf.Type) || IsTypeMap(sf.Type) {
+				if IsTypeSlice(sf.Type) || IsTypeKeyedList(sf.Type) {
 					// Since lists can have path compression - where the path contains more
 					// than one element, ensure that the schema path we received is only two
 					// elements long. This protects against compression errors where there are
@@ -853,7 +909,7 @@ func forEachDataFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction Fi
 	case IsTypeSlice(t):
 		// Only iterate in the data tree if the slice is of structs, otherwise
 		// for leaf-lists we only run once.
-		if !IsTypeStructPtr(t.Elem()) && !IsTypeStruct(t.Elem()) {
+		if !IsTypeStructPtr(t.Elem()) && !IsTypeStruct(t.Elem()) || IsTypeGenericUnion(t.Elem()) {
 			return errs
 		}
 
@@ -923,7 +979,7 @@ func getNodesInternal(schema *yang.Entry, root interface{}, path *gpb.Path) ([]i
 	DbgPrint("GetNode next path %v, value %v", path.GetElem()[0], ValueStrDebug(root))
 
 	switch {
-	case schema.IsContainer() || (schema.IsList() && IsTypeStructPtr(reflect.TypeOf(root))):
+	case schema.IsContainer() || (schema.IsList() && IsTypeStructPtr(reflect.TypeOf(root)) && !IsTypeOrderedMap(reflect.TypeOf(root))):
 		// Either a container or list schema with struct data node (which could
 		// be an element of a list).
 		return getNodesContainer(schema, root, path)
@@ -975,7 +1031,7 @@ func getNodesContainer(schema *yang.Entry, root interface{}, path *gpb.Path) ([]
 				// don't trim whole prefix  for keyed list since name and key
 				// are a in the same element.
 				to := len(p)
-				if IsTypeMap(ft.Type) {
+				if IsTypeKeyedList(ft.Type) {
 					to--
 				}
 				return getNodesInternal(cschema, f.Interface(), TrimGNMIPathPrefix(path, p[0:to]))
@@ -997,10 +1053,20 @@ func getNodesList(schema *yang.Entry, root interface{}, path *gpb.Path) ([]inter
 	if schema.Key == "" {
 		return nil, nil, fmt.Errorf("getNodesList: path %v cannot traverse unkeyed list type %T", path, root)
 	}
+	// An ordered map is traversed as a map, whilst retaining the order of
+	// its keys such that matching nodes are returned in order.
+	var keys []reflect.Value
+	if IsValueOrderedMap(rv) {
+		keys = OrderedMapKeys(rv)
+		rv = OrderedMapAsMap(rv)
+	}
 	if !IsValueMap(rv) {
 		// Only keyed lists can be traversed with a path.
 		return nil, nil, fmt.Errorf("getNodesList: root has type %T, expect map", root)
 	}
+	if keys == nil {
+		keys = rv.MapKeys()
+	}
 	emptyKey := false
 	if len(path.GetElem()[0].GetKey()) == 0 {
 		DbgPrint("path %v at %T points to list with empty wildcard key", path, root)
@@ -1014,12 +1080,12 @@ func getNodesList(schema *yang.Entry, root interface{}, path *gpb.Path) ([]inter
 	var matchSchemas []*yang.Entry
 
 	// Iterate through all the map keys to see if any match the path.
-	for _, k := range rv.MapKeys() {
+	for _, k := range keys {
 		ev := rv.MapIndex(k)
 		DbgPrint("checking key %v, value %v", k.Interface(), ValueStrDebug(ev.Interface()))
 		match := true
 		if !emptyKey { // empty key matches everything.
-			if !IsValueStruct(k) {
+			if !IsValueStruct(k) || IsValueGenericUnion(k) {
 				// Compare just the single value of the key represented as a string.
 				pathKey, ok := path.GetElem()[0].GetKey()[schema.Key]
 				if !ok {
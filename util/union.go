@@ -0,0 +1,100 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// genericUnion is the interface implemented by the generic union types that
+// are used to represent YANG unions when generic unions are generated. It
+// mirrors the ygot.GenericUnion interface, which cannot be referenced by this
+// package.
+type genericUnion interface {
+	IsYANGGenericUnion()
+	Value() interface{}
+	Types() []reflect.Type
+}
+
+// genericUnionSetter mirrors the ygot.GenericUnionSetter interface.
+type genericUnionSetter interface {
+	genericUnion
+	SetValue(interface{}) error
+}
+
+// genericUnionType is the reflect.Type of the genericUnion interface.
+var genericUnionType = reflect.TypeOf((*genericUnion)(nil)).Elem()
+
+// IsTypeGenericUnion reports whether t is a generic union type, or a pointer
+// to one.
+func IsTypeGenericUnion(t reflect.Type) bool {
+	if t == reflect.TypeOf(nil) {
+		return false
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t.Implements(genericUnionType)
+}
+
+// IsValueGenericUnion reports whether v is a generic union, or a pointer to
+// one.
+func IsValueGenericUnion(v reflect.Value) bool {
+	return v.IsValid() && IsTypeGenericUnion(v.Type())
+}
+
+// GenericUnionValue returns the value that is stored within the generic union
+// v, which may be a pointer to a union. It returns nil if the union is unset
+// or v is a nil pointer.
+func GenericUnionValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface().(genericUnion).Value()
+}
+
+// GenericUnionTypes returns the Go types of the members of the generic union
+// type t, which may be a pointer to a union type.
+func GenericUnionTypes(t reflect.Type) []reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return reflect.Zero(t).Interface().(genericUnion).Types()
+}
+
+// NewGenericUnion returns a new generic union of type t, which may be a
+// pointer to a union type, whose value is set to v. An error is returned if v
+// is not of one of the union's member types.
+func NewGenericUnion(t reflect.Type, v interface{}) (reflect.Value, error) {
+	isPtr := t.Kind() == reflect.Ptr
+	if isPtr {
+		t = t.Elem()
+	}
+	if !IsTypeGenericUnion(t) {
+		return reflect.Value{}, fmt.Errorf("type %v is not a generic union", t)
+	}
+	nv := reflect.New(t)
+	if err := nv.Interface().(genericUnionSetter).SetValue(v); err != nil {
+		return reflect.Value{}, err
+	}
+	if isPtr {
+		return nv, nil
+	}
+	return nv.Elem(), nil
+}
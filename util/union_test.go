@@ -0,0 +1,115 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+// stringOrInt32Union is a minimal hand-written equivalent of a generic union
+// of a string and an int32.
+type stringOrInt32Union struct {
+	v interface{}
+}
+
+func (stringOrInt32Union) IsYANGGenericUnion() {}
+
+func (u stringOrInt32Union) Value() interface{} { return u.v }
+
+func (stringOrInt32Union) Types() []reflect.Type {
+	return []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(int32(0))}
+}
+
+func (u *stringOrInt32Union) SetValue(v interface{}) error {
+	switch v.(type) {
+	case nil, string, int32:
+		u.v = v
+		return nil
+	}
+	return fmt.Errorf("cannot set union to %T", v)
+}
+
+func TestIsTypeGenericUnion(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   reflect.Type
+		want bool
+	}{{
+		desc: "nil",
+		in:   reflect.TypeOf(nil),
+	}, {
+		desc: "struct",
+		in:   reflect.TypeOf(BasicStruct{}),
+	}, {
+		desc: "union",
+		in:   reflect.TypeOf(stringOrInt32Union{}),
+		want: true,
+	}, {
+		desc: "union ptr",
+		in:   reflect.TypeOf(&stringOrInt32Union{}),
+		want: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := IsTypeGenericUnion(tt.in); got != tt.want {
+				t.Errorf("IsTypeGenericUnion(%v): got %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenericUnionHelpers(t *testing.T) {
+	if got := GenericUnionValue(reflect.ValueOf((*stringOrInt32Union)(nil))); got != nil {
+		t.Errorf("GenericUnionValue(nil): got %v, want nil", got)
+	}
+	if got, want := GenericUnionValue(reflect.ValueOf(stringOrInt32Union{"foo"})), "foo"; got != want {
+		t.Errorf("GenericUnionValue: got %v, want %v", got, want)
+	}
+
+	ut := reflect.TypeOf(&stringOrInt32Union{})
+	if got, want := GenericUnionTypes(ut), (stringOrInt32Union{}).Types(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GenericUnionTypes: got %v, want %v", got, want)
+	}
+
+	pv, err := NewGenericUnion(ut, int32(42))
+	if err != nil {
+		t.Fatalf("NewGenericUnion: unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(&stringOrInt32Union{int32(42)}, pv.Interface(), cmp.AllowUnexported(stringOrInt32Union{})); diff != "" {
+		t.Errorf("NewGenericUnion: (-want, +got):\n%s", diff)
+	}
+	vv, err := NewGenericUnion(ut.Elem(), "foo")
+	if err != nil {
+		t.Fatalf("NewGenericUnion: unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(stringOrInt32Union{"foo"}, vv.Interface(), cmp.AllowUnexported(stringOrInt32Union{})); diff != "" {
+		t.Errorf("NewGenericUnion: (-want, +got):\n%s", diff)
+	}
+
+	_, err = NewGenericUnion(ut, true)
+	if diff := errdiff.Substring(err, "cannot set union"); diff != "" {
+		t.Errorf("NewGenericUnion: %s", diff)
+	}
+	_, err = NewGenericUnion(reflect.TypeOf(BasicStruct{}), "foo")
+	if diff := errdiff.Substring(err, "is not a generic union"); diff != "" {
+		t.Errorf("NewGenericUnion: %s", diff)
+	}
+}
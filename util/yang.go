@@ -37,6 +37,13 @@ var (
 // SchemaTree.
 const CompressedSchemaAnnotation string = "isCompressedSchema"
 
+// NotificationSchemaAnnotation stores the name of the annotation indicating
+// that a yang.Entry represents a YANG notification. It is added to the
+// notifications within a schema generated by ygen when the schema is loaded,
+// since the Kind of such entries is changed such that they are handled as
+// containers.
+const NotificationSchemaAnnotation string = "isNotification"
+
 // Children returns all child elements of a directory element e that are not
 // RPC entries.
 func Children(e *yang.Entry) []*yang.Entry {
@@ -50,6 +57,20 @@ func Children(e *yang.Entry) []*yang.Entry {
 	return entries
 }
 
+// DataTreeChildren returns all child elements of a directory element e that
+// are within the data tree, i.e., that are not RPC, action or notification
+// entries.
+func DataTreeChildren(e *yang.Entry) []*yang.Entry {
+	var entries []*yang.Entry
+
+	for _, e := range e.Dir {
+		if e.RPC == nil && !IsNotification(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
 // SchemaTreeRoot returns the root of the schema tree, given any node in that
 // tree. It returns nil if schema is nil.
 func SchemaTreeRoot(schema *yang.Entry) *yang.Entry {
@@ -118,6 +139,42 @@ func IsAnydata(e *yang.Entry) bool {
 	return e.Kind == yang.AnyDataEntry
 }
 
+// IsNotification returns true if the entry is a YANG notification, either
+// because it is of the notification kind, or because it has been annotated
+// as a notification with NotificationSchemaAnnotation.
+func IsNotification(e *yang.Entry) bool {
+	if e == nil {
+		return false
+	}
+	if e.Kind == yang.NotificationEntry {
+		return true
+	}
+	isNotification, ok := e.Annotation[NotificationSchemaAnnotation].(bool)
+	return ok && isNotification
+}
+
+// IsOperationDescendant returns true if the entry is a YANG notification, the
+// input or output of a YANG rpc or action, or a descendant of such an entry,
+// and hence is not part of the data tree.
+func IsOperationDescendant(e *yang.Entry) bool {
+	for ; e != nil; e = e.Parent {
+		if IsNotification(e) || e.RPC != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOperationRoot returns true if the entry is a YANG notification, or the
+// input or output of a YANG rpc or action, and hence is the root of a schema
+// tree that is distinct from the data tree.
+func IsOperationRoot(e *yang.Entry) bool {
+	if e == nil {
+		return false
+	}
+	return IsNotification(e) || (e.Parent != nil && e.Parent.RPC != nil)
+}
+
 // IsLeafRef reports whether schema is a leafref schema node type.
 func IsLeafRef(schema *yang.Entry) bool {
 	if schema == nil || schema.Type == nil {
@@ -16,6 +16,7 @@ package util
 
 import (
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -354,6 +355,48 @@ func TestYangChildren(t *testing.T) {
 	}
 }
 
+func TestDataTreeChildren(t *testing.T) {
+	in := &yang.Entry{
+		Dir: map[string]*yang.Entry{
+			"notification": {Name: "notification", Kind: yang.NotificationEntry},
+			"annotated": {
+				Name:       "annotated",
+				Kind:       yang.DirectoryEntry,
+				Annotation: map[string]interface{}{NotificationSchemaAnnotation: true},
+			},
+			"rpc":    {Name: "rpc", RPC: &yang.RPCEntry{}},
+			"config": {Name: "config"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		inFn           func(*yang.Entry) []*yang.Entry
+		wantChildNames []string
+	}{{
+		name:           "Children retains notifications",
+		inFn:           Children,
+		wantChildNames: []string{"annotated", "config", "notification"},
+	}, {
+		name:           "DataTreeChildren excludes notifications",
+		inFn:           DataTreeChildren,
+		wantChildNames: []string{"config"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, ch := range tt.inFn(in) {
+				got = append(got, ch.Name)
+			}
+			sort.Strings(got)
+			if diff := cmp.Diff(tt.wantChildNames, got); diff != "" {
+				t.Errorf("did not get expected children, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 // TestIsConfig tests the isConfig function to ensure that the config parameter is correctly
 // determined.
 func TestIsConfig(t *testing.T) {
@@ -933,6 +976,126 @@ func TestIsFakeRoot(t *testing.T) {
 	}
 }
 
+func TestIsNotification(t *testing.T) {
+	tests := []struct {
+		desc   string
+		schema *yang.Entry
+		want   bool
+	}{{
+		desc: "nil schema",
+	}, {
+		desc:   "container",
+		schema: &yang.Entry{Kind: yang.DirectoryEntry},
+	}, {
+		desc:   "notification",
+		schema: &yang.Entry{Kind: yang.NotificationEntry},
+		want:   true,
+	}, {
+		desc: "annotated notification",
+		schema: &yang.Entry{
+			Kind:       yang.DirectoryEntry,
+			Annotation: map[string]interface{}{NotificationSchemaAnnotation: true},
+		},
+		want: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got, want := IsNotification(tt.schema), tt.want; got != want {
+				t.Errorf("got: %v want: %v", got, want)
+			}
+		})
+	}
+}
+
+func TestIsOperationDescendant(t *testing.T) {
+	module := &yang.Entry{Name: "module"}
+	container := &yang.Entry{Name: "container", Kind: yang.DirectoryEntry, Parent: module}
+	notification := &yang.Entry{Name: "notification", Kind: yang.NotificationEntry, Parent: container}
+	notificationLeaf := &yang.Entry{Name: "leaf", Kind: yang.LeafEntry, Parent: notification}
+	rpc := &yang.Entry{Name: "rpc", Parent: module, RPC: &yang.RPCEntry{}}
+	input := &yang.Entry{Name: "input", Kind: yang.InputEntry, Parent: rpc}
+	inputLeaf := &yang.Entry{Name: "leaf", Kind: yang.LeafEntry, Parent: input}
+
+	tests := []struct {
+		desc   string
+		schema *yang.Entry
+		want   bool
+	}{{
+		desc: "nil schema",
+	}, {
+		desc:   "container",
+		schema: container,
+	}, {
+		desc:   "notification",
+		schema: notification,
+		want:   true,
+	}, {
+		desc:   "leaf within notification",
+		schema: notificationLeaf,
+		want:   true,
+	}, {
+		desc:   "rpc input",
+		schema: input,
+		want:   true,
+	}, {
+		desc:   "leaf within rpc input",
+		schema: inputLeaf,
+		want:   true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got, want := IsOperationDescendant(tt.schema), tt.want; got != want {
+				t.Errorf("got: %v want: %v", got, want)
+			}
+		})
+	}
+}
+
+func TestIsOperationRoot(t *testing.T) {
+	module := &yang.Entry{Name: "module"}
+	container := &yang.Entry{Name: "container", Kind: yang.DirectoryEntry, Parent: module}
+	notification := &yang.Entry{Name: "notification", Kind: yang.NotificationEntry, Parent: container}
+	notificationLeaf := &yang.Entry{Name: "leaf", Kind: yang.LeafEntry, Parent: notification}
+	rpc := &yang.Entry{Name: "rpc", Parent: module, RPC: &yang.RPCEntry{}}
+	output := &yang.Entry{Name: "output", Kind: yang.DirectoryEntry, Parent: rpc}
+	outputContainer := &yang.Entry{Name: "container", Kind: yang.DirectoryEntry, Parent: output}
+
+	tests := []struct {
+		desc   string
+		schema *yang.Entry
+		want   bool
+	}{{
+		desc: "nil schema",
+	}, {
+		desc:   "container",
+		schema: container,
+	}, {
+		desc:   "notification",
+		schema: notification,
+		want:   true,
+	}, {
+		desc:   "leaf within notification",
+		schema: notificationLeaf,
+	}, {
+		desc:   "rpc output",
+		schema: output,
+		want:   true,
+	}, {
+		desc:   "container within rpc output",
+		schema: outputContainer,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got, want := IsOperationRoot(tt.schema), tt.want; got != want {
+				t.Errorf("got: %v want: %v", got, want)
+			}
+		})
+	}
+}
+
 func TestIsOrNotKeyedList(t *testing.T) {
 	tests := []struct {
 		desc            string
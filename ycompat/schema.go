@@ -0,0 +1,263 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ycompat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// ModuleSet is a revision of a set of YANG modules.
+type ModuleSet struct {
+	// Files is the set of YANG files that are compared.
+	Files []string
+	// IncludePaths is the set of paths that are searched for modules
+	// that are imported or included by Files.
+	IncludePaths []string
+}
+
+// Schema is a snapshot of a revision of a set of YANG modules, and of the Go
+// code that is generated for them, containing the details that are compared
+// by Compare. A Schema can be serialised as JSON, such that the snapshot of a
+// released revision can be stored, and compared against later revisions.
+type Schema struct {
+	// Directories is the set of containers and lists that are mapped to
+	// structs in the generated code, keyed by their schema path.
+	Directories map[string]*Directory
+	// Enums is the set of generated enumerated types, keyed by their Go
+	// type name, with values that map the name of each of the type's
+	// constants to its value.
+	Enums map[string]map[string]int64
+}
+
+// Directory is a container or list that is mapped to a struct.
+type Directory struct {
+	// GoName is the name of the generated struct.
+	GoName string
+	// Kind is the kind of YANG node, i.e., container or list.
+	Kind string
+	// Key is the YANG key of a list, and is empty for containers.
+	Key string
+	// GoKey describes the names and Go types of the keys of a list, and
+	// is empty for containers.
+	GoKey string
+	// Fields is the set of fields of the directory, keyed by the YANG
+	// name of the field.
+	Fields map[string]*Field
+}
+
+// Field is a field of a Directory.
+type Field struct {
+	// Path is the schema path of the node that the field is mapped to.
+	Path string
+	// Kind is the kind of YANG node, i.e., container, list, leaf or
+	// leaf-list.
+	Kind string
+	// Config indicates whether the node is configuration.
+	Config bool
+	// Mandatory indicates whether the node is mandatory.
+	Mandatory bool
+	// Default is the default value of a leaf.
+	Default string
+	// Type is the YANG type of a leaf or leaf-list, and is nil for other
+	// nodes.
+	Type *Type
+	// GoName is the name of the field in the generated struct.
+	GoName string
+	// GoType is the name of the Go type of a leaf or leaf-list, and is
+	// empty for other nodes.
+	GoType string
+	// GoUnionTypes is the set of Go types of the members of a union,
+	// where the union is represented by a generated type.
+	GoUnionTypes []string
+}
+
+// Type is a YANG type.
+type Type struct {
+	// Name is the name of the type, including the name of its base type
+	// if it is a typedef.
+	Name string
+	// Kind is the name of the YANG built-in type that the type is based on.
+	Kind string
+	// FractionDigits is the number of fraction digits of a decimal64.
+	FractionDigits int
+	// Range is the range restriction of a numeric type.
+	Range yang.YangRange
+	// Length is the length restriction of a string or binary type.
+	Length yang.YangRange
+	// Patterns is the set of patterns of a string type.
+	Patterns []string
+	// LeafrefPath is the path of a leafref.
+	LeafrefPath string
+	// EnumValues maps the names of the values of an enumeration to their
+	// values.
+	EnumValues map[string]int64
+	// IdentityBase is the name of the base of an identityref.
+	IdentityBase string
+	// Identities is the set of names of the identities that are derived
+	// from the base of an identityref.
+	Identities []string
+	// UnionTypes is the set of member types of a union.
+	UnionTypes []*Type
+}
+
+// Load returns the Schema for the supplied module set, processed using cfg,
+// which must specify compressed paths, such that the Go API that is described
+// is that generated by ygen with the same options.
+//
+// goyang resolves identities using a dictionary that is global to the
+// process, such that the identities of modules parsed by an earlier call to
+// Load are retained. To ensure that identities that are removed between two
+// revisions of a module are detected, each revision should be loaded in a
+// separate process, and its Schema serialised between them.
+func Load(ms ModuleSet, cfg *ygen.DirectoryGenConfig) (*Schema, error) {
+	if !cfg.TransformationOptions.CompressBehaviour.CompressEnabled() {
+		return nil, fmt.Errorf("compression is disabled, but only compressed paths are supported")
+	}
+	dirs, leafTypes, errs := cfg.GetDirectoriesAndLeafTypes(ms.Files, ms.IncludePaths)
+	if errs != nil {
+		return nil, errs
+	}
+	opts := ygen.IROptions{
+		ParseOptions:                        cfg.ParseOptions,
+		TransformationOptions:               cfg.TransformationOptions,
+		AppendEnumSuffixForSimpleUnionEnums: cfg.GoOptions.AppendEnumSuffixForSimpleUnionEnums,
+	}
+	ir, err := ygen.GenerateIR(ms.Files, ms.IncludePaths, func() ygen.LangMapper { return ygen.NewGoLangMapper(opts) }, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Schema{
+		Directories: map[string]*Directory{},
+		Enums:       map[string]map[string]int64{},
+	}
+	for p, d := range dirs {
+		s.Directories[p] = newDirectory(d, leafTypes[p])
+	}
+	for _, e := range ir.Enums {
+		consts := map[string]int64{}
+		for v, n := range e.ValToCodeName {
+			consts[fmt.Sprintf("%s_%s", e.Name, n)] = v
+		}
+		s.Enums[fmt.Sprintf("E_%s", e.Name)] = consts
+	}
+	return s, nil
+}
+
+// newDirectory returns the Directory describing the ygen Directory d, whose
+// leaves have the supplied types.
+func newDirectory(d *ygen.Directory, leafTypes map[string]*ygen.MappedType) *Directory {
+	dir := &Directory{
+		GoName: d.Name,
+		Kind:   nodeKind(d.Entry),
+		Fields: map[string]*Field{},
+	}
+	if d.ListAttr != nil {
+		dir.Key = d.Entry.Key
+		var keys []string
+		for _, k := range d.ListAttr.KeyElems {
+			t := "unknown"
+			if mt, ok := d.ListAttr.Keys[k.Name]; ok && mt != nil {
+				t = mt.NativeType
+			}
+			keys = append(keys, fmt.Sprintf("%s %s", k.Name, t))
+		}
+		dir.GoKey = fmt.Sprintf("(%s)", strings.Join(keys, ", "))
+	}
+
+	goNames := ygen.GoFieldNameMap(d)
+	for n, e := range d.Fields {
+		f := &Field{
+			Path:      util.SchemaTreePath(e),
+			Kind:      nodeKind(e),
+			Config:    util.IsConfig(e),
+			Mandatory: e.Mandatory == yang.TSTrue,
+			GoName:    goNames[n],
+		}
+		if e.IsLeaf() || e.IsLeafList() {
+			f.Default = e.Default
+			f.Type = newType(e.Type)
+			if mt := leafTypes[n]; mt != nil {
+				f.GoType = mt.NativeType
+				if len(mt.UnionTypes) > 1 {
+					for t := range mt.UnionTypes {
+						f.GoUnionTypes = append(f.GoUnionTypes, t)
+					}
+					sort.Strings(f.GoUnionTypes)
+				}
+			}
+		}
+		dir.Fields[n] = f
+	}
+	return dir
+}
+
+// newType returns the Type describing the YANG type t.
+func newType(t *yang.YangType) *Type {
+	if t == nil {
+		return nil
+	}
+	nt := &Type{
+		Name:           typeName(t),
+		Kind:           t.Kind.String(),
+		FractionDigits: t.FractionDigits,
+		Range:          t.Range,
+		Length:         t.Length,
+		Patterns:       t.Pattern,
+		LeafrefPath:    t.Path,
+	}
+	if t.Enum != nil {
+		nt.EnumValues = t.Enum.NameMap()
+	}
+	if t.IdentityBase != nil {
+		nt.IdentityBase = t.IdentityBase.Name
+		for _, v := range t.IdentityBase.Values {
+			nt.Identities = append(nt.Identities, v.Name)
+		}
+		sort.Strings(nt.Identities)
+	}
+	for _, m := range t.Type {
+		nt.UnionTypes = append(nt.UnionTypes, newType(m))
+	}
+	return nt
+}
+
+// nodeKind returns the kind of YANG schema node that e represents.
+func nodeKind(e *yang.Entry) string {
+	switch {
+	case e.IsList():
+		return "list"
+	case e.IsLeafList():
+		return "leaf-list"
+	case e.IsLeaf():
+		return "leaf"
+	}
+	return "container"
+}
+
+// typeName returns the name of the YANG type t, along with its base type if
+// it is a typedef.
+func typeName(t *yang.YangType) string {
+	if t.Name == "" || t.Name == t.Kind.String() {
+		return t.Kind.String()
+	}
+	return fmt.Sprintf("%s (%s)", t.Name, t.Kind)
+}
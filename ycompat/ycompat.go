@@ -0,0 +1,486 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ycompat compares two revisions of a set of YANG modules, reporting
+// the changes between them that are breaking or non-breaking for clients of
+// the YANG schema, and for users of the Go code that ygen generates for it.
+//
+// Each revision is described by a Schema, which is produced by Load from the
+// Directory and leaf type output of ygen's GetDirectoriesAndLeafTypes, along
+// with the enumerated types of the ygen IR, such that the names and types of
+// the Go API that are compared are those that are used in the generated code.
+package ycompat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// Layer is the layer of the API in which a change is found.
+type Layer int64
+
+const (
+	// YANGLayer indicates a change to the YANG schema, which affects
+	// clients that use the schema directly, e.g., via gNMI.
+	YANGLayer Layer = iota
+	// GoLayer indicates a change to the generated Go API.
+	GoLayer
+)
+
+// String returns the name of the Layer.
+func (l Layer) String() string {
+	switch l {
+	case YANGLayer:
+		return "yang"
+	case GoLayer:
+		return "go"
+	}
+	return fmt.Sprintf("unknown layer %d", int64(l))
+}
+
+// Change is an individual change between two revisions of a schema.
+type Change struct {
+	// Layer is the layer of the API in which the change is found.
+	Layer Layer
+	// Breaking indicates whether the change is backwards-incompatible.
+	Breaking bool
+	// Path is the entity that is changed. For YANGLayer changes it is the
+	// schema path of the changed node, and for GoLayer changes it is the
+	// name of the changed Go type, optionally followed by the name of the
+	// changed field or constant, separated by a period.
+	Path string
+	// Description is a human-readable description of the change.
+	Description string
+}
+
+// String returns a single-line human-readable representation of the Change.
+func (c *Change) String() string {
+	kind := "non-breaking"
+	if c.Breaking {
+		kind = "breaking"
+	}
+	return fmt.Sprintf("%s %s %s: %s", kind, c.Layer, c.Path, c.Description)
+}
+
+// Report is the set of changes between two revisions of a schema.
+type Report struct {
+	// Changes is the set of changes, ordered by layer and then by path.
+	Changes []*Change
+}
+
+// HasBreaking returns true if the report contains a breaking change.
+func (r *Report) HasBreaking() bool {
+	for _, c := range r.Changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Breaking returns the breaking changes within the report.
+func (r *Report) Breaking() []*Change {
+	var b []*Change
+	for _, c := range r.Changes {
+		if c.Breaking {
+			b = append(b, c)
+		}
+	}
+	return b
+}
+
+// String returns a human-readable representation of the report, with one
+// change per line.
+func (r *Report) String() string {
+	var b strings.Builder
+	for _, c := range r.Changes {
+		b.WriteString(c.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Compare compares the oldSchema and newSchema revisions of a set of YANG
+// modules, returning a report of the changes between them.
+func Compare(oldSchema, newSchema *Schema) *Report {
+	c := &comparer{}
+	c.compareDirectories(oldSchema.Directories, newSchema.Directories)
+	c.compareEnums(oldSchema.Enums, newSchema.Enums)
+
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		a, b := c.changes[i], c.changes[j]
+		switch {
+		case a.Layer != b.Layer:
+			return a.Layer < b.Layer
+		case a.Path != b.Path:
+			return a.Path < b.Path
+		}
+		return a.Description < b.Description
+	})
+	return &Report{Changes: c.changes}
+}
+
+// comparer accumulates the changes found when comparing two schemas.
+type comparer struct {
+	changes []*Change
+}
+
+// add adds a change with the supplied details to the comparer.
+func (c *comparer) add(layer Layer, breaking bool, path, format string, args ...interface{}) {
+	c.changes = append(c.changes, &Change{
+		Layer:       layer,
+		Breaking:    breaking,
+		Path:        path,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// compareDirectories compares the directories of the old and new schemas,
+// which are keyed by their schema path.
+func (c *comparer) compareDirectories(oldDirs, newDirs map[string]*Directory) {
+	var removed, added []string
+	for _, p := range orderedKeys(oldDirs) {
+		od := oldDirs[p]
+		nd, ok := newDirs[p]
+		if !ok {
+			removed = append(removed, p)
+			c.add(GoLayer, true, od.GoName, "struct removed")
+			continue
+		}
+		if od.GoName != nd.GoName {
+			c.add(GoLayer, true, od.GoName, "struct renamed to %s", nd.GoName)
+		}
+		// Changes between containers and lists are reported by the
+		// parent directory's fields.
+		if od.Kind == "list" && nd.Kind == "list" {
+			if od.Key != nd.Key {
+				c.add(YANGLayer, true, p, "list key changed from %q to %q", od.Key, nd.Key)
+			}
+			if od.GoKey != nd.GoKey {
+				c.add(GoLayer, true, od.GoName, "key changed from %s to %s", od.GoKey, nd.GoKey)
+			}
+		}
+		c.compareFields(od, nd)
+	}
+	for _, p := range orderedKeys(newDirs) {
+		if _, ok := oldDirs[p]; !ok {
+			added = append(added, p)
+			c.add(GoLayer, false, newDirs[p].GoName, "struct added")
+		}
+	}
+
+	// Only the outermost directory of a removed or added subtree is
+	// reported as a YANG change, since its descendants are implicitly
+	// removed or added with it.
+	for _, p := range outermostPaths(removed) {
+		c.add(YANGLayer, true, p, "%s removed", oldDirs[p].Kind)
+	}
+	for _, p := range outermostPaths(added) {
+		c.add(YANGLayer, false, p, "%s added", newDirs[p].Kind)
+	}
+}
+
+// compareFields compares the fields of a directory in the old and new
+// schemas.
+func (c *comparer) compareFields(od, nd *Directory) {
+	for _, fn := range orderedKeys(od.Fields) {
+		of := od.Fields[fn]
+		goField := fmt.Sprintf("%s.%s", od.GoName, of.GoName)
+		nf, ok := nd.Fields[fn]
+		if !ok {
+			// Removed containers and lists are reported by
+			// compareDirectories.
+			if of.Type != nil {
+				c.add(YANGLayer, true, of.Path, "%s removed", of.Kind)
+			}
+			c.add(GoLayer, true, goField, "field removed")
+			continue
+		}
+
+		if of.GoName != nf.GoName {
+			c.add(GoLayer, true, goField, "field renamed to %s", nf.GoName)
+		}
+		if of.Kind != nf.Kind {
+			c.add(YANGLayer, true, nf.Path, "node changed from %s to %s", of.Kind, nf.Kind)
+			continue
+		}
+		if of.Config != nf.Config {
+			c.add(YANGLayer, true, nf.Path, "config changed from %v to %v", of.Config, nf.Config)
+		}
+		if of.Mandatory != nf.Mandatory {
+			c.add(YANGLayer, nf.Mandatory, nf.Path, "mandatory changed from %v to %v", of.Mandatory, nf.Mandatory)
+		}
+		if of.Default != nf.Default {
+			// The default of a state leaf is informational, since its
+			// value is always supplied by the server, such that only
+			// changes to the defaults of configuration leaves alter
+			// the behaviour of clients that omit them.
+			c.add(YANGLayer, nf.Config, nf.Path, "default changed from %q to %q", of.Default, nf.Default)
+		}
+		c.compareTypes(nf.Path, of.Type, nf.Type)
+		c.compareGoTypes(goField, of, nf)
+	}
+
+	for _, fn := range orderedKeys(nd.Fields) {
+		if _, ok := od.Fields[fn]; ok {
+			continue
+		}
+		nf := nd.Fields[fn]
+		switch {
+		case nf.Type == nil:
+			// Added containers and lists are reported by
+			// compareDirectories.
+		case nf.Mandatory && nf.Config:
+			// A mandatory configuration leaf must be populated by
+			// clients, and hence cannot be added compatibly.
+			c.add(YANGLayer, true, nf.Path, "mandatory %s added", nf.Kind)
+		default:
+			c.add(YANGLayer, false, nf.Path, "%s added", nf.Kind)
+		}
+		c.add(GoLayer, false, fmt.Sprintf("%s.%s", nd.GoName, nf.GoName), "field added")
+	}
+}
+
+// compareTypes compares the YANG types of the leaf at path p in the old and
+// new schemas.
+func (c *comparer) compareTypes(p string, ot, nt *Type) {
+	if ot == nil || nt == nil {
+		return
+	}
+	if ot.Kind != nt.Kind {
+		c.add(YANGLayer, true, p, "type changed from %s to %s", ot.Name, nt.Name)
+		return
+	}
+
+	if ot.FractionDigits != nt.FractionDigits {
+		c.add(YANGLayer, true, p, "fraction-digits changed from %d to %d", ot.FractionDigits, nt.FractionDigits)
+	}
+	c.compareRanges(p, "range", ot.Range, nt.Range)
+	c.compareRanges(p, "length", ot.Length, nt.Length)
+
+	oldPatterns, newPatterns := stringSet(ot.Patterns), stringSet(nt.Patterns)
+	for _, pat := range ot.Patterns {
+		if !newPatterns[pat] {
+			c.add(YANGLayer, false, p, "pattern %q removed", pat)
+		}
+	}
+	for _, pat := range nt.Patterns {
+		if !oldPatterns[pat] {
+			c.add(YANGLayer, true, p, "pattern %q added", pat)
+		}
+	}
+
+	if ot.LeafrefPath != nt.LeafrefPath {
+		c.add(YANGLayer, true, p, "leafref path changed from %q to %q", ot.LeafrefPath, nt.LeafrefPath)
+	}
+
+	renamed := enumRenames(ot.EnumValues, nt.EnumValues)
+	renamedTo := map[string]bool{}
+	for _, n := range orderedKeys(ot.EnumValues) {
+		nv, ok := nt.EnumValues[n]
+		switch {
+		case renamed[n] != "":
+			c.add(YANGLayer, true, p, "enum value %s renamed to %s", n, renamed[n])
+			renamedTo[renamed[n]] = true
+		case !ok:
+			c.add(YANGLayer, true, p, "enum value %s removed", n)
+		case nv != ot.EnumValues[n]:
+			c.add(YANGLayer, true, p, "value of enum %s changed from %d to %d", n, ot.EnumValues[n], nv)
+		}
+	}
+	for _, n := range orderedKeys(nt.EnumValues) {
+		if _, ok := ot.EnumValues[n]; !ok && !renamedTo[n] {
+			c.add(YANGLayer, false, p, "enum value %s added", n)
+		}
+	}
+
+	if ot.IdentityBase != nt.IdentityBase {
+		c.add(YANGLayer, true, p, "identityref base changed from %s to %s", ot.IdentityBase, nt.IdentityBase)
+	}
+	oldIDs, newIDs := stringSet(ot.Identities), stringSet(nt.Identities)
+	for _, n := range ot.Identities {
+		if !newIDs[n] {
+			c.add(YANGLayer, true, p, "identity %s removed", n)
+		}
+	}
+	for _, n := range nt.Identities {
+		if !oldIDs[n] {
+			c.add(YANGLayer, false, p, "identity %s added", n)
+		}
+	}
+
+	oldMembers, newMembers := unionMembers(ot), unionMembers(nt)
+	for _, n := range orderedKeys(oldMembers) {
+		if nm, ok := newMembers[n]; ok {
+			c.compareTypes(p, oldMembers[n], nm)
+			continue
+		}
+		c.add(YANGLayer, true, p, "union member type %s removed", n)
+	}
+	for _, n := range orderedKeys(newMembers) {
+		if _, ok := oldMembers[n]; !ok {
+			c.add(YANGLayer, false, p, "union member type %s added", n)
+		}
+	}
+}
+
+// compareRanges compares the old and new values of a range or length
+// restriction, named kind, of the leaf at path p. An empty range indicates
+// that the values are unrestricted.
+func (c *comparer) compareRanges(p, kind string, or, nr yang.YangRange) {
+	switch {
+	case or.Equal(nr):
+	case len(nr) == 0:
+		c.add(YANGLayer, false, p, "%s restriction %s removed", kind, or)
+	case len(or) == 0:
+		c.add(YANGLayer, true, p, "%s restriction %s added", kind, nr)
+	case nr.Contains(or):
+		c.add(YANGLayer, false, p, "%s widened from %s to %s", kind, or, nr)
+	default:
+		c.add(YANGLayer, true, p, "%s narrowed from %s to %s", kind, or, nr)
+	}
+}
+
+// compareGoTypes compares the Go types of the field goField in the old and
+// new generated code.
+func (c *comparer) compareGoTypes(goField string, of, nf *Field) {
+	if of.GoType != nf.GoType {
+		c.add(GoLayer, true, goField, "type changed from %s to %s", of.GoType, nf.GoType)
+		return
+	}
+	if len(of.GoUnionTypes) == 0 || len(nf.GoUnionTypes) == 0 {
+		return
+	}
+	oldMembers, newMembers := stringSet(of.GoUnionTypes), stringSet(nf.GoUnionTypes)
+	for _, t := range of.GoUnionTypes {
+		if !newMembers[t] {
+			c.add(GoLayer, true, goField, "union member type %s removed", t)
+		}
+	}
+	for _, t := range nf.GoUnionTypes {
+		if !oldMembers[t] {
+			c.add(GoLayer, false, goField, "union member type %s added", t)
+		}
+	}
+}
+
+// compareEnums compares the generated Go enumerated types of the old and new
+// schemas.
+func (c *comparer) compareEnums(oldEnums, newEnums map[string]map[string]int64) {
+	for _, name := range orderedKeys(oldEnums) {
+		oldConsts := oldEnums[name]
+		newConsts, ok := newEnums[name]
+		if !ok {
+			c.add(GoLayer, true, name, "enumerated type removed")
+			continue
+		}
+		renamed := enumRenames(oldConsts, newConsts)
+		renamedTo := map[string]bool{}
+		for _, cn := range orderedKeys(oldConsts) {
+			nv, ok := newConsts[cn]
+			switch {
+			case renamed[cn] != "":
+				c.add(GoLayer, true, cn, "constant renamed to %s", renamed[cn])
+				renamedTo[renamed[cn]] = true
+			case !ok:
+				c.add(GoLayer, true, cn, "constant removed")
+			case nv != oldConsts[cn]:
+				// Renumbering changes the value of the constant, which
+				// breaks any stored or serialised values of the type.
+				c.add(GoLayer, true, cn, "constant renumbered from %d to %d", oldConsts[cn], nv)
+			}
+		}
+		for _, cn := range orderedKeys(newConsts) {
+			if _, ok := oldConsts[cn]; !ok && !renamedTo[cn] {
+				c.add(GoLayer, false, cn, "constant added")
+			}
+		}
+	}
+	for _, name := range orderedKeys(newEnums) {
+		if _, ok := oldEnums[name]; !ok {
+			c.add(GoLayer, false, name, "enumerated type added")
+		}
+	}
+}
+
+// enumRenames returns the names within oldValues that are removed in
+// newValues and are assumed to have been renamed, mapped to their new names.
+// A removed name is assumed to have been renamed where exactly one removed
+// name and exactly one added name have its value, since renaming an
+// enumerated value does not change its value. Where a value is ambiguous,
+// the names are reported as removed and added.
+func enumRenames(oldValues, newValues map[string]int64) map[string]string {
+	removed, added := map[int64][]string{}, map[int64][]string{}
+	for n, v := range oldValues {
+		if _, ok := newValues[n]; !ok {
+			removed[v] = append(removed[v], n)
+		}
+	}
+	for n, v := range newValues {
+		if _, ok := oldValues[n]; !ok {
+			added[v] = append(added[v], n)
+		}
+	}
+	renames := map[string]string{}
+	for v, names := range removed {
+		if len(names) == 1 && len(added[v]) == 1 {
+			renames[names[0]] = added[v][0]
+		}
+	}
+	return renames
+}
+
+// unionMembers returns the member types of the union t, keyed by their name.
+func unionMembers(t *Type) map[string]*Type {
+	members := map[string]*Type{}
+	for _, m := range t.UnionTypes {
+		members[m.Name] = m
+	}
+	return members
+}
+
+// stringSet returns the set of strings within s.
+func stringSet(s []string) map[string]bool {
+	set := make(map[string]bool, len(s))
+	for _, v := range s {
+		set[v] = true
+	}
+	return set
+}
+
+// outermostPaths returns the paths within the sorted slice paths that are
+// not descendants of another path within it.
+func outermostPaths(paths []string) []string {
+	var out []string
+	for _, p := range paths {
+		if len(out) != 0 && strings.HasPrefix(p, out[len(out)-1]+"/") {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// orderedKeys returns the keys of the map m in alphabetical order.
+func orderedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
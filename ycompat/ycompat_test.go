@@ -0,0 +1,272 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ycompat
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/ygen"
+)
+
+var (
+	compressed = &ygen.DirectoryGenConfig{
+		TransformationOptions: ygen.TransformationOpts{
+			CompressBehaviour: genutil.PreferIntendedConfig,
+		},
+	}
+	oldModules = ModuleSet{Files: []string{filepath.Join("testdata", "old", "compat-test.yang")}}
+	newModules = ModuleSet{Files: []string{filepath.Join("testdata", "new", "compat-test.yang")}}
+)
+
+func TestCompare(t *testing.T) {
+	// Each revision is loaded once, with the old revision loaded first,
+	// since goyang retains the identities of previously parsed modules.
+	oldSchema, err := Load(oldModules, compressed)
+	if err != nil {
+		t.Fatalf("Load(old): unexpected error: %v", err)
+	}
+	newSchema, err := Load(newModules, compressed)
+	if err != nil {
+		t.Fatalf("Load(new): unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		desc         string
+		inOld        *Schema
+		inNew        *Schema
+		want         []string
+		wantBreaking bool
+	}{{
+		desc:  "identical modules",
+		inOld: oldSchema,
+		inNew: oldSchema,
+	}, {
+		desc:  "changed modules",
+		inOld: oldSchema,
+		inNew: newSchema,
+		want: []string{
+			`non-breaking yang /compat-test/top/added-container: container added`,
+			`non-breaking yang /compat-test/top/config/added-leaf: leaf added`,
+			`non-breaking yang /compat-test/top/config/animal: identity CAT added`,
+			`breaking yang /compat-test/top/config/colour: enum value GREEN removed`,
+			`non-breaking yang /compat-test/top/config/colour: enum value YELLOW added`,
+			`breaking yang /compat-test/top/config/colour: value of enum BLUE changed from 2 to 5`,
+			`breaking yang /compat-test/top/config/defaulted: default changed from "10" to "20"`,
+			`breaking yang /compat-test/top/config/multi: union member type boolean removed`,
+			`breaking yang /compat-test/top/config/narrowed: range narrowed from 1..100 to 1..50`,
+			`breaking yang /compat-test/top/config/patterned: pattern "[a-z]+" added`,
+			`breaking yang /compat-test/top/config/removed-leaf: leaf removed`,
+			`breaking yang /compat-test/top/config/required: mandatory leaf added`,
+			`breaking yang /compat-test/top/config/retyped: type changed from string to int32`,
+			`breaking yang /compat-test/top/config/size: enum value LARGE renamed to BIG`,
+			`non-breaking yang /compat-test/top/config/widened: range widened from 1..10 to 1..20`,
+			`breaking yang /compat-test/top/items/item: list key changed from "id" to "name"`,
+			`breaking yang /compat-test/top/items/item/config/id: leaf removed`,
+			`non-breaking yang /compat-test/top/items/item/config/name: leaf added`,
+			`breaking yang /compat-test/top/removed-container: container removed`,
+			`non-breaking yang /compat-test/top/state/interval: default changed from "1" to "2"`,
+			`non-breaking go CompatTest_BASE_CAT: constant added`,
+			`breaking go CompatTest_BASE_DOG: constant renumbered from 1 to 2`,
+			`breaking go CompatTest_BASE_FISH: constant renumbered from 2 to 3`,
+			`breaking go CompatTest_Colour_BLUE: constant renumbered from 3 to 6`,
+			`breaking go CompatTest_Colour_GREEN: constant removed`,
+			`non-breaking go CompatTest_Colour_YELLOW: constant added`,
+			`breaking go CompatTest_Size_LARGE: constant renamed to CompatTest_Size_BIG`,
+			`non-breaking go Top.AddedContainer: field added`,
+			`non-breaking go Top.AddedLeaf: field added`,
+			`breaking go Top.Multi: union member type bool removed`,
+			`breaking go Top.RemovedContainer: field removed`,
+			`breaking go Top.RemovedLeaf: field removed`,
+			`non-breaking go Top.Required: field added`,
+			`breaking go Top.Retyped: type changed from string to int32`,
+			`non-breaking go Top_AddedContainer: struct added`,
+			`breaking go Top_Item: key changed from (id uint32) to (name string)`,
+			`breaking go Top_Item.Id: field removed`,
+			`non-breaking go Top_Item.Name: field added`,
+			`breaking go Top_RemovedContainer: struct removed`,
+			`breaking go Top_RemovedContainer_Inner: struct removed`,
+		},
+		wantBreaking: true,
+	}, {
+		desc:  "reverse changes",
+		inOld: newSchema,
+		inNew: oldSchema,
+		want: []string{
+			`breaking yang /compat-test/top/added-container: container removed`,
+			`breaking yang /compat-test/top/config/added-leaf: leaf removed`,
+			`breaking yang /compat-test/top/config/animal: identity CAT removed`,
+			`non-breaking yang /compat-test/top/config/colour: enum value GREEN added`,
+			`breaking yang /compat-test/top/config/colour: enum value YELLOW removed`,
+			`breaking yang /compat-test/top/config/colour: value of enum BLUE changed from 5 to 2`,
+			`breaking yang /compat-test/top/config/defaulted: default changed from "20" to "10"`,
+			`non-breaking yang /compat-test/top/config/multi: union member type boolean added`,
+			`non-breaking yang /compat-test/top/config/narrowed: range widened from 1..50 to 1..100`,
+			`non-breaking yang /compat-test/top/config/patterned: pattern "[a-z]+" removed`,
+			`non-breaking yang /compat-test/top/config/removed-leaf: leaf added`,
+			`breaking yang /compat-test/top/config/required: leaf removed`,
+			`breaking yang /compat-test/top/config/retyped: type changed from int32 to string`,
+			`breaking yang /compat-test/top/config/size: enum value BIG renamed to LARGE`,
+			`breaking yang /compat-test/top/config/widened: range narrowed from 1..20 to 1..10`,
+			`breaking yang /compat-test/top/items/item: list key changed from "name" to "id"`,
+			`non-breaking yang /compat-test/top/items/item/config/id: leaf added`,
+			`breaking yang /compat-test/top/items/item/config/name: leaf removed`,
+			`non-breaking yang /compat-test/top/removed-container: container added`,
+			`non-breaking yang /compat-test/top/state/interval: default changed from "2" to "1"`,
+			`breaking go CompatTest_BASE_CAT: constant removed`,
+			`breaking go CompatTest_BASE_DOG: constant renumbered from 2 to 1`,
+			`breaking go CompatTest_BASE_FISH: constant renumbered from 3 to 2`,
+			`breaking go CompatTest_Colour_BLUE: constant renumbered from 6 to 3`,
+			`non-breaking go CompatTest_Colour_GREEN: constant added`,
+			`breaking go CompatTest_Colour_YELLOW: constant removed`,
+			`breaking go CompatTest_Size_BIG: constant renamed to CompatTest_Size_LARGE`,
+			`breaking go Top.AddedContainer: field removed`,
+			`breaking go Top.AddedLeaf: field removed`,
+			`non-breaking go Top.Multi: union member type bool added`,
+			`non-breaking go Top.RemovedContainer: field added`,
+			`non-breaking go Top.RemovedLeaf: field added`,
+			`breaking go Top.Required: field removed`,
+			`breaking go Top.Retyped: type changed from int32 to string`,
+			`breaking go Top_AddedContainer: struct removed`,
+			`breaking go Top_Item: key changed from (name string) to (id uint32)`,
+			`non-breaking go Top_Item.Id: field added`,
+			`breaking go Top_Item.Name: field removed`,
+			`non-breaking go Top_RemovedContainer: struct added`,
+			`non-breaking go Top_RemovedContainer_Inner: struct added`,
+		},
+		wantBreaking: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := Compare(tt.inOld, tt.inNew)
+			var gotChanges []string
+			for _, c := range got.Changes {
+				gotChanges = append(gotChanges, c.String())
+			}
+			if diff := cmp.Diff(tt.want, gotChanges); diff != "" {
+				t.Errorf("Compare: did not get expected changes, (-want, +got):\n%s", diff)
+			}
+			if gotBreaking := got.HasBreaking(); gotBreaking != tt.wantBreaking {
+				t.Errorf("HasBreaking: got %v, want %v", gotBreaking, tt.wantBreaking)
+			}
+			for _, c := range got.Breaking() {
+				if !c.Breaking {
+					t.Errorf("Breaking: got non-breaking change %s", c)
+				}
+			}
+			if gotString, wantLines := got.String(), len(tt.want); strings.Count(gotString, "\n") != wantLines {
+				t.Errorf("String: got %d lines, want %d", strings.Count(gotString, "\n"), wantLines)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inModules        ModuleSet
+		inConfig         *ygen.DirectoryGenConfig
+		wantErrSubstring string
+	}{{
+		desc:      "valid modules",
+		inModules: oldModules,
+		inConfig:  compressed,
+	}, {
+		desc:             "uncompressed paths",
+		inModules:        oldModules,
+		inConfig:         &ygen.DirectoryGenConfig{},
+		wantErrSubstring: "compression is disabled",
+	}, {
+		desc:             "missing module",
+		inModules:        ModuleSet{Files: []string{filepath.Join("testdata", "new", "missing.yang")}},
+		inConfig:         compressed,
+		wantErrSubstring: "missing.yang",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Load(tt.inModules, tt.inConfig)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Load: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			// The schema must be unchanged when serialised as JSON, such
+			// that stored snapshots can be compared.
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("cannot marshal schema: %v", err)
+			}
+			rt := &Schema{}
+			if err := json.Unmarshal(b, rt); err != nil {
+				t.Fatalf("cannot unmarshal schema: %v", err)
+			}
+			if diff := cmp.Diff(got, rt); diff != "" {
+				t.Errorf("did not get same schema after JSON round trip, (-want, +got):\n%s", diff)
+			}
+			if r := Compare(got, rt); len(r.Changes) != 0 {
+				t.Errorf("Compare: got changes after JSON round trip:\n%s", r)
+			}
+		})
+	}
+}
+
+func TestEnumRenames(t *testing.T) {
+	tests := []struct {
+		desc  string
+		inOld map[string]int64
+		inNew map[string]int64
+		want  map[string]string
+	}{{
+		desc:  "renamed value",
+		inOld: map[string]int64{"A": 0, "B": 1},
+		inNew: map[string]int64{"A": 0, "C": 1},
+		want:  map[string]string{"B": "C"},
+	}, {
+		desc:  "removed and added values with different values",
+		inOld: map[string]int64{"A": 0, "B": 1},
+		inNew: map[string]int64{"A": 0, "C": 2},
+		want:  map[string]string{},
+	}, {
+		desc:  "ambiguous added values",
+		inOld: map[string]int64{"A": 0, "B": 1},
+		inNew: map[string]int64{"C": 0, "D": 1, "E": 1},
+		want:  map[string]string{"A": "C"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, enumRenames(tt.inOld, tt.inNew)); diff != "" {
+				t.Errorf("enumRenames: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOutermostPaths(t *testing.T) {
+	in := []string{"/a", "/a/b", "/a/b/c", "/ab", "/c/d", "/c/d/e"}
+	want := []string{"/a", "/ab", "/c/d"}
+	if diff := cmp.Diff(want, outermostPaths(in)); diff != "" {
+		t.Errorf("outermostPaths: (-want, +got):\n%s", diff)
+	}
+}
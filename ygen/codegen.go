@@ -67,6 +67,9 @@ type GeneratorConfig struct {
 	GoOptions GoOpts
 	// ProtoOptions stores a struct which contains Protobuf specific options.
 	ProtoOptions ProtoOpts
+	// JSONSchemaOptions stores a struct which contains options that are
+	// specific to the generation of JSON Schema and OpenAPI documents.
+	JSONSchemaOptions JSONSchemaOpts
 	// IncludeDescriptions specifies that YANG entry descriptions are added
 	// to the JSON schema. Is false by default, to reduce the size of generated schema
 	IncludeDescriptions bool
@@ -127,6 +130,31 @@ type ParseOpts struct {
 	// When it is disabled, two different enumerations (ModuleName_(State|Config)_Enabled)
 	// will be output in the generated code.
 	SkipEnumDeduplication bool
+	// GenerateRPCTypes specifies whether the input and output of YANG rpc
+	// and action statements should be mapped to directories within the
+	// generated code. By default (false), rpc and action statements are
+	// ignored.
+	GenerateRPCTypes bool
+	// GenerateNotificationTypes specifies whether YANG notification
+	// statements, including those nested within containers and lists,
+	// should be mapped to directories within the generated code. By default
+	// (false), notification statements are ignored.
+	GenerateNotificationTypes bool
+	// EnabledFeatures specifies the set of YANG features that are enabled
+	// for code generation. Each feature is specified in the form
+	// "module:feature", where module is the name of the module that
+	// defines the feature. The feature name AllFeatures (i.e.,
+	// "module:all") enables every feature defined by the module. When
+	// EnabledFeatures is nil, if-feature statements are ignored and all
+	// nodes in the schema are generated. When it is non-nil, any node whose
+	// if-feature statements are not satisfied by the set of enabled
+	// features is removed from the schema prior to code generation.
+	EnabledFeatures []string
+	// DeviationModules specifies the paths to a set of YANG modules that
+	// contain deviation statements that should be applied to the input
+	// schema. The deviation modules are parsed alongside the input modules,
+	// but code is not generated for any entities that they define.
+	DeviationModules []string
 }
 
 // TransformationOpts specifies transformations to the generated code with
@@ -206,6 +234,13 @@ type GoOpts struct {
 	// represent union subtypes in the generated code instead of using
 	// wrapper types.
 	GenerateSimpleUnions bool
+	// GenerateGenericUnions specifies whether the generic union types of
+	// the ygot package (ygot.Union2, ygot.Union3, etc.) are used to
+	// represent multi-type unions in the generated code. Each union is
+	// output as an alias of the generic union type whose type parameters
+	// are the Go types of the union's members. It takes precedence over
+	// GenerateSimpleUnions.
+	GenerateGenericUnions bool
 	// GenerateLeafGetters specifies whether Get* methods should be created for
 	// leaf fields of a struct. Care should be taken with this option since a Get
 	// method returns the *Go* zero value for a particular entity if the field is
@@ -213,6 +248,20 @@ type GoOpts struct {
 	// whether a field has been explicitly set to the zero value (i.e., an integer
 	// field is set to 0), or whether the field was actually unset.
 	GenerateLeafGetters bool
+	// GenerateLeafSetters specifies whether Set* methods should be created
+	// for leaf fields of a struct. Each method sets the value of the field
+	// to its argument, and returns the receiver such that calls can be
+	// chained. Setters are not generated for the key fields of a list, since
+	// changing their values would make them inconsistent with the key of the
+	// map that the list entry is stored in.
+	GenerateLeafSetters bool
+	// GenerateListEntryConstructors specifies whether a New* function should
+	// be created for the struct representing each keyed list. The function
+	// takes the values of the list's keys as arguments, and returns a new
+	// list entry whose key fields are populated, such that the entry can
+	// be added to a list (e.g., using an Append* method) with a key that is
+	// consistent with its fields.
+	GenerateListEntryConstructors bool
 	// GNMIProtoPath specifies the path to the generated gNMI protobuf, which
 	// is used to store the catalogue entries for generated modules.
 	GNMIProtoPath string
@@ -229,6 +278,20 @@ type GoOpts struct {
 	// only applies when useDefiningModuleForTypedefEnumNames is also set
 	// to true.
 	AppendEnumSuffixForSimpleUnionEnums bool
+	// GenerateDeepCopyMethod specifies whether ΛDeepCopy and ΛMerge methods
+	// should be generated for each struct. These methods allow ygot.DeepCopy,
+	// ygot.MergeStructs and ygot.MergeStructInto to copy and merge structs
+	// without the use of reflection.
+	GenerateDeepCopyMethod bool
+	// GenerateEqualMethod specifies whether a ΛEqual method should be
+	// generated for each struct. The method allows the ygot.Equal and
+	// ygot.Diff functions to compare structs without the use of reflection.
+	GenerateEqualMethod bool
+	// GenerateOrderedMaps specifies whether keyed lists that are
+	// "ordered-by user" should be represented by a generated ordered map
+	// type, rather than a Go map, such that the order of the list's
+	// entries is retained.
+	GenerateOrderedMaps bool
 }
 
 // ProtoOpts stores Protobuf specific options for the code generation library.
@@ -290,8 +353,9 @@ func NewYANGCodeGenerator(c *GeneratorConfig) *YANGCodeGenerator {
 // yangEnum represents an enumerated type in YANG that is to be output in the
 // Go code. The enumerated type may be a YANG 'identity' or enumeration.
 type yangEnum struct {
-	name  string      // name is the name of the enumeration or identity.
-	entry *yang.Entry // entry is the yang.Entry corresponding to the enumerated value.
+	name  string              // name is the name of the enumeration or identity.
+	entry *yang.Entry         // entry is the yang.Entry corresponding to the enumerated value.
+	kind  EnumeratedValueType // kind is the type of YANG construct from which the enumerated value was derived.
 }
 
 // GeneratedGoCode contains generated code snippets that can be processed by the calling
@@ -329,6 +393,17 @@ type GeneratedGoCode struct {
 	RawJSONSchema []byte
 	// EnumTypeMap is a Go map that allows YANG schemapaths to be mapped to reflect.Type values.
 	EnumTypeMap string
+	// RPCTypeMap is a Go map that allows the qualified names of YANG rpcs and
+	// actions to be mapped to the types of their generated input and output
+	// structs. It is populated only if the GenerateRPCTypes ParseOpts field is
+	// set to true.
+	RPCTypeMap string
+	// NotificationTypeMap is a Go map that allows the paths of YANG
+	// notifications to be mapped to the types of their generated structs,
+	// along with a function to unmarshal a notification where the schema
+	// is generated. It is populated only if the GenerateNotificationTypes
+	// ParseOpts field is set to true.
+	NotificationTypeMap string
 }
 
 // GeneratedProto3 stores a set of generated Protobuf packages.
@@ -378,7 +453,7 @@ func IsFakeRoot(e *yang.Entry) bool {
 // checkForBinaryKeys returns a non-empty list of errors if the input directory
 // has one or more binary types (including union types containing binary types)
 // as a list key.
-func checkForBinaryKeys(dir *Directory) []error {
+func checkForBinaryKeys(dir *ParsedDirectory) []error {
 	var errs []error
 	if dir.ListAttr != nil {
 		for _, t := range dir.ListAttr.Keys {
@@ -409,26 +484,21 @@ func checkForBinaryKeys(dir *Directory) []error {
 //	   within the specified models.
 // If errors are encountered during code generation, an error is returned.
 func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*GeneratedGoCode, util.Errors) {
-	// Extract the entities to be mapped into structs and enumerations in the output
-	// Go code. Extract the schematree from the modules provided such that it can be
-	// used to reference entities within the tree.
-	mdef, errs := mappedDefinitions(yangFiles, includePaths, &cg.Config)
-	if errs != nil {
-		return nil, errs
+	// Produce the IR for the schema, using the Go LangMapper such that the
+	// names within the IR match those used within the generated code. The
+	// generator state is retained such that it can be used to reference
+	// entities within the schema during code generation.
+	opts := IROptions{
+		ParseOptions:                        cg.Config.ParseOptions,
+		TransformationOptions:               cg.Config.TransformationOptions,
+		AppendEnumSuffixForSimpleUnionEnums: cg.Config.GoOptions.AppendEnumSuffixForSimpleUnionEnums,
 	}
-
-	enumSet, goEnums, errs := findEnumSet(mdef.enumEntries, cg.Config.TransformationOptions.CompressBehaviour.CompressEnabled(), false, cg.Config.ParseOptions.SkipEnumDeduplication, cg.Config.TransformationOptions.ShortenEnumLeafNames, cg.Config.TransformationOptions.UseDefiningModuleForTypedefEnumNames, cg.Config.GoOptions.AppendEnumSuffixForSimpleUnionEnums, false, cg.Config.TransformationOptions.EnumOrgPrefixesToTrim)
-	if errs != nil {
-		return nil, errs
-	}
-
-	// Store the returned schematree and enumSet within the state for this code generation.
-	gogen := newGoGenState(mdef.schematree, enumSet)
-
-	directoryMap, errs := gogen.buildDirectoryDefinitions(mdef.directoryEntries, cg.Config.TransformationOptions.CompressBehaviour, cg.Config.TransformationOptions.GenerateFakeRoot, cg.Config.ParseOptions.SkipEnumDeduplication, cg.Config.TransformationOptions.ShortenEnumLeafNames, cg.Config.TransformationOptions.UseDefiningModuleForTypedefEnumNames, cg.Config.TransformationOptions.EnumOrgPrefixesToTrim)
+	langMapper := newGoLangMapper(opts)
+	gir, errs := generateIR(yangFiles, includePaths, langMapper, opts)
 	if errs != nil {
 		return nil, errs
 	}
+	gogen, directoryMap, mdef := langMapper.goGenState, gir.ir.Directories, gir.defs
 
 	var rootName string
 	if rootName = resolveRootName(cg.Config.TransformationOptions.FakeRootName, defaultRootName, cg.Config.TransformationOptions.GenerateFakeRoot); rootName != "" {
@@ -446,8 +516,7 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 	}
 
 	// Alphabetically order directories to produce deterministic output.
-	orderedDirNames, dirNameMap, err := GetOrderedDirectories(directoryMap)
-
+	orderedDirPaths, err := gir.ir.OrderedDirectoryPathsByName()
 	if err != nil {
 		return nil, util.AppendErr(codegenErr, err)
 	}
@@ -455,12 +524,12 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 	// enumTypeMap stores the map of the path to type.
 	enumTypeMap := map[string][]string{}
 	var structSnippets []GoStructCodeSnippet
-	for _, directoryName := range orderedDirNames {
-		if errs := checkForBinaryKeys(dirNameMap[directoryName]); len(errs) != 0 {
+	for _, p := range orderedDirPaths {
+		if errs := checkForBinaryKeys(directoryMap[p]); len(errs) != 0 {
 			codegenErr = util.AppendErrs(codegenErr, errs)
 			continue
 		}
-		structOut, errs := writeGoStruct(dirNameMap[directoryName], directoryMap, gogen,
+		structOut, errs := writeGoStruct(directoryMap[p], directoryMap, gogen,
 			cg.Config.TransformationOptions.CompressBehaviour.CompressEnabled(), cg.Config.TransformationOptions.IgnoreShadowSchemaPaths, cg.Config.GenerateJSONSchema, cg.Config.ParseOptions.SkipEnumDeduplication, cg.Config.TransformationOptions.ShortenEnumLeafNames, cg.Config.TransformationOptions.UseDefiningModuleForTypedefEnumNames, cg.Config.TransformationOptions.EnumOrgPrefixesToTrim, cg.Config.GoOptions)
 		if errs != nil {
 			codegenErr = util.AppendErrs(codegenErr, errs)
@@ -475,11 +544,27 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 		}
 	}
 
-	enumSnippets, enumMap, errs := generateEnumCode(goEnums)
+	enumSnippets, enumMap, errs := generateEnumCode(gir.ir.Enums)
 	if errs != nil {
 		codegenErr = util.AppendErrs(codegenErr, errs)
 	}
 
+	var rpcTypeMapCode string
+	if cg.Config.ParseOptions.GenerateRPCTypes {
+		var errs []error
+		if rpcTypeMapCode, errs = generateRPCTypeMap(directoryMap); errs != nil {
+			codegenErr = util.AppendErrs(codegenErr, errs)
+		}
+	}
+
+	var notificationTypeMapCode string
+	if cg.Config.ParseOptions.GenerateNotificationTypes {
+		var errs []error
+		if notificationTypeMapCode, errs = generateNotificationTypeMap(directoryMap, cg.Config.GenerateJSONSchema); errs != nil {
+			codegenErr = util.AppendErrs(codegenErr, errs)
+		}
+	}
+
 	var rawSchema []byte
 	var jsonSchema string
 	var enumTypeMapCode string
@@ -508,14 +593,16 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 	}
 
 	return &GeneratedGoCode{
-		CommonHeader:   commonHeader,
-		OneOffHeader:   oneoffHeader,
-		Structs:        structSnippets,
-		Enums:          enumSnippets,
-		EnumMap:        enumMap,
-		JSONSchemaCode: jsonSchema,
-		RawJSONSchema:  rawSchema,
-		EnumTypeMap:    enumTypeMapCode,
+		CommonHeader:        commonHeader,
+		OneOffHeader:        oneoffHeader,
+		Structs:             structSnippets,
+		Enums:               enumSnippets,
+		EnumMap:             enumMap,
+		JSONSchemaCode:      jsonSchema,
+		RawJSONSchema:       rawSchema,
+		EnumTypeMap:         enumTypeMapCode,
+		RPCTypeMap:          rpcTypeMapCode,
+		NotificationTypeMap: notificationTypeMapCode,
 	}, nil
 }
 
@@ -532,10 +619,6 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 // modules that are included by the specified set of modules, or submodules of
 // those modules). Any errors encountered during code generation are returned.
 func (dcg *DirectoryGenConfig) GetDirectoriesAndLeafTypes(yangFiles, includePaths []string) (map[string]*Directory, map[string]map[string]*MappedType, util.Errors) {
-	if !dcg.TransformationOptions.CompressBehaviour.CompressEnabled() {
-		return nil, nil, util.Errors{fmt.Errorf("GetDirectoriesAndLeafTypes currently does not have unit tests for when compression is disabled; if support needed, add unit tests and remove this error")}
-	}
-
 	cg := &GeneratorConfig{ParseOptions: dcg.ParseOptions, TransformationOptions: dcg.TransformationOptions, GoOptions: dcg.GoOptions}
 	// Extract the entities to be mapped into structs and enumerations in the output
 	// Go code. Extract the schematree from the modules provided such that it can be
@@ -593,17 +676,20 @@ func (dcg *DirectoryGenConfig) GetDirectoriesAndLeafTypes(yangFiles, includePath
 	return directoryMap, leafTypeMap, nil
 }
 
-func generateEnumCode(goEnums map[string]*yangEnum) ([]string, string, util.Errors) {
+// generateEnumCode generates the Go code for the enumerated types within
+// the IR, returning the code for each type, and the map that allows their
+// values to be mapped to the names used in the YANG schema.
+func generateEnumCode(goEnums map[string]*EnumeratedYANGType) ([]string, string, util.Errors) {
 	// orderedEnumNames is used to get the enumerated types that have been
 	// identified in alphabetical order, such that they are returned in a
 	// deterministic order to the calling application. This ensures that
 	// the diffs are minimised, similarly to the purpose of GetOrderedDirectories.
 	var orderedEnumNames []string
-	enumNameMap := make(map[string]*yangEnum)
+	enumNameMap := make(map[string]*EnumeratedYANGType)
 
 	for _, goEnum := range goEnums {
-		orderedEnumNames = append(orderedEnumNames, goEnum.name)
-		enumNameMap[goEnum.name] = goEnum
+		orderedEnumNames = append(orderedEnumNames, goEnum.Name)
+		enumNameMap[goEnum.Name] = goEnum
 	}
 	sort.Strings(orderedEnumNames)
 
@@ -645,25 +731,34 @@ func generateEnumCode(goEnums map[string]*yangEnum) ([]string, string, util.Erro
 // It returns a GeneratedProto3 struct containing the messages that are to be
 // output, along with any associated values (e.g., enumerations).
 func (cg *YANGCodeGenerator) GenerateProto3(yangFiles, includePaths []string) (*GeneratedProto3, util.Errors) {
-	mdef, errs := mappedDefinitions(yangFiles, includePaths, &cg.Config)
-	if errs != nil {
-		return nil, errs
+	basePackageName := cg.Config.PackageName
+	if basePackageName == "" {
+		basePackageName = DefaultBasePackageName
+	}
+	enumPackageName := cg.Config.ProtoOptions.EnumPackageName
+	if enumPackageName == "" {
+		enumPackageName = DefaultEnumPackageName
 	}
+
 	// If UseConsistentNamesForProtoUnionEnums=true, then also set
 	// appendEnumSuffixForSimpleUnionEnums=true for consistent union enum
 	// names.
-	enumSet, penums, errs := findEnumSet(mdef.enumEntries, cg.Config.TransformationOptions.CompressBehaviour.CompressEnabled(), true, cg.Config.ParseOptions.SkipEnumDeduplication, cg.Config.TransformationOptions.ShortenEnumLeafNames, cg.Config.TransformationOptions.UseDefiningModuleForTypedefEnumNames, cg.Config.ProtoOptions.UseConsistentNamesForProtoUnionEnums, cg.Config.ProtoOptions.UseConsistentNamesForProtoUnionEnums, cg.Config.TransformationOptions.EnumOrgPrefixesToTrim)
-	if errs != nil {
-		return nil, errs
+	opts := IROptions{
+		ParseOptions:                         cg.Config.ParseOptions,
+		TransformationOptions:                cg.Config.TransformationOptions,
+		AppendEnumSuffixForSimpleUnionEnums:  cg.Config.ProtoOptions.UseConsistentNamesForProtoUnionEnums,
+		UseConsistentNamesForProtoUnionEnums: cg.Config.ProtoOptions.UseConsistentNamesForProtoUnionEnums,
+		// Protobuf schema path annotations are always absolute.
+		AbsoluteMapPaths: true,
 	}
-	protogen := newProtoGenState(mdef.schematree, enumSet)
-
-	protoEnums, errs := writeProtoEnums(penums, cg.Config.ProtoOptions.AnnotateEnumNames)
+	langMapper := newProtoLangMapper(opts, basePackageName, enumPackageName)
+	gir, errs := generateIR(yangFiles, includePaths, langMapper, opts)
 	if errs != nil {
 		return nil, errs
 	}
+	protogen, protoMsgs := langMapper.protoGenState, gir.ir.Directories
 
-	protoMsgs, errs := protogen.buildDirectoryDefinitions(mdef.directoryEntries, cg.Config.TransformationOptions.CompressBehaviour)
+	protoEnums, errs := writeProtoEnums(gir.enums, cg.Config.ProtoOptions.AnnotateEnumNames)
 	if errs != nil {
 		return nil, errs
 	}
@@ -683,7 +778,7 @@ func (cg *YANGCodeGenerator) GenerateProto3(yangFiles, includePaths []string) (*
 	// sorting the message paths. We use the path rather than the name as the
 	// proto message name may not be unique.
 	msgPaths := []string{}
-	msgMap := map[string]*Directory{}
+	msgMap := map[string]*ParsedDirectory{}
 	for _, m := range protoMsgs {
 		k := strings.Join(m.Path, "/")
 		msgPaths = append(msgPaths, k)
@@ -691,14 +786,6 @@ func (cg *YANGCodeGenerator) GenerateProto3(yangFiles, includePaths []string) (*
 	}
 	sort.Strings(msgPaths)
 
-	basePackageName := cg.Config.PackageName
-	if basePackageName == "" {
-		basePackageName = DefaultBasePackageName
-	}
-	enumPackageName := cg.Config.ProtoOptions.EnumPackageName
-	if enumPackageName == "" {
-		enumPackageName = DefaultEnumPackageName
-	}
 	ywrapperPath := cg.Config.ProtoOptions.YwrapperPath
 	if ywrapperPath == "" {
 		ywrapperPath = DefaultYwrapperPath
@@ -817,11 +904,14 @@ func (cg *YANGCodeGenerator) GenerateProto3(yangFiles, includePaths []string) (*
 }
 
 // processModules takes a list of the filenames of YANG modules (yangFiles),
-// and a list of paths in which included modules or submodules may be found,
-// and returns a processed set of yang.Entry pointers which correspond to the
-// generated code for the modules. If errors are returned during the Goyang
-// processing of the modules, these errors are returned.
-func processModules(yangFiles, includePaths []string, options yang.Options) ([]*yang.Entry, util.Errors) {
+// a list of the filenames of modules containing deviations that are to be
+// applied to them (deviationFiles), and a list of paths in which included
+// modules or submodules may be found, and returns a processed set of
+// yang.Entry pointers which correspond to the generated code for the modules,
+// along with the names of the modules defined by the deviation files. If
+// errors are returned during the Goyang processing of the modules, these
+// errors are returned.
+func processModules(yangFiles, deviationFiles, includePaths []string, options yang.Options) ([]*yang.Entry, []string, util.Errors) {
 	// Append the includePaths to the Goyang path variable, this ensures
 	// that where a YANG module uses an 'include' statement to reference
 	// another module, then Goyang can find this module to process.
@@ -842,12 +932,32 @@ func processModules(yangFiles, includePaths []string, options yang.Options) ([]*
 		errs = util.AppendErr(errs, moduleSet.Read(name))
 	}
 
+	// Deviation modules are read after the input modules, such that the
+	// set of modules that they define can be determined - these modules
+	// are returned to the caller such that they can be excluded from code
+	// generation.
+	inputMods := map[string]bool{}
+	for n := range moduleSet.Modules {
+		inputMods[n] = true
+	}
+	for _, name := range deviationFiles {
+		errs = util.AppendErr(errs, moduleSet.Read(name))
+	}
+
 	if errs != nil {
-		return nil, errs
+		return nil, nil, errs
+	}
+
+	var deviationMods []string
+	for n, m := range moduleSet.Modules {
+		if !inputMods[n] && n == m.Name {
+			deviationMods = append(deviationMods, m.Name)
+		}
 	}
+	sort.Strings(deviationMods)
 
 	if errs := moduleSet.Process(); errs != nil {
-		return nil, errs
+		return nil, nil, errs
 	}
 
 	// Deduplicate the modules that are to be processed.
@@ -866,7 +976,7 @@ func processModules(yangFiles, includePaths []string, options yang.Options) ([]*
 	for _, modName := range modNames {
 		entries = append(entries, yang.ToEntry(mods[modName]))
 	}
-	return entries, nil
+	return entries, deviationMods, nil
 }
 
 // mappedYANGDefinitions stores the entities extracted from a YANG schema that are to be mapped to
@@ -902,14 +1012,40 @@ type mappedYANGDefinitions struct {
 // It returns a mappedYANGDefinitions struct populated with the directory, enum
 // entries in the input schemas as well as the calculated schema tree.
 func mappedDefinitions(yangFiles, includePaths []string, cfg *GeneratorConfig) (*mappedYANGDefinitions, util.Errors) {
-	modules, errs := processModules(yangFiles, includePaths, cfg.ParseOptions.YANGParseOptions)
+	yangOpts := cfg.ParseOptions.YANGParseOptions
+	// if-feature statements on uses statements can only be evaluated
+	// when goyang retains the uses statements within the schema.
+	if cfg.ParseOptions.EnabledFeatures != nil {
+		yangOpts.StoreUses = true
+	}
+	modules, deviationMods, errs := processModules(yangFiles, cfg.ParseOptions.DeviationModules, includePaths, yangOpts)
 	if errs != nil {
 		return nil, errs
 	}
 
+	// Remove the nodes of the schema that are not supported with the
+	// set of features that are enabled.
+	if cfg.ParseOptions.EnabledFeatures != nil {
+		fs, err := newFeatureSet(cfg.ParseOptions.EnabledFeatures)
+		if err != nil {
+			return nil, []error{err}
+		}
+		errs = fs.validate(modules)
+		for _, module := range modules {
+			errs = util.AppendErrs(errs, pruneDisabledFeatures(module, fs))
+		}
+		if errs != nil {
+			return nil, errs
+		}
+	}
+
+	// Code is not generated for deviation modules, hence they are
+	// handled in the same way as modules that are explicitly excluded.
+	excludeModules := append(append([]string{}, cfg.ParseOptions.ExcludeModules...), deviationMods...)
+
 	// Build a map of excluded modules to simplify lookup.
 	excluded := map[string]bool{}
-	for _, e := range cfg.ParseOptions.ExcludeModules {
+	for _, e := range excludeModules {
 		excluded[e] = true
 	}
 
@@ -922,12 +1058,20 @@ func mappedDefinitions(yangFiles, includePaths []string, cfg *GeneratorConfig) (
 		// Need to transform the AST based on compression behaviour.
 		genutil.TransformEntry(module, cfg.TransformationOptions.CompressBehaviour)
 
-		errs = append(errs, findMappableEntities(module, dirs, enums, cfg.ParseOptions.ExcludeModules, cfg.TransformationOptions.CompressBehaviour.CompressEnabled(), modules)...)
+		errs = append(errs, findMappableEntities(module, dirs, enums, excludeModules, cfg.TransformationOptions.CompressBehaviour.CompressEnabled(), modules)...)
 		if module == nil {
 			errs = append(errs, errors.New("found a nil module in the returned module set"))
 			continue
 		}
 
+		if cfg.ParseOptions.GenerateRPCTypes && !excluded[module.Name] {
+			errs = append(errs, findRPCEntities(module, dirs, enums, excludeModules, cfg.TransformationOptions.CompressBehaviour.CompressEnabled(), modules)...)
+		}
+
+		if cfg.ParseOptions.GenerateNotificationTypes && !excluded[module.Name] {
+			errs = append(errs, findNotificationEntities(module, dirs, enums, excludeModules, cfg.TransformationOptions.CompressBehaviour.CompressEnabled(), modules)...)
+		}
+
 		for _, e := range module.Dir {
 			if !excluded[module.Name] {
 				rootElems = append(rootElems, e)
@@ -1034,7 +1178,7 @@ func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[
 	}
 
 	var errs util.Errors
-	for _, ch := range util.Children(e) {
+	for _, ch := range util.DataTreeChildren(e) {
 		switch {
 		case ch.IsLeaf(), ch.IsLeafList():
 			// Leaves are not mapped as directories so do not map them unless we find
@@ -1087,11 +1231,72 @@ func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[
 	return errs
 }
 
+// findRPCEntities finds the YANG rpc and action statements that are
+// descendants of the yang.Entry e, and appends their input and output entries
+// to the dirs map such that they are mapped to directories in the generated
+// code. The descendants of each input and output are mapped using
+// findMappableEntities, with the excludeModules, compressPaths and modules
+// arguments having the same semantics as for that function. The entries are
+// not modified, since they may be shared with other users of the goyang
+// schema; an input or output keeps its distinct kind of entry, which is
+// changed to a container only when the generated schema is loaded.
+func findRPCEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[string]*yang.Entry, excludeModules []string, compressPaths bool, modules []*yang.Entry) util.Errors {
+	var errs util.Errors
+	for _, ch := range e.Dir {
+		switch {
+		case ch.RPC != nil:
+			for _, io := range []*yang.Entry{ch.RPC.Input, ch.RPC.Output} {
+				if io == nil {
+					continue
+				}
+				// An input or output with no children is still mapped
+				// such that each operation has a complete set of types.
+				dirs[io.Path()] = io
+				errs = util.AppendErrs(errs, findMappableEntities(io, dirs, enums, excludeModules, compressPaths, modules))
+			}
+		case ch.IsDir():
+			// Actions may be defined within any container or list in the
+			// data tree, including those within choice and case statements.
+			errs = util.AppendErrs(errs, findRPCEntities(ch, dirs, enums, excludeModules, compressPaths, modules))
+		}
+	}
+	return errs
+}
+
+// findNotificationEntities finds the YANG notification statements that are
+// descendants of the yang.Entry e, and appends them to the dirs map such that
+// they are mapped to directories in the generated code. The descendants of
+// each notification are mapped using findMappableEntities, with the
+// excludeModules, compressPaths and modules arguments having the same
+// semantics as for that function. As for findRPCEntities, the notifications
+// are not modified, and are changed to containers only when the generated
+// schema is loaded.
+func findNotificationEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[string]*yang.Entry, excludeModules []string, compressPaths bool, modules []*yang.Entry) util.Errors {
+	var errs util.Errors
+	for _, ch := range e.Dir {
+		switch {
+		case util.IsNotification(ch):
+			dirs[ch.Path()] = ch
+			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressPaths, modules))
+		case ch.IsDir():
+			// Notifications may be defined within any container or list in
+			// the data tree, including those within choice and case statements.
+			errs = util.AppendErrs(errs, findNotificationEntities(ch, dirs, enums, excludeModules, compressPaths, modules))
+		}
+	}
+	return errs
+}
+
 // findRootEntries finds the entities that are at the root of the YANG schema tree,
 // and returns them.
 func findRootEntries(structs map[string]*yang.Entry, compressPaths bool) map[string]*yang.Entry {
 	rootEntries := map[string]*yang.Entry{}
 	for n, s := range structs {
+		// Notifications, and the input and output of RPCs and actions,
+		// are not part of the data tree, and hence are not root entities.
+		if util.IsOperationDescendant(s) {
+			continue
+		}
 		pp := strings.Split(s.Path(), "/")
 		switch len(pp) {
 		case 3:
@@ -850,6 +850,173 @@ func TestSimpleStructs(t *testing.T) {
 			},
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-list-enum-key.getters-append.formatted-txt"),
+	}, {
+		name:    "module with deepcopy, merge and equal methods",
+		inFiles: []string{filepath.Join(datapath, "", "openconfig-list-enum-key.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot: true,
+			},
+			GoOptions: GoOpts{
+				GenerateDeepCopyMethod: true,
+				GenerateEqualMethod:    true,
+				GenerateSimpleUnions:   true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-list-enum-key.deepcopy-equal.formatted-txt"),
+	}, {
+		name:    "module with rpc and action types, with compression and fakeroot",
+		inFiles: []string{filepath.Join(datapath, "openconfig-rpc.yang")},
+		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				GenerateRPCTypes: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-rpc.formatted-txt"),
+	}, {
+		name:    "module with rpc and action types, without compression",
+		inFiles: []string{filepath.Join(datapath, "openconfig-rpc.yang")},
+		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				GenerateRPCTypes: true,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-rpc-no-compress.formatted-txt"),
+	}, {
+		name:    "module with notification types, with compression and fakeroot",
+		inFiles: []string{filepath.Join(datapath, "openconfig-notification.yang")},
+		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				GenerateNotificationTypes: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-notification.formatted-txt"),
+	}, {
+		name:    "module with notifications, without notification types",
+		inFiles: []string{filepath.Join(datapath, "openconfig-notification.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-notification.no-notification-types.formatted-txt"),
+	}, {
+		name:    "module with if-feature statements and deviations, with a subset of features enabled",
+		inFiles: []string{filepath.Join(datapath, "openconfig-features.yang")},
+		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				EnabledFeatures:  []string{"openconfig-features:counters"},
+				DeviationModules: []string{filepath.Join(datapath, "openconfig-features-deviations.yang")},
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-features.formatted-txt"),
+	}, {
+		name:    "module with if-feature statements, with all features enabled",
+		inFiles: []string{filepath.Join(datapath, "openconfig-features.yang")},
+		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				EnabledFeatures: []string{"openconfig-features:all"},
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-features-all.formatted-txt"),
+	}, {
+		name:    "module with if-feature statements, with an undefined feature enabled",
+		inFiles: []string{filepath.Join(datapath, "openconfig-features.yang")},
+		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				EnabledFeatures: []string{"openconfig-features:isis"},
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+		},
+		wantErrSubstring: "feature isis is not defined in module openconfig-features",
+	}, {
+		name:    "module with ordered-by user lists, with ordered maps",
+		inFiles: []string{filepath.Join(datapath, "openconfig-ordered-list.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:   true,
+				GenerateGetters:        true,
+				GenerateDeleteMethod:   true,
+				GenerateAppendMethod:   true,
+				GenerateRenameMethod:   true,
+				GenerateDeepCopyMethod: true,
+				GenerateEqualMethod:    true,
+				GenerateOrderedMaps:    true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-ordered-list.formatted-txt"),
+	}, {
+		name:    "module with unions, with generic unions",
+		inFiles: []string{filepath.Join(datapath, "openconfig-generic-union.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+			GoOptions: GoOpts{
+				GenerateGenericUnions:  true,
+				GenerateGetters:        true,
+				GenerateLeafGetters:    true,
+				GenerateDeepCopyMethod: true,
+				GenerateEqualMethod:    true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-generic-union.formatted-txt"),
+	}, {
+		name:    "module with a union containing an unsupported type, with generic unions",
+		inFiles: []string{filepath.Join(datapath, "openconfig-generic-union-unsupported.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+			GoOptions: GoOpts{
+				GenerateGenericUnions: true,
+			},
+		},
+		wantErrSubstring: "union has a member of an unsupported type",
 	}, {
 		name:    "module with excluded state, with RO list, path compression on",
 		inFiles: []string{filepath.Join(datapath, "", "exclude-state-ro-list.yang")},
@@ -1043,6 +1210,23 @@ func TestSimpleStructs(t *testing.T) {
 			},
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-list-enum-key.leaf-getters.formatted-txt"),
+	}, {
+		name:    "module with leaf setters and list entry constructors",
+		inFiles: []string{filepath.Join(datapath, "", "openconfig-list-enum-key.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:                     true,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+			},
+			GoOptions: GoOpts{
+				GenerateLeafSetters:           true,
+				GenerateListEntryConstructors: true,
+				GenerateSimpleUnions:          true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-list-enum-key.leaf-setters.formatted-txt"),
 	}, {
 		name:    "uncompressed module with two different enums",
 		inFiles: []string{filepath.Join(datapath, "", "enum-list-uncompressed.yang")},
@@ -1194,6 +1378,10 @@ func TestSimpleStructs(t *testing.T) {
 				// Write generated enumeration map out.
 				fmt.Fprint(&gotCode, gotGeneratedCode.EnumMap)
 
+				// Write the generated rpc and notification type maps out.
+				fmt.Fprint(&gotCode, gotGeneratedCode.RPCTypeMap)
+				fmt.Fprint(&gotCode, gotGeneratedCode.NotificationTypeMap)
+
 				var gotJSON map[string]interface{}
 				if tt.inConfig.GenerateJSONSchema {
 					// Write the schema byte array out.
@@ -1467,6 +1655,138 @@ func TestGetDirectoriesAndLeafTypes(t *testing.T) {
 				"a-leaf": {NativeType: "string"},
 			},
 		},
+	}, {
+		name:           "simple openconfig test with compression disabled",
+		inFiles:        []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inIncludePaths: []string{filepath.Join(TestRoot, "testdata", "structs")},
+		inConfig: &DirectoryGenConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.Uncompressed,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+			},
+			ParseOptions: ParseOpts{
+				ExcludeModules: []string{},
+			},
+		},
+		wantDirMap: map[string]*Directory{
+			"/openconfig-simple/parent": {
+				Name: "OpenconfigSimple_Parent",
+				Fields: map[string]*yang.Entry{
+					"child": {Name: "child", Type: nil},
+				},
+				Path: []string{"", "openconfig-simple", "parent"},
+			},
+			"/openconfig-simple/parent/child": {
+				Name: "OpenconfigSimple_Parent_Child",
+				Fields: map[string]*yang.Entry{
+					"config": {Name: "config", Type: nil},
+					"state":  {Name: "state", Type: nil},
+				},
+				Path: []string{"", "openconfig-simple", "parent", "child"},
+			},
+			"/openconfig-simple/parent/child/config": {
+				Name: "OpenconfigSimple_Parent_Child_Config",
+				Fields: map[string]*yang.Entry{
+					"one":   {Name: "one", Type: &yang.YangType{Kind: yang.Ystring}},
+					"three": {Name: "three", Type: &yang.YangType{Kind: yang.Yenum}},
+					"four":  {Name: "four", Type: &yang.YangType{Kind: yang.Ybinary}},
+				},
+				Path: []string{"", "openconfig-simple", "parent", "child", "config"},
+			},
+			"/openconfig-simple/parent/child/state": {
+				Name: "OpenconfigSimple_Parent_Child_State",
+				Fields: map[string]*yang.Entry{
+					"one":   {Name: "one", Type: &yang.YangType{Kind: yang.Ystring}},
+					"two":   {Name: "two", Type: &yang.YangType{Kind: yang.Ystring}},
+					"three": {Name: "three", Type: &yang.YangType{Kind: yang.Yenum}},
+					"four":  {Name: "four", Type: &yang.YangType{Kind: yang.Ybinary}},
+				},
+				Path: []string{"", "openconfig-simple", "parent", "child", "state"},
+			},
+			"/openconfig-simple/remote-container": {
+				Name: "OpenconfigSimple_RemoteContainer",
+				Fields: map[string]*yang.Entry{
+					"config": {Name: "config", Type: nil},
+					"state":  {Name: "state", Type: nil},
+				},
+				Path: []string{"", "openconfig-simple", "remote-container"},
+			},
+			"/openconfig-simple/remote-container/config": {
+				Name: "OpenconfigSimple_RemoteContainer_Config",
+				Fields: map[string]*yang.Entry{
+					"a-leaf": {Name: "a-leaf", Type: &yang.YangType{Kind: yang.Ystring}},
+				},
+				Path: []string{"", "openconfig-simple", "remote-container", "config"},
+			},
+			"/openconfig-simple/remote-container/state": {
+				Name: "OpenconfigSimple_RemoteContainer_State",
+				Fields: map[string]*yang.Entry{
+					"a-leaf": {Name: "a-leaf", Type: &yang.YangType{Kind: yang.Ystring}},
+				},
+				Path: []string{"", "openconfig-simple", "remote-container", "state"},
+			},
+		},
+		wantFieldPath: map[string]map[string]string{
+			"/openconfig-simple/parent": {
+				"child": "/openconfig-simple/parent/child",
+			},
+			"/openconfig-simple/parent/child": {
+				"config": "/openconfig-simple/parent/child/config",
+				"state":  "/openconfig-simple/parent/child/state",
+			},
+			"/openconfig-simple/parent/child/config": {
+				"one":   "/openconfig-simple/parent/child/config/one",
+				"three": "/openconfig-simple/parent/child/config/three",
+				"four":  "/openconfig-simple/parent/child/config/four",
+			},
+			"/openconfig-simple/parent/child/state": {
+				"one":   "/openconfig-simple/parent/child/state/one",
+				"two":   "/openconfig-simple/parent/child/state/two",
+				"three": "/openconfig-simple/parent/child/state/three",
+				"four":  "/openconfig-simple/parent/child/state/four",
+			},
+			"/openconfig-simple/remote-container": {
+				"config": "/openconfig-simple/remote-container/config",
+				"state":  "/openconfig-simple/remote-container/state",
+			},
+			"/openconfig-simple/remote-container/config": {
+				"a-leaf": "/openconfig-simple/remote-container/config/a-leaf",
+			},
+			"/openconfig-simple/remote-container/state": {
+				"a-leaf": "/openconfig-simple/remote-container/state/a-leaf",
+			},
+		},
+		wantTypeMap: map[string]map[string]*MappedType{
+			"/openconfig-simple/parent": {
+				"child": nil,
+			},
+			"/openconfig-simple/parent/child": {
+				"config": nil,
+				"state":  nil,
+			},
+			"/openconfig-simple/parent/child/config": {
+				"one":   {NativeType: "string"},
+				"three": {NativeType: "E_OpenconfigSimple_Parent_Child_Config_Three", IsEnumeratedValue: true},
+				"four":  {NativeType: "Binary"},
+			},
+			"/openconfig-simple/parent/child/state": {
+				"one":   {NativeType: "string"},
+				"two":   {NativeType: "string"},
+				"three": {NativeType: "E_OpenconfigSimple_Parent_Child_Config_Three", IsEnumeratedValue: true},
+				"four":  {NativeType: "Binary"},
+			},
+			"/openconfig-simple/remote-container": {
+				"config": nil,
+				"state":  nil,
+			},
+			"/openconfig-simple/remote-container/config": {
+				"a-leaf": {NativeType: "string"},
+			},
+			"/openconfig-simple/remote-container/state": {
+				"a-leaf": {NativeType: "string"},
+			},
+		},
 	}, {
 		name:           "enum openconfig test with enum-types module excluded",
 		inFiles:        []string{filepath.Join(datapath, "enum-module.yang")},
@@ -1779,6 +2099,138 @@ func TestGetDirectoriesAndLeafTypes(t *testing.T) {
 				"value": {NativeType: "BList_Value_Union"},
 			},
 		},
+	}, {
+		name:           "simple openconfig test with uncompressed paths",
+		inFiles:        []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inIncludePaths: []string{filepath.Join(TestRoot, "testdata", "structs")},
+		inConfig: &DirectoryGenConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.Uncompressed,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+			},
+			ParseOptions: ParseOpts{
+				ExcludeModules: []string{},
+			},
+		},
+		wantDirMap: map[string]*Directory{
+			"/openconfig-simple/parent": {
+				Name: "OpenconfigSimple_Parent",
+				Fields: map[string]*yang.Entry{
+					"child": {Name: "child", Type: nil},
+				},
+				Path: []string{"", "openconfig-simple", "parent"},
+			},
+			"/openconfig-simple/parent/child": {
+				Name: "OpenconfigSimple_Parent_Child",
+				Fields: map[string]*yang.Entry{
+					"config": {Name: "config", Type: nil},
+					"state":  {Name: "state", Type: nil},
+				},
+				Path: []string{"", "openconfig-simple", "parent", "child"},
+			},
+			"/openconfig-simple/parent/child/config": {
+				Name: "OpenconfigSimple_Parent_Child_Config",
+				Fields: map[string]*yang.Entry{
+					"one":   {Name: "one", Type: &yang.YangType{Kind: yang.Ystring}},
+					"three": {Name: "three", Type: &yang.YangType{Kind: yang.Yenum}},
+					"four":  {Name: "four", Type: &yang.YangType{Kind: yang.Ybinary}},
+				},
+				Path: []string{"", "openconfig-simple", "parent", "child", "config"},
+			},
+			"/openconfig-simple/parent/child/state": {
+				Name: "OpenconfigSimple_Parent_Child_State",
+				Fields: map[string]*yang.Entry{
+					"one":   {Name: "one", Type: &yang.YangType{Kind: yang.Ystring}},
+					"two":   {Name: "two", Type: &yang.YangType{Kind: yang.Ystring}},
+					"three": {Name: "three", Type: &yang.YangType{Kind: yang.Yenum}},
+					"four":  {Name: "four", Type: &yang.YangType{Kind: yang.Ybinary}},
+				},
+				Path: []string{"", "openconfig-simple", "parent", "child", "state"},
+			},
+			"/openconfig-simple/remote-container": {
+				Name: "OpenconfigSimple_RemoteContainer",
+				Fields: map[string]*yang.Entry{
+					"config": {Name: "config", Type: nil},
+					"state":  {Name: "state", Type: nil},
+				},
+				Path: []string{"", "openconfig-simple", "remote-container"},
+			},
+			"/openconfig-simple/remote-container/config": {
+				Name: "OpenconfigSimple_RemoteContainer_Config",
+				Fields: map[string]*yang.Entry{
+					"a-leaf": {Name: "a-leaf", Type: &yang.YangType{Kind: yang.Ystring}},
+				},
+				Path: []string{"", "openconfig-simple", "remote-container", "config"},
+			},
+			"/openconfig-simple/remote-container/state": {
+				Name: "OpenconfigSimple_RemoteContainer_State",
+				Fields: map[string]*yang.Entry{
+					"a-leaf": {Name: "a-leaf", Type: &yang.YangType{Kind: yang.Ystring}},
+				},
+				Path: []string{"", "openconfig-simple", "remote-container", "state"},
+			},
+		},
+		wantFieldPath: map[string]map[string]string{
+			"/openconfig-simple/parent": {
+				"child": "/openconfig-simple/parent/child",
+			},
+			"/openconfig-simple/parent/child": {
+				"config": "/openconfig-simple/parent/child/config",
+				"state":  "/openconfig-simple/parent/child/state",
+			},
+			"/openconfig-simple/parent/child/config": {
+				"one":   "/openconfig-simple/parent/child/config/one",
+				"three": "/openconfig-simple/parent/child/config/three",
+				"four":  "/openconfig-simple/parent/child/config/four",
+			},
+			"/openconfig-simple/parent/child/state": {
+				"one":   "/openconfig-simple/parent/child/state/one",
+				"two":   "/openconfig-simple/parent/child/state/two",
+				"three": "/openconfig-simple/parent/child/state/three",
+				"four":  "/openconfig-simple/parent/child/state/four",
+			},
+			"/openconfig-simple/remote-container": {
+				"config": "/openconfig-simple/remote-container/config",
+				"state":  "/openconfig-simple/remote-container/state",
+			},
+			"/openconfig-simple/remote-container/config": {
+				"a-leaf": "/openconfig-simple/remote-container/config/a-leaf",
+			},
+			"/openconfig-simple/remote-container/state": {
+				"a-leaf": "/openconfig-simple/remote-container/state/a-leaf",
+			},
+		},
+		wantTypeMap: map[string]map[string]*MappedType{
+			"/openconfig-simple/parent": {
+				"child": nil,
+			},
+			"/openconfig-simple/parent/child": {
+				"config": nil,
+				"state":  nil,
+			},
+			"/openconfig-simple/parent/child/config": {
+				"one":   {NativeType: "string"},
+				"three": {NativeType: "E_OpenconfigSimple_Parent_Child_Config_Three", IsEnumeratedValue: true},
+				"four":  {NativeType: "Binary"},
+			},
+			"/openconfig-simple/parent/child/state": {
+				"one":   {NativeType: "string"},
+				"two":   {NativeType: "string"},
+				"three": {NativeType: "E_OpenconfigSimple_Parent_Child_Config_Three", IsEnumeratedValue: true},
+				"four":  {NativeType: "Binary"},
+			},
+			"/openconfig-simple/remote-container": {
+				"config": nil,
+				"state":  nil,
+			},
+			"/openconfig-simple/remote-container/config": {
+				"a-leaf": {NativeType: "string"},
+			},
+			"/openconfig-simple/remote-container/state": {
+				"a-leaf": {NativeType: "string"},
+			},
+		},
 	}, {
 		name:           "simple openconfig test with openconfig-simple module excluded with fakeroot",
 		inFiles:        []string{filepath.Join(datapath, "openconfig-simple.yang")},
@@ -1869,6 +2321,43 @@ func TestGetDirectoriesAndLeafTypes(t *testing.T) {
 	}
 }
 
+func TestFindOperationEntities(t *testing.T) {
+	module := &yang.Entry{Name: "module", Kind: yang.DirectoryEntry, Dir: map[string]*yang.Entry{}}
+	rpc := &yang.Entry{Name: "reboot", Parent: module, RPC: &yang.RPCEntry{}}
+	rpc.RPC.Input = &yang.Entry{Name: "input", Kind: yang.InputEntry, Parent: rpc, Dir: map[string]*yang.Entry{}}
+	rpc.RPC.Input.Dir["delay"] = &yang.Entry{Name: "delay", Kind: yang.LeafEntry, Parent: rpc.RPC.Input, Type: &yang.YangType{Kind: yang.Yuint32}}
+	notification := &yang.Entry{Name: "restart", Kind: yang.NotificationEntry, Parent: module, Dir: map[string]*yang.Entry{}}
+	notification.Dir["reason"] = &yang.Entry{Name: "reason", Kind: yang.LeafEntry, Parent: notification, Type: &yang.YangType{Kind: yang.Ystring}}
+	module.Dir["reboot"] = rpc
+	module.Dir["restart"] = notification
+
+	dirs := map[string]*yang.Entry{}
+	if errs := findRPCEntities(module, dirs, map[string]*yang.Entry{}, nil, false, nil); errs != nil {
+		t.Fatalf("findRPCEntities: unexpected errors: %v", errs)
+	}
+	if errs := findNotificationEntities(module, dirs, map[string]*yang.Entry{}, nil, false, nil); errs != nil {
+		t.Fatalf("findNotificationEntities: unexpected errors: %v", errs)
+	}
+
+	want := map[string]*yang.Entry{
+		"/module/reboot/input": rpc.RPC.Input,
+		"/module/restart":      notification,
+	}
+	if len(dirs) != len(want) {
+		t.Errorf("got %d directories, want %d", len(dirs), len(want))
+	}
+	for path, e := range want {
+		if dirs[path] != e {
+			t.Errorf("did not get entry %s for path %s, got: %v", e.Name, path, dirs[path])
+		}
+	}
+	// The goyang entries may be shared with other users of the schema, and
+	// hence must not be modified.
+	if rpc.RPC.Input.Kind != yang.InputEntry || notification.Kind != yang.NotificationEntry || notification.Annotation != nil {
+		t.Errorf("operation entries were modified, got input kind %v, notification kind %v and annotations %v", rpc.RPC.Input.Kind, notification.Kind, notification.Annotation)
+	}
+}
+
 func TestFindRootEntries(t *testing.T) {
 	tests := []struct {
 		name                       string
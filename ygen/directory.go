@@ -141,6 +141,15 @@ func FindSchemaPath(parent *Directory, fieldName string, absolutePaths bool) ([]
 	return schemaPaths, err
 }
 
+// FindShadowedSchemaPath finds the relative or absolute schema path of the
+// shadowed field of a Directory, i.e., the field duplicated and deprioritized
+// via compression, whose name is specified. If the field does not have a
+// shadowed field, a nil path is returned.
+func FindShadowedSchemaPath(parent *Directory, fieldName string, absolutePaths bool) ([]string, error) {
+	schemaPaths, _, err := findSchemaPath(parent, fieldName, true, absolutePaths)
+	return schemaPaths, err
+}
+
 // findSchemaPath finds the relative or absolute schema path of a given field
 // of a Directory, or the shadowed field path (i.e. field duplicated and
 // deprioritized via compression) of a Directory. The first returned slice
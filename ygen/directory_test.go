@@ -433,6 +433,73 @@ func TestFindSchemaPath(t *testing.T) {
 	}
 }
 
+// TestFindShadowedSchemaPath ensures that the schema paths of the shadowed
+// fields of a directory are properly extracted.
+func TestFindShadowedSchemaPath(t *testing.T) {
+	ms := compileModules(t, map[string]string{
+		"e-module": `
+			module e-module {
+				prefix "e";
+				namespace "urn:e";
+
+				container e-container {
+					container config {
+						leaf name { type string; }
+					}
+					container state {
+						leaf name { type string; }
+						leaf counter { type uint64; }
+					}
+				}
+			}
+		`,
+	})
+
+	dir := &Directory{
+		Name: "EContainer",
+		Path: []string{"", "e-module", "e-container"},
+		Fields: map[string]*yang.Entry{
+			"name":    findEntry(t, ms, "e-module", "e-container/state/name"),
+			"counter": findEntry(t, ms, "e-module", "e-container/state/counter"),
+		},
+		ShadowedFields: map[string]*yang.Entry{
+			"name": findEntry(t, ms, "e-module", "e-container/config/name"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		inFieldName     string
+		inAbsolutePaths bool
+		wantPath        []string
+	}{{
+		name:        "shadowed field relative path",
+		inFieldName: "name",
+		wantPath:    []string{"config", "name"},
+	}, {
+		name:            "shadowed field absolute path",
+		inFieldName:     "name",
+		inAbsolutePaths: true,
+		wantPath:        []string{"", "e-container", "config", "name"},
+	}, {
+		name:        "field without shadowed field",
+		inFieldName: "counter",
+		wantPath:    nil,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPath, err := FindShadowedSchemaPath(dir, tt.inFieldName, tt.inAbsolutePaths)
+			if err != nil {
+				t.Fatalf("FindShadowedSchemaPath: unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(gotPath, tt.wantPath); diff != "" {
+				t.Errorf("(-gotPath, want):\n%s", diff)
+			}
+		})
+	}
+}
+
 // TestFindMapPaths ensures that the schema paths that an entity should be
 // mapped to are properly extracted from a schema element.
 func TestFindMapPaths(t *testing.T) {
@@ -96,6 +96,7 @@ func (s *enumSet) enumeratedUnionEntry(e *yang.Entry, compressPaths, noUnderscor
 			}
 			en = &yangEnum{
 				name: identityName,
+				kind: IdentityType,
 				entry: &yang.Entry{
 					Name: e.Name,
 					Type: &yang.YangType{
@@ -127,8 +128,13 @@ func (s *enumSet) enumeratedUnionEntry(e *yang.Entry, compressPaths, noUnderscor
 				return nil, err
 			}
 
+			kind := UnionEnumerationType
+			if !util.IsYANGBaseType(t) {
+				kind = DerivedUnionEnumerationType
+			}
 			en = &yangEnum{
 				name: enumName,
+				kind: kind,
 				entry: &yang.Entry{
 					Name: e.Name,
 					Type: &yang.YangType{
@@ -550,6 +556,7 @@ func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, s
 				genEnums[idBaseName] = &yangEnum{
 					name:  idBaseName,
 					entry: e,
+					kind:  IdentityType,
 				}
 			}
 		case e.Type.Name == "enumeration":
@@ -568,6 +575,7 @@ func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, s
 				genEnums[enumName] = &yangEnum{
 					name:  enumName,
 					entry: e,
+					kind:  SimpleEnumerationType,
 				}
 			}
 		default:
@@ -578,9 +586,14 @@ func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, s
 				continue
 			}
 			if _, ok := genEnums[typeName]; !ok {
+				kind := DerivedEnumerationType
+				if e.Type.IdentityBase != nil {
+					kind = IdentityType
+				}
 				genEnums[typeName] = &yangEnum{
 					name:  typeName,
 					entry: e,
+					kind:  kind,
 				}
 			}
 		}
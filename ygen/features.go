@@ -0,0 +1,334 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+const (
+	// AllFeatures is the feature name that can be used within an
+	// EnabledFeatures entry to specify that all features defined by a
+	// module are enabled, e.g., "openconfig-foo:all".
+	AllFeatures = "all"
+)
+
+// featureSet stores the set of YANG features that are enabled for code
+// generation. It is keyed by module name, and then by feature name.
+type featureSet map[string]map[string]bool
+
+// newFeatureSet parses the supplied set of feature specifications, each of
+// which is of the form "module:feature", and returns the corresponding
+// featureSet. The feature name may be AllFeatures to enable every feature
+// defined by the module.
+func newFeatureSet(features []string) (featureSet, error) {
+	fs := featureSet{}
+	for _, f := range features {
+		p := strings.Split(f, ":")
+		if len(p) != 2 || p[0] == "" || p[1] == "" {
+			return nil, fmt.Errorf("invalid feature %q, must be of the form module:feature", f)
+		}
+		if fs[p[0]] == nil {
+			fs[p[0]] = map[string]bool{}
+		}
+		fs[p[0]][p[1]] = true
+	}
+	return fs, nil
+}
+
+// enabled returns true if the feature named feature within the module mod is
+// enabled in the featureSet.
+func (fs featureSet) enabled(mod, feature string) bool {
+	return fs[mod][feature] || fs[mod][AllFeatures]
+}
+
+// validate checks that each feature within the featureSet that belongs to one
+// of the supplied modules is defined by that module, or one of its
+// submodules. Features of modules that are not within the supplied set are
+// not checked, such that a common feature set can be used across different
+// sets of input modules.
+func (fs featureSet) validate(modules []*yang.Entry) util.Errors {
+	defined := map[string]map[string]bool{}
+	for _, m := range modules {
+		mod, ok := m.Node.(*yang.Module)
+		if !ok {
+			continue
+		}
+		defined[m.Name] = map[string]bool{}
+		for _, f := range moduleFeatures(mod) {
+			defined[m.Name][f.Name] = true
+		}
+	}
+
+	var errs util.Errors
+	for mod, features := range fs {
+		if defined[mod] == nil {
+			continue
+		}
+		for f := range features {
+			if f != AllFeatures && !defined[mod][f] {
+				errs = util.AppendErr(errs, fmt.Errorf("feature %s is not defined in module %s", f, mod))
+			}
+		}
+	}
+	return errs
+}
+
+// moduleFeatures returns the features defined within the module m, including
+// those that are defined within its included submodules.
+func moduleFeatures(m *yang.Module) []*yang.Feature {
+	fs := append([]*yang.Feature{}, m.Feature...)
+	for _, i := range m.Include {
+		if i.Module != nil {
+			fs = append(fs, moduleFeatures(i.Module)...)
+		}
+	}
+	return fs
+}
+
+// pruneDisabledFeatures removes from the schema tree rooted at e any entry
+// whose if-feature statements are not satisfied by the enabled feature set
+// fs. Since the schema is modified in place, it must be called prior to
+// any entities being extracted from the schema. It returns any errors
+// encountered whilst evaluating if-feature statements.
+//
+// if-feature statements that are specified on an augment or uses statement
+// are applied to each of the nodes that it adds to the schema. Evaluating
+// if-feature statements on uses statements requires that the schema was
+// parsed with the StoreUses goyang option set.
+func pruneDisabledFeatures(e *yang.Entry, fs featureSet) util.Errors {
+	var errs util.Errors
+	for _, u := range e.Uses {
+		if u.Uses == nil || u.Grouping == nil {
+			continue
+		}
+		enabled, err := ifFeaturesEnabled(e.Path(), u.Uses, u.Uses.IfFeature, fs)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		if !enabled {
+			for name := range u.Grouping.Dir {
+				delete(e.Dir, name)
+			}
+		}
+	}
+
+	for name, ch := range e.Dir {
+		enabled, err := entryEnabled(ch, fs)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		if !enabled {
+			delete(e.Dir, name)
+			continue
+		}
+		errs = util.AppendErrs(errs, pruneDisabledFeatures(ch, fs))
+	}
+
+	if e.RPC != nil {
+		for _, io := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+			if io != nil {
+				errs = util.AppendErrs(errs, pruneDisabledFeatures(io, fs))
+			}
+		}
+	}
+	return errs
+}
+
+// entryEnabled returns true if all of the if-feature statements that apply to
+// the entry e are satisfied by the feature set fs.
+func entryEnabled(e *yang.Entry, fs featureSet) (bool, error) {
+	exprs := ifFeatureValues(e.Extra["if-feature"])
+	if e.Node != nil {
+		if a, ok := e.Node.ParentNode().(*yang.Augment); ok {
+			exprs = append(exprs, a.IfFeature...)
+		}
+	}
+	return ifFeaturesEnabled(e.Path(), e.Node, exprs, fs)
+}
+
+// ifFeaturesEnabled returns true if each of the if-feature expressions in
+// exprs is satisfied by the feature set fs. Feature prefixes are resolved in
+// the context of the node n, and path is used to identify the schema node
+// to which the expressions apply in any error that is returned.
+func ifFeaturesEnabled(path string, n yang.Node, exprs []*yang.Value, fs featureSet) (bool, error) {
+	for _, v := range exprs {
+		ok, err := evalIfFeature(v.Name, func(ref string) (bool, error) {
+			return featureRefEnabled(n, ref, fs)
+		})
+		if err != nil {
+			return false, fmt.Errorf("%s: invalid if-feature %q: %v", path, v.Name, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ifFeatureValues extracts the if-feature arguments from the extra
+// if-feature statements that goyang stores for an entry.
+func ifFeatureValues(extra []interface{}) []*yang.Value {
+	var vals []*yang.Value
+	for _, x := range extra {
+		switch v := x.(type) {
+		case []*yang.Value:
+			for _, i := range v {
+				if i != nil {
+					vals = append(vals, i)
+				}
+			}
+		case *yang.Value:
+			if v != nil {
+				vals = append(vals, v)
+			}
+		}
+	}
+	return vals
+}
+
+// featureRefEnabled returns true if the feature referenced by ref, which is
+// an identifier optionally prefixed by the prefix of the module defining it,
+// is enabled within fs. The prefix is resolved in the context of the node n.
+func featureRefEnabled(n yang.Node, ref string, fs featureSet) (bool, error) {
+	prefix, name := "", ref
+	if i := strings.Index(ref, ":"); i != -1 {
+		prefix, name = ref[:i], ref[i+1:]
+	}
+	if n == nil {
+		return false, fmt.Errorf("cannot resolve feature %s for a nil node", ref)
+	}
+	m := yang.FindModuleByPrefix(n, prefix)
+	if m == nil {
+		return false, fmt.Errorf("cannot resolve prefix %q of feature %s", prefix, ref)
+	}
+	mod := m.Name
+	if m.Kind() == "submodule" && m.BelongsTo != nil {
+		mod = m.BelongsTo.Name
+	}
+	return fs.enabled(mod, name), nil
+}
+
+// evalIfFeature evaluates the if-feature expression expr, as defined in
+// RFC7950 Section 7.20.2, using the resolve function to determine whether
+// each feature referenced within the expression is enabled.
+func evalIfFeature(expr string, resolve func(string) (bool, error)) (bool, error) {
+	p := &ifFeatureParser{tokens: tokeniseIfFeature(expr), resolve: resolve}
+	if len(p.tokens) == 0 {
+		return false, fmt.Errorf("empty expression")
+	}
+	v, err := p.expr()
+	if err != nil {
+		return false, err
+	}
+	if p.pos != len(p.tokens) {
+		return false, fmt.Errorf("unexpected token %q", p.tokens[p.pos])
+	}
+	return v, nil
+}
+
+// tokeniseIfFeature splits the if-feature expression expr into its
+// constituent tokens.
+func tokeniseIfFeature(expr string) []string {
+	return strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expr))
+}
+
+// ifFeatureParser is a recursive descent parser for if-feature expressions.
+type ifFeatureParser struct {
+	// tokens is the set of tokens in the expression.
+	tokens []string
+	// pos is the index of the next token to be consumed.
+	pos int
+	// resolve returns whether the named feature is enabled.
+	resolve func(string) (bool, error)
+}
+
+// peek returns the next token, or the empty string if there are no further
+// tokens.
+func (p *ifFeatureParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+// expr parses if-feature-expr = if-feature-term [sep "or" sep if-feature-expr].
+func (p *ifFeatureParser) expr() (bool, error) {
+	v, err := p.term()
+	if err != nil {
+		return false, err
+	}
+	for p.peek() == "or" {
+		p.pos++
+		o, err := p.term()
+		if err != nil {
+			return false, err
+		}
+		v = v || o
+	}
+	return v, nil
+}
+
+// term parses if-feature-term = if-feature-factor [sep "and" sep if-feature-term].
+func (p *ifFeatureParser) term() (bool, error) {
+	v, err := p.factor()
+	if err != nil {
+		return false, err
+	}
+	for p.peek() == "and" {
+		p.pos++
+		o, err := p.factor()
+		if err != nil {
+			return false, err
+		}
+		v = v && o
+	}
+	return v, nil
+}
+
+// factor parses if-feature-factor = "not" sep if-feature-factor /
+// "(" if-feature-expr ")" / identifier-ref-arg.
+func (p *ifFeatureParser) factor() (bool, error) {
+	switch t := p.peek(); t {
+	case "":
+		return false, fmt.Errorf("unexpected end of expression")
+	case "not":
+		p.pos++
+		v, err := p.factor()
+		return !v, err
+	case "(":
+		p.pos++
+		v, err := p.expr()
+		if err != nil {
+			return false, err
+		}
+		if p.peek() != ")" {
+			return false, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return v, nil
+	case ")", "and", "or":
+		return false, fmt.Errorf("unexpected token %q", t)
+	default:
+		p.pos++
+		return p.resolve(t)
+	}
+}
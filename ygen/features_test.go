@@ -0,0 +1,223 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func TestNewFeatureSet(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               []string
+		want             featureSet
+		wantErrSubstring string
+	}{{
+		desc: "empty set",
+		in:   []string{},
+		want: featureSet{},
+	}, {
+		desc: "features in multiple modules",
+		in:   []string{"mod-a:foo", "mod-a:bar", "mod-b:all"},
+		want: featureSet{
+			"mod-a": {"foo": true, "bar": true},
+			"mod-b": {"all": true},
+		},
+	}, {
+		desc:             "missing module",
+		in:               []string{"foo"},
+		wantErrSubstring: `invalid feature "foo"`,
+	}, {
+		desc:             "empty feature",
+		in:               []string{"mod-a:"},
+		wantErrSubstring: `invalid feature "mod-a:"`,
+	}, {
+		desc:             "too many elements",
+		in:               []string{"mod-a:foo:bar"},
+		wantErrSubstring: `invalid feature "mod-a:foo:bar"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := newFeatureSet(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("newFeatureSet(%v): did not get expected error, %s", tt.in, diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("newFeatureSet(%v): did not get expected set, (-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestEvalIfFeature(t *testing.T) {
+	enabled := map[string]bool{"a": true, "p:b": true}
+	resolve := func(f string) (bool, error) {
+		if f == "err" {
+			return false, fmt.Errorf("unresolvable feature")
+		}
+		return enabled[f], nil
+	}
+
+	tests := []struct {
+		in               string
+		want             bool
+		wantErrSubstring string
+	}{
+		{in: "a", want: true},
+		{in: "p:b", want: true},
+		{in: "c", want: false},
+		{in: "not a", want: false},
+		{in: "not not a", want: true},
+		{in: "a and c", want: false},
+		{in: "a and p:b", want: true},
+		{in: "c or a", want: true},
+		{in: "c or a and c", want: false},
+		{in: "(c or a) and p:b", want: true},
+		{in: "not (a and c)", want: true},
+		{in: "((a))", want: true},
+		{in: "", wantErrSubstring: "empty expression"},
+		{in: "a and", wantErrSubstring: "unexpected end of expression"},
+		{in: "(a or c", wantErrSubstring: "missing closing parenthesis"},
+		{in: "a c", wantErrSubstring: `unexpected token "c"`},
+		{in: "or a", wantErrSubstring: `unexpected token "or"`},
+		{in: "a and err", wantErrSubstring: "unresolvable feature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := evalIfFeature(tt.in, resolve)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("evalIfFeature(%q): did not get expected error, %s", tt.in, diff)
+			}
+			if got != tt.want {
+				t.Errorf("evalIfFeature(%q): did not get expected result, got: %v, want: %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPruneDisabledFeatures(t *testing.T) {
+	// leafPaths returns the sorted paths of the leaves within the tree rooted at e.
+	var leafPaths func(e *yang.Entry) []string
+	leafPaths = func(e *yang.Entry) []string {
+		var paths []string
+		for _, ch := range e.Dir {
+			if ch.IsLeaf() {
+				paths = append(paths, ch.Path())
+				continue
+			}
+			paths = append(paths, leafPaths(ch)...)
+		}
+		sort.Strings(paths)
+		return paths
+	}
+
+	tests := []struct {
+		desc       string
+		inFeatures []string
+		wantLeaves []string
+	}{{
+		desc:       "no features enabled",
+		inFeatures: []string{},
+		wantLeaves: []string{
+			"/openconfig-features/interfaces/interface/config/name",
+			"/openconfig-features/interfaces/interface/name",
+			"/openconfig-features/interfaces/interface/state/counters/in-pkts",
+			"/openconfig-features/interfaces/interface/state/name",
+		},
+	}, {
+		desc:       "feature used in a grouping, uses and disjunction enabled",
+		inFeatures: []string{"openconfig-features:counters"},
+		wantLeaves: []string{
+			"/openconfig-features/interfaces/interface/config/mtu",
+			"/openconfig-features/interfaces/interface/config/name",
+			"/openconfig-features/interfaces/interface/name",
+			"/openconfig-features/interfaces/interface/state/counters/in-discards",
+			"/openconfig-features/interfaces/interface/state/counters/in-pkts",
+			"/openconfig-features/interfaces/interface/state/counters/out-pkts",
+			"/openconfig-features/interfaces/interface/state/name",
+		},
+	}, {
+		desc:       "feature used in a container and conjunction enabled",
+		inFeatures: []string{"openconfig-features:bfd"},
+		wantLeaves: []string{
+			"/openconfig-features/bfd/enabled",
+			"/openconfig-features/interfaces/interface/config/bfd-interval",
+			"/openconfig-features/interfaces/interface/config/name",
+			"/openconfig-features/interfaces/interface/name",
+			"/openconfig-features/interfaces/interface/state/counters/in-pkts",
+			"/openconfig-features/interfaces/interface/state/name",
+		},
+	}, {
+		desc:       "feature used in an augment enabled",
+		inFeatures: []string{"openconfig-features:bfd", "openconfig-features:mpls"},
+		wantLeaves: []string{
+			"/openconfig-features/bfd/enabled",
+			"/openconfig-features/interfaces/interface/config/label",
+			"/openconfig-features/interfaces/interface/config/mtu",
+			"/openconfig-features/interfaces/interface/config/name",
+			"/openconfig-features/interfaces/interface/name",
+			"/openconfig-features/interfaces/interface/state/counters/in-pkts",
+			"/openconfig-features/interfaces/interface/state/name",
+		},
+	}, {
+		desc:       "all features enabled",
+		inFeatures: []string{"openconfig-features:all"},
+		wantLeaves: []string{
+			"/openconfig-features/bfd/enabled",
+			"/openconfig-features/interfaces/interface/config/label",
+			"/openconfig-features/interfaces/interface/config/mtu",
+			"/openconfig-features/interfaces/interface/config/name",
+			"/openconfig-features/interfaces/interface/name",
+			"/openconfig-features/interfaces/interface/state/counters/in-discards",
+			"/openconfig-features/interfaces/interface/state/counters/in-pkts",
+			"/openconfig-features/interfaces/interface/state/counters/out-pkts",
+			"/openconfig-features/interfaces/interface/state/name",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			modules, _, errs := processModules([]string{filepath.Join(datapath, "openconfig-features.yang")}, nil, nil, yang.Options{StoreUses: true})
+			if errs != nil {
+				t.Fatalf("processModules: cannot process input module, %v", errs)
+			}
+			fs, err := newFeatureSet(tt.inFeatures)
+			if err != nil {
+				t.Fatalf("newFeatureSet(%v): unexpected error, %v", tt.inFeatures, err)
+			}
+
+			var got []string
+			for _, m := range modules {
+				errs = append(errs, pruneDisabledFeatures(m, fs)...)
+				got = append(got, leafPaths(m)...)
+			}
+			if errs != nil {
+				t.Fatalf("pruneDisabledFeatures: unexpected errors, %v", errs)
+			}
+			if diff := cmp.Diff(tt.wantLeaves, got); diff != "" {
+				t.Errorf("pruneDisabledFeatures: did not get expected leaves, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
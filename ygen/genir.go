@@ -14,6 +14,18 @@
 
 package ygen
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// IROptions contains options that control the production of the IR.
 type IROptions struct {
 	// ParseOptions specifies the options for how the YANG schema is
 	// produced.
@@ -22,6 +34,22 @@ type IROptions struct {
 	// Transformation options specifies any transformations that should
 	// be applied to the input YANG schema when producing the IR.
 	TransformationOptions TransformationOpts
+
+	// AppendEnumSuffixForSimpleUnionEnums appends an "Enum" suffix to the
+	// enumeration name for simple (i.e., non-typedef) leaves which are
+	// unions with an enumeration inside. It only applies when
+	// UseDefiningModuleForTypedefEnumNames is also set to true.
+	AppendEnumSuffixForSimpleUnionEnums bool
+
+	// UseConsistentNamesForProtoUnionEnums specifies that the names of
+	// enumerations within unions should be calculated consistently with
+	// those of other enumerations. It is used by the protobuf generator.
+	UseConsistentNamesForProtoUnionEnums bool
+
+	// AbsoluteMapPaths specifies that the paths that each field of a
+	// directory is mapped to should be absolute, rather than relative to
+	// the directory. It is used by the protobuf generator.
+	AbsoluteMapPaths bool
 }
 
 // GenerateIR creates the ygen intermediate representation for a set of
@@ -37,6 +65,304 @@ type IROptions struct {
 //
 // GenerateIR returns the complete ygen intermediate representation.
 func GenerateIR(yangFiles, includePaths []string, newLangMapper NewLangMapperFn, opts IROptions) (*IR, error) {
-	// TODO(robjs): Implementation of GenerateIR.
-	return nil, nil
+	if newLangMapper == nil {
+		return nil, fmt.Errorf("a LangMapper must be supplied to generate the IR")
+	}
+	gir, errs := generateIR(yangFiles, includePaths, newLangMapper(), opts)
+	if errs != nil {
+		return nil, errs
+	}
+	return gir.ir, nil
+}
+
+// generatedIR stores the IR, along with the intermediate state that was
+// used to produce it, such that the code generation for the languages that
+// are implemented within ygen can refer to the YANG schema.
+type generatedIR struct {
+	// ir is the intermediate representation that was produced.
+	ir *IR
+	// defs is the set of definitions that were extracted from the YANG
+	// schema.
+	defs *mappedYANGDefinitions
+	// directories is the set of Directory entries from which the IR's
+	// directories were produced, keyed by the path to the directory in
+	// the YANG schema.
+	directories map[string]*Directory
+	// enums is the set of enumerated types from which the IR's enumerations
+	// were produced, keyed by the name of the enumerated type.
+	enums map[string]*yangEnum
+}
+
+// generateIR produces the IR for the supplied YANG files using the supplied
+// LangMapper. It returns the IR along with the state used to produce it.
+func generateIR(yangFiles, includePaths []string, langMapper LangMapper, opts IROptions) (*generatedIR, util.Errors) {
+	cfg := &GeneratorConfig{
+		ParseOptions:          opts.ParseOptions,
+		TransformationOptions: opts.TransformationOptions,
+	}
+	mdef, errs := mappedDefinitions(yangFiles, includePaths, cfg)
+	if errs != nil {
+		return nil, errs
+	}
+
+	cb := opts.TransformationOptions.CompressBehaviour
+	enumSet, enums, errs := findEnumSet(mdef.enumEntries, cb.CompressEnabled(), !langMapper.EnumerationsUseUnderscores(), opts.ParseOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.AppendEnumSuffixForSimpleUnionEnums, opts.UseConsistentNamesForProtoUnionEnums, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+	if errs != nil {
+		return nil, errs
+	}
+
+	langMapper.SetEnumSet(enumSet)
+	langMapper.SetSchemaTree(mdef.schematree)
+
+	var nameErrs util.Errors
+	directories, errs := buildDirectoryDefinitions(mdef.directoryEntries, cb,
+		func(e *yang.Entry) string {
+			n, err := langMapper.DirectoryName(e, cb)
+			if err != nil {
+				nameErrs = util.AppendErr(nameErrs, err)
+			}
+			return n
+		},
+		func(keyleaf *yang.Entry) (*MappedType, error) {
+			return langMapper.KeyLeafType(keyleaf, cb)
+		})
+	if errs = util.AppendErrs(nameErrs, errs); errs != nil {
+		return nil, errs
+	}
+
+	ir := &IR{
+		Directories: map[string]*ParsedDirectory{},
+		Enums:       map[string]*EnumeratedYANGType{},
+	}
+
+	for p, dir := range directories {
+		pd, err := parsedDirectory(dir, langMapper, mdef.schematree, cb, opts.AbsoluteMapPaths)
+		if err != nil {
+			errs = util.AppendErrs(errs, err)
+			continue
+		}
+		ir.Directories[p] = pd
+	}
+
+	for name, e := range enums {
+		et, err := enumeratedYANGType(e, langMapper)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		ir.Enums[name] = et
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return &generatedIR{
+		ir:          ir,
+		defs:        mdef,
+		directories: directories,
+		enums:       enums,
+	}, nil
+}
+
+// parsedDirectory maps the supplied Directory to the ParsedDirectory that
+// represents it in the IR, using the supplied LangMapper to determine the
+// names and types of its fields. The supplied schemaTree is used to resolve
+// the targets of leafref fields. If absolutePaths is set, the paths that
+// each field is mapped to are absolute, otherwise they are relative to the
+// directory.
+func parsedDirectory(dir *Directory, langMapper LangMapper, st *schemaTree, cb genutil.CompressBehaviour, absolutePaths bool) (*ParsedDirectory, []error) {
+	pd := &ParsedDirectory{
+		Name:       dir.Name,
+		Type:       Container,
+		Fields:     map[string]*NodeDetails{},
+		ListAttr:   dir.ListAttr,
+		IsFakeRoot: dir.IsFakeRoot,
+		Path:       dir.Path,
+		Operation:  operationType(dir.Entry),
+	}
+	if dir.Entry.IsList() {
+		pd.Type = List
+		pd.ListKeyYANGNames = strings.Fields(dir.Entry.Key)
+	}
+
+	var errs []error
+	pkg, err := langMapper.PackageName(dir.Entry, cb)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	pd.PackageName = pkg
+
+	definedNames := map[string]bool{}
+	for _, fn := range GetOrderedFieldNames(dir) {
+		field := dir.Fields[fn]
+
+		name, err := langMapper.FieldName(field)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		name = genutil.MakeNameUnique(name, definedNames)
+
+		module, err := field.InstantiatingModule()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		mapPaths, mapModules, err := findMapPaths(dir, fn, cb.CompressEnabled(), false, absolutePaths)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		shadowPaths, shadowModules, err := findMapPaths(dir, fn, cb.CompressEnabled(), true, absolutePaths)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		nd := &NodeDetails{
+			Name: name,
+			YANGDetails: YANGNodeDetails{
+				Name:          field.Name,
+				Default:       field.Default,
+				Module:        module,
+				Path:          strings.Split(util.SchemaTreePath(field), "/"),
+				SchemaPath:    field.Path(),
+				OrderedByUser: field.ListAttr != nil && field.ListAttr.OrderedBy != nil && field.ListAttr.OrderedBy.Name == "user",
+			},
+			MapPaths:             mapPaths,
+			MapPathModules:       mapModules,
+			ShadowMapPaths:       shadowPaths,
+			ShadowMapPathModules: shadowModules,
+		}
+
+		switch {
+		case field.IsList():
+			nd.Type = ListNode
+		case field.IsLeafList():
+			nd.Type = LeafListNode
+		case field.IsLeaf():
+			nd.Type = LeafNode
+		case util.IsAnydata(field):
+			nd.Type = AnyDataNode
+		case field.IsDir():
+			nd.Type = DirectoryNode
+		default:
+			errs = append(errs, fmt.Errorf("%s was not a valid node type", field.Path()))
+			continue
+		}
+
+		if nd.Type == LeafNode || nd.Type == LeafListNode {
+			if err := leafDetails(nd, field, langMapper, st, cb); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		pd.Fields[name] = nd
+	}
+
+	return pd, errs
+}
+
+// leafDetails populates the details of the node nd which are specific to
+// leaves and leaf-lists from the schema entry e, using the supplied
+// LangMapper to determine its type and default value in the output code.
+// The supplied schemaTree is used to resolve the target of a leafref.
+func leafDetails(nd *NodeDetails, e *yang.Entry, langMapper LangMapper, st *schemaTree, cb genutil.CompressBehaviour) error {
+	nd.YANGDetails.Type = e.Type
+	if e.Type != nil && e.Type.Kind == yang.Yleafref {
+		target, err := st.resolveLeafrefTarget(e.Type.Path, e)
+		if err != nil {
+			return err
+		}
+		nd.YANGDetails.LeafrefTarget = &YANGNodeDetails{
+			Name:       target.Name,
+			Default:    target.Default,
+			Path:       strings.Split(util.SchemaTreePath(target), "/"),
+			SchemaPath: target.Path(),
+			Type:       target.Type,
+		}
+	}
+
+	var err error
+	if nd.LangType, err = langMapper.LeafType(e, cb); err != nil {
+		return err
+	}
+	nd.LangDefault, err = langMapper.LeafDefault(e, nd.LangType, cb)
+	return err
+}
+
+// operationType returns the part of a YANG rpc, action or notification that
+// the directory entry e represents.
+func operationType(e *yang.Entry) OperationType {
+	if util.IsNotification(e) {
+		return OperationNotification
+	}
+	if op := e.Parent; op != nil && op.RPC != nil {
+		switch e {
+		case op.RPC.Input:
+			return OperationInput
+		case op.RPC.Output:
+			return OperationOutput
+		}
+	}
+	return NotOperation
+}
+
+// enumeratedYANGType maps the supplied enumerated type to the
+// EnumeratedYANGType that represents it in the IR, using the supplied
+// LangMapper to determine the names of its values. Values are numbered from
+// 1 such that 0 can be used to represent an unset value. For identities,
+// values are numbered in alphabetical order of their names, since there is
+// no explicit ordering of the values of an identity.
+func enumeratedYANGType(e *yangEnum, langMapper LangMapper) (*EnumeratedYANGType, error) {
+	et := &EnumeratedYANGType{
+		Name:             e.name,
+		Kind:             e.kind,
+		TypeName:         e.entry.Type.Name,
+		ValToCodeName:    map[int64]string{},
+		ValToYANGDetails: map[int64]*ygot.EnumDefinition{},
+	}
+	if vp, ok := e.entry.Annotation["valuePrefix"].([]string); ok {
+		et.ValuePrefix = vp
+	}
+
+	switch {
+	case e.entry.Type.IdentityBase != nil:
+		var valNames []string
+		valLookup := map[string]*yang.Identity{}
+		for _, v := range e.entry.Type.IdentityBase.Values {
+			valNames = append(valNames, v.Name)
+			valLookup[v.Name] = v
+		}
+		sort.Strings(valNames)
+
+		for i, v := range valNames {
+			n, err := langMapper.EnumeratedValueName(v)
+			if err != nil {
+				return nil, err
+			}
+			et.ValToCodeName[int64(i)+1] = n
+			et.ValToYANGDetails[int64(i)+1] = &ygot.EnumDefinition{
+				Name:           v,
+				DefiningModule: genutil.ParentModuleName(valLookup[v]),
+			}
+		}
+	case e.entry.Type.Enum != nil:
+		for i, v := range e.entry.Type.Enum.ValueMap() {
+			n, err := langMapper.EnumeratedValueName(v)
+			if err != nil {
+				return nil, err
+			}
+			et.ValToCodeName[i+1] = n
+			et.ValToYANGDetails[i+1] = &ygot.EnumDefinition{Name: v}
+		}
+	default:
+		return nil, fmt.Errorf("enumerated type %s did not have enumerated values", e.name)
+	}
+
+	return et, nil
 }
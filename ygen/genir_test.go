@@ -15,15 +15,160 @@
 package ygen
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
 )
 
+// parsedDirectoryFixtures maps the supplied target Directory, and the set of
+// Directories that it may reference, to their IR representation using the
+// supplied LangMapper. It is used by the tests of the code generators, whose
+// fixtures do not describe complete schemas (e.g., they omit the entry of a
+// directory, or the namespace of a field), such that parsedDirectory cannot
+// be used. The names of the referenced directories are overridden by those
+// in names, keyed by schema path, which may also name directories that are
+// not otherwise described by the fixture. Leafrefs are resolved using st. The
+// schema paths of each field are only resolved if resolveMapPaths is set.
+func parsedDirectoryFixtures(target *Directory, dirs map[string]*Directory, lm LangMapper, st *schemaTree, names map[string]string, compressPaths, absolutePaths, resolveMapPaths bool) (*ParsedDirectory, map[string]*ParsedDirectory, []error) {
+	cb := genutil.Uncompressed
+	if compressPaths {
+		cb = genutil.PreferIntendedConfig
+	}
+
+	var errs []error
+	toIR := func(d *Directory) *ParsedDirectory {
+		pd := &ParsedDirectory{
+			Name:       d.Name,
+			Type:       Container,
+			Fields:     map[string]*NodeDetails{},
+			ListAttr:   d.ListAttr,
+			IsFakeRoot: d.IsFakeRoot,
+			Path:       d.Path,
+		}
+		if d.Entry != nil {
+			pd.Operation = operationType(d.Entry)
+		}
+		// The package of messages whose entry has no parent cannot be
+		// determined, and is left unset.
+		if d.Entry != nil && (d.Entry.Parent != nil || d.IsFakeRoot) {
+			pkg, err := lm.PackageName(d.Entry, cb)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			pd.PackageName = pkg
+		}
+		// Fixtures that omit the entry of a list describe its keys by
+		// their key elements.
+		if d.Entry == nil && d.ListAttr != nil {
+			for _, k := range d.ListAttr.KeyElems {
+				pd.ListKeyYANGNames = append(pd.ListKeyYANGNames, k.Name)
+			}
+		}
+		if d.Entry != nil && d.Entry.IsList() {
+			pd.Type = List
+			pd.ListKeyYANGNames = strings.Fields(d.Entry.Key)
+			if util.IsKeyedList(d.Entry) && (d.ListAttr == nil || d.ListAttr.Keys == nil) {
+				pd.ListAttr = &YangListAttr{Keys: map[string]*MappedType{}}
+				for _, k := range strings.Fields(d.Entry.Key) {
+					kf, ok := d.Fields[k]
+					if !ok {
+						continue
+					}
+					mt, err := lm.KeyLeafType(kf, cb)
+					if err != nil {
+						errs = append(errs, err)
+						continue
+					}
+					pd.ListAttr.Keys[k] = mt
+				}
+			}
+		}
+
+		definedNames := map[string]bool{}
+		for _, fn := range GetOrderedFieldNames(d) {
+			field := d.Fields[fn]
+			name, err := lm.FieldName(field)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			nd := &NodeDetails{
+				Name: genutil.MakeNameUnique(name, definedNames),
+				YANGDetails: YANGNodeDetails{
+					Name:          fn,
+					Default:       field.Default,
+					Path:          strings.Split(util.SchemaTreePath(field), "/"),
+					SchemaPath:    field.Path(),
+					OrderedByUser: field.ListAttr != nil && field.ListAttr.OrderedBy != nil && field.ListAttr.OrderedBy.Name == "user",
+				},
+			}
+
+			switch {
+			case field.IsList():
+				nd.Type = ListNode
+			case field.IsLeafList():
+				nd.Type = LeafListNode
+			case field.IsLeaf():
+				nd.Type = LeafNode
+			case util.IsAnydata(field):
+				nd.Type = AnyDataNode
+			case field.IsDir():
+				nd.Type = DirectoryNode
+			}
+			if nd.Type == LeafNode || nd.Type == LeafListNode {
+				if err := leafDetails(nd, field, lm, st, cb); err != nil {
+					errs = append(errs, err)
+					continue
+				}
+			}
+
+			// The schema paths of fields without a parent cannot be resolved.
+			if resolveMapPaths && field.Parent != nil {
+				if nd.MapPaths, nd.MapPathModules, err = findMapPaths(d, fn, compressPaths, false, absolutePaths); err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				nd.ShadowMapPaths, nd.ShadowMapPathModules, _ = findMapPaths(d, fn, compressPaths, true, absolutePaths)
+			}
+			pd.Fields[nd.Name] = nd
+		}
+		return pd
+	}
+
+	pdirs := map[string]*ParsedDirectory{}
+	// Containers that are only named within the fixture are represented
+	// by a directory that has only a name.
+	for p, n := range names {
+		pdirs[p] = &ParsedDirectory{Name: n, Type: Container}
+	}
+	for p, d := range dirs {
+		pd := toIR(d)
+		if d.Entry != nil {
+			if n, ok := names[d.Entry.Path()]; ok {
+				pd.Name = n
+			}
+		}
+		pdirs[p] = pd
+	}
+	return toIR(target), pdirs, errs
+}
+
 func TestGenerateIR(t *testing.T) {
-	// TODO(robjs): Add test coverage.
+	compressedFakeRoot := IROptions{
+		TransformationOptions: TransformationOpts{
+			CompressBehaviour: genutil.PreferIntendedConfig,
+			GenerateFakeRoot:  true,
+		},
+	}
+
 	tests := []struct {
 		desc             string
 		inYANGFiles      []string
@@ -33,7 +178,466 @@ func TestGenerateIR(t *testing.T) {
 		wantIR           *IR
 		wantErrSubstring string
 	}{{
-		desc: "no error",
+		desc:             "no LangMapper supplied",
+		inYANGFiles:      []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inIncludePaths:   []string{datapath},
+		wantErrSubstring: "a LangMapper must be supplied",
+	}, {
+		desc:           "simple openconfig test, with compression and fakeroot, Go LangMapper",
+		inYANGFiles:    []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inIncludePaths: []string{datapath},
+		inLangMapperFn: func() LangMapper { return NewGoLangMapper(compressedFakeRoot) },
+		inOpts:         compressedFakeRoot,
+		wantIR: &IR{
+			Directories: map[string]*ParsedDirectory{
+				"/device": {
+					Name: "Device",
+					Type: Container,
+					Path: []string{"", "device"},
+					Fields: map[string]*NodeDetails{
+						"Parent": {
+							Name: "Parent",
+							YANGDetails: YANGNodeDetails{
+								Name:       "parent",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent"},
+								SchemaPath: "/openconfig-simple/parent",
+							},
+							Type:           DirectoryNode,
+							MapPaths:       [][]string{{"parent"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+						"RemoteContainer": {
+							Name: "RemoteContainer",
+							YANGDetails: YANGNodeDetails{
+								Name:       "remote-container",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "remote-container"},
+								SchemaPath: "/openconfig-simple/remote-container",
+							},
+							Type:           DirectoryNode,
+							MapPaths:       [][]string{{"remote-container"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+					},
+					IsFakeRoot: true,
+				},
+				"/openconfig-simple/parent": {
+					Name: "Parent",
+					Type: Container,
+					Path: []string{"", "openconfig-simple", "parent"},
+					Fields: map[string]*NodeDetails{
+						"Child": {
+							Name: "Child",
+							YANGDetails: YANGNodeDetails{
+								Name:       "child",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child"},
+								SchemaPath: "/openconfig-simple/parent/child",
+							},
+							Type:           DirectoryNode,
+							MapPaths:       [][]string{{"child"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+					},
+				},
+				"/openconfig-simple/parent/child": {
+					Name: "Parent_Child",
+					Type: Container,
+					Path: []string{"", "openconfig-simple", "parent", "child"},
+					Fields: map[string]*NodeDetails{
+						"Four": {
+							Name: "Four",
+							YANGDetails: YANGNodeDetails{
+								Name:       "four",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "config", "four"},
+								SchemaPath: "/openconfig-simple/parent/child/config/four",
+								Type:       &yang.YangType{Name: "binary", Kind: yang.Ybinary},
+							},
+							Type: LeafNode,
+							LangType: &MappedType{
+								NativeType: "Binary",
+								ZeroValue:  "nil",
+							},
+							MapPaths:             [][]string{{"config", "four"}},
+							MapPathModules:       [][]string{{"openconfig-simple", "openconfig-simple"}},
+							ShadowMapPaths:       [][]string{{"state", "four"}},
+							ShadowMapPathModules: [][]string{{"openconfig-simple", "openconfig-simple"}},
+						},
+						"One": {
+							Name: "One",
+							YANGDetails: YANGNodeDetails{
+								Name:       "one",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "config", "one"},
+								SchemaPath: "/openconfig-simple/parent/child/config/one",
+								Type:       &yang.YangType{Name: "string", Kind: yang.Ystring},
+							},
+							Type: LeafNode,
+							LangType: &MappedType{
+								NativeType: "string",
+								ZeroValue:  `""`,
+							},
+							MapPaths:             [][]string{{"config", "one"}},
+							MapPathModules:       [][]string{{"openconfig-simple", "openconfig-simple"}},
+							ShadowMapPaths:       [][]string{{"state", "one"}},
+							ShadowMapPathModules: [][]string{{"openconfig-simple", "openconfig-simple"}},
+						},
+						"Three": {
+							Name: "Three",
+							YANGDetails: YANGNodeDetails{
+								Name:       "three",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "config", "three"},
+								SchemaPath: "/openconfig-simple/parent/child/config/three",
+								Type:       &yang.YangType{Name: "enumeration", Kind: yang.Yenum},
+							},
+							Type: LeafNode,
+							LangType: &MappedType{
+								NativeType:        "E_OpenconfigSimple_Child_Three",
+								IsEnumeratedValue: true,
+								ZeroValue:         "0",
+							},
+							MapPaths:             [][]string{{"config", "three"}},
+							MapPathModules:       [][]string{{"openconfig-simple", "openconfig-simple"}},
+							ShadowMapPaths:       [][]string{{"state", "three"}},
+							ShadowMapPathModules: [][]string{{"openconfig-simple", "openconfig-simple"}},
+						},
+						"Two": {
+							Name: "Two",
+							YANGDetails: YANGNodeDetails{
+								Name:       "two",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "state", "two"},
+								SchemaPath: "/openconfig-simple/parent/child/state/two",
+								Type:       &yang.YangType{Name: "string", Kind: yang.Ystring},
+							},
+							Type: LeafNode,
+							LangType: &MappedType{
+								NativeType: "string",
+								ZeroValue:  `""`,
+							},
+							MapPaths:       [][]string{{"state", "two"}},
+							MapPathModules: [][]string{{"openconfig-simple", "openconfig-simple"}},
+						},
+					},
+				},
+				"/openconfig-simple/remote-container": {
+					Name: "RemoteContainer",
+					Type: Container,
+					Path: []string{"", "openconfig-simple", "remote-container"},
+					Fields: map[string]*NodeDetails{
+						"ALeaf": {
+							Name: "ALeaf",
+							YANGDetails: YANGNodeDetails{
+								Name:       "a-leaf",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "remote-container", "config", "a-leaf"},
+								SchemaPath: "/openconfig-simple/remote-container/config/a-leaf",
+								Type:       &yang.YangType{Name: "string", Kind: yang.Ystring},
+							},
+							Type: LeafNode,
+							LangType: &MappedType{
+								NativeType: "string",
+								ZeroValue:  `""`,
+							},
+							MapPaths:             [][]string{{"config", "a-leaf"}},
+							MapPathModules:       [][]string{{"openconfig-simple", "openconfig-simple"}},
+							ShadowMapPaths:       [][]string{{"state", "a-leaf"}},
+							ShadowMapPathModules: [][]string{{"openconfig-simple", "openconfig-simple"}},
+						},
+					},
+				},
+			},
+			Enums: map[string]*EnumeratedYANGType{
+				"OpenconfigSimple_Child_Three": {
+					Name:     "OpenconfigSimple_Child_Three",
+					Kind:     SimpleEnumerationType,
+					TypeName: "enumeration",
+					ValToCodeName: map[int64]string{
+						1: "ONE",
+						2: "TWO",
+					},
+					ValToYANGDetails: map[int64]*ygot.EnumDefinition{
+						1: {Name: "ONE"},
+						2: {Name: "TWO"},
+					},
+				},
+			},
+		},
+	}, {
+		desc:           "simple openconfig test, without compression, proto LangMapper",
+		inYANGFiles:    []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inIncludePaths: []string{datapath},
+		inLangMapperFn: func() LangMapper { return NewProtoLangMapper(IROptions{}, "", "") },
+		wantIR: &IR{
+			Directories: map[string]*ParsedDirectory{
+				"/openconfig-simple/parent": {
+					Name:        "Parent",
+					Type:        Container,
+					Path:        []string{"", "openconfig-simple", "parent"},
+					PackageName: "openconfig_simple",
+					Fields: map[string]*NodeDetails{
+						"child": {
+							Name: "child",
+							YANGDetails: YANGNodeDetails{
+								Name:       "child",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child"},
+								SchemaPath: "/openconfig-simple/parent/child",
+							},
+							Type:           DirectoryNode,
+							MapPaths:       [][]string{{"child"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+					},
+				},
+				"/openconfig-simple/parent/child": {
+					Name:        "Child",
+					Type:        Container,
+					Path:        []string{"", "openconfig-simple", "parent", "child"},
+					PackageName: "openconfig_simple.parent",
+					Fields: map[string]*NodeDetails{
+						"config": {
+							Name: "config",
+							YANGDetails: YANGNodeDetails{
+								Name:       "config",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "config"},
+								SchemaPath: "/openconfig-simple/parent/child/config",
+							},
+							Type:           DirectoryNode,
+							MapPaths:       [][]string{{"config"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+						"state": {
+							Name: "state",
+							YANGDetails: YANGNodeDetails{
+								Name:       "state",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "state"},
+								SchemaPath: "/openconfig-simple/parent/child/state",
+							},
+							Type:           DirectoryNode,
+							MapPaths:       [][]string{{"state"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+					},
+				},
+				"/openconfig-simple/parent/child/config": {
+					Name:        "Config",
+					Type:        Container,
+					Path:        []string{"", "openconfig-simple", "parent", "child", "config"},
+					PackageName: "openconfig_simple.parent.child",
+					Fields: map[string]*NodeDetails{
+						"four": {
+							Name: "four",
+							YANGDetails: YANGNodeDetails{
+								Name:       "four",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "config", "four"},
+								SchemaPath: "/openconfig-simple/parent/child/config/four",
+								Type:       &yang.YangType{Name: "binary", Kind: yang.Ybinary},
+							},
+							Type:           LeafNode,
+							LangType:       &MappedType{NativeType: "ywrapper.BytesValue"},
+							MapPaths:       [][]string{{"four"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+						"one": {
+							Name: "one",
+							YANGDetails: YANGNodeDetails{
+								Name:       "one",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "config", "one"},
+								SchemaPath: "/openconfig-simple/parent/child/config/one",
+								Type:       &yang.YangType{Name: "string", Kind: yang.Ystring},
+							},
+							Type:           LeafNode,
+							LangType:       &MappedType{NativeType: "ywrapper.StringValue"},
+							MapPaths:       [][]string{{"one"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+						"three": {
+							Name: "three",
+							YANGDetails: YANGNodeDetails{
+								Name:       "three",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "config", "three"},
+								SchemaPath: "/openconfig-simple/parent/child/config/three",
+								Type:       &yang.YangType{Name: "enumeration", Kind: yang.Yenum},
+							},
+							Type: LeafNode,
+							LangType: &MappedType{
+								NativeType:        "Three",
+								IsEnumeratedValue: true,
+							},
+							MapPaths:       [][]string{{"three"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+					},
+				},
+				"/openconfig-simple/parent/child/state": {
+					Name:        "State",
+					Type:        Container,
+					Path:        []string{"", "openconfig-simple", "parent", "child", "state"},
+					PackageName: "openconfig_simple.parent.child",
+					Fields: map[string]*NodeDetails{
+						"four": {
+							Name: "four",
+							YANGDetails: YANGNodeDetails{
+								Name:       "four",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "state", "four"},
+								SchemaPath: "/openconfig-simple/parent/child/state/four",
+								Type:       &yang.YangType{Name: "binary", Kind: yang.Ybinary},
+							},
+							Type:           LeafNode,
+							LangType:       &MappedType{NativeType: "ywrapper.BytesValue"},
+							MapPaths:       [][]string{{"four"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+						"one": {
+							Name: "one",
+							YANGDetails: YANGNodeDetails{
+								Name:       "one",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "state", "one"},
+								SchemaPath: "/openconfig-simple/parent/child/state/one",
+								Type:       &yang.YangType{Name: "string", Kind: yang.Ystring},
+							},
+							Type:           LeafNode,
+							LangType:       &MappedType{NativeType: "ywrapper.StringValue"},
+							MapPaths:       [][]string{{"one"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+						"three": {
+							Name: "three",
+							YANGDetails: YANGNodeDetails{
+								Name:       "three",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "state", "three"},
+								SchemaPath: "/openconfig-simple/parent/child/state/three",
+								Type:       &yang.YangType{Name: "enumeration", Kind: yang.Yenum},
+							},
+							Type: LeafNode,
+							LangType: &MappedType{
+								NativeType:        "Three",
+								IsEnumeratedValue: true,
+							},
+							MapPaths:       [][]string{{"three"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+						"two": {
+							Name: "two",
+							YANGDetails: YANGNodeDetails{
+								Name:       "two",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "parent", "child", "state", "two"},
+								SchemaPath: "/openconfig-simple/parent/child/state/two",
+								Type:       &yang.YangType{Name: "string", Kind: yang.Ystring},
+							},
+							Type:           LeafNode,
+							LangType:       &MappedType{NativeType: "ywrapper.StringValue"},
+							MapPaths:       [][]string{{"two"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+					},
+				},
+				"/openconfig-simple/remote-container": {
+					Name:        "RemoteContainer",
+					Type:        Container,
+					Path:        []string{"", "openconfig-simple", "remote-container"},
+					PackageName: "openconfig_simple",
+					Fields: map[string]*NodeDetails{
+						"config": {
+							Name: "config",
+							YANGDetails: YANGNodeDetails{
+								Name:       "config",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "remote-container", "config"},
+								SchemaPath: "/openconfig-simple/remote-container/config",
+							},
+							Type:           DirectoryNode,
+							MapPaths:       [][]string{{"config"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+						"state": {
+							Name: "state",
+							YANGDetails: YANGNodeDetails{
+								Name:       "state",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "remote-container", "state"},
+								SchemaPath: "/openconfig-simple/remote-container/state",
+							},
+							Type:           DirectoryNode,
+							MapPaths:       [][]string{{"state"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+					},
+				},
+				"/openconfig-simple/remote-container/config": {
+					Name:        "Config",
+					Type:        Container,
+					Path:        []string{"", "openconfig-simple", "remote-container", "config"},
+					PackageName: "openconfig_simple.remote_container",
+					Fields: map[string]*NodeDetails{
+						"a_leaf": {
+							Name: "a_leaf",
+							YANGDetails: YANGNodeDetails{
+								Name:       "a-leaf",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "remote-container", "config", "a-leaf"},
+								SchemaPath: "/openconfig-simple/remote-container/config/a-leaf",
+								Type:       &yang.YangType{Name: "string", Kind: yang.Ystring},
+							},
+							Type:           LeafNode,
+							LangType:       &MappedType{NativeType: "ywrapper.StringValue"},
+							MapPaths:       [][]string{{"a-leaf"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+					},
+				},
+				"/openconfig-simple/remote-container/state": {
+					Name:        "State",
+					Type:        Container,
+					Path:        []string{"", "openconfig-simple", "remote-container", "state"},
+					PackageName: "openconfig_simple.remote_container",
+					Fields: map[string]*NodeDetails{
+						"a_leaf": {
+							Name: "a_leaf",
+							YANGDetails: YANGNodeDetails{
+								Name:       "a-leaf",
+								Module:     "openconfig-simple",
+								Path:       []string{"", "openconfig-simple", "remote-container", "state", "a-leaf"},
+								SchemaPath: "/openconfig-simple/remote-container/state/a-leaf",
+								Type:       &yang.YangType{Name: "string", Kind: yang.Ystring},
+							},
+							Type:           LeafNode,
+							LangType:       &MappedType{NativeType: "ywrapper.StringValue"},
+							MapPaths:       [][]string{{"a-leaf"}},
+							MapPathModules: [][]string{{"openconfig-simple"}},
+						},
+					},
+				},
+			},
+			Enums: map[string]*EnumeratedYANGType{
+				"OpenconfigSimpleParentChildConfigThree": {
+					Name:     "OpenconfigSimpleParentChildConfigThree",
+					Kind:     SimpleEnumerationType,
+					TypeName: "enumeration",
+					ValToCodeName: map[int64]string{
+						1: "ONE",
+						2: "TWO",
+					},
+					ValToYANGDetails: map[int64]*ygot.EnumDefinition{
+						1: {Name: "ONE"},
+						2: {Name: "TWO"},
+					},
+				},
+			},
+		},
 	}}
 
 	for _, tt := range tests {
@@ -42,7 +646,15 @@ func TestGenerateIR(t *testing.T) {
 			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
 				t.Fatalf("did not get expected error, %s", diff)
 			}
-			if diff := cmp.Diff(got, tt.wantIR, cmpopts.IgnoreUnexported()); diff != "" {
+			// YANG types are compared by name and kind, since they are
+			// cyclic data structures.
+			typeCmp := cmp.Comparer(func(a, b *yang.YangType) bool {
+				if a == nil || b == nil {
+					return a == b
+				}
+				return a.Name == b.Name && a.Kind == b.Kind
+			})
+			if diff := cmp.Diff(got, tt.wantIR, cmpopts.IgnoreUnexported(ParsedDirectory{}, NodeDetails{}), cmpopts.EquateEmpty(), typeCmp); diff != "" {
 				t.Fatalf("did not get expected IR, diff(-got,+want):\n%s", diff)
 			}
 		})
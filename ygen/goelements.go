@@ -145,6 +145,14 @@ type goGenState struct {
 	// where two entities re-use a union that has already been created (e.g.,
 	// a leafref to a union) then it is output only once in the generated code.
 	generatedUnions map[string]bool
+	// orderedMapNames stores a map, keyed by the name of the struct that
+	// represents the entries of an "ordered-by user" list, whose values are
+	// the name of the ordered map type that represents the list. It ensures
+	// that the same ordered map type is used for each reference to a list.
+	orderedMapNames map[string]string
+	// generatedOrderedMaps stores the set of ordered map types that have
+	// already been output in the generated code.
+	generatedOrderedMaps map[string]bool
 }
 
 // newGoGenState creates a new goGenState instance, initialised with the
@@ -161,9 +169,122 @@ func newGoGenState(schematree *schemaTree, eSet *enumSet) *goGenState {
 		},
 		uniqueDirectoryNames: map[string]string{},
 		generatedUnions:      map[string]bool{},
+		orderedMapNames:      map[string]string{},
+		generatedOrderedMaps: map[string]bool{},
 	}
 }
 
+// goLangMapper is the LangMapper implementation for Go code generation. It
+// wraps a goGenState, such that the names that are produced when generating
+// the IR are also used when generating Go code.
+type goLangMapper struct {
+	*goGenState
+
+	// genFakeRoot specifies whether the fake root exists within the schema.
+	genFakeRoot bool
+	// skipEnumDedup specifies whether leaves of type enumeration that are
+	// used more than once in the schema should share a common type.
+	skipEnumDedup bool
+	// shortenEnumLeafNames removes the module name from the name of
+	// enumeration leaves.
+	shortenEnumLeafNames bool
+	// useDefiningModuleForTypedefEnumNames uses the defining module name
+	// to prefix typedef enumerated types.
+	useDefiningModuleForTypedefEnumNames bool
+	// enumOrgPrefixesToTrim is the set of organization names that are
+	// trimmed from the names of enumeration leaves.
+	enumOrgPrefixesToTrim []string
+}
+
+// NewGoLangMapper returns a LangMapper that maps the YANG schema to names
+// and types that are used in the Go code generated by ygen, using the
+// supplied IROptions. It can be used with GenerateIR to produce an IR whose
+// names and types match those of the generated Go structs.
+func NewGoLangMapper(opts IROptions) LangMapper {
+	return newGoLangMapper(opts)
+}
+
+// newGoLangMapper returns a goLangMapper initialised with the supplied
+// options.
+func newGoLangMapper(opts IROptions) *goLangMapper {
+	return &goLangMapper{
+		goGenState:                           newGoGenState(nil, nil),
+		genFakeRoot:                          opts.TransformationOptions.GenerateFakeRoot,
+		skipEnumDedup:                        opts.ParseOptions.SkipEnumDeduplication,
+		shortenEnumLeafNames:                 opts.TransformationOptions.ShortenEnumLeafNames,
+		useDefiningModuleForTypedefEnumNames: opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames,
+		enumOrgPrefixesToTrim:                opts.TransformationOptions.EnumOrgPrefixesToTrim,
+	}
+}
+
+// FieldName returns the CamelCase name of the field e. The name is not
+// checked for uniqueness.
+func (g *goLangMapper) FieldName(e *yang.Entry) (string, error) {
+	return genutil.EntryCamelCaseName(e), nil
+}
+
+// DirectoryName returns the unique name of the struct that is generated for
+// the directory e.
+func (g *goLangMapper) DirectoryName(e *yang.Entry, cb genutil.CompressBehaviour) (string, error) {
+	return g.goStructName(e, cb.CompressEnabled(), g.genFakeRoot), nil
+}
+
+// KeyLeafType returns the Go type of the list key leaf e.
+func (g *goLangMapper) KeyLeafType(e *yang.Entry, cb genutil.CompressBehaviour) (*MappedType, error) {
+	return g.LeafType(e, cb)
+}
+
+// LeafType returns the Go type of the leaf or leaf-list e.
+func (g *goLangMapper) LeafType(e *yang.Entry, cb genutil.CompressBehaviour) (*MappedType, error) {
+	return g.yangTypeToGoType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, cb.CompressEnabled(), g.skipEnumDedup, g.shortenEnumLeafNames, g.useDefiningModuleForTypedefEnumNames, g.enumOrgPrefixesToTrim)
+}
+
+// LeafDefault returns the Go snippet for the default value of the leaf or
+// leaf-list e, whose Go type is t. The default specified by the leaf takes
+// precedence over that of its type. Where the leaf's type is a union, the
+// snippet is the value of the member type, without conversion to the union
+// type.
+func (g *goLangMapper) LeafDefault(e *yang.Entry, t *MappedType, cb genutil.CompressBehaviour) (*MappedDefault, error) {
+	var value *string
+	switch {
+	case e.Default != "":
+		value = &e.Default
+	case t.DefaultValue != nil:
+		value = t.DefaultValue
+	default:
+		return nil, nil
+	}
+	snippet, kind, err := g.yangDefaultValueToGo(*value, resolveTypeArgs{yangType: e.Type, contextEntry: e}, true, cb.CompressEnabled(), g.skipEnumDedup, g.shortenEnumLeafNames, g.useDefiningModuleForTypedefEnumNames, g.enumOrgPrefixesToTrim)
+	if err != nil {
+		return nil, err
+	}
+	return &MappedDefault{Value: *snippet, Kind: kind}, nil
+}
+
+// PackageName returns the empty string, since all Go structs are generated
+// within a single package.
+func (g *goLangMapper) PackageName(*yang.Entry, genutil.CompressBehaviour) (string, error) {
+	return "", nil
+}
+
+// EnumeratedValueName returns a Go-safe name for the enumerated value v.
+func (g *goLangMapper) EnumeratedValueName(v string) (string, error) {
+	return safeGoEnumeratedValueName(v), nil
+}
+
+// EnumeratedTypePrefix returns the prefix used for enumerated types in Go.
+func (g *goLangMapper) EnumeratedTypePrefix() string { return goEnumPrefix }
+
+// EnumerationsUseUnderscores returns true, since the names of enumerated
+// types in Go use underscores between path elements.
+func (g *goLangMapper) EnumerationsUseUnderscores() bool { return true }
+
+// SetEnumSet sets the enumSet used to look up enumerated types.
+func (g *goLangMapper) SetEnumSet(e *enumSet) { g.enumSet = e }
+
+// SetSchemaTree sets the schemaTree used to resolve leafrefs.
+func (g *goLangMapper) SetSchemaTree(st *schemaTree) { g.schematree = st }
+
 // resolveTypeArgs is a structure used as an input argument to the yangTypeToGoType
 // function which allows extra context to be handed on. This provides the ability
 // to use not only the YangType but also the yang.Entry that the type was part of
@@ -203,6 +203,17 @@ type goStructField struct {
 	// in templates to determine whether GetXXX methods should be created using
 	// the base template.
 	IsYANGList bool
+	// IsEnumeratedValue stores whether the field is a single enumerated
+	// value (i.e., not a leaf-list or union), which is represented by a
+	// non-pointer type.
+	IsEnumeratedValue bool
+	// OrderedListType is the name of the struct that represents the entries
+	// of a YANG list that is represented by an ordered map type. It is empty
+	// for all other fields.
+	OrderedListType string
+	// IsGenericUnion stores whether the field is a union leaf that is
+	// represented by a pointer to a generic union type.
+	IsGenericUnion bool
 }
 
 // goUnionInterface contains a definition of an interface that should
@@ -216,6 +227,7 @@ type goUnionInterface struct {
 	ConversionSpecs      []*unionConversionSpec // ConversionSpecs contains information on how to convert primitive types to their own union-satisfying types.
 	HasUnsupported       bool                   // HasUnsupported indicates that at least one of the union's subtypes is unsupported.
 	SubtypeDocumentation string                 // SubtypeDocumentation gives a documentation-style string on the subtypes of the union.
+	GenericType          string                 // GenericType is the instantiated generic union type that the union is an alias of, when generic unions are generated.
 }
 
 // generatedGoStruct is used to repesent a Go structure to be handed to a template for output.
@@ -242,6 +254,12 @@ type generatedGoListMethod struct {
 	Keys      []goStructField // Keys of the list that is being generated (length = 1 if the list is single keyed).
 	KeyStruct string          // KeyStruct is the name of the struct used as a key for a multi-keyed list.
 	Receiver  string          // Receiver is the name of the parent struct of the list, which is the receiver for the generated method.
+	// OrderedMap is the name of the ordered map type that is generated to
+	// represent the list. It is set only for "ordered-by user" lists when
+	// ordered maps are being generated.
+	OrderedMap string
+	// KeyType is the Go type of the key of the list.
+	KeyType string
 }
 
 // generatedGoKeyHelper contains the fields required for generating a method
@@ -281,12 +299,13 @@ type generatedGoEnumeration struct {
 }
 
 // generatedLeafGetter is used to represent the parameters required to generate a
-// getter for a leaf within the generated Go code.
+// getter or a setter for a leaf within the generated Go code.
 type generatedLeafGetter struct {
-	// Name is the name of the field. It is used as a suffix to Get to generate
-	// the getter.
+	// Name is the name of the field. It is used as a suffix to Get or Set
+	// to generate the getter or setter.
 	Name string
-	// Type is the type of the field, returned by the generated method.
+	// Type is the type of the field, returned by the generated getter and
+	// taken as the argument of the generated setter.
 	Type string
 	// Zero is the value that should be returned if the field is set to nil.
 	Zero string
@@ -769,6 +788,44 @@ func (t *{{ .Receiver }}) Get{{ .Name }}() {{ .Type }} {
 	}
 	return {{ if .IsPtr -}} * {{- end -}} t.{{ .Name }}
 }
+`)
+
+	// goLeafSetterTemplate defines a template for a function that, for a
+	// particular leaf, generates a setter method which returns the receiver
+	// such that calls can be chained.
+	goLeafSetterTemplate = mustMakeTemplate("setLeaf", `
+// Set{{ .Name }} sets the value of the leaf {{ .Name }} in the {{ .Receiver }}
+// struct, and returns the receiver such that calls can be chained.
+func (t *{{ .Receiver }}) Set{{ .Name }}(v {{ .Type }}) *{{ .Receiver }} {
+	t.{{ .Name }} = {{ if .IsPtr -}} & {{- end -}} v
+	return t
+}
+`)
+
+	// goListEntryConstructorTemplate defines a template for a function that
+	// creates a new entry of a keyed list, populating the key fields of the
+	// entry from its arguments.
+	goListEntryConstructorTemplate = mustMakeTemplate("newListEntryStruct", `
+// New{{ .Receiver }} returns a new {{ .Receiver }} list entry whose key
+// fields are populated from the input arguments, such that its key is
+// consistent with the key of the list that it is added to.
+func New{{ .Receiver }}(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) *{{ .Receiver }} {
+	return &{{ .Receiver }}{
+		{{- range $key := .Keys }}
+		{{- if $key.IsScalarField }}
+		{{ $key.Name }}: &{{ $key.Name }},
+		{{- else }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end -}}
+		{{- end }}
+	}
+}
 `)
 
 	// goDeleteListTemplate defines a template for a function that, for a
@@ -887,31 +944,639 @@ func (t *{{ .Receiver }}) Rename{{ .ListName }}(
 		return fmt.Errorf("key %v already exists in {{ .ListName }}", newK)
 	}
 
-	e, ok := t.{{ .ListName }}[oldK]
+	e, ok := t.{{ .ListName }}[oldK]
+	if !ok {
+		return fmt.Errorf("key %v not found in {{ .ListName }}", oldK)
+	}
+
+	{{- if ne .KeyStruct "" -}}
+	{{- range $key := .Keys -}}
+	{{- if $key.IsScalarField }}
+	e.{{ $key.Name }} = &newK.{{ $key.Name }}
+	{{- else }}
+	e.{{ $key.Name }} = newK.{{ $key.Name }}
+	{{- end -}}
+	{{- end -}}
+	{{ else -}}
+	{{- $key := index .Keys 0 -}}
+	{{- if $key.IsScalarField }}
+	e.{{ $key.Name }} = &newK
+	{{- else }}
+	e.{{ $key.Name }} = newK
+	{{- end -}}
+	{{- end }}
+
+	t.{{ .ListName }}[newK] = e
+	delete(t.{{ .ListName }}, oldK)
+	return nil
+}
+`)
+
+	// goOrderedMapTemplate takes an input generatedGoListMethod struct and
+	// outputs the definition of the ordered map type that represents an
+	// "ordered-by user" list, along with its methods. The ordered map stores
+	// the keys of the list in a slice, such that the order in which entries
+	// are inserted is retained.
+	goOrderedMapTemplate = mustMakeTemplate("orderedMap", `
+// {{ .OrderedMap }} is an ordered map that represents an "ordered-by user"
+// list whose entries are represented by the {{ .ListType }} struct. The
+// zero value of {{ .OrderedMap }} is an empty list that is ready for use.
+type {{ .OrderedMap }} struct {
+	keys     []{{ .KeyType }}
+	valueMap map[{{ .KeyType }}]*{{ .ListType }}
+}
+
+// IsYANGOrderedList ensures that {{ .OrderedMap }} implements the
+// ygot.GoOrderedMap interface.
+func (*{{ .OrderedMap }}) IsYANGOrderedList() {}
+
+// init initialises any uninitialised values of the ordered map.
+func (o *{{ .OrderedMap }}) init() {
+	if o.valueMap == nil {
+		o.valueMap = map[{{ .KeyType }}]*{{ .ListType }}{}
+	}
+}
+
+// Keys returns a copy of the keys of the list, in order.
+func (o *{{ .OrderedMap }}) Keys() []{{ .KeyType }} {
+	if o == nil {
+		return nil
+	}
+	return append([]{{ .KeyType }}{}, o.keys...)
+}
+
+// Values returns the entries of the list, in order.
+func (o *{{ .OrderedMap }}) Values() []*{{ .ListType }} {
+	if o == nil {
+		return nil
+	}
+	var vs []*{{ .ListType }}
+	for _, k := range o.keys {
+		vs = append(vs, o.valueMap[k])
+	}
+	return vs
+}
+
+// Len returns the number of entries in the list.
+func (o *{{ .OrderedMap }}) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.keys)
+}
+
+// Get returns the entry of the list with the specified key, or nil if there
+// is no such entry.
+func (o *{{ .OrderedMap }}) Get(key {{ .KeyType }}) *{{ .ListType }} {
+	if o == nil {
+		return nil
+	}
+	return o.valueMap[key]
+}
+
+// Delete removes the entry with the specified key from the list. It returns
+// true if the entry was present in the list.
+func (o *{{ .OrderedMap }}) Delete(key {{ .KeyType }}) bool {
+	i := o.index(key)
+	if i == -1 {
+		return false
+	}
+	o.keys = append(o.keys[:i], o.keys[i+1:]...)
+	delete(o.valueMap, key)
+	return true
+}
+
+// Append appends the supplied {{ .ListType }} struct to the end of the list.
+// An error is returned if the key value(s) of v are unset, or already exist
+// in the list.
+func (o *{{ .OrderedMap }}) Append(v *{{ .ListType }}) error {
+	return o.insertAt(o.Len(), v)
+}
+
+// AppendNew creates a new entry with the specified keys, and appends it to the
+// end of the list. An error is returned if the keys already exist in the list.
+func (o *{{ .OrderedMap }}) AppendNew(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) (*{{ .ListType }}, error) {
+	v := &{{ .ListType }}{
+		{{- range $key := .Keys }}
+		{{- if $key.IsScalarField }}
+		{{ $key.Name }}: &{{ $key.Name }},
+		{{- else }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end -}}
+		{{- end }}
+	}
+	if err := o.Append(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// InsertBefore inserts the supplied {{ .ListType }} struct into the list
+// immediately before the entry with the key before. An error is returned if
+// before is not present in the list, or the key value(s) of v are unset or
+// already exist in the list.
+func (o *{{ .OrderedMap }}) InsertBefore(before {{ .KeyType }}, v *{{ .ListType }}) error {
+	i := o.index(before)
+	if i == -1 {
+		return fmt.Errorf("key %v not found in list {{ .ListName }}", before)
+	}
+	return o.insertAt(i, v)
+}
+
+// InsertAfter inserts the supplied {{ .ListType }} struct into the list
+// immediately after the entry with the key after. An error is returned if
+// after is not present in the list, or the key value(s) of v are unset or
+// already exist in the list.
+func (o *{{ .OrderedMap }}) InsertAfter(after {{ .KeyType }}, v *{{ .ListType }}) error {
+	i := o.index(after)
+	if i == -1 {
+		return fmt.Errorf("key %v not found in list {{ .ListName }}", after)
+	}
+	return o.insertAt(i+1, v)
+}
+
+// MoveBefore moves the entry with the specified key such that it is
+// immediately before the entry with the key before. An error is returned if
+// either key is not present in the list.
+func (o *{{ .OrderedMap }}) MoveBefore(key, before {{ .KeyType }}) error {
+	return o.move(key, before, 0)
+}
+
+// MoveAfter moves the entry with the specified key such that it is
+// immediately after the entry with the key after. An error is returned if
+// either key is not present in the list.
+func (o *{{ .OrderedMap }}) MoveAfter(key, after {{ .KeyType }}) error {
+	return o.move(key, after, 1)
+}
+
+// index returns the position of the specified key within the list, or -1 if
+// the key is not present.
+func (o *{{ .OrderedMap }}) index(key {{ .KeyType }}) int {
+	if o == nil {
+		return -1
+	}
+	for i, k := range o.keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertAt inserts v into the list at position i.
+func (o *{{ .OrderedMap }}) insertAt(i int, v *{{ .ListType }}) error {
+	if v == nil {
+		return fmt.Errorf("invalid nil entry for list {{ .ListName }}")
+	}
+	{{ if ne .KeyStruct "" -}}
+	{{- range $key := .Keys }}
+	{{- if $key.IsScalarField -}}
+	if v.{{ $key.Name }} == nil {
+		return fmt.Errorf("invalid nil key for {{ $key.Name }}")
+	}
+
+	{{ end -}}
+	{{- end -}}
+	key := {{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{- if $key.IsScalarField }}
+		{{ $key.Name }}: *v.{{ $key.Name }},
+		{{- else }}
+		{{ $key.Name }}: v.{{ $key.Name }},
+		{{- end -}}
+		{{ end }}
+	}
+	{{- else -}}
+	{{- range $key := .Keys -}}
+		{{- if $key.IsScalarField -}}
+	if v.{{ $key.Name }} == nil {
+		return fmt.Errorf("invalid nil key received for {{ $key.Name }}")
+	}
+
+	key := *v.{{ $key.Name }}
+		{{- else -}}
+	key := v.{{ $key.Name }}
+		{{- end -}}
+	{{- end -}}
+	{{- end }}
+
+	if _, ok := o.valueMap[key]; ok {
+		return fmt.Errorf("duplicate key for list {{ .ListName }} %v", key)
+	}
+	o.init()
+	o.keys = append(o.keys, key)
+	copy(o.keys[i+1:], o.keys[i:])
+	o.keys[i] = key
+	o.valueMap[key] = v
+	return nil
+}
+
+// move moves the entry with the specified key such that it is at the
+// supplied offset from the entry with the key target.
+func (o *{{ .OrderedMap }}) move(key, target {{ .KeyType }}, offset int) error {
+	i := o.index(key)
+	if i == -1 {
+		return fmt.Errorf("key %v not found in list {{ .ListName }}", key)
+	}
+	if o.index(target) == -1 {
+		return fmt.Errorf("key %v not found in list {{ .ListName }}", target)
+	}
+	if key == target {
+		return nil
+	}
+	o.keys = append(o.keys[:i], o.keys[i+1:]...)
+	j := o.index(target) + offset
+	o.keys = append(o.keys, key)
+	copy(o.keys[j+1:], o.keys[j:])
+	o.keys[j] = key
+	return nil
+}
+`)
+
+	// goNewOrderedListMemberTemplate takes an input generatedGoListMethod
+	// struct for a list that is represented by an ordered map, and outputs a
+	// method, using the specified receiver, that creates a new entry at the
+	// end of the list, populating its keys from the input arguments.
+	goNewOrderedListMemberTemplate = mustMakeTemplate("newOrderedListEntry", `
+// New{{ .ListName }} creates a new entry at the end of the {{ .ListName }}
+// ordered list of the {{ .Receiver}} struct. The keys of the list are populated
+// from the input arguments.
+func (t *{{ .Receiver }}) New{{ .ListName }}(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) (*{{ .ListType }}, error){
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.{{ .ListName }} == nil {
+		t.{{ .ListName }} = &{{ .OrderedMap }}{}
+	}
+
+	return t.{{ .ListName }}.AppendNew(
+		{{- range $i, $key := .Keys -}}
+		{{ $key.Name }}
+		{{- if ne (inc $i) $length -}}, {{ end -}}
+		{{- end -}})
+}
+`)
+
+	// goOrderedListGetterTemplate defines a template for a function that, for
+	// a particular key of a list represented by an ordered map, gets an
+	// existing list entry.
+	goOrderedListGetterTemplate = mustMakeTemplate("getOrderedList", `
+// Get{{ .ListName }} retrieves the value with the specified key from
+// the {{ .ListName }} ordered list of {{ .Receiver }}. If the receiver is nil,
+// or the specified key is not present in the list, nil is returned such that
+// Get* methods may be safely chained.
+func (t *{{ .Receiver }}) Get{{ .ListName }}(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) (*{{ .ListType }}){
+
+	if t == nil {
+		return nil
+	}
+
+	{{ if ne .KeyStruct "" -}}
+	key := {{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end }}
+	}
+	{{- else -}}
+	{{- range $key := .Keys -}}
+	key := {{ $key.Name }}
+	{{- end -}}
+	{{- end }}
+
+	return t.{{ .ListName }}.Get(key)
+}
+`)
+
+	// goGetOrCreateOrderedListTemplate defines a template for a function that,
+	// for a particular key of a list represented by an ordered map, gets an
+	// existing list entry, or appends it to the list if it doesn't exist.
+	goGetOrCreateOrderedListTemplate = mustMakeTemplate("getOrCreateOrderedList", `
+// GetOrCreate{{ .ListName }} retrieves the value with the specified keys from
+// the receiver {{ .Receiver }}. If the entry does not exist, then it is created
+// at the end of the list. It returns the existing or new list member.
+func (t *{{ .Receiver }}) GetOrCreate{{ .ListName }}(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) (*{{ .ListType }}){
+
+	{{ if ne .KeyStruct "" -}}
+	key := {{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end }}
+	}
+	{{- else -}}
+	{{- range $key := .Keys -}}
+	key := {{ $key.Name }}
+	{{- end -}}
+	{{- end }}
+
+	if v := t.{{ .ListName }}.Get(key); v != nil {
+		return v
+	}
+	// Panic if we receive an error, since we should have retrieved an existing
+	// list member. This allows chaining of GetOrCreate methods.
+	v, err := t.New{{ .ListName }}(
+		{{- range $i, $key := .Keys -}}
+		{{ $key.Name }}
+		{{- if ne (inc $i) $length -}}, {{ end -}}
+		{{- end -}})
+	if err != nil {
+		panic(fmt.Sprintf("GetOrCreate{{ .ListName }} got unexpected error: %v", err))
+	}
+	return v
+}
+`)
+
+	// goDeleteOrderedListTemplate defines a template for a function that, for
+	// a particular key of a list represented by an ordered map, deletes an
+	// existing list entry.
+	goDeleteOrderedListTemplate = mustMakeTemplate("deleteOrderedList", `
+// Delete{{ .ListName }} deletes the value with the specified keys from
+// the receiver {{ .Receiver }}. If there is no such element, the function
+// is a no-op.
+func (t *{{ .Receiver }}) Delete{{ .ListName }}(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) {
+	{{ if ne .KeyStruct "" -}}
+	key := {{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end }}
+	}
+	{{- else -}}
+	{{- range $key := .Keys -}}
+	key := {{ $key.Name }}
+	{{- end -}}
+	{{- end }}
+
+	t.{{ .ListName }}.Delete(key)
+}
+`)
+
+	// goOrderedListAppendTemplate defines a template for a function that
+	// takes an input list member struct, and appends it to the end of a list
+	// that is represented by an ordered map.
+	goOrderedListAppendTemplate = mustMakeTemplate("appendOrderedList", `
+// Append{{ .ListName }} appends the supplied {{ .ListType }} struct to the
+// end of the ordered list {{ .ListName }} of {{ .Receiver }}. If the key
+// value(s) specified in the supplied {{ .ListType }} already exist in the list,
+// an error is returned.
+func (t *{{ .Receiver }}) Append{{ .ListName }}(v *{{ .ListType }}) error {
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.{{ .ListName }} == nil {
+		t.{{ .ListName }} = &{{ .OrderedMap }}{}
+	}
+	return t.{{ .ListName }}.Append(v)
+}
+`)
+
+	// goDeepCopyTemplate takes an input generatedHelperStruct, which describes
+	// a generated struct, and generates ΛDeepCopy and ΛMerge methods for it.
+	// The values of unions are compared, and copied where they are pointers
+	// or slices, using the reflect package, since their types are not known
+	// when the struct is generated.
+	goDeepCopyTemplate = mustMakeTemplate("deepCopy", `
+// ΛDeepCopy returns a deep copy of the {{ .StructName }} struct, without the
+// use of reflection.
+func (t *{{ .StructName }}) ΛDeepCopy() (ygot.GoStruct, error) {
+	n := &{{ .StructName }}{}
+	if err := n.ΛMerge(t); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// ΛMerge merges the contents of src, which must be of type *{{ .StructName }},
+// into t without the use of reflection. The semantics of the merge are the
+// same as those of ygot.MergeStructInto.
+func (t *{{ .StructName }}) ΛMerge(src ygot.GoStruct, opts ...ygot.MergeOpt) error {
+	s, ok := src.(*{{ .StructName }})
+	if !ok {
+		return fmt.Errorf("cannot merge structs that are not of matching types, %T != %T", t, src)
+	}
+	if s == nil {
+		return nil
+	}
+{{- if .CheckOverwrite }}
+	overwrite := false
+	for _, o := range opts {
+		if _, ok := o.(*ygot.MergeOverwriteExistingFields); ok {
+			overwrite = true
+		}
+	}
+{{- end }}
+{{- range $field := .Fields }}
+	{{- if eq $field.Kind "scalar" }}
+	if s.{{ $field.Name }} != nil {
+		if t.{{ $field.Name }} != nil && *t.{{ $field.Name }} != *s.{{ $field.Name }} && !overwrite {
+			return fmt.Errorf("destination value was set, but was not equal to source value when merging field {{ $field.Name }}, src: %v, dst: %v", *s.{{ $field.Name }}, *t.{{ $field.Name }})
+		}
+		v := *s.{{ $field.Name }}
+		t.{{ $field.Name }} = &v
+	}
+	{{- else if eq $field.Kind "enum" }}
+	if s.{{ $field.Name }} != 0 {
+		if t.{{ $field.Name }} != 0 && t.{{ $field.Name }} != s.{{ $field.Name }} && !overwrite {
+			return fmt.Errorf("destination and source values were set when merging enum field {{ $field.Name }}, dst: %d, src: %d", t.{{ $field.Name }}, s.{{ $field.Name }})
+		}
+		t.{{ $field.Name }} = s.{{ $field.Name }}
+	}
+	{{- else if eq $field.Kind "value" }}
+	t.{{ $field.Name }} = s.{{ $field.Name }}
+	{{- else if eq $field.Kind "container" }}
+	if s.{{ $field.Name }} != nil {
+		if t.{{ $field.Name }} == nil {
+			t.{{ $field.Name }} = &{{ $field.ElemType }}{}
+		}
+		if err := t.{{ $field.Name }}.ΛMerge(s.{{ $field.Name }}, opts...); err != nil {
+			return err
+		}
+	}
+	{{- else if eq $field.Kind "map" }}
+	if len(s.{{ $field.Name }}) != 0 {
+		if t.{{ $field.Name }} == nil {
+			t.{{ $field.Name }} = make({{ $field.Type }}, len(s.{{ $field.Name }}))
+		}
+		for k, v := range s.{{ $field.Name }} {
+			d, ok := t.{{ $field.Name }}[k]
+			if !ok {
+				d = &{{ $field.ElemType }}{}
+			}
+			if err := d.ΛMerge(v, opts...); err != nil {
+				return err
+			}
+			t.{{ $field.Name }}[k] = d
+		}
+	}
+	{{- else if eq $field.Kind "orderedmap" }}
+	if s.{{ $field.Name }}.Len() != 0 {
+		if t.{{ $field.Name }} == nil {
+			t.{{ $field.Name }} = &{{ $field.MapType }}{}
+		}
+		for _, k := range s.{{ $field.Name }}.Keys() {
+			d := t.{{ $field.Name }}.Get(k)
+			isNew := d == nil
+			if isNew {
+				d = &{{ $field.ElemType }}{}
+			}
+			if err := d.ΛMerge(s.{{ $field.Name }}.Get(k), opts...); err != nil {
+				return err
+			}
+			if isNew {
+				if err := t.{{ $field.Name }}.Append(d); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	{{- else if eq $field.Kind "genericunion" }}
+	if s.{{ $field.Name }} != nil {
+		if t.{{ $field.Name }} != nil && !overwrite && !reflect.DeepEqual(*t.{{ $field.Name }}, *s.{{ $field.Name }}) {
+			return fmt.Errorf("destination value was set, but was not equal to source value when merging field {{ $field.Name }}, src: %v, dst: %v", *s.{{ $field.Name }}, *t.{{ $field.Name }})
+		}
+		v := *s.{{ $field.Name }}
+		t.{{ $field.Name }} = &v
+	}
+	{{- else if eq $field.Kind "union" }}
+	if s.{{ $field.Name }} != nil {
+		if t.{{ $field.Name }} != nil && !overwrite && !reflect.DeepEqual(t.{{ $field.Name }}, s.{{ $field.Name }}) {
+			return fmt.Errorf("destination value was set, but was not equal to source value when merging field {{ $field.Name }}, src: %v, dst: %v", s.{{ $field.Name }}, t.{{ $field.Name }})
+		}
+		switch v := reflect.ValueOf(s.{{ $field.Name }}); v.Kind() {
+		case reflect.Ptr:
+			c := reflect.New(v.Elem().Type())
+			c.Elem().Set(v.Elem())
+			t.{{ $field.Name }} = c.Interface().({{ $field.Type }})
+		case reflect.Slice:
+			t.{{ $field.Name }} = reflect.AppendSlice(reflect.Zero(v.Type()), v).Interface().({{ $field.Type }})
+		default:
+			t.{{ $field.Name }} = s.{{ $field.Name }}
+		}
+	}
+	{{- else if eq $field.Kind "slice" }}
+	if len(s.{{ $field.Name }}) != 0 && !reflect.DeepEqual(t.{{ $field.Name }}, s.{{ $field.Name }}) {
+		for _, v := range s.{{ $field.Name }} {
+			for _, d := range t.{{ $field.Name }} {
+				if reflect.DeepEqual(v, d) {
+					return fmt.Errorf("source and destination lists must be unique when merging field {{ $field.Name }}, src: %v, dst: %v", s.{{ $field.Name }}, t.{{ $field.Name }})
+				}
+			}
+		}
+		{{- if $field.ElemType }}
+		for _, v := range s.{{ $field.Name }} {
+			d := &{{ $field.ElemType }}{}
+			if err := d.ΛMerge(v, opts...); err != nil {
+				return err
+			}
+			t.{{ $field.Name }} = append(t.{{ $field.Name }}, d)
+		}
+		{{- else }}
+		t.{{ $field.Name }} = append(t.{{ $field.Name }}, s.{{ $field.Name }}...)
+		{{- end }}
+	}
+	{{- else if eq $field.Kind "annotation" }}
+	t.{{ $field.Name }} = append(t.{{ $field.Name }}, s.{{ $field.Name }}...)
+	{{- end }}
+{{- end }}
+	return nil
+}
+`)
+
+	// goEqualTemplate takes an input generatedHelperStruct, which describes a
+	// generated struct, and generates a ΛEqual method for it. Annotation fields
+	// are not compared.
+	goEqualTemplate = mustMakeTemplate("equal", `
+// ΛEqual returns true if other is of type *{{ .StructName }} and has the same
+// contents as t, without the use of reflection. Containers and lists that are
+// nil are considered equal to those that are empty.
+func (t *{{ .StructName }}) ΛEqual(other ygot.GoStruct) bool {
+	s, ok := other.(*{{ .StructName }})
 	if !ok {
-		return fmt.Errorf("key %v not found in {{ .ListName }}", oldK)
+		return false
+	}
+	if t == nil {
+		t = &{{ .StructName }}{}
+	}
+	if s == nil {
+		s = &{{ .StructName }}{}
+	}
+{{- range $field := .Fields }}
+	{{- if eq $field.Kind "scalar" }}
+	if (t.{{ $field.Name }} == nil) != (s.{{ $field.Name }} == nil) || (t.{{ $field.Name }} != nil && *t.{{ $field.Name }} != *s.{{ $field.Name }}) {
+		return false
+	}
+	{{- else if or (eq $field.Kind "enum") (eq $field.Kind "value") }}
+	if t.{{ $field.Name }} != s.{{ $field.Name }} {
+		return false
+	}
+	{{- else if eq $field.Kind "container" }}
+	if !t.{{ $field.Name }}.ΛEqual(s.{{ $field.Name }}) {
+		return false
+	}
+	{{- else if eq $field.Kind "map" }}
+	if len(t.{{ $field.Name }}) != len(s.{{ $field.Name }}) {
+		return false
+	}
+	for k, v := range t.{{ $field.Name }} {
+		if o, ok := s.{{ $field.Name }}[k]; !ok || !v.ΛEqual(o) {
+			return false
+		}
+	}
+	{{- else if eq $field.Kind "orderedmap" }}
+	{
+		tk, sk := t.{{ $field.Name }}.Keys(), s.{{ $field.Name }}.Keys()
+		if len(tk) != len(sk) {
+			return false
+		}
+		for i, k := range tk {
+			if sk[i] != k || !t.{{ $field.Name }}.Get(k).ΛEqual(s.{{ $field.Name }}.Get(k)) {
+				return false
+			}
+		}
+	}
+	{{- else if and (eq $field.Kind "slice") $field.ElemType }}
+	if len(t.{{ $field.Name }}) != len(s.{{ $field.Name }}) {
+		return false
+	}
+	for i, v := range t.{{ $field.Name }} {
+		if !v.ΛEqual(s.{{ $field.Name }}[i]) {
+			return false
+		}
+	}
+	{{- else if ne $field.Kind "annotation" }}
+	if !reflect.DeepEqual(t.{{ $field.Name }}, s.{{ $field.Name }}) {
+		return false
 	}
-
-	{{- if ne .KeyStruct "" -}}
-	{{- range $key := .Keys -}}
-	{{- if $key.IsScalarField }}
-	e.{{ $key.Name }} = &newK.{{ $key.Name }}
-	{{- else }}
-	e.{{ $key.Name }} = newK.{{ $key.Name }}
-	{{- end -}}
-	{{- end -}}
-	{{ else -}}
-	{{- $key := index .Keys 0 -}}
-	{{- if $key.IsScalarField }}
-	e.{{ $key.Name }} = &newK
-	{{- else }}
-	e.{{ $key.Name }} = newK
-	{{- end -}}
 	{{- end }}
-
-	t.{{ .ListName }}[newK] = e
-	delete(t.{{ .ListName }}, oldK)
-	return nil
+{{- end }}
+	return true
 }
 `)
 
@@ -977,6 +1642,68 @@ var ΛEnumTypes = map[string][]reflect.Type{
 	},
 	{{- end }}
 }
+`)
+
+	// goRPCTypeMapTemplate provides a template to output a constant map which
+	// contains the types of the generated structs for the input and output
+	// of each YANG rpc or action, keyed by the qualified name of the
+	// operation.
+	goRPCTypeMapTemplate = mustMakeTemplate("rpcTypeMap", `
+// ΛRPCTypes is a map, keyed by the qualified name of a YANG rpc or action, of
+// the types of the generated structs that represent the input and output of
+// the operation. RPCs are keyed by a name of the form "module:rpc-name", and
+// actions by their schema path, with the first element of the path prefixed
+// by the name of the module, e.g., "/module:container/list/action-name".
+var ΛRPCTypes = map[string]*ygot.RPCTypes{
+	{{- range $name, $types := . }}
+	"{{ $name }}": {
+		{{- if $types.Input }}
+		Input: reflect.TypeOf((*{{ $types.Input }})(nil)),
+		{{- end }}
+		{{- if $types.Output }}
+		Output: reflect.TypeOf((*{{ $types.Output }})(nil)),
+		{{- end }}
+	},
+	{{- end }}
+}
+`)
+
+	// goNotificationTypeMapTemplate provides a template to output a constant
+	// map which contains the types of the generated structs for each YANG
+	// notification, keyed by the path of the notification. Where the schema
+	// is generated, a function to unmarshal a notification is also output.
+	goNotificationTypeMapTemplate = mustMakeTemplate("notificationTypeMap", `
+// ΛNotificationTypes is a map, keyed by the path of a YANG notification, of the
+// type of the generated struct that represents the notification. Paths are of
+// the form "/module:notification-name" for notifications at the root of a
+// module, or "/module:container/list/notification-name" for those that are
+// nested within the data tree.
+var ΛNotificationTypes = map[string]reflect.Type{
+	{{- range $path, $name := .Types }}
+	"{{ $path }}": reflect.TypeOf((*{{ $name }})(nil)),
+	{{- end }}
+}
+{{- if .GenerateSchema }}
+
+// UnmarshalNotification unmarshals data, which must be RFC7951 JSON format,
+// into a new instance of the struct that represents the notification at the
+// supplied path, which must be a key of ΛNotificationTypes. The supplied
+// options (opts) are used to control the behaviour of the unmarshal function.
+func UnmarshalNotification(path string, data []byte, opts ...ytypes.UnmarshalOpt) (ygot.ValidatedGoStruct, error) {
+	t, ok := ΛNotificationTypes[path]
+	if !ok {
+		return nil, fmt.Errorf("unknown notification %s", path)
+	}
+	n, ok := reflect.New(t.Elem()).Interface().(ygot.ValidatedGoStruct)
+	if !ok {
+		return nil, fmt.Errorf("type %v for notification %s is not a ValidatedGoStruct", t, path)
+	}
+	if err := Unmarshal(data, n, opts...); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+{{- end }}
 `)
 
 	// goEnumTypeMapAccessTemplate provides a template to output an accessor
@@ -1109,6 +1836,16 @@ func (t *{{ .ParentReceiver }}) To_{{ .Name }}(i interface{}) ({{ .Name }}, erro
 	{{- end -}}
 	]", i, i)
 }
+`)
+
+	// unionTypeGenericTemplate outputs the type that corresponds to a multi-type
+	// union in the YANG schema when generic unions are generated. The type is an
+	// alias of one of the generic union types of the ygot package.
+	unionTypeGenericTemplate = mustMakeTemplate("unionTypeGeneric", `
+// {{ .Name }} is a union that represents the leaf {{ .LeafPath }} within
+// the YANG schema. Its value can be one of [{{ .SubtypeDocumentation }}], in the
+// order of the union's type parameters.
+type {{ .Name }} = {{ .GenericType }}
 `)
 
 	// templateHelperFunctions specifies a set of functions that are supplied as
@@ -1240,23 +1977,35 @@ func IsScalarField(field *yang.Entry, t *MappedType) bool {
 	// A non-leaf has a generated type which are always stored by pointers.
 	case field.Kind != yang.LeafEntry:
 		return false
+	// a leaflist can use nil already, so it should also not be a pointer.
+	case field.ListAttr != nil:
+		return false
+	}
+	return isScalarType(t)
+}
+
+// isScalarType determines whether a leaf whose Go type is t should be stored
+// as a pointer, such that it can be checked against nil.
+func isScalarType(t *MappedType) bool {
+	switch {
 	// A union shouldn't be a pointer since its field type is an interface;
 	case len(t.UnionTypes) >= 2:
 		return false
 	// an enumerated value shouldn't be a pointer either since its has an UNSET value;
 	case t.IsEnumeratedValue:
 		return false
-	// an unmapped type (interface{}), byte slice, or a leaflist can also use nil already, so they should also not be pointers.
-	case t.NativeType == ygot.BinaryTypeName, t.NativeType == ygot.EmptyTypeName, t.NativeType == "interface{}", field.ListAttr != nil:
+	// an unmapped type (interface{}), or byte slice can also use nil already, so they should also not be pointers.
+	case t.NativeType == ygot.BinaryTypeName, t.NativeType == ygot.EmptyTypeName, t.NativeType == "interface{}":
 		return false
 	}
 	return true
 }
 
-// writeGoStruct generates code snippets for targetStruct. The parameter goStructElements
-// contains other Directory structs for which code is being generated, that may be referenced
-// during the generation of the code corresponding to targetStruct (e.g., to determine a
-// child container's struct name).
+// writeGoStruct generates code snippets for targetStruct, a directory within the IR. The
+// names and types of its fields, and the schema paths that they are mapped to, are those
+// determined by the IR. The parameter goStructElements contains the other IR directories
+// for which code is being generated, that may be referenced during the generation of the
+// code corresponding to targetStruct (e.g., to determine a child container's struct name).
 //
 // writeGoStruct takes the following additional arguments:
 //  - state - the current generator state, as a genState pointer.
@@ -1276,7 +2025,7 @@ func IsScalarField(field *yang.Entry, t *MappedType) bool {
 //	   of targetStruct (listKeys).
 //	3. Methods with the struct corresponding to targetStruct as a receiver, e.g., for each
 //	   list a NewListMember() method is generated.
-func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directory, gogen *goGenState, compressPaths, ignoreShadowSchemaPaths, generateJSONSchema, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string, goOpts GoOpts) (GoStructCodeSnippet, []error) {
+func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*ParsedDirectory, gogen *goGenState, compressPaths, ignoreShadowSchemaPaths, generateJSONSchema, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string, goOpts GoOpts) (GoStructCodeSnippet, []error) {
 	var errs []error
 
 	// structDef is used to store the attributes of the structure for which code is being
@@ -1302,8 +2051,10 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 	// is set to true.
 	var associatedLeafGetters []*generatedLeafGetter
 
-	// The Go names of the struct's fields.
-	goFieldNameMap := GoFieldNameMap(targetStruct)
+	// associatedLeafSetters is a slice of structs which define the set of leaf setters
+	// to be generated for the struct. It is only populated if the GenerateLeafSetters
+	// option is set to true.
+	var associatedLeafSetters []*generatedLeafGetter
 
 	// definedNameMap defines a map, keyed by YANG identifier to the Go struct field name.
 	definedNameMap := map[string]*yangFieldMap{}
@@ -1334,23 +2085,22 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 	}
 
 	// Alphabetically order fields to produce deterministic output.
-	for _, fName := range GetOrderedFieldNames(targetStruct) {
+	for _, field := range targetStruct.yangOrderedFields() {
 		// Iterate through the fields of the struct that we are generating code for.
-		// For each field, calculate the name of the field (ensuring that it is unique), and
-		// the corresponding type. fieldDef is used to store the definition of the field (name
-		// and type) that are calculated.
+		// The unique name of each field, and the type of its leaves, are
+		// determined by the IR. fieldDef is used to store the definition of the
+		// field (name and type) that are calculated.
 		var fieldDef *goStructField
 
-		field := targetStruct.Fields[fName]
-		fieldName := goFieldNameMap[fName]
+		fName, fieldName := field.YANGDetails.Name, field.Name
 		definedNameMap[fName] = &yangFieldMap{YANGName: fName, GoName: fieldName}
 
-		switch {
-		case field.IsList():
+		switch field.Type {
+		case ListNode:
 			// If the field within the struct is a list, then generate code for this list. This
 			// includes extracting any new types that are required to represent the key of a
 			// list that has multiple keys.
-			fieldType, multiKeyListKey, listMethods, listErr := yangListFieldToGoType(field, fieldName, targetStruct, goStructElements, gogen)
+			fieldType, multiKeyListKey, listMethods, listErr := yangListFieldToGoType(field, fieldName, targetStruct, goStructElements, gogen, goOpts.GenerateOrderedMaps, goOpts.GenerateGenericUnions)
 			if listErr != nil {
 				errs = append(errs, listErr)
 			}
@@ -1363,6 +2113,9 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 
 			if listMethods != nil {
 				associatedListMethods = append(associatedListMethods, listMethods)
+				if listMethods.OrderedMap != "" {
+					fieldDef.OrderedListType = listMethods.ListType
+				}
 			}
 
 			if multiKeyListKey != nil {
@@ -1371,62 +2124,70 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 				associatedListKeyStructs = append(associatedListKeyStructs, multiKeyListKey)
 			}
 
-		case field.IsContainer():
+		case DirectoryNode:
 			// This is a YANG container, so it is represented in code using a pointer to the struct type that
 			// is defined for the entity. findMappableEntities has already determined which fields are to
 			// be output, so no filtering of the set of fields is required here.
-			structName, ok := gogen.uniqueDirectoryNames[field.Path()]
+			child, ok := goStructElements[field.YANGDetails.SchemaPath]
 			if !ok {
-				errs = append(errs, fmt.Errorf("could not resolve %s into a defined struct", field.Path()))
+				errs = append(errs, fmt.Errorf("could not resolve %s into a defined struct", field.YANGDetails.SchemaPath))
 				continue
 			}
 
 			fieldDef = &goStructField{
 				Name:            fieldName,
-				Type:            fmt.Sprintf("*%s", structName),
+				Type:            fmt.Sprintf("*%s", child.Name),
 				IsYANGContainer: true,
 			}
-		case field.IsLeaf() || field.IsLeafList():
-			// This is a leaf or leaf-list, so we map it into the Go type that corresponds to the
-			// YANG type that the leaf represents.
-			mtype, err := gogen.yangTypeToGoType(resolveTypeArgs{yangType: field.Type, contextEntry: field}, compressPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
-			if err != nil {
-				errs = append(errs, err)
+		case LeafNode, LeafListNode:
+			// This is a leaf or leaf-list, whose Go type has been determined by the
+			// IR according to the YANG type that the leaf represents.
+			mtype := field.LangType
+			if mtype == nil {
+				errs = append(errs, fmt.Errorf("no type was mapped for leaf %s", field.YANGDetails.SchemaPath))
 				continue
 			}
 
-			// Set the default type to the mapped Go type.
+			// isGenericUnion indicates that the field's type is a multi-type
+			// union that is represented by a generic union type.
+			isGenericUnion := goOpts.GenerateGenericUnions && len(mtype.UnionTypes) > 1
+			// The IR maps the default value of a union to the value of its
+			// member type, which is wrapped in the type of the union here.
 			var defaultValue *string
-			switch {
-			case field.Default != "":
-				defaultValue = &field.Default
-			case mtype.DefaultValue != nil:
-				defaultValue = mtype.DefaultValue
-			}
-			if defaultValue != nil {
-				var err error
-				if defaultValue, _, err = gogen.yangDefaultValueToGo(*defaultValue, resolveTypeArgs{yangType: field.Type, contextEntry: field}, len(mtype.UnionTypes) == 1, compressPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim); err != nil {
-					errs = append(errs, err)
+			if d := field.LangDefault; d != nil {
+				switch {
+				case isGenericUnion:
+					var err error
+					if defaultValue, err = genericUnionDefault(mtype, d.Value, d.Kind); err != nil {
+						errs = append(errs, fmt.Errorf("path %q: %v", field.YANGDetails.SchemaPath, err))
+					}
+				case len(mtype.UnionTypes) > 1:
+					defaultValue = ygot.String(d.Value)
+					if simpleName, ok := simpleUnionConversionsFromKind[d.Kind]; ok {
+						defaultValue = ygot.String(fmt.Sprintf("%s(%s)", simpleName, d.Value))
+					}
+				default:
+					defaultValue = ygot.String(d.Value)
 				}
 			}
 			// TODO(wenbli): In ygot v1, we should no longer
 			// support the wrapper union generated code, so this if
 			// block would be obsolete.
-			if !goOpts.GenerateSimpleUnions {
-				defaultValue = goLeafDefault(field, mtype)
+			if !goOpts.GenerateSimpleUnions && !goOpts.GenerateGenericUnions {
+				defaultValue = goLeafDefault(field)
 				if defaultValue != nil && len(mtype.UnionTypes) > 1 {
 					// If the default value is applied to a union type, we will generate
 					// non-compilable code when generating wrapper unions, so error out and inform
 					// the user instead of having the user find out that the code doesn't compile.
-					errs = append(errs, fmt.Errorf("path %q: default value not supported for wrapper union values, please generate using simplified union leaves", field.Path()))
+					errs = append(errs, fmt.Errorf("path %q: default value not supported for wrapper union values, please generate using simplified union leaves", field.YANGDetails.SchemaPath))
 					continue
 				}
 			}
 
 			fType := mtype.NativeType
-			schemapath := util.SchemaTreePathNoModule(field)
+			schemapath := util.SlicePathToString(append([]string{""}, field.YANGDetails.Path[2:]...))
 			if _, ok := enumTypeMap[schemapath]; ok {
-				errs = append(errs, fmt.Errorf("unexpected error: field %q has identical schemapath with another schema: %q", field.Path(), schemapath))
+				errs = append(errs, fmt.Errorf("unexpected error: field %q has identical schemapath with another schema: %q", field.YANGDetails.SchemaPath, schemapath))
 				continue
 			}
 			zeroValue := mtype.ZeroValue
@@ -1448,7 +2209,7 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 				intf := goUnionInterface{
 					Name:           mtype.NativeType,
 					Types:          map[string]string{},
-					LeafPath:       field.Path(),
+					LeafPath:       field.YANGDetails.SchemaPath,
 					ParentReceiver: targetStruct.Name,
 				}
 
@@ -1495,20 +2256,44 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 				}
 				// Create the subtype documentation string.
 				intf.SubtypeDocumentation = strings.Join(genTypes, ", ")
+				if goOpts.GenerateGenericUnions {
+					// The type parameters of a generic union are the Go types of
+					// its members, in schema order.
+					members := genericUnionMemberTypes(mtype)
+					if len(members) > ygot.MaxGenericUnionTypes {
+						errs = append(errs, fmt.Errorf("path %q: union has %d member types, generic unions support at most %d", field.YANGDetails.SchemaPath, len(members), ygot.MaxGenericUnionTypes))
+						continue
+					}
+					// A member of an unsupported type is mapped to interface{},
+					// which would match any value stored in the union, such that
+					// its typed accessors could not determine the member that is set.
+					if _, ok := mtype.UnionTypes["interface{}"]; ok {
+						errs = append(errs, fmt.Errorf("path %q: union has a member of an unsupported type, which cannot be represented by a generic union", field.YANGDetails.SchemaPath))
+						continue
+					}
+					intf.GenericType = fmt.Sprintf("ygot.Union%d[%s]", len(members), strings.Join(members, ", "))
+					intf.SubtypeDocumentation = strings.Join(members, ", ")
+				}
 				genUnions = append(genUnions, intf)
 			}
+			if isGenericUnion {
+				// The zero value of a generic union is its unset value.
+				zeroValue = fmt.Sprintf("%s{}", mtype.NativeType)
+			}
 
-			if field.ListAttr != nil {
-				// If the field's ListAttr is set, then this indicates that this
-				// element is a leaf-list. We represent a leaf-list in the output
-				// code using a slice of the type that the element was mapped to.
+			isLeafList := field.Type == LeafListNode
+			if isLeafList {
+				// We represent a leaf-list in the output code using a slice of
+				// the type that the element was mapped to.
 				fType = fmt.Sprintf("[]%s", fType)
 				// Slices have a nil zero value rather than the value of their
 				// underlying type.
 				zeroValue = "nil"
 			}
 
-			scalarField := IsScalarField(field, mtype)
+			// A generic union leaf is stored as a pointer, such that it is nil
+			// when unset.
+			scalarField := (field.Type == LeafNode && isScalarType(mtype)) || (isGenericUnion && !isLeafList)
 
 			definedNameMap[fName].IsPtr = scalarField
 			if mtype.IsEnumeratedValue {
@@ -1517,27 +2302,36 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 				enumTypeMap[schemapath] = append(enumTypeMap[schemapath], mtype.NativeType)
 			}
 
+			leaf := &generatedLeafGetter{
+				Name:     fieldName,
+				Type:     fType,
+				Zero:     zeroValue,
+				IsPtr:    scalarField,
+				Receiver: targetStruct.Name,
+				Default:  defaultValue,
+			}
 			if goOpts.GenerateLeafGetters {
 				// If we are generating leaf getters, then append the relevant information
 				// to the associatedLeafGetters slice to be generated along with other
 				// associated methods.
-				associatedLeafGetters = append(associatedLeafGetters, &generatedLeafGetter{
-					Name:     fieldName,
-					Type:     fType,
-					Zero:     zeroValue,
-					IsPtr:    scalarField,
-					Receiver: targetStruct.Name,
-					Default:  defaultValue,
-				})
+				associatedLeafGetters = append(associatedLeafGetters, leaf)
+			}
+			// The key fields of a list do not have setters, since they must
+			// remain consistent with the key of the map that the list entry
+			// is stored in.
+			if goOpts.GenerateLeafSetters && !(targetStruct.ListAttr != nil && targetStruct.ListAttr.Keys[fName] != nil) {
+				associatedLeafSetters = append(associatedLeafSetters, leaf)
 			}
 
 			fieldDef = &goStructField{
-				Name:          fieldName,
-				Type:          fType,
-				IsScalarField: scalarField,
+				Name:              fieldName,
+				Type:              fType,
+				IsScalarField:     scalarField,
+				IsEnumeratedValue: mtype.IsEnumeratedValue && len(mtype.UnionTypes) <= 1 && !isLeafList,
+				IsGenericUnion:    isGenericUnion && !isLeafList,
 			}
 		default:
-			errs = append(errs, fmt.Errorf("unknown entity type for mapping to Go: %s, Kind: %v", field.Path(), field.Kind))
+			errs = append(errs, fmt.Errorf("unknown entity type for mapping to Go: %s, Type: %v", field.YANGDetails.SchemaPath, field.Type))
 			continue
 		}
 
@@ -1545,10 +2339,12 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 		// addSchemaPathsToBuffers adds the slice of paths to the tag
 		// and metadata tag buffers.
 		addSchemaPathsToBuffers := func(schemaPaths [][]string, addToMetadata bool) {
-			for i, p := range schemaPaths {
-				tagBuf.WriteString(util.SlicePathToString(p))
+			for i, sp := range schemaPaths {
+				tagBuf.WriteString(util.SlicePathToString(sp))
 
-				// Prepend "@" to the last element in the schema path.
+				// Prepend "@" to the last element in a copy of the schema path,
+				// such that the IR is not modified.
+				p := append([]string{}, sp...)
 				p[len(p)-1] = fmt.Sprintf("@%s", p[len(p)-1])
 				if addToMetadata {
 					metadataTagBuf.WriteString(util.SlicePathToString(p))
@@ -1569,34 +2365,23 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 
 		tagBuf.WriteString(`path:"`)
 		metadataTagBuf.WriteString(`path:"`)
-		// Find the schema paths that the field corresponds to, such that these can
-		// be used as annotations (tags) within the generated struct. Go paths are
-		// always relative.
-		schemaMapPaths, schemaModulePaths, err := findMapPaths(targetStruct, fName, compressPaths, false, false)
-		if err != nil {
-			errs = append(errs, err)
-			continue
-		}
-		addSchemaPathsToBuffers(schemaMapPaths, true)
+		// The schema paths that the field corresponds to are used as annotations
+		// (tags) within the generated struct. Go paths are always relative.
+		addSchemaPathsToBuffers(field.MapPaths, true)
 
 		// Append a tag indicating the module that instantiates this field.
 		tagBuf.WriteString(` module:"`)
-		addSchemaPathsToBuffers(schemaModulePaths, false)
+		addSchemaPathsToBuffers(field.MapPathModules, false)
 
 		if ignoreShadowSchemaPaths {
-			shadowSchemaMapPaths, shadowSchemaModulePaths, err := findMapPaths(targetStruct, fName, compressPaths, true, false)
-			if err != nil {
-				errs = append(errs, err)
-				continue
-			}
-			if len(shadowSchemaMapPaths) > 0 {
+			if len(field.ShadowMapPaths) > 0 {
 				tagBuf.WriteString(` shadow-path:"`)
-				addSchemaPathsToBuffers(shadowSchemaMapPaths, false)
+				addSchemaPathsToBuffers(field.ShadowMapPaths, false)
 			}
-			if len(shadowSchemaModulePaths) > 0 {
+			if len(field.ShadowMapPathModules) > 0 {
 				// Append a tag indicating the module that instantiates this field.
 				tagBuf.WriteString(` shadow-module:"`)
-				addSchemaPathsToBuffers(shadowSchemaModulePaths, false)
+				addSchemaPathsToBuffers(field.ShadowMapPathModules, false)
 			}
 		}
 
@@ -1633,16 +2418,34 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 			errs = append(errs, err)
 		}
 	}
+	// Ordered map types that represent "ordered-by user" lists are written
+	// alongside the list key structs. Since a list may be referenced by more
+	// than one struct, each ordered map type is written only once.
+	for _, method := range associatedListMethods {
+		if method.OrderedMap == "" || gogen.generatedOrderedMaps[method.OrderedMap] {
+			continue
+		}
+		if err := goOrderedMapTemplate.Execute(&listkeyBuf, method); err != nil {
+			errs = append(errs, err)
+		}
+		gogen.generatedOrderedMaps[method.OrderedMap] = true
+	}
 
 	// methodBuf is used to store the code generated for methods that have the
 	// target entity's generated struct as a receiver.
 	var methodBuf bytes.Buffer
 	for _, method := range associatedListMethods {
-		if err := goNewListMemberTemplate.Execute(&methodBuf, method); err != nil {
+		newTmpl := goNewListMemberTemplate
+		if method.OrderedMap != "" {
+			newTmpl = goNewOrderedListMemberTemplate
+		}
+		if err := newTmpl.Execute(&methodBuf, method); err != nil {
 			errs = append(errs, err)
 		}
 
-		if goOpts.GenerateRenameMethod {
+		// Renaming an entry of an ordered list is not supported, since it
+		// would change the key that is used to determine its position.
+		if goOpts.GenerateRenameMethod && method.OrderedMap == "" {
 			if err := goListMemberRenameTemplate.Execute(&methodBuf, method); err != nil {
 				errs = append(errs, err)
 			}
@@ -1685,14 +2488,41 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 		}
 	}
 
+	if goOpts.GenerateLeafSetters {
+		if err := generateLeafSetters(&methodBuf, associatedLeafSetters); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if goOpts.GenerateListEntryConstructors {
+		if err := generateListEntryConstructor(&methodBuf, targetStruct, definedNameMap, goOpts.GenerateGenericUnions); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	if err := generateGetListKey(&methodBuf, targetStruct, definedNameMap); err != nil {
 		errs = append(errs, err)
 	}
 
+	if goOpts.GenerateDeepCopyMethod || goOpts.GenerateEqualMethod {
+		if err := generateHelperMethods(&methodBuf, structDef, goOpts.GenerateDeepCopyMethod, goOpts.GenerateEqualMethod); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	// interfaceBuf is used to store the code generated for interfaces that
 	// are used for multi-type unions within the struct.
 	var interfaceBuf bytes.Buffer
 	for _, intf := range genUnions {
+		if goOpts.GenerateGenericUnions {
+			if _, ok := gogen.generatedUnions[intf.Name]; !ok {
+				if err := unionTypeGenericTemplate.Execute(&interfaceBuf, intf); err != nil {
+					errs = append(errs, err)
+				}
+				gogen.generatedUnions[intf.Name] = true
+			}
+			continue
+		}
 		if goOpts.GenerateSimpleUnions {
 			if _, ok := gogen.generatedUnions[intf.Name]; !ok {
 				if err := unionTypeSimpleTemplate.Execute(&interfaceBuf, intf); err != nil {
@@ -1831,6 +2661,48 @@ func generateLeafGetters(buf *bytes.Buffer, leaves []*generatedLeafGetter) error
 	return errs.Err()
 }
 
+// generateLeafSetters generates SetXXX methods for the leaf fields described by
+// the supplied slice of generatedLeafGetter structs.
+func generateLeafSetters(buf *bytes.Buffer, leaves []*generatedLeafGetter) error {
+	var errs errlist.List
+	for _, l := range leaves {
+		if err := goLeafSetterTemplate.Execute(buf, l); err != nil {
+			errs.Add(err)
+		}
+	}
+	return errs.Err()
+}
+
+// generateListEntryConstructor generates a NewXXX function for the struct s if
+// it represents a keyed list, which takes the values of the list's keys as
+// arguments in the order in which they are specified in the YANG schema. The
+// names of the key fields of the struct are taken from nameMap. If
+// genericUnions is true, keys whose type is a multi-type union are stored as
+// pointers.
+func generateListEntryConstructor(buf *bytes.Buffer, s *ParsedDirectory, nameMap map[string]*yangFieldMap, genericUnions bool) error {
+	if s.ListAttr == nil || len(s.ListAttr.Keys) == 0 {
+		return nil
+	}
+
+	m := &generatedGoListMethod{
+		ListType: s.Name,
+		Receiver: s.Name,
+	}
+	for _, k := range s.ListKeyYANGNames {
+		f, ok := nameMap[k]
+		if !ok {
+			return fmt.Errorf("key %s of list %s is not a field of struct %s", k, util.SlicePathToString(s.Path), s.Name)
+		}
+		mt := s.ListAttr.Keys[k]
+		m.Keys = append(m.Keys, goStructField{
+			Name:          f.GoName,
+			Type:          mt.NativeType,
+			IsScalarField: isScalarType(mt) || (genericUnions && len(mt.UnionTypes) > 1),
+		})
+	}
+	return goListEntryConstructorTemplate.Execute(buf, m)
+}
+
 // generateGetOrCreateList generates a getter function similar to that created
 // by the generateGetOrCreateStruct function for maps within the generated Go
 // code (which represent YANG lists). It handles both simple and composite key
@@ -1845,6 +2717,9 @@ func generateLeafGetters(buf *bytes.Buffer, leaves []*generatedLeafGetter) error
 // The generated function is written to the supplied buffer, using the method
 // argument to determine the list's characteristics in the template.
 func generateGetOrCreateList(buf *bytes.Buffer, method *generatedGoListMethod) error {
+	if method.OrderedMap != "" {
+		return goGetOrCreateOrderedListTemplate.Execute(buf, method)
+	}
 	return goGetOrCreateListTemplate.Execute(buf, method)
 }
 
@@ -1853,6 +2728,9 @@ func generateGetOrCreateList(buf *bytes.Buffer, method *generatedGoListMethod) e
 // of the same form as those that are given to the GetOrCreate method generated
 // by generateGetOrCreateList.
 func generateListGetter(buf *bytes.Buffer, method *generatedGoListMethod) error {
+	if method.OrderedMap != "" {
+		return goOrderedListGetterTemplate.Execute(buf, method)
+	}
 	return goListGetterTemplate.Execute(buf, method)
 }
 
@@ -1861,6 +2739,9 @@ func generateListGetter(buf *bytes.Buffer, method *generatedGoListMethod) error
 // of the same form as those that are given to the GetOrCreate method generated
 // by generateGetOrCreateList.
 func generateListDelete(buf *bytes.Buffer, method *generatedGoListMethod) error {
+	if method.OrderedMap != "" {
+		return goDeleteOrderedListTemplate.Execute(buf, method)
+	}
 	return goDeleteListTemplate.Execute(buf, method)
 }
 
@@ -1872,15 +2753,114 @@ func generateListDelete(buf *bytes.Buffer, method *generatedGoListMethod) error
 // The generated function is written to the supplied buffer - using the supplied
 // method argument to determine the list's characteristics in the template.
 func generateListAppend(buf *bytes.Buffer, method *generatedGoListMethod) error {
+	if method.OrderedMap != "" {
+		return goOrderedListAppendTemplate.Execute(buf, method)
+	}
 	return goListAppendTemplate.Execute(buf, method)
 }
 
+// generatedHelperField describes a field of a generated struct for the
+// templates that generate reflection-free helper methods.
+type generatedHelperField struct {
+	// Name is the name of the field.
+	Name string
+	// Type is the Go type of the field.
+	Type string
+	// ElemType is the name of the struct type that is referenced by a
+	// container field, or is the value of a map or ordered map field.
+	ElemType string
+	// MapType is the name of the ordered map type of an ordered map field.
+	MapType string
+	// Kind specifies how the field is handled by the generated methods, it
+	// is one of "scalar" (pointer leaves), "enum" (enumerated values),
+	// "value" (other non-pointer values), "container", "map" (keyed lists),
+	// "orderedmap" (keyed lists that are ordered-by user), "genericunion"
+	// (pointers to generic unions), "union" (union interfaces and
+	// unsupported types), "slice" (leaf-lists, binary leaves and keyless
+	// lists, whose ElemType is set), or "annotation" (annotation fields).
+	Kind string
+}
+
+// generatedHelperStruct describes a generated struct for the templates
+// that generate reflection-free helper methods.
+type generatedHelperStruct struct {
+	// StructName is the name of the struct.
+	StructName string
+	// Fields is the set of fields of the struct.
+	Fields []*generatedHelperField
+	// CheckOverwrite specifies whether the struct has fields for which the
+	// generated ΛMerge method checks whether existing values may be
+	// overwritten.
+	CheckOverwrite bool
+}
+
+// generateHelperMethods generates the ΛDeepCopy and ΛMerge methods (if
+// deepCopy is true), and the ΛEqual method (if equal is true) for the struct
+// described by structDef, and appends them to the supplied buffer. These
+// methods allow the ygot library to copy, merge and compare the generated
+// structs without the use of reflection.
+func generateHelperMethods(buf *bytes.Buffer, structDef generatedGoStruct, deepCopy, equal bool) error {
+	h := generatedHelperStruct{StructName: structDef.StructName}
+	for _, f := range structDef.Fields {
+		hf := &generatedHelperField{Name: f.Name, Type: f.Type, Kind: "union"}
+		switch {
+		case f.Type == annotationFieldType:
+			hf.Kind = "annotation"
+		case f.IsYANGContainer:
+			hf.Kind = "container"
+			hf.ElemType = strings.TrimPrefix(f.Type, "*")
+		case f.IsYANGList && strings.HasPrefix(f.Type, "map["):
+			hf.Kind = "map"
+			hf.ElemType = strings.TrimPrefix(f.Type[strings.Index(f.Type, "]")+1:], "*")
+		case f.IsYANGList && f.OrderedListType != "":
+			hf.Kind = "orderedmap"
+			hf.ElemType = f.OrderedListType
+			hf.MapType = strings.TrimPrefix(f.Type, "*")
+		case f.IsYANGList:
+			// Keyless lists are merged and compared using the
+			// generated methods of their entries.
+			hf.Kind = "slice"
+			hf.ElemType = strings.TrimPrefix(strings.TrimPrefix(f.Type, "[]"), "*")
+		case f.IsGenericUnion:
+			// Generic unions may hold values that are not comparable
+			// (e.g., binary), and hence are compared using the
+			// reflect package.
+			hf.Kind = "genericunion"
+		case f.IsScalarField:
+			hf.Kind = "scalar"
+		case f.IsEnumeratedValue:
+			hf.Kind = "enum"
+		case f.Type == ygot.EmptyTypeName:
+			hf.Kind = "value"
+		case strings.HasPrefix(f.Type, "[]") || f.Type == ygot.BinaryTypeName:
+			hf.Kind = "slice"
+		}
+		switch hf.Kind {
+		case "scalar", "enum", "genericunion", "union":
+			h.CheckOverwrite = true
+		}
+		h.Fields = append(h.Fields, hf)
+	}
+
+	if deepCopy {
+		if err := goDeepCopyTemplate.Execute(buf, h); err != nil {
+			return err
+		}
+	}
+	if equal {
+		if err := goEqualTemplate.Execute(buf, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // generateGetListKey generates a function extracting the keys from a list
-// defined in the Directory s, and appends it to the supplier buffer. The
+// defined in the IR directory s, and appends it to the supplier buffer. The
 // nameMap stores maps between the key YANG field identifiers and their Go
 // identifiers.
 //
-// If the input Directory is the following list entry:
+// If the input directory is the following list entry:
 //
 //  list foo {
 //    key "bar baz";
@@ -1919,8 +2899,8 @@ func generateListAppend(buf *bytes.Buffer, method *generatedGoListMethod) error
 //	  "baz": *t.Baz,
 //	}
 //  }
-func generateGetListKey(buf *bytes.Buffer, s *Directory, nameMap map[string]*yangFieldMap) error {
-	if !s.isList() {
+func generateGetListKey(buf *bytes.Buffer, s *ParsedDirectory, nameMap map[string]*yangFieldMap) error {
+	if s.ListAttr == nil {
 		return nil
 	}
 
@@ -1941,7 +2921,7 @@ func generateGetListKey(buf *bytes.Buffer, s *Directory, nameMap map[string]*yan
 	return goKeyMapTemplate.Execute(buf, h)
 }
 
-// yangListFieldToGoType takes a yang.Entry (listField) and returns a string corresponding to the Go
+// yangListFieldToGoType takes an IR node (listField) and returns a string corresponding to the Go
 // type that should be used to represent it within its parent struct (the parent argument). A map, keyed
 // by schema path, of the other code entities that have been extracted within the context that the
 // listField is being generated are provided to the function as input, such that the struct representing
@@ -1954,25 +2934,28 @@ func generateGetListKey(buf *bytes.Buffer, s *Directory, nameMap map[string]*yan
 //	- If the list has multiple keys, a new struct is defined which represents the set of
 //	  leaves that make up the key. The type of the list is then a map, keyed by the new struct
 //	  type.
+//	- If orderedMaps is true and the keyed list is "ordered-by user", a pointer to a new
+//	  ordered map type, which retains the order of the list's entries, is returned. The
+//	  returned list method specification names the ordered map type.
+// If genericUnions is true, keys whose type is a multi-type union are represented by a
+// generic union, which is stored as a pointer within the list's struct.
 // In the case that the list has multiple keys, the type generated as the key of the list is returned.
 // If errors are encountered during the type generation for the list, the error is returned.
-func yangListFieldToGoType(listField *yang.Entry, listFieldName string, parent *Directory, goStructElements map[string]*Directory, gogen *goGenState) (string, *generatedGoMultiKeyListStruct, *generatedGoListMethod, error) {
+func yangListFieldToGoType(listField *NodeDetails, listFieldName string, parent *ParsedDirectory, goStructElements map[string]*ParsedDirectory, gogen *goGenState, orderedMaps, genericUnions bool) (string, *generatedGoMultiKeyListStruct, *generatedGoListMethod, error) {
 	// The list itself, since it is a container, has a struct associated with it. Retrieve
-	// this from the set of Directory structs for which code (a Go struct) will be
+	// this from the set of directories for which code (a Go struct) will be
 	//  generated such that additional details can be used in the code generation.
-	listElem, ok := goStructElements[listField.Path()]
+	listElem, ok := goStructElements[listField.YANGDetails.SchemaPath]
 	if !ok {
-		return "", nil, nil, fmt.Errorf("struct for %s did not exist", listField.Path())
+		return "", nil, nil, fmt.Errorf("struct for %s did not exist", listField.YANGDetails.SchemaPath)
 	}
 
-	// Find the name of the struct that refers to the list provided as input. The YANGCodeGenerator
-	// instance stores this information. It is populated by structName which always runs prior to
-	// this function being called (as all mappable entities, which includes lists, have been found.
-	// Thus, in the case that this struct does not have a known name, then code cannot be generated
-	// for it, and hence we skip the element.
-	listName, ok := gogen.uniqueDirectoryNames[listField.Path()]
-	if !ok {
-		return "", nil, nil, fmt.Errorf("list element %s did not have a resolved name", listField.Path())
+	// The name of the struct that refers to the list provided as input is the
+	// name of its directory within the IR. In the case that the directory does
+	// not have a name, then code cannot be generated for it.
+	listName := listElem.Name
+	if listName == "" {
+		return "", nil, nil, fmt.Errorf("list element %s did not have a resolved name", listField.YANGDetails.SchemaPath)
 	}
 
 	if listElem.ListAttr == nil || len(listElem.ListAttr.Keys) == 0 {
@@ -1981,23 +2964,25 @@ func yangListFieldToGoType(listField *yang.Entry, listFieldName string, parent *
 		return fmt.Sprintf("[]*%s", listName), nil, nil, nil
 	}
 
-	var listType string
+	var keyType string
 	var multiListKey *generatedGoMultiKeyListStruct
 	var listKeys []goStructField
 	var listKeyStructName string
 
 	// Key name elements are ordered per Section 7.8.2 of RFC6020. Rely on this
 	// fact for determisitic ordering in output code and rendering.
-	keyElemNames := strings.Fields(listField.Key)
-
 	usedKeyElemNames := make(map[string]bool)
-	for _, keName := range keyElemNames {
+	for _, keName := range listElem.ListKeyYANGNames {
+		kf := listElem.fieldByYANGName(keName)
+		if kf == nil {
+			return "", nil, nil, fmt.Errorf("key %s of list %s is not a field of struct %s", keName, listField.YANGDetails.SchemaPath, listName)
+		}
 		keyField := goStructField{
-			Name: genutil.MakeNameUnique(genutil.EntryCamelCaseName(listField.Dir[keName]), usedKeyElemNames),
+			Name: genutil.MakeNameUnique(kf.Name, usedKeyElemNames),
 			Type: listElem.ListAttr.Keys[keName].NativeType,
 			Tags: fmt.Sprintf(`path:"%s"`, keName),
 		}
-		keyField.IsScalarField = IsScalarField(listField.Dir[keName], listElem.ListAttr.Keys[keName])
+		keyField.IsScalarField = isScalarType(listElem.ListAttr.Keys[keName]) || (genericUnions && len(listElem.ListAttr.Keys[keName].UnionTypes) > 1)
 		listKeys = append(listKeys, keyField)
 	}
 
@@ -2007,7 +2992,7 @@ func yangListFieldToGoType(listField *yang.Entry, listFieldName string, parent *
 		// a simple Go type as the key. Note that a leaf-list can never be
 		// a key, so we do not need to handle the case whereby we would have to
 		// have a slice which keys the list.
-		listType = fmt.Sprintf("map[%s]*%s", listKeys[0].Type, listName)
+		keyType = listKeys[0].Type
 	default:
 		// This is a list with multiple keys, so we need to generate a new structure
 		// that represents the list key itself - this struct is described in a
@@ -2017,7 +3002,7 @@ func yangListFieldToGoType(listField *yang.Entry, listFieldName string, parent *
 		if gogen.definedGlobals[listKeyStructName] {
 			listKeyStructName = fmt.Sprintf("%s_%s_YANGListKey", parent.Name, listFieldName)
 			if gogen.definedGlobals[listKeyStructName] {
-				return "", nil, nil, fmt.Errorf("unexpected generated list key name conflict for %s", listField.Path())
+				return "", nil, nil, fmt.Errorf("unexpected generated list key name conflict for %s", listField.YANGDetails.SchemaPath)
 			}
 			gogen.definedGlobals[listKeyStructName] = true
 		}
@@ -2027,7 +3012,7 @@ func yangListFieldToGoType(listField *yang.Entry, listFieldName string, parent *
 			ListName:      listFieldName,
 			Keys:          listKeys,
 		}
-		listType = fmt.Sprintf("map[%s]*%s", listKeyStructName, listName)
+		keyType = listKeyStructName
 	}
 
 	// Generate the specification for the methods that should be generated for this
@@ -2038,18 +3023,35 @@ func yangListFieldToGoType(listField *yang.Entry, listFieldName string, parent *
 		KeyStruct: listKeyStructName,
 		Keys:      listKeys,
 		Receiver:  parent.Name,
+		KeyType:   keyType,
+	}
+
+	if orderedMaps && listField.YANGDetails.OrderedByUser {
+		orderedMapName, ok := gogen.orderedMapNames[listName]
+		if !ok {
+			orderedMapName = fmt.Sprintf("%s_OrderedMap", listName)
+			if gogen.definedGlobals[orderedMapName] {
+				orderedMapName = fmt.Sprintf("%s_YANGOrderedMap", listName)
+				if gogen.definedGlobals[orderedMapName] {
+					return "", nil, nil, fmt.Errorf("unexpected generated ordered map name conflict for %s", listField.YANGDetails.SchemaPath)
+				}
+			}
+			gogen.definedGlobals[orderedMapName] = true
+			gogen.orderedMapNames[listName] = orderedMapName
+		}
+		listMethodSpec.OrderedMap = orderedMapName
+		return fmt.Sprintf("*%s", orderedMapName), multiListKey, listMethodSpec, nil
 	}
 
-	return listType, multiListKey, listMethodSpec, nil
+	return fmt.Sprintf("map[%s]*%s", keyType, listName), multiListKey, listMethodSpec, nil
 }
 
-// writeGoEnum takes an input yangEnum, and generates the code corresponding
-// to it. If the enum that is input has multiple enumerated types within it
-// (i.e., is a union) then the relevant enumerated type is output for each
-// included entity. If errors are encountered whilst mapping the enumeration to
-// code, they are returned. The enumDefinition template is used to convert a
-// constructed generatedGoEnumeration struct to code within the function.
-func writeGoEnum(inputEnum *yangEnum) (goEnumCodeSnippet, error) {
+// writeGoEnum takes an input EnumeratedYANGType from the IR, and generates
+// the code corresponding to it. If errors are encountered whilst mapping the
+// enumeration to code, they are returned. The enumDefinition template is used
+// to convert a constructed generatedGoEnumeration struct to code within the
+// function.
+func writeGoEnum(inputEnum *EnumeratedYANGType) (goEnumCodeSnippet, error) {
 	// initialised to be UNSET, such that it is possible to determine that the enumerated value
 	// was not modified.
 	values := map[int64]string{
@@ -2064,40 +3066,16 @@ func writeGoEnum(inputEnum *yangEnum) (goEnumCodeSnippet, error) {
 	// module within which the identity was defined.
 	origValues := map[int64]ygot.EnumDefinition{}
 
-	switch {
-	case inputEnum.entry.Type.IdentityBase != nil:
-		// The inputEnum corresponds to an identityref - hence the values are defined
-		// based on the values that the identity has. Since there is no explicit ordering
-		// in an identity, then we go through and put the values in alphabetical order in
-		// order to avoid reordering during code generation of the same entity.
-		valNames := []string{}
-		valLookup := map[string]*yang.Identity{}
-		for _, v := range inputEnum.entry.Type.IdentityBase.Values {
-			valNames = append(valNames, v.Name)
-			valLookup[v.Name] = v
-		}
-		sort.Strings(valNames)
-
-		for i, v := range valNames {
-			values[int64(i)+1] = safeGoEnumeratedValueName(v)
-			origValues[int64(i)+1] = ygot.EnumDefinition{
-				Name:           v,
-				DefiningModule: genutil.ParentModuleName(valLookup[v]),
-			}
-		}
-	default:
-		// The remaining enumerated types are all represented as an Enum type within the
-		// Goyang entry construct. The values are accessed in a map keyed by an int64
-		// and with a value of the name of the enumerated value - retrieved via ValueMap().
-		for i, value := range inputEnum.entry.Type.Enum.ValueMap() {
-			values[i+1] = safeGoEnumeratedValueName(value)
-			origValues[i+1] = ygot.EnumDefinition{Name: value}
-		}
+	for i, v := range inputEnum.ValToCodeName {
+		values[i] = v
+	}
+	for i, d := range inputEnum.ValToYANGDetails {
+		origValues[i] = *d
 	}
 
 	// Initialise the input to the template, and generate the output.
 	templateInput := generatedGoEnumeration{
-		EnumerationPrefix: inputEnum.name,
+		EnumerationPrefix: inputEnum.Name,
 		Values:            values,
 	}
 
@@ -2106,7 +3084,7 @@ func writeGoEnum(inputEnum *yangEnum) (goEnumCodeSnippet, error) {
 	return goEnumCodeSnippet{
 		constDef:    buf.String(),
 		valToString: origValues,
-		name:        inputEnum.name,
+		name:        inputEnum.Name,
 	}, err
 }
 
@@ -2139,6 +3117,119 @@ func generateEnumTypeMap(enumTypeMap map[string][]string) (string, error) {
 	return buf.String(), nil
 }
 
+// rpcTypeNames stores the names of the generated structs that represent the
+// input and output of a YANG rpc or action.
+type rpcTypeNames struct {
+	// Input is the name of the struct representing the input of the
+	// operation, or the empty string if the operation has no input.
+	Input string
+	// Output is the name of the struct representing the output of the
+	// operation, or the empty string if the operation has no output.
+	Output string
+}
+
+// generateRPCTypeMap outputs a map using the rpcTypeMap template. It takes the
+// set of directories that are being output, and finds those that represent the
+// input or output of a YANG rpc or action. If no such directories exist, the
+// empty string is returned.
+func generateRPCTypeMap(directories map[string]*ParsedDirectory) (string, []error) {
+	rpcTypes := map[string]*rpcTypeNames{}
+	var errs []error
+	for _, dir := range directories {
+		if dir.Operation != OperationInput && dir.Operation != OperationOutput {
+			continue
+		}
+		// The rpc or action is the parent of its input and output.
+		name, err := rpcQualifiedName(dir.Path[1 : len(dir.Path)-1])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if rpcTypes[name] == nil {
+			rpcTypes[name] = &rpcTypeNames{}
+		}
+		switch dir.Operation {
+		case OperationInput:
+			rpcTypes[name].Input = dir.Name
+		case OperationOutput:
+			rpcTypes[name].Output = dir.Name
+		}
+	}
+	if errs != nil {
+		return "", errs
+	}
+	if len(rpcTypes) == 0 {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := goRPCTypeMapTemplate.Execute(&buf, rpcTypes); err != nil {
+		return "", []error{err}
+	}
+	return buf.String(), nil
+}
+
+// rpcQualifiedName returns the name by which the YANG rpc or action whose
+// schema path is p, which includes the module name as its first element, is
+// identified. RPCs, which are defined at the root of a module, are named
+// "module:rpc-name", whereas actions are named by their schema path with the
+// first element prefixed by the module name, e.g., "/module:a/b/action-name".
+func rpcQualifiedName(p []string) (string, error) {
+	switch {
+	case len(p) < 2:
+		return "", fmt.Errorf("/%s is not a valid rpc or action", strings.Join(p, "/"))
+	case len(p) == 2:
+		return fmt.Sprintf("%s:%s", p[0], p[1]), nil
+	default:
+		return qualifiedSchemaPath(p), nil
+	}
+}
+
+// qualifiedSchemaPath returns the schema path p, which includes the module
+// name as its first element, as a string of the form "/module:a/b/c".
+func qualifiedSchemaPath(p []string) string {
+	return fmt.Sprintf("/%s:%s", p[0], strings.Join(p[1:], "/"))
+}
+
+// generateNotificationTypeMap outputs a map using the notificationTypeMap
+// template. It takes the set of directories that are being output, and finds
+// those that represent YANG notifications. If generateSchema is set to true,
+// a function to unmarshal a notification using the map is also output. If no
+// notifications exist, the empty string is returned.
+func generateNotificationTypeMap(directories map[string]*ParsedDirectory, generateSchema bool) (string, []error) {
+	notificationTypes := map[string]string{}
+	var errs []error
+	for _, dir := range directories {
+		if dir.Operation != OperationNotification {
+			continue
+		}
+		p := dir.Path[1:]
+		if len(p) < 2 {
+			errs = append(errs, fmt.Errorf("%s is not a valid notification", util.SlicePathToString(dir.Path)))
+			continue
+		}
+		notificationTypes[qualifiedSchemaPath(p)] = dir.Name
+	}
+	if errs != nil {
+		return "", errs
+	}
+	if len(notificationTypes) == 0 {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := goNotificationTypeMapTemplate.Execute(&buf, struct {
+		Types          map[string]string
+		GenerateSchema bool
+	}{
+		Types:          notificationTypes,
+		GenerateSchema: generateSchema,
+	}); err != nil {
+		return "", []error{err}
+	}
+	return buf.String(), nil
+}
+
 // generateEnumTypeMapAccessor generates a function which returns the defined
 // enumTypeMap for a struct.
 func generateEnumTypeMapAccessor(b *bytes.Buffer, s generatedGoStruct) error {
@@ -2175,15 +3266,16 @@ func writeGoSchema(js []byte, schemaVarName string) (string, error) {
 	return buf.String(), nil
 }
 
-// goLeafDefault returns the default value of the leaf e if specified. If it
+// goLeafDefault returns the default value of the leaf n if specified. If it
 // is unspecified, the value specified by the type is returned if it is not nil,
 // otherwise nil is returned to indicate no default was specified.
-func goLeafDefault(e *yang.Entry, t *MappedType) *string {
-	if e.Default != "" {
+func goLeafDefault(n *NodeDetails) *string {
+	t := n.LangType
+	if d := n.YANGDetails.Default; d != "" {
 		if t.IsEnumeratedValue {
-			return enumDefaultValue(t.NativeType, e.Default, goEnumPrefix)
+			return enumDefaultValue(t.NativeType, d, goEnumPrefix)
 		}
-		return quoteDefault(&e.Default, t.NativeType)
+		return quoteDefault(&d, t.NativeType)
 	}
 
 	if t.DefaultValue != nil {
@@ -2196,6 +3288,53 @@ func goLeafDefault(e *yang.Entry, t *MappedType) *string {
 	return nil
 }
 
+// genericUnionMemberTypes returns the Go types of the members of the union
+// represented by the mapped type t, in the order in which they are defined in
+// the YANG schema.
+func genericUnionMemberTypes(t *MappedType) []string {
+	members := make([]string, len(t.UnionTypes))
+	for name, i := range t.UnionTypes {
+		members[i] = name
+	}
+	return members
+}
+
+// genericUnionDefault returns the Go snippet for the default value of a leaf
+// whose type is the multi-type union described by t, which is represented by
+// a generic union. The snippet and kind are the default value converted to
+// its member type, and the YANG kind of the member, as returned by
+// yangDefaultValueToGo.
+func genericUnionDefault(t *MappedType, snippet string, kind yang.TypeKind) (*string, error) {
+	members := genericUnionMemberTypes(t)
+	index := -1
+	switch kind {
+	case yang.Yenum, yang.Yidentityref:
+		// The snippet is the name of an enumerated value, which is prefixed
+		// by the name of its type. Where more than one enumerated type
+		// matches, the most specific is used.
+		var matched string
+		for i, m := range members {
+			prefix := strings.TrimPrefix(m, goEnumPrefix) + "_"
+			if _, builtin := validGoBuiltinTypes[m]; !builtin && strings.HasPrefix(snippet, prefix) && len(prefix) > len(matched) {
+				index, matched = i, prefix
+			}
+		}
+	default:
+		// The simple union type that the kind maps to identifies the
+		// builtin Go type of the member.
+		simpleName := simpleUnionConversionsFromKind[kind]
+		for i, m := range members {
+			if simpleName != "" && ygot.SimpleUnionBuiltinGoTypes[m] == simpleName {
+				index = i
+			}
+		}
+	}
+	if index == -1 {
+		return nil, fmt.Errorf("cannot find union member of type %v for default value %s", kind, snippet)
+	}
+	return ygot.String(fmt.Sprintf("*%s{}.Of%d(%s)", t.NativeType, index+1, snippet)), nil
+}
+
 // quoteDefault adds quotation marks to the value string if the goType specified
 // is a string, and hence requires quoting.
 func quoteDefault(value *string, goType string) *string {
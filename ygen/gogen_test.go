@@ -35,6 +35,20 @@ type wantGoStructOut struct {
 	interfaces string // interfaces contains code corresponding to interfaces associated with the mapped struct.
 }
 
+// goParsedDirectories maps the supplied target directory, and the set of
+// directories that it may reference, to their IR representation using the
+// Go LangMapper. The names of the referenced directories are taken from the
+// unique directory names stored in s.
+func goParsedDirectories(target *Directory, dirs map[string]*Directory, s *goGenState, compressPaths, skipEnumDedup bool) (*ParsedDirectory, map[string]*ParsedDirectory, []error) {
+	lm := &goLangMapper{
+		goGenState:                           s,
+		skipEnumDedup:                        skipEnumDedup,
+		shortenEnumLeafNames:                 true,
+		useDefiningModuleForTypedefEnumNames: true,
+	}
+	return parsedDirectoryFixtures(target, dirs, lm, s.schematree, s.uniqueDirectoryNames, compressPaths, false, true)
+}
+
 // TestGoCodeStructGeneration tests the code generation from a known schema generates
 // the correct structures, key types and methods for a YANG container.
 func TestGoCodeStructGeneration(t *testing.T) {
@@ -673,6 +687,12 @@ func (t *QStruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes
 						},
 					},
 				},
+				Fields: map[string]*yang.Entry{
+					"keyLeaf": {
+						Name: "keyLeaf",
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+				},
 				Path: []string{"", "root-module", "tstruct", "listWithKey"},
 			},
 		},
@@ -980,6 +1000,20 @@ func (t *Tstruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes
 						"keyLeafOne": {NativeType: "string"},
 						"keyLeafTwo": {NativeType: "int8"},
 					},
+					KeyElems: []*yang.Entry{
+						{Name: "keyLeafOne"},
+						{Name: "keyLeafTwo"},
+					},
+				},
+				Fields: map[string]*yang.Entry{
+					"keyLeafOne": {
+						Name: "keyLeafOne",
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"keyLeafTwo": {
+						Name: "keyLeafTwo",
+						Type: &yang.YangType{Kind: yang.Yint8},
+					},
 				},
 				Path: []string{"", "root-module", "tstruct", "listWithKey"},
 			},
@@ -1323,6 +1357,20 @@ func (t *Tstruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes
 						"keyLeafOne": {NativeType: "string"},
 						"keyLeafTwo": {NativeType: "int8"},
 					},
+					KeyElems: []*yang.Entry{
+						{Name: "keyLeafOne"},
+						{Name: "keyLeafTwo"},
+					},
+				},
+				Fields: map[string]*yang.Entry{
+					"keyLeafOne": {
+						Name: "keyLeafOne",
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"keyLeafTwo": {
+						Name: "keyLeafTwo",
+						Type: &yang.YangType{Kind: yang.Yint8},
+					},
 				},
 				Path: []string{"", "root-module", "tstruct", "listWithKey"},
 			},
@@ -1542,6 +1590,12 @@ func (t *Tstruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes
 						},
 					},
 				},
+				Fields: map[string]*yang.Entry{
+					"keyLeaf": {
+						Name: "keyLeaf",
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+				},
 				Path: []string{"", "root-module", "tstruct", "listWithKey"},
 			},
 		},
@@ -1974,8 +2028,12 @@ func (t *Container) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTyp
 				s := newGoGenState(nil, nil)
 				s.uniqueDirectoryNames = tt.inUniqueDirectoryNames
 
-				// Always generate the JSON schema for this test.
-				got, errs := writeGoStruct(tt.inStructToMap, tt.inMappableEntities, s, compressed, false, true, tt.inSkipEnumDedup, true, true, nil, tt.inGoOpts)
+				var got GoStructCodeSnippet
+				inStruct, inMappable, errs := goParsedDirectories(tt.inStructToMap, tt.inMappableEntities, s, compressed, tt.inSkipEnumDedup)
+				if len(errs) == 0 {
+					// Always generate the JSON schema for this test.
+					got, errs = writeGoStruct(inStruct, inMappable, s, compressed, false, true, tt.inSkipEnumDedup, true, true, nil, tt.inGoOpts)
+				}
 
 				if len(errs) != 0 && !want.wantErr {
 					t.Errorf("%s writeGoStruct(compressPaths: %v, targetStruct: %v): received unexpected errors: %v",
@@ -2221,7 +2279,13 @@ const (
 	}}
 
 	for _, tt := range tests {
-		got, err := writeGoEnum(tt.in)
+		in, err := enumeratedYANGType(tt.in, newGoLangMapper(IROptions{}))
+		if err != nil {
+			t.Errorf("%s: enumeratedYANGType(%v): got unexpected error: %v", tt.name, tt.in, err)
+			continue
+		}
+
+		got, err := writeGoEnum(in)
 		if err != nil {
 			t.Errorf("%s: writeGoEnum(%v): got unexpected error: %v",
 				tt.name, tt.in, err)
@@ -2319,36 +2383,30 @@ var ΛEnum = map[string]map[int64]ygot.EnumDefinition{
 
 func TestGoLeafDefault(t *testing.T) {
 	tests := []struct {
-		name   string
-		inLeaf *yang.Entry
-		inType *MappedType
-		want   *string
+		name          string
+		inLeafDefault string
+		inType        *MappedType
+		want          *string
 	}{{
-		name: "quoted default in leaf",
-		inLeaf: &yang.Entry{
-			Default: "a-default-value",
-		},
-		inType: &MappedType{NativeType: "string"},
-		want:   ygot.String(`"a-default-value"`),
+		name:          "quoted default in leaf",
+		inLeafDefault: "a-default-value",
+		inType:        &MappedType{NativeType: "string"},
+		want:          ygot.String(`"a-default-value"`),
 	}, {
-		name: "unquoted default in leaf",
-		inLeaf: &yang.Entry{
-			Default: "42",
-		},
-		inType: &MappedType{NativeType: "int32"},
-		want:   ygot.String("42"),
+		name:          "unquoted default in leaf",
+		inLeafDefault: "42",
+		inType:        &MappedType{NativeType: "int32"},
+		want:          ygot.String("42"),
 	}, {
 		name:   "no default",
-		inLeaf: &yang.Entry{},
 		inType: &MappedType{NativeType: "int32"},
 	}, {
 		name:   "default in type",
-		inLeaf: &yang.Entry{},
 		inType: &MappedType{NativeType: "int32", DefaultValue: ygot.String("0")},
 		want:   ygot.String("0"),
 	}, {
-		name:   "enumerated default in leaf",
-		inLeaf: &yang.Entry{Default: "FORTY_TWO"},
+		name:          "enumerated default in leaf",
+		inLeafDefault: "FORTY_TWO",
 		inType: &MappedType{
 			NativeType:        fmt.Sprintf("%sEnumType", goEnumPrefix),
 			IsEnumeratedValue: true,
@@ -2358,10 +2416,46 @@ func TestGoLeafDefault(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := goLeafDefault(tt.inLeaf, tt.inType)
+			got := goLeafDefault(&NodeDetails{
+				YANGDetails: YANGNodeDetails{Default: tt.inLeafDefault},
+				LangType:    tt.inType,
+			})
 			if diff := cmp.Diff(tt.want, got); diff != "" {
 				t.Fatalf("did not get expected default, (-want, +got):\n%s", diff)
 			}
 		})
 	}
 }
+
+func TestRPCQualifiedName(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      []string
+		want    string
+		wantErr bool
+	}{{
+		name: "rpc",
+		in:   []string{"mod", "reboot"},
+		want: "mod:reboot",
+	}, {
+		name: "action within a container",
+		in:   []string{"mod", "interfaces", "clear"},
+		want: "/mod:interfaces/clear",
+	}, {
+		name:    "module",
+		in:      []string{"mod"},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rpcQualifiedName(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rpcQualifiedName(%v): did not get expected error, got: %v, wantErr: %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("rpcQualifiedName(%v): did not get expected name, got: %s, want: %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -15,6 +15,9 @@
 package ygen
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/genutil"
 	"github.com/openconfig/ygot/ygot"
@@ -55,6 +58,19 @@ type LangMapper interface {
 	// field within a directory within the output IR.
 	LeafType(*yang.Entry, genutil.CompressBehaviour) (*MappedType, error)
 
+	// LeafDefault maps the default value of an input yang.Entry, which
+	// must represent a leaf or leaf-list whose type has been mapped to the
+	// supplied MappedType, to the value that should be used in the output
+	// IR. It returns nil if the leaf has no default value, or if default
+	// values are not used by the output language.
+	LeafDefault(*yang.Entry, *MappedType, genutil.CompressBehaviour) (*MappedDefault, error)
+
+	// PackageName maps an input yang.Entry, which must represent a
+	// directory, to the name of the package within which its definition
+	// should be output. Languages that do not output directories into
+	// separate packages return the empty string.
+	PackageName(*yang.Entry, genutil.CompressBehaviour) (string, error)
+
 	// EnumeratedValueName maps an input string representing an enumerated
 	// value to a language-safe name for the enumerated value. This function
 	// should ensure that the returned string is sanitised to ensure that
@@ -99,6 +115,29 @@ type IR struct {
 	Enums map[string]*EnumeratedYANGType
 }
 
+// OrderedDirectoryPathsByName returns the paths of the directories within
+// the IR, ordered alphabetically by the name of the directory. This allows
+// code generation to produce deterministic output. If the names of the
+// directories are not unique, an error is returned.
+func (ir *IR) OrderedDirectoryPathsByName() ([]string, error) {
+	names := make([]string, 0, len(ir.Directories))
+	pathByName := make(map[string]string, len(ir.Directories))
+	for p, d := range ir.Directories {
+		if op, ok := pathByName[d.Name]; ok {
+			return nil, fmt.Errorf("directory name conflict(s) exist: %s is used by %s and %s", d.Name, op, p)
+		}
+		names = append(names, d.Name)
+		pathByName[d.Name] = p
+	}
+	sort.Strings(names)
+
+	paths := make([]string, 0, len(names))
+	for _, n := range names {
+		paths = append(paths, pathByName[n])
+	}
+	return paths, nil
+}
+
 // ParsedDirectory describes an internal node within the generated
 // code. Such a 'directory' may represent a struct, or a message,
 // in the generated code. It represents a YANG 'container' or 'list'.
@@ -121,8 +160,82 @@ type ParsedDirectory struct {
 	// is the root entity and has been synthetically generated by
 	// ygen.
 	IsFakeRoot bool
+	// Path is the path of the directory within the YANG schema,
+	// expressed as a slice of its elements, where the first element
+	// is empty, and the second is the name of the module.
+	Path []string
+	// ListKeyYANGNames is the names of the YANG leaves that are the keys
+	// of a list, in the order in which they are specified by the list's
+	// 'key' statement. It is empty for containers and unkeyed lists.
+	ListKeyYANGNames []string
+	// PackageName is the name of the package within which the directory
+	// is output, as determined by the LangMapper.
+	PackageName string
+	// Operation indicates whether the directory represents part of a
+	// YANG rpc, action or notification.
+	Operation OperationType
+}
+
+// OrderedFieldNames returns the names of the fields of the directory in
+// alphabetical order.
+func (d *ParsedDirectory) OrderedFieldNames() []string {
+	names := make([]string, 0, len(d.Fields))
+	for n := range d.Fields {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// yangOrderedFields returns the fields of the directory in alphabetical
+// order of their YANG names, such that the code generated for them is in
+// the same order as the YANG schema's node identifiers.
+func (d *ParsedDirectory) yangOrderedFields() []*NodeDetails {
+	fields := make([]*NodeDetails, 0, len(d.Fields))
+	for _, f := range d.Fields {
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].YANGDetails.Name < fields[j].YANGDetails.Name
+	})
+	return fields
 }
 
+// fieldByYANGName returns the field of the directory whose YANG name is
+// the supplied name, or nil if no such field exists.
+func (d *ParsedDirectory) fieldByYANGName(name string) *NodeDetails {
+	for _, f := range d.Fields {
+		if f.YANGDetails.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// isChildOfModule determines whether the directory represents a container
+// or list member that is the direct child of a module entry.
+func (d *ParsedDirectory) isChildOfModule() bool {
+	// The path is of the form []string{"", <module>, <element>} for
+	// top-level entities within a module.
+	return d.IsFakeRoot || len(d.Path) == 3
+}
+
+// OperationType describes the part of a YANG rpc, action or notification
+// that a directory within the IR represents.
+type OperationType int64
+
+const (
+	// NotOperation indicates that the directory is not part of a YANG
+	// rpc, action or notification.
+	NotOperation OperationType = iota
+	// OperationInput represents the 'input' of a YANG rpc or action.
+	OperationInput
+	// OperationOutput represents the 'output' of a YANG rpc or action.
+	OperationOutput
+	// OperationNotification represents a YANG 'notification'.
+	OperationNotification
+)
+
 // DirType describes the different types of Directory that
 // can be output within the IR such that 'list' directories
 // can have special handling applied.
@@ -158,11 +271,36 @@ type NodeDetails struct {
 	// the output code, using the output of the language-specific
 	// type mapping provided by calling the LangMapper interface.
 	LangType *MappedType
+	// LangDefault describes the default value of the node in the
+	// output code, as mapped by the LangMapper. It is nil if the node
+	// does not have a default value.
+	LangDefault *MappedDefault
 	// MapPaths describes the paths that the output node should
 	// be mapped to in the output code - these annotations can be
 	// used to annotation the output code with the field(s) that it
 	// corresponds to in the YANG schema.
 	MapPaths [][]string
+	// MapPathModules describes the modules that instantiate each
+	// element of the paths in MapPaths.
+	MapPathModules [][]string
+	// ShadowMapPaths describes the paths of the node that were
+	// deprioritised by schema compression (e.g., the state leaf that
+	// corresponds to a config leaf), if any.
+	ShadowMapPaths [][]string
+	// ShadowMapPathModules describes the modules that instantiate each
+	// element of the paths in ShadowMapPaths.
+	ShadowMapPathModules [][]string
+}
+
+// MappedDefault describes the default value of a leaf or leaf-list in the
+// output code.
+type MappedDefault struct {
+	// Value is the default value, expressed in the output language.
+	Value string
+	// Kind is the YANG type that the default value was mapped from. Where
+	// the leaf's type is a union, it is the type of the member of the
+	// union that the value corresponds to.
+	Kind yang.TypeKind
 }
 
 // NodeType describes the different types of node that can
@@ -181,6 +319,8 @@ const (
 	LeafNode
 	// LeafListNode represents a YANG 'leaf-list'.
 	LeafListNode
+	// AnyDataNode represents a YANG 'anydata'.
+	AnyDataNode
 )
 
 // YANGNodeDetails stores the YANG-specific details of a node
@@ -196,6 +336,19 @@ type YANGNodeDetails struct {
 	Module string
 	// Path specifies the complete YANG schema node path.
 	Path []string
+	// SchemaPath is the path of the node within the YANG schema
+	// tree, including any choice and case nodes. For containers and
+	// lists, it is the key of the node's directory within the IR.
+	SchemaPath string
+	// OrderedByUser indicates that the node is a list or leaf-list
+	// that is "ordered-by user".
+	OrderedByUser bool
+	// Type is the YANG type of a leaf or leaf-list node.
+	Type *yang.YangType
+	// LeafrefTarget describes the node that is referenced by a leaf or
+	// leaf-list whose type is a leafref. Only the Name, Default, Path,
+	// SchemaPath and Type of the referenced node are populated.
+	LeafrefTarget *YANGNodeDetails
 }
 
 // EnumeratedValueType is used to indicate the source YANG type
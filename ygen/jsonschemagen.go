@@ -0,0 +1,772 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/util"
+)
+
+// This file contains the generation of JSON Schema and OpenAPI documents that
+// describe the RFC7951 JSON encoding of the data tree of a YANG schema. Each
+// directory in the IR is output as a named schema that describes the JSON
+// object that represents the corresponding container or list entry, such
+// that the names of the schemas match the names of the generated Go structs.
+
+const (
+	// JSONSchemaDialect is the URI of the JSON Schema dialect that is used
+	// for generated JSON Schema documents.
+	JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	// OpenAPIVersion is the version of the OpenAPI specification used
+	// for generated OpenAPI documents. OpenAPI 3.1 is used since its
+	// schema objects are JSON Schema draft 2020-12.
+	OpenAPIVersion = "3.1.0"
+	// DefaultOpenAPIInfoVersion is the version of the described API that
+	// is specified in the info object of generated OpenAPI documents if
+	// none is specified.
+	DefaultOpenAPIInfoVersion = "1.0.0"
+)
+
+// JSONSchemaOpts stores options that are specific to the generation of JSON
+// Schema and OpenAPI documents.
+type JSONSchemaOpts struct {
+	// SchemaID is the URI that is used as the $id of the generated JSON
+	// Schema document.
+	SchemaID string
+	// Title is the title of the generated documents. If it is not
+	// specified, the names of the input YANG files are used.
+	Title string
+	// APIVersion is the version of the API that is specified in the info
+	// object of the generated OpenAPI document. If it is not specified,
+	// DefaultOpenAPIInfoVersion is used.
+	APIVersion string
+}
+
+// GeneratedJSONSchemaDocuments stores the documents that describe the
+// RFC7951 JSON encoding of a YANG schema.
+type GeneratedJSONSchemaDocuments struct {
+	// JSONSchema is a JSON Schema (draft 2020-12) document whose root
+	// schema describes the root of the data tree, with a definition in
+	// $defs for each container and list.
+	JSONSchema []byte
+	// OpenAPI is an OpenAPI 3.1 document which contains a component
+	// schema for the root of the data tree, and for each container and
+	// list.
+	OpenAPI []byte
+}
+
+// jsonSchema is a JSON Schema, of which only the keywords that are used to
+// describe YANG schema elements are represented.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Const                interface{}            `json:"const,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Minimum              *int64                 `json:"minimum,omitempty"`
+	Maximum              *int64                 `json:"maximum,omitempty"`
+	XMinimum             string                 `json:"x-minimum,omitempty"`
+	XMaximum             string                 `json:"x-maximum,omitempty"`
+	MinLength            *uint64                `json:"minLength,omitempty"`
+	MaxLength            *uint64                `json:"maxLength,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	ContentEncoding      string                 `json:"contentEncoding,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AllOf                []*jsonSchema          `json:"allOf,omitempty"`
+	AnyOf                []*jsonSchema          `json:"anyOf,omitempty"`
+	ReadOnly             bool                   `json:"readOnly,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// newJSONObject returns a JSON Schema for an object with no properties
+// other than those that are subsequently added to it.
+func newJSONObject() *jsonSchema {
+	f := false
+	return &jsonSchema{
+		Type:                 "object",
+		Properties:           map[string]*jsonSchema{},
+		AdditionalProperties: &f,
+	}
+}
+
+// openAPIDocument is an OpenAPI document, of which only the fields that are
+// used to describe component schemas are represented.
+type openAPIDocument struct {
+	OpenAPI string `json:"openapi"`
+	Info    struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths      map[string]interface{} `json:"paths"`
+	Components struct {
+		Schemas map[string]*jsonSchema `json:"schemas"`
+	} `json:"components"`
+}
+
+// jsonSchemaGen stores the state that is used to generate the JSON Schema
+// for a set of directories.
+type jsonSchemaGen struct {
+	// gir is the IR, and the state used to produce it, for which schemas
+	// are generated.
+	gir *generatedIR
+	// refPrefix is the prefix of the references to the named schemas,
+	// such as "#/$defs/".
+	refPrefix string
+	// compressPaths indicates whether the schema paths are compressed.
+	compressPaths bool
+	// includeDescriptions indicates whether the descriptions of YANG
+	// entries are included in the schemas.
+	includeDescriptions bool
+}
+
+// GenerateJSONSchemaDocuments generates a JSON Schema document and an OpenAPI
+// document that describe the RFC7951 JSON encoding of the data tree for the
+// input set of YANG files, with included modules being searched for in
+// includePaths. The schemas honour the compression, fake root and state
+// exclusion options of the generator, such that there is a named schema for
+// each struct that is generated for the Go code with the same options, and
+// the JSON that ygot emits for each struct is described by its schema.
+func (cg *YANGCodeGenerator) GenerateJSONSchemaDocuments(yangFiles, includePaths []string) (*GeneratedJSONSchemaDocuments, util.Errors) {
+	opts := IROptions{
+		ParseOptions:                        cg.Config.ParseOptions,
+		TransformationOptions:               cg.Config.TransformationOptions,
+		AppendEnumSuffixForSimpleUnionEnums: cg.Config.GoOptions.AppendEnumSuffixForSimpleUnionEnums,
+	}
+	gir, errs := generateIR(yangFiles, includePaths, newGoLangMapper(opts), opts)
+	if errs != nil {
+		return nil, errs
+	}
+
+	title := cg.Config.JSONSchemaOptions.Title
+	if title == "" {
+		var names []string
+		for _, f := range yangFiles {
+			names = append(names, strings.TrimSuffix(filepath.Base(f), ".yang"))
+		}
+		title = strings.Join(names, ", ")
+	}
+	gen := &jsonSchemaGen{
+		gir:                 gir,
+		compressPaths:       cg.Config.TransformationOptions.CompressBehaviour.CompressEnabled(),
+		includeDescriptions: cg.Config.IncludeDescriptions,
+	}
+
+	gen.refPrefix = "#/$defs/"
+	defs, rootName, errs := gen.definitions(cg.Config.TransformationOptions.FakeRootName)
+	if errs != nil {
+		return nil, errs
+	}
+	doc := &jsonSchema{
+		Schema: JSONSchemaDialect,
+		ID:     cg.Config.JSONSchemaOptions.SchemaID,
+		Title:  title,
+		Ref:    gen.refPrefix + rootName,
+		Defs:   defs,
+	}
+	js, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, util.NewErrs(err)
+	}
+
+	gen.refPrefix = "#/components/schemas/"
+	if defs, _, errs = gen.definitions(cg.Config.TransformationOptions.FakeRootName); errs != nil {
+		return nil, errs
+	}
+	api := &openAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Paths:   map[string]interface{}{},
+	}
+	api.Info.Title = title
+	api.Info.Version = cg.Config.JSONSchemaOptions.APIVersion
+	if api.Info.Version == "" {
+		api.Info.Version = DefaultOpenAPIInfoVersion
+	}
+	api.Components.Schemas = defs
+	oa, err := json.MarshalIndent(api, "", "  ")
+	if err != nil {
+		return nil, util.NewErrs(err)
+	}
+
+	return &GeneratedJSONSchemaDocuments{
+		JSONSchema: append(js, '\n'),
+		OpenAPI:    append(oa, '\n'),
+	}, nil
+}
+
+// definitions returns the named schemas for each directory, keyed by the
+// name of the directory, along with the name of the schema that describes
+// the root of the data tree. If a fake root is generated, it is the root's
+// schema, otherwise a root schema is synthesised from the top-level
+// containers and lists, and is named according to fakeRootName.
+func (g *jsonSchemaGen) definitions(fakeRootName string) (map[string]*jsonSchema, string, util.Errors) {
+	var errs util.Errors
+	defs := map[string]*jsonSchema{}
+	var rootName string
+	for p, dir := range g.gir.directories {
+		name := g.gir.ir.Directories[p].Name
+		s, err := g.directorySchema(dir)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		defs[name] = s
+		if dir.IsFakeRoot {
+			rootName = name
+		}
+	}
+	if errs != nil {
+		return nil, "", errs
+	}
+	if rootName != "" {
+		return defs, rootName, nil
+	}
+
+	rootName = yang.CamelCase(resolveRootName(fakeRootName, defaultRootName, true))
+	if _, ok := defs[rootName]; ok {
+		return nil, "", util.NewErrs(fmt.Errorf("cannot use %s as the name of the root schema, since it is the name of a directory", rootName))
+	}
+	root, err := g.rootSchema()
+	if err != nil {
+		return nil, "", util.NewErrs(err)
+	}
+	defs[rootName] = root
+	return defs, rootName, nil
+}
+
+// rootSchema returns the schema for the root of the data tree when there is
+// no fake root, whose properties are the directories that are not contained
+// within another directory.
+func (g *jsonSchemaGen) rootSchema() (*jsonSchema, error) {
+	root := newJSONObject()
+	for _, p := range genutil.GetOrderedEntryKeys(directoryEntries(g.gir.directories)) {
+		dir := g.gir.directories[p]
+		// Notifications, and the input and output of RPCs and actions,
+		// are not part of the data tree.
+		if util.IsOperationDescendant(dir.Entry) {
+			continue
+		}
+		entries := util.SchemaEntryPathNoChoiceCase(dir.Entry)[1:]
+		topLevel := true
+		for _, e := range entries[:len(entries)-1] {
+			if _, ok := g.gir.directories[e.Path()]; ok {
+				topLevel = false
+				break
+			}
+		}
+		if !topLevel {
+			continue
+		}
+
+		var path, modules []string
+		for _, e := range entries {
+			m, err := e.InstantiatingModule()
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, e.Name)
+			modules = append(modules, m)
+		}
+		s, err := g.fieldSchema(dir.Entry)
+		if err != nil {
+			return nil, err
+		}
+		addJSONProperty(root, path, modules, "", s)
+	}
+	return root, nil
+}
+
+// directoryEntries returns the entries of the supplied directories, keyed by
+// their paths.
+func directoryEntries(dirs map[string]*Directory) map[string]*yang.Entry {
+	entries := map[string]*yang.Entry{}
+	for p, d := range dirs {
+		entries[p] = d.Entry
+	}
+	return entries
+}
+
+// directorySchema returns the schema of the JSON object that represents the
+// supplied directory. Each field is a property of the object at each of the
+// paths that it is mapped to, including the paths of fields that are
+// shadowed by compression, such that JSON containing both intended
+// configuration and state is valid. The keys of a list are required.
+func (g *jsonSchemaGen) directorySchema(dir *Directory) (*jsonSchema, error) {
+	s := newJSONObject()
+	if g.includeDescriptions && !dir.IsFakeRoot {
+		s.Description = dir.Entry.Description
+	}
+	var module string
+	if !dir.IsFakeRoot {
+		var err error
+		if module, err = dir.Entry.InstantiatingModule(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, fn := range GetOrderedFieldNames(dir) {
+		// The paths of the shadowed field are added first, such that
+		// where the field and the shadowed field are both mapped to
+		// a path, such as the key of a list, the field's schema is
+		// used.
+		if shadow, ok := dir.ShadowedFields[fn]; ok {
+			ss, err := g.fieldSchema(shadow)
+			if err != nil {
+				return nil, err
+			}
+			paths, modules, err := findMapPaths(dir, fn, g.compressPaths, true, false)
+			if err != nil {
+				return nil, err
+			}
+			for i, p := range paths {
+				addJSONProperty(s, p, modules[i], module, ss)
+			}
+		}
+
+		fs, err := g.fieldSchema(dir.Fields[fn])
+		if err != nil {
+			return nil, err
+		}
+		paths, modules, err := findMapPaths(dir, fn, g.compressPaths, false, false)
+		if err != nil {
+			return nil, err
+		}
+		for i, p := range paths {
+			addJSONProperty(s, p, modules[i], module, fs)
+		}
+	}
+
+	if dir.ListAttr != nil {
+		for _, k := range strings.Fields(dir.Entry.Key) {
+			ke, ok := dir.Entry.Dir[k]
+			if !ok {
+				return nil, fmt.Errorf("cannot find key %s of list %s", k, dir.Entry.Path())
+			}
+			km, err := ke.InstantiatingModule()
+			if err != nil {
+				return nil, err
+			}
+			if n := jsonMemberName(k, km, module); s.Properties[n] != nil {
+				s.Required = append(s.Required, n)
+			}
+		}
+	}
+	return s, nil
+}
+
+// addJSONProperty adds the schema s as a property of the object described by
+// obj at the supplied path, whose elements are defined by the corresponding
+// modules, creating objects for any intermediate elements of the path. The
+// parentModule is the module of the node that obj represents, which is empty
+// for the root of the data tree.
+func addJSONProperty(obj *jsonSchema, path, modules []string, parentModule string, s *jsonSchema) {
+	for i, p := range path {
+		n := jsonMemberName(p, modules[i], parentModule)
+		parentModule = modules[i]
+		if i == len(path)-1 {
+			obj.Properties[n] = s
+			return
+		}
+		child, ok := obj.Properties[n]
+		if !ok {
+			child = newJSONObject()
+			obj.Properties[n] = child
+		}
+		obj = child
+	}
+}
+
+// jsonMemberName returns the name of the JSON member that represents the
+// node with the supplied name, which is defined by module and is a child of
+// a node defined by parentModule. As specified by RFC7951, the name is
+// qualified by the module name if the modules differ.
+func jsonMemberName(name, module, parentModule string) string {
+	if module == parentModule {
+		return name
+	}
+	return fmt.Sprintf("%s:%s", module, name)
+}
+
+// fieldSchema returns the schema of the JSON value that represents the
+// supplied field of a directory.
+func (g *jsonSchemaGen) fieldSchema(e *yang.Entry) (*jsonSchema, error) {
+	var s *jsonSchema
+	switch {
+	case e.IsList():
+		s = &jsonSchema{Type: "array", Items: &jsonSchema{Ref: g.refPrefix + g.gir.ir.Directories[e.Path()].Name}}
+	case e.IsDir():
+		s = &jsonSchema{Ref: g.refPrefix + g.gir.ir.Directories[e.Path()].Name}
+	case e.IsLeafList():
+		ts, err := g.typeSchema(e.Type, e)
+		if err != nil {
+			return nil, err
+		}
+		s = &jsonSchema{Type: "array", Items: ts}
+	default:
+		var err error
+		if s, err = g.typeSchema(e.Type, e); err != nil {
+			return nil, err
+		}
+	}
+	if g.includeDescriptions {
+		s.Description = e.Description
+	}
+	if (e.IsLeaf() || e.IsLeafList()) && !util.IsConfig(e) {
+		s.ReadOnly = true
+	}
+	return s, nil
+}
+
+var (
+	// jsonIntegerKinds is the set of YANG types whose values are encoded
+	// as JSON numbers in RFC7951.
+	jsonIntegerKinds = map[yang.TypeKind]bool{
+		yang.Yint8:   true,
+		yang.Yint16:  true,
+		yang.Yint32:  true,
+		yang.Yuint8:  true,
+		yang.Yuint16: true,
+		yang.Yuint32: true,
+	}
+	// jsonInt64Patterns specifies the patterns of the JSON strings that
+	// encode the values of the 64-bit integer types in RFC7951.
+	jsonInt64Patterns = map[yang.TypeKind]string{
+		yang.Yint64:  "^-?[0-9]+$",
+		yang.Yuint64: "^[0-9]+$",
+	}
+	// jsonInt64Ranges specifies the ranges of the unrestricted 64-bit
+	// integer types, for which no range restriction is output.
+	jsonInt64Ranges = map[yang.TypeKind]yang.YangRange{
+		yang.Yint64:  yang.Int64Range,
+		yang.Yuint64: yang.Uint64Range,
+	}
+)
+
+// typeSchema returns the schema of the JSON value that encodes a value of the
+// YANG type t according to RFC7951, for the leaf ctx.
+func (g *jsonSchemaGen) typeSchema(t *yang.YangType, ctx *yang.Entry) (*jsonSchema, error) {
+	if t == nil {
+		return nil, fmt.Errorf("%s has no type", ctx.Path())
+	}
+	switch {
+	case jsonIntegerKinds[t.Kind]:
+		return rangeSchema(&jsonSchema{Type: "integer"}, t.Range, func(s *jsonSchema, r yang.YRange) {
+			if r.Min.Kind != yang.MinNumber {
+				if v, err := r.Min.Int(); err == nil {
+					s.Minimum = &v
+				}
+			}
+			if r.Max.Kind != yang.MaxNumber {
+				if v, err := r.Max.Int(); err == nil {
+					s.Maximum = &v
+				}
+			}
+		}), nil
+	case jsonInt64Patterns[t.Kind] != "":
+		s := &jsonSchema{Type: "string", Pattern: jsonInt64Patterns[t.Kind]}
+		if t.Range.Equal(jsonInt64Ranges[t.Kind]) {
+			return s, nil
+		}
+		return rangeSchema(s, t.Range, stringRangeBounds), nil
+	}
+
+	switch t.Kind {
+	case yang.Ydecimal64:
+		s := &jsonSchema{Type: "string", Pattern: fmt.Sprintf(`^-?[0-9]+(\.[0-9]{1,%d})?$`, t.FractionDigits)}
+		return rangeSchema(s, t.Range, stringRangeBounds), nil
+	case yang.Ystring:
+		s := &jsonSchema{Type: "string"}
+		var patterns []*jsonSchema
+		for _, p := range t.Pattern {
+			ep, err := ecmaPattern(p)
+			if err != nil {
+				return nil, fmt.Errorf("%s: unsupported pattern %q: %v", ctx.Path(), p, err)
+			}
+			// YANG patterns are implicitly anchored, whereas JSON
+			// Schema patterns are not.
+			patterns = append(patterns, &jsonSchema{Pattern: fmt.Sprintf("^(?:%s)$", ep)})
+		}
+		switch len(patterns) {
+		case 0:
+		case 1:
+			s.Pattern = patterns[0].Pattern
+		default:
+			s.AllOf = patterns
+		}
+		return rangeSchema(s, t.Length, func(s *jsonSchema, r yang.YRange) {
+			if r.Min.Kind != yang.MinNumber && r.Min.Value != 0 {
+				v := r.Min.Value
+				s.MinLength = &v
+			}
+			if r.Max.Kind != yang.MaxNumber && r.Max.Value != math.MaxUint64 {
+				v := r.Max.Value
+				s.MaxLength = &v
+			}
+		}), nil
+	case yang.Ybool:
+		return &jsonSchema{Type: "boolean"}, nil
+	case yang.Yempty:
+		return &jsonSchema{Const: []interface{}{nil}}, nil
+	case yang.Ybinary:
+		return &jsonSchema{Type: "string", ContentEncoding: "base64"}, nil
+	case yang.Ybits:
+		names := t.Bit.Names()
+		for i, n := range names {
+			names[i] = regexp.QuoteMeta(n)
+		}
+		bit := strings.Join(names, "|")
+		return &jsonSchema{Type: "string", Pattern: fmt.Sprintf("^((%s)( (%s))*)?$", bit, bit)}, nil
+	case yang.Yenum:
+		vals := t.Enum.ValueMap()
+		var nums []int64
+		for v := range vals {
+			nums = append(nums, v)
+		}
+		sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+		s := &jsonSchema{Type: "string"}
+		for _, v := range nums {
+			s.Enum = append(s.Enum, vals[v])
+		}
+		return s, nil
+	case yang.Yidentityref:
+		if t.IdentityBase == nil {
+			return nil, fmt.Errorf("identityref %s has no base", ctx.Path())
+		}
+		module, err := ctx.InstantiatingModule()
+		if err != nil {
+			return nil, err
+		}
+		s := &jsonSchema{Type: "string"}
+		for _, v := range t.IdentityBase.Values {
+			// RFC7951 requires identities that are defined in a
+			// module other than that of the leaf to be qualified
+			// by their module's name, and allows them to be
+			// qualified otherwise.
+			m := genutil.ParentModuleName(v)
+			s.Enum = append(s.Enum, fmt.Sprintf("%s:%s", m, v.Name))
+			if m == module {
+				s.Enum = append(s.Enum, v.Name)
+			}
+		}
+		sort.Strings(s.Enum)
+		return s, nil
+	case yang.Yleafref:
+		target, err := g.gir.defs.schematree.resolveLeafrefTarget(t.Path, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return g.typeSchema(target.Type, target)
+	case yang.Yunion:
+		s := &jsonSchema{}
+		for _, m := range t.Type {
+			ms, err := g.typeSchema(m, ctx)
+			if err != nil {
+				return nil, err
+			}
+			s.AnyOf = append(s.AnyOf, ms)
+		}
+		return s, nil
+	case yang.YinstanceIdentifier:
+		return &jsonSchema{Type: "string"}, nil
+	}
+	return nil, fmt.Errorf("%s has unsupported type %v", ctx.Path(), t.Kind)
+}
+
+// rangeSchema applies the supplied ranges to s using the supplied function,
+// which sets the keywords of a schema according to a single range. If there
+// are several ranges, then each is applied to a separate schema, any of which
+// must be satisfied.
+func rangeSchema(s *jsonSchema, ranges yang.YangRange, apply func(*jsonSchema, yang.YRange)) *jsonSchema {
+	switch len(ranges) {
+	case 0:
+	case 1:
+		apply(s, ranges[0])
+	default:
+		for _, r := range ranges {
+			rs := &jsonSchema{}
+			apply(rs, r)
+			s.AnyOf = append(s.AnyOf, rs)
+		}
+	}
+	return s
+}
+
+// stringRangeBounds sets the x-minimum and x-maximum extensions of s to the
+// bounds of r. It is used for the types whose values are encoded as JSON
+// strings in RFC7951, to which the minimum and maximum keywords do not apply,
+// and whose bounds may not be representable as JSON numbers.
+func stringRangeBounds(s *jsonSchema, r yang.YRange) {
+	if r.Min.Kind != yang.MinNumber {
+		s.XMinimum = r.Min.String()
+	}
+	if r.Max.Kind != yang.MaxNumber {
+		s.XMaximum = r.Max.String()
+	}
+}
+
+// xsdBlockRanges maps the names of the Unicode blocks that can be referenced
+// by the \p{IsX} escapes of XSD regular expressions to the ECMA-262 character
+// ranges that they correspond to.
+var xsdBlockRanges = map[string]string{
+	"BasicLatin":           `\u0000-\u007F`,
+	"Latin-1Supplement":    `\u0080-\u00FF`,
+	"LatinExtended-A":      `\u0100-\u017F`,
+	"LatinExtended-B":      `\u0180-\u024F`,
+	"Greek":                `\u0370-\u03FF`,
+	"Cyrillic":             `\u0400-\u04FF`,
+	"Hebrew":               `\u0590-\u05FF`,
+	"Arabic":               `\u0600-\u06FF`,
+	"GeneralPunctuation":   `\u2000-\u206F`,
+	"Hiragana":             `\u3040-\u309F`,
+	"Katakana":             `\u30A0-\u30FF`,
+	"CJKUnifiedIdeographs": `\u4E00-\u9FFF`,
+}
+
+// ecmaPattern translates the YANG pattern p, which is an XSD regular
+// expression, to the ECMA-262 regular expression that is used by JSON
+// Schema. Unicode block escapes are replaced by the character ranges of the
+// block, character class subtractions are expressed using a negative
+// lookahead, and the ^ and $ characters, which are not anchors in XSD, are
+// escaped. An error is returned for XSD constructs that cannot be
+// translated.
+func ecmaPattern(p string) (string, error) {
+	r := []rune(p)
+	var b strings.Builder
+	for i := 0; i < len(r); i++ {
+		switch r[i] {
+		case '\\':
+			esc, n, err := xsdEscape(r, i, false)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(esc)
+			i = n - 1
+		case '[':
+			class, n, err := xsdCharClass(r, i)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(class)
+			i = n - 1
+		case '^', '$':
+			b.WriteRune('\\')
+			b.WriteRune(r[i])
+		default:
+			b.WriteRune(r[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// xsdEscape translates the escape that starts at r[i], returning its
+// ECMA-262 equivalent and the index following the escape. The inClass
+// argument specifies whether the escape is within a character class.
+func xsdEscape(r []rune, i int, inClass bool) (string, int, error) {
+	if i+1 >= len(r) {
+		return "", 0, fmt.Errorf("trailing backslash")
+	}
+	switch c := r[i+1]; c {
+	case 'i', 'I', 'c', 'C':
+		return "", 0, fmt.Errorf("XSD escape \\%c is not supported", c)
+	case 'p', 'P':
+		if i+2 >= len(r) || r[i+2] != '{' {
+			return "", 0, fmt.Errorf("invalid escape \\%c", c)
+		}
+		end := i + 3
+		for end < len(r) && r[end] != '}' {
+			end++
+		}
+		if end == len(r) {
+			return "", 0, fmt.Errorf("unterminated escape \\%c", c)
+		}
+		name := string(r[i+3 : end])
+		if !strings.HasPrefix(name, "Is") {
+			// Unicode general categories are supported by ECMA-262.
+			return string(r[i : end+1]), end + 1, nil
+		}
+		rng, ok := xsdBlockRanges[strings.TrimPrefix(name, "Is")]
+		switch {
+		case !ok:
+			return "", 0, fmt.Errorf("Unicode block %s is not supported", name)
+		case inClass && c == 'P':
+			return "", 0, fmt.Errorf("negated Unicode block %s is not supported within a character class", name)
+		case inClass:
+			return rng, end + 1, nil
+		case c == 'P':
+			return "[^" + rng + "]", end + 1, nil
+		}
+		return "[" + rng + "]", end + 1, nil
+	}
+	return string(r[i : i+2]), i + 2, nil
+}
+
+// xsdCharClass translates the character class that starts at r[i], returning
+// its ECMA-262 equivalent and the index following the class.
+func xsdCharClass(r []rune, i int) (string, int, error) {
+	var b strings.Builder
+	b.WriteRune('[')
+	i++
+	if i < len(r) && r[i] == '^' {
+		b.WriteRune('^')
+		i++
+	}
+	var subtract string
+	for ; i < len(r); i++ {
+		switch {
+		case r[i] == ']':
+			class := b.String() + "]"
+			if subtract != "" {
+				// ECMA-262 has no class subtraction, so the
+				// subtracted characters are excluded by a negative
+				// lookahead.
+				class = fmt.Sprintf("(?:(?!%s)%s)", subtract, class)
+			}
+			return class, i + 1, nil
+		case subtract != "":
+			return "", 0, fmt.Errorf("character class subtraction must be the last element of a class")
+		case r[i] == '\\':
+			esc, n, err := xsdEscape(r, i, true)
+			if err != nil {
+				return "", 0, err
+			}
+			b.WriteString(esc)
+			i = n - 1
+		case r[i] == '-' && i+1 < len(r) && r[i+1] == '[':
+			sub, n, err := xsdCharClass(r, i+1)
+			if err != nil {
+				return "", 0, err
+			}
+			subtract = sub
+			i = n - 1
+		default:
+			b.WriteRune(r[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated character class")
+}
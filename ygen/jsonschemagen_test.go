@@ -0,0 +1,224 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/testutil"
+)
+
+func TestGenerateJSONSchemaDocuments(t *testing.T) {
+	datapath := filepath.Join(TestRoot, "testdata", "jsonschema")
+	inFiles := []string{
+		filepath.Join(datapath, "jsonschema-test.yang"),
+		filepath.Join(datapath, "jsonschema-augment.yang"),
+	}
+
+	tests := []struct {
+		name               string
+		inConfig           GeneratorConfig
+		wantJSONSchemaFile string
+		wantOpenAPIFile    string
+		wantErrSubstring   string
+	}{{
+		name: "compressed with fakeroot",
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+		},
+		wantJSONSchemaFile: "jsonschema-test.compress.schema.json",
+		wantOpenAPIFile:    "jsonschema-test.compress.openapi.json",
+	}, {
+		name: "compressed excluding state with descriptions",
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.ExcludeDerivedState,
+			},
+			IncludeDescriptions: true,
+		},
+		wantJSONSchemaFile: "jsonschema-test.exclude-state.schema.json",
+		wantOpenAPIFile:    "jsonschema-test.exclude-state.openapi.json",
+	}, {
+		name: "uncompressed with document options",
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.Uncompressed,
+				FakeRootName:      "root",
+			},
+			JSONSchemaOptions: JSONSchemaOpts{
+				SchemaID:   "https://example.com/jsonschema-test.json",
+				Title:      "JSON Schema test",
+				APIVersion: "2.0.0",
+			},
+		},
+		wantJSONSchemaFile: "jsonschema-test.uncompress.schema.json",
+		wantOpenAPIFile:    "jsonschema-test.uncompress.openapi.json",
+	}, {
+		name: "root name clashes with directory",
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				FakeRootName:      "top",
+			},
+		},
+		wantErrSubstring: "cannot use Top as the name of the root schema",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := NewYANGCodeGenerator(&tt.inConfig)
+			got, errs := cg.GenerateJSONSchemaDocuments(inFiles, nil)
+			var err error
+			if errs != nil {
+				err = errs
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("GenerateJSONSchemaDocuments: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			for _, f := range []struct {
+				file string
+				got  []byte
+			}{{tt.wantJSONSchemaFile, got.JSONSchema}, {tt.wantOpenAPIFile, got.OpenAPI}} {
+				want, err := ioutil.ReadFile(filepath.Join(datapath, f.file))
+				if err != nil {
+					t.Fatalf("ioutil.ReadFile(%q) error: %v", f.file, err)
+				}
+				if string(want) != string(f.got) {
+					diff, _ := testutil.GenerateUnifiedDiff(string(want), string(f.got))
+					t.Errorf("GenerateJSONSchemaDocuments: did not get expected output for %s, diff(-want, +got):\n%s", f.file, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONMemberName(t *testing.T) {
+	tests := []struct {
+		name           string
+		inName         string
+		inModule       string
+		inParentModule string
+		want           string
+	}{{
+		name:           "same module",
+		inName:         "leaf",
+		inModule:       "mod-a",
+		inParentModule: "mod-a",
+		want:           "leaf",
+	}, {
+		name:           "augmenting module",
+		inName:         "leaf",
+		inModule:       "mod-b",
+		inParentModule: "mod-a",
+		want:           "mod-b:leaf",
+	}, {
+		name:     "top-level node",
+		inName:   "container",
+		inModule: "mod-a",
+		want:     "mod-a:container",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jsonMemberName(tt.inName, tt.inModule, tt.inParentModule); got != tt.want {
+				t.Errorf("jsonMemberName(%q, %q, %q): got %q, want %q", tt.inName, tt.inModule, tt.inParentModule, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestECMAPattern(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               string
+		want             string
+		wantErrSubstring string
+	}{{
+		name: "pattern without XSD constructs",
+		in:   `[a-z][a-z0-9\-]*`,
+		want: `[a-z][a-z0-9\-]*`,
+	}, {
+		name: "literal anchors",
+		in:   `a^b$`,
+		want: `a\^b\$`,
+	}, {
+		name: "negated class",
+		in:   `[^a-z]`,
+		want: `[^a-z]`,
+	}, {
+		name: "unicode block",
+		in:   `\p{IsBasicLatin}+`,
+		want: `[\u0000-\u007F]+`,
+	}, {
+		name: "negated unicode block",
+		in:   `\P{IsGreek}`,
+		want: `[^\u0370-\u03FF]`,
+	}, {
+		name: "unicode block within class",
+		in:   `[\p{IsGreek}a]`,
+		want: `[\u0370-\u03FFa]`,
+	}, {
+		name: "unicode category",
+		in:   `\p{Lu}[\p{Nd}]`,
+		want: `\p{Lu}[\p{Nd}]`,
+	}, {
+		name: "class subtraction",
+		in:   `[a-z-[aeiou]]+`,
+		want: `(?:(?![aeiou])[a-z])+`,
+	}, {
+		name: "nested class subtraction",
+		in:   `[a-z-[a-f-[c]]]`,
+		want: `(?:(?!(?:(?![c])[a-f]))[a-z])`,
+	}, {
+		name:             "unsupported unicode block",
+		in:               `\p{IsTibetan}`,
+		wantErrSubstring: "Unicode block IsTibetan is not supported",
+	}, {
+		name:             "negated unicode block within class",
+		in:               `[\P{IsGreek}]`,
+		wantErrSubstring: "not supported within a character class",
+	}, {
+		name:             "name character escape",
+		in:               `\i\c*`,
+		wantErrSubstring: `XSD escape \i is not supported`,
+	}, {
+		name:             "unterminated class",
+		in:               `[a-z`,
+		wantErrSubstring: "unterminated character class",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecmaPattern(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ecmaPattern(%q): %s", tt.in, diff)
+			}
+			if got != tt.want {
+				t.Errorf("ecmaPattern(%q): got %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
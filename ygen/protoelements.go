@@ -65,6 +65,119 @@ func newProtoGenState(schematree *schemaTree, eSet *enumSet) *protoGenState {
 	}
 }
 
+// protoLangMapper is the LangMapper implementation for protobuf code
+// generation. It wraps a protoGenState, such that the names that are produced
+// when generating the IR are also used when generating protobuf messages.
+type protoLangMapper struct {
+	*protoGenState
+
+	// basePackageName is the name of the package within which all
+	// generated packages are generated.
+	basePackageName string
+	// enumPackageName is the name of the package within which global
+	// enumerated values are defined.
+	enumPackageName string
+	// useDefiningModuleForTypedefEnumNames uses the defining module name
+	// to prefix typedef enumerated types.
+	useDefiningModuleForTypedefEnumNames bool
+	// useConsistentNamesForProtoUnionEnums specifies that the names of
+	// enumerations within unions are calculated consistently.
+	useConsistentNamesForProtoUnionEnums bool
+}
+
+// NewProtoLangMapper returns a LangMapper that maps the YANG schema to names
+// and types that are used in the protobuf messages generated by ygen, using
+// the supplied IROptions. basePackageName and enumPackageName specify the
+// protobuf packages within which messages and global enumerations are
+// generated, if they are empty, the defaults are used.
+func NewProtoLangMapper(opts IROptions, basePackageName, enumPackageName string) LangMapper {
+	return newProtoLangMapper(opts, basePackageName, enumPackageName)
+}
+
+// newProtoLangMapper returns a protoLangMapper initialised with the
+// supplied options.
+func newProtoLangMapper(opts IROptions, basePackageName, enumPackageName string) *protoLangMapper {
+	if basePackageName == "" {
+		basePackageName = DefaultBasePackageName
+	}
+	if enumPackageName == "" {
+		enumPackageName = DefaultEnumPackageName
+	}
+	return &protoLangMapper{
+		protoGenState:                        newProtoGenState(nil, nil),
+		basePackageName:                      basePackageName,
+		enumPackageName:                      enumPackageName,
+		useDefiningModuleForTypedefEnumNames: opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames,
+		useConsistentNamesForProtoUnionEnums: opts.UseConsistentNamesForProtoUnionEnums,
+	}
+}
+
+// FieldName returns the protobuf-safe name of the field e. The name is not
+// checked for uniqueness.
+func (p *protoLangMapper) FieldName(e *yang.Entry) (string, error) {
+	return safeProtoIdentifierName(e.Name), nil
+}
+
+// DirectoryName returns the name of the message that is generated for the
+// directory e, which is unique within its package.
+func (p *protoLangMapper) DirectoryName(e *yang.Entry, cb genutil.CompressBehaviour) (string, error) {
+	return p.protoMsgName(e, cb.CompressEnabled()), nil
+}
+
+// KeyLeafType returns the protobuf type of the list key leaf e. Since all
+// keys must be set, scalar types are used rather than wrapper types.
+func (p *protoLangMapper) KeyLeafType(e *yang.Entry, cb genutil.CompressBehaviour) (*MappedType, error) {
+	return p.yangTypeToProtoScalarType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, resolveProtoTypeArgs{
+		basePackageName:             p.basePackageName,
+		enumPackageName:             p.enumPackageName,
+		scalarTypeInSingleTypeUnion: true,
+	}, p.useDefiningModuleForTypedefEnumNames, p.useConsistentNamesForProtoUnionEnums)
+}
+
+// LeafType returns the protobuf type of the leaf or leaf-list e.
+func (p *protoLangMapper) LeafType(e *yang.Entry, cb genutil.CompressBehaviour) (*MappedType, error) {
+	return p.yangTypeToProtoType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, resolveProtoTypeArgs{
+		basePackageName: p.basePackageName,
+		enumPackageName: p.enumPackageName,
+	}, p.useDefiningModuleForTypedefEnumNames, p.useConsistentNamesForProtoUnionEnums)
+}
+
+// LeafDefault returns nil, since default values are not represented within
+// the generated protobuf messages.
+func (p *protoLangMapper) LeafDefault(*yang.Entry, *MappedType, genutil.CompressBehaviour) (*MappedDefault, error) {
+	return nil, nil
+}
+
+// PackageName returns the name of the protobuf package within which the
+// message for the directory e is output, when each message is output within
+// the package corresponding to its path.
+func (p *protoLangMapper) PackageName(e *yang.Entry, cb genutil.CompressBehaviour) (string, error) {
+	if !IsFakeRoot(e) && e.Parent == nil {
+		return "", fmt.Errorf("YANG schema element %s does not have a parent, protobuf messages are not generated for modules", e.Path())
+	}
+	return p.protobufPackage(e, cb.CompressEnabled()), nil
+}
+
+// EnumeratedValueName returns a protobuf-safe name for the enumerated
+// value v.
+func (p *protoLangMapper) EnumeratedValueName(v string) (string, error) {
+	return safeProtoIdentifierName(v), nil
+}
+
+// EnumeratedTypePrefix returns the prefix used for enumerated types in
+// protobuf, which is empty.
+func (p *protoLangMapper) EnumeratedTypePrefix() string { return "" }
+
+// EnumerationsUseUnderscores returns false, since the names of enumerated
+// types in protobuf do not use underscores between path elements.
+func (p *protoLangMapper) EnumerationsUseUnderscores() bool { return false }
+
+// SetEnumSet sets the enumSet used to look up enumerated types.
+func (p *protoLangMapper) SetEnumSet(e *enumSet) { p.enumSet = e }
+
+// SetSchemaTree sets the schemaTree used to resolve leafrefs.
+func (p *protoLangMapper) SetSchemaTree(st *schemaTree) { p.schematree = st }
+
 // buildDirectoryDefinitions extracts the yang.Entry instances from a map of
 // entries that need struct definitions built for them. It resolves each
 // non-leaf yang.Entry to a Directory which contains the elements that are
@@ -114,23 +227,34 @@ func yangEnumTypeToProtoType(args resolveTypeArgs, useDefiningModuleForTypedefEn
 	if args.contextEntry == nil {
 		return nil, fmt.Errorf("cannot map enumeration without context entry: %v", args)
 	}
-	// However, if the enumeration is inlined within a union, then
-	// we add a suffix to indicate that it is part of a larger
-	// union type.
-	typeName := yang.CamelCase(args.contextEntry.Name)
-	definingType, err := util.DefiningType(args.yangType, args.contextEntry.Type)
+	typeName, err := protoEnumTypeName(args.yangType, args.contextEntry.Name, args.contextEntry.Type, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 	if err != nil {
 		return nil, err
 	}
-	if useDefiningModuleForTypedefEnumNames && useConsistentNamesForProtoUnionEnums && definingType.Kind == yang.Yunion {
-		typeName += enumeratedUnionSuffix
-	}
 	return &MappedType{
 		NativeType:        typeName,
 		IsEnumeratedValue: true,
 	}, nil
 }
 
+// protoEnumTypeName returns the name of the enumeration that is embedded
+// within a protobuf message for the enumerated type t, which is used by the
+// leaf with the supplied name, whose type is leafType.
+func protoEnumTypeName(t *yang.YangType, leafName string, leafType *yang.YangType, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (string, error) {
+	// The enumeration is named by the leaf's CamelCase name. However, if
+	// the enumeration is inlined within a union, then we add a suffix to
+	// indicate that it is part of a larger union type.
+	typeName := yang.CamelCase(leafName)
+	definingType, err := util.DefiningType(t, leafType)
+	if err != nil {
+		return "", err
+	}
+	if useDefiningModuleForTypedefEnumNames && useConsistentNamesForProtoUnionEnums && definingType.Kind == yang.Yunion {
+		typeName += enumeratedUnionSuffix
+	}
+	return typeName, nil
+}
+
 // yangTypeToProtoType takes an input resolveTypeArgs (containing a yang.YangType
 // and a context node) and returns the protobuf type that it is to be represented
 // by. The types that are used in the protobuf are wrapper types as described
@@ -299,36 +299,43 @@ type protoMsgConfig struct {
 }
 
 // writeProto3Message outputs the generated Protobuf3 code for a particular protobuf message. It takes:
-//  - msg:               The Directory struct that describes a particular protobuf3 message.
-//  - msgs:              The set of other Directory structs, keyed by schema path, that represent the other proto3
+//  - msg:               The IR directory that describes a particular protobuf3 message.
+//  - msgs:              The set of other IR directories, keyed by schema path, that represent the other proto3
 //                       messages to be generated.
 //  - protogen:             The current generator state.
 //  - cfg:		 The configuration for the message creation as defined in a protoMsgConfig struct.
 //  It returns a generatedProto3Message pointer which includes the definition of the proto3 message, particularly the
 //  name of the package it is within, the code for the message, and any imports for packages that are referenced by
 //  the message.
-func writeProto3Msg(msg *Directory, msgs map[string]*Directory, protogen *protoGenState, cfg *protoMsgConfig, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (*generatedProto3Message, util.Errors) {
+func writeProto3Msg(msg *ParsedDirectory, msgs map[string]*ParsedDirectory, protogen *protoGenState, cfg *protoMsgConfig, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (*generatedProto3Message, util.Errors) {
 	if cfg.nestedMessages {
 		if !outputNestedMessage(msg, cfg.compressPaths) {
 			return nil, nil
 		}
-		return writeProto3MsgNested(msg, msgs, protogen, cfg, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
+		// Nested messages are output within the package of the top-level
+		// message. When path compression is enabled, top-level messages
+		// are output within the root package.
+		pkg := msg.PackageName
+		if cfg.compressPaths {
+			pkg = ""
+		}
+		return writeProto3MsgNested(msg, pkg, msgs, protogen, cfg, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 	}
 	return writeProto3MsgSingleMsg(msg, msgs, protogen, cfg, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 }
 
 // outputNestedMessage determines whether the message represented by the supplied
-// Directory is a message that should be output when nested messages are being
+// directory is a message that should be output when nested messages are being
 // created. The compressPaths argument specifies whether path compression is enabled.
 // Valid messages are those that are direct children of a module, or become a direct
 // child when path compression is enabled (i.e., lists that have their parent
 // surrounding container removed).
-func outputNestedMessage(msg *Directory, compressPaths bool) bool {
+func outputNestedMessage(msg *ParsedDirectory, compressPaths bool) bool {
 	// If path compression is enabled, and this entry is a list, then its top-level
 	// parent will have been removed, therefore this is a valid message. The path
 	// is 4 elements long since it is of the form
 	// []string{"", module-name, surrounding-container, list-name}.
-	if compressPaths && msg.Entry.IsList() && len(msg.Path) == 4 {
+	if compressPaths && msg.Type == List && len(msg.Path) == 4 {
 		return true
 	}
 
@@ -339,28 +346,27 @@ func outputNestedMessage(msg *Directory, compressPaths bool) bool {
 // supplied, which is expected to be a top-level message that code generation is
 // being performed for. It takes:
 //  - msg: the top-level directory definition
+//  - pkg: the name of the package within which the top-level message is output
 //  - msgs: the set of message definitions (keyed by path) that are to be output
 //  - protogen: the current code generation state.
 //  - cfg: the configuration for the current code generation.
 // It returns a generated protobuf3 message.
-func writeProto3MsgNested(msg *Directory, msgs map[string]*Directory, protogen *protoGenState, cfg *protoMsgConfig, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (*generatedProto3Message, util.Errors) {
+func writeProto3MsgNested(msg *ParsedDirectory, pkg string, msgs map[string]*ParsedDirectory, protogen *protoGenState, cfg *protoMsgConfig, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (*generatedProto3Message, util.Errors) {
 	var gerrs util.Errors
 	var childMsgs []*generatedProto3Message
-	// Find all the children of the current message that should be output.
-	for _, n := range msgs {
-		if util.IsDirectEntryChild(msg.Entry, n.Entry, cfg.compressPaths) {
-			cmsg, errs := writeProto3MsgNested(n, msgs, protogen, cfg, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
-			if errs != nil {
-				gerrs = append(gerrs, errs...)
-				continue
-			}
-			childMsgs = append(childMsgs, cmsg)
+	// Find all the children of the current message that should be output. The
+	// children of the fake root are top-level messages, and hence are not nested.
+	for _, field := range msg.yangOrderedFields() {
+		n, ok := msgs[field.YANGDetails.SchemaPath]
+		if msg.IsFakeRoot || !ok || (field.Type != DirectoryNode && field.Type != ListNode) {
+			continue
 		}
-	}
-
-	pkg, err := protobufPackageForMsg(msg, protogen, cfg.compressPaths, cfg.nestedMessages)
-	if err != nil {
-		return nil, append(gerrs, err)
+		cmsg, errs := writeProto3MsgNested(n, pkg, msgs, protogen, cfg, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
+		if errs != nil {
+			gerrs = append(gerrs, errs...)
+			continue
+		}
+		childMsgs = append(childMsgs, cmsg)
 	}
 
 	// Generate this message, and its associated messages.
@@ -418,61 +424,11 @@ func writeProto3MsgNested(msg *Directory, msgs map[string]*Directory, protogen *
 	return gmsg, nil
 }
 
-// protobufPackageForMsg takes a YANG directory definition, the current generator
-// state, whether path compression is currently enabled, and whether nested messages
-// are to be output and determines the package name for the output protobuf. In the
-// case that nested messages are being output, the package name is derived based
-// on the top-level module that the message is within.
-func protobufPackageForMsg(msg *Directory, protogen *protoGenState, compressPaths, nestedMessages bool) (string, error) {
-	switch {
-	case msg.IsFakeRoot:
-		// In this case, we explicitly leave the package name as nil, which is interpeted
-		// as meaning that the base package is used throughout the handling code.
-		return "", nil
-	case msg.Entry.Parent == nil:
-		return "", fmt.Errorf("YANG schema element %s does not have a parent, protobuf messages are not generated for modules", msg.Entry.Path())
-	}
-
-	e := msg.Entry
-	// If we have nested messages enabled, the protobuf package name is defined
-	// based on the top-level message within the schema tree that is created -
-	// we therefore need to derive the name of this message.
-	if nestedMessages {
-		if compressPaths {
-			if e.Parent.Parent == nil {
-				// In the special case that the grandparent of this entry is nil, and
-				// compress paths is enabled, then we are a top-level schema element - so
-				// this message should be in the root package.
-				return "", nil
-			}
-			if e.IsList() && e.Parent.Parent.Parent == nil {
-				// If this is a list, and our great-grandparent is a module, then
-				// since the level above this node has been compressed out, then it
-				// is at the root.
-				return "", nil
-			}
-		}
-
-		if e.Parent != nil && e.Parent.Parent != nil {
-			var n *yang.Entry
-			for n = e.Parent; n.Parent.Parent != nil; n = n.Parent {
-			}
-			e = n
-		}
-	}
-
-	return protogen.protobufPackage(e, compressPaths), nil
-}
-
 // writeProto3MsgSingleMsg generates a protobuf message definition. It takes the
 // arguments of writeProto3Message, outputting an individual message that outputs
 // a package definition and a single protobuf message.
-func writeProto3MsgSingleMsg(msg *Directory, msgs map[string]*Directory, protogen *protoGenState, cfg *protoMsgConfig, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (*generatedProto3Message, util.Errors) {
-	pkg, err := protobufPackageForMsg(msg, protogen, cfg.compressPaths, cfg.nestedMessages)
-	if err != nil {
-		return nil, []error{err}
-	}
-
+func writeProto3MsgSingleMsg(msg *ParsedDirectory, msgs map[string]*ParsedDirectory, protogen *protoGenState, cfg *protoMsgConfig, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (*generatedProto3Message, util.Errors) {
+	pkg := msg.PackageName
 	msgDefs, errs := genProto3Msg(msg, msgs, protogen, cfg, pkg, nil, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 	if errs != nil {
 		return nil, errs
@@ -557,7 +513,7 @@ func genProto3MsgCode(cfg *protoMsgConfig, pkg string, msgDefs []*protoMsg, path
 	}, nil
 }
 
-// genProto3Msg takes an input Directory which describes a container or list entry
+// genProto3Msg takes an input IR directory which describes a container or list entry
 // within the YANG schema and returns a protoMsg which can be mapped to the protobuf
 // code representing it. It uses the set of messages that have been extracted and the
 // current generator state to map to other messages and ensure uniqueness of names.
@@ -565,7 +521,7 @@ func genProto3MsgCode(cfg *protoMsgConfig, pkg string, msgDefs []*protoMsg, path
 // as a protoMsgConfig struct. The parentPkg argument specifies the name of the parent
 // package for the protobuf message(s) that are being generated, such that relative
 // paths can be used in the messages.
-func genProto3Msg(msg *Directory, msgs map[string]*Directory, protogen *protoGenState, cfg *protoMsgConfig, parentPkg string, childMsgs []*generatedProto3Message, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) ([]*protoMsg, util.Errors) {
+func genProto3Msg(msg *ParsedDirectory, msgs map[string]*ParsedDirectory, protogen *protoGenState, cfg *protoMsgConfig, parentPkg string, childMsgs []*generatedProto3Message, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) ([]*protoMsg, util.Errors) {
 	var errs util.Errors
 
 	var msgDefs []*protoMsg
@@ -582,31 +538,23 @@ func genProto3Msg(msg *Directory, msgs map[string]*Directory, protogen *protoGen
 	definedFieldNames := map[string]bool{}
 	imports := map[string]interface{}{}
 
-	var fNames []string
-	for name := range msg.Fields {
-		fNames = append(fNames, name)
-	}
-	sort.Strings(fNames)
-
 	skipFields := map[string]bool{}
-	if util.IsKeyedList(msg.Entry) {
-		skipFields = util.ListKeyFieldsMap(msg.Entry)
+	for _, k := range msg.ListKeyYANGNames {
+		skipFields[k] = true
 	}
-	for _, name := range fNames {
+	for _, field := range msg.yangOrderedFields() {
 		// Skip fields that we are explicitly not asked to include.
-		if _, ok := skipFields[name]; ok {
+		if _, ok := skipFields[field.YANGDetails.Name]; ok {
 			continue
 		}
 
-		field := msg.Fields[name]
-
 		fieldDef := &protoMsgField{
-			Name: genutil.MakeNameUnique(safeProtoIdentifierName(name), definedFieldNames),
+			Name: genutil.MakeNameUnique(field.Name, definedFieldNames),
 		}
 
-		t, err := protoTagForEntry(field)
+		t, err := fieldTag(field.YANGDetails.SchemaPath)
 		if err != nil {
-			errs = append(errs, fmt.Errorf("proto: could not generate tag for field %s: %v", field.Name, err))
+			errs = append(errs, fmt.Errorf("proto: could not generate tag for field %s: %v", field.YANGDetails.Name, err))
 			continue
 		}
 		fieldDef.Tag = t
@@ -620,8 +568,8 @@ func genProto3Msg(msg *Directory, msgs map[string]*Directory, protogen *protoGen
 			cfg:                cfg,
 			parentPkg:          parentPkg,
 		}
-		switch {
-		case field.IsList():
+		switch field.Type {
+		case ListNode:
 			keyMsg, listImports, listErrs := addProtoListField(fieldDef, msgDef, defArgs, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 			if listErrs != nil {
 				errs = append(errs, listErrs...)
@@ -631,14 +579,14 @@ func genProto3Msg(msg *Directory, msgs map[string]*Directory, protogen *protoGen
 			if keyMsg != nil {
 				msgDefs = append(msgDefs, keyMsg)
 			}
-		case field.IsContainer():
+		case DirectoryNode:
 			cImports, err := addProtoContainerField(fieldDef, defArgs)
 			if err != nil {
 				errs = append(errs, err)
 				continue
 			}
 			addNewKeys(imports, cImports)
-		case field.IsLeaf() || field.IsLeafList():
+		case LeafNode, LeafListNode:
 			repeatedMsg, lImports, lErrs := addProtoLeafOrLeafListField(fieldDef, msgDef, defArgs, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 			if lErrs != nil {
 				errs = append(errs, lErrs...)
@@ -648,20 +596,15 @@ func genProto3Msg(msg *Directory, msgs map[string]*Directory, protogen *protoGen
 			if repeatedMsg != nil {
 				msgDefs = append(msgDefs, repeatedMsg)
 			}
-		case util.IsAnydata(field):
+		case AnyDataNode:
 			fieldDef.Type = protoAnyType
 			imports[protoAnyPackage] = true
 		default:
-			err = fmt.Errorf("proto: unknown field type in message %s, field %s", msg.Name, field.Name)
+			err = fmt.Errorf("proto: unknown field type in message %s, field %s", msg.Name, field.YANGDetails.Name)
 		}
 
 		if cfg.annotateSchemaPaths {
-			o, err := protoSchemaPathAnnotation(msg, name, cfg.compressPaths)
-			if err != nil {
-				errs = append(errs, err)
-				continue
-			}
-			fieldDef.Options = append(fieldDef.Options, o)
+			fieldDef.Options = append(fieldDef.Options, protoSchemaPathAnnotation(field))
 		}
 
 		if err != nil {
@@ -678,11 +621,11 @@ func genProto3Msg(msg *Directory, msgs map[string]*Directory, protogen *protoGen
 
 // protoDefinitionArgs is used as the input argument when YANG is being mapped to protobuf.
 type protoDefinitionArgs struct {
-	field              *yang.Entry           // field is the yang.Entry for which the proto output is being defined, in the case that the definition is for an individual entry.
-	directory          *Directory            // directory is the Directory for which the proto output is being defined, in the case that the definition is for an directory entry.
-	definedDirectories map[string]*Directory // definedDirectories specifies the set of Directories that have been defined in the current code generation context.
-	definedFieldNames  map[string]bool       // definedFieldNames specifies the field names that have been defined in the context.
-	protogen           *protoGenState        // protogen is the current generator state.
+	field              *NodeDetails                // field is the IR node for which the proto output is being defined, in the case that the definition is for an individual entry.
+	directory          *ParsedDirectory            // directory is the IR directory for which the proto output is being defined, in the case that the definition is for an directory entry.
+	definedDirectories map[string]*ParsedDirectory // definedDirectories specifies the set of IR directories that have been defined in the current code generation context.
+	definedFieldNames  map[string]bool             // definedFieldNames specifies the field names that have been defined in the context.
+	protogen           *protoGenState              // protogen is the current generator state.
 	cfg                *protoMsgConfig
 	parentPkg          string // parentPackage stores the name of the protobuf package that the field's parent is within.
 }
@@ -695,7 +638,7 @@ type protoDefinitionArgs struct {
 func addProtoListField(fieldDef *protoMsgField, msgDef *protoMsg, args *protoDefinitionArgs, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (*protoMsg, []string, util.Errors) {
 	listDef, keyMsg, err := protoListDefinition(args, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 	if err != nil {
-		return nil, nil, []error{fmt.Errorf("could not define list %s: %v", args.field.Path(), err)}
+		return nil, nil, []error{fmt.Errorf("could not define list %s: %v", args.field.YANGDetails.SchemaPath, err)}
 	}
 
 	var nKeyMsg *protoMsg
@@ -725,16 +668,16 @@ func addProtoListField(fieldDef *protoMsgField, msgDef *protoMsg, args *protoDef
 // a YANG schema) to contain the definition of the field described by the args. It returns a slice of strings containing
 // the protobuf package imports that are required for the container definition.
 func addProtoContainerField(fieldDef *protoMsgField, args *protoDefinitionArgs) ([]string, error) {
-	childmsg, ok := args.definedDirectories[args.field.Path()]
+	childmsg, ok := args.definedDirectories[args.field.YANGDetails.SchemaPath]
 	if !ok {
-		return nil, fmt.Errorf("proto: could not resolve %s into a defined struct", args.field.Path())
+		return nil, fmt.Errorf("proto: could not resolve %s into a defined struct", args.field.YANGDetails.SchemaPath)
 	}
 
 	imports := map[string]interface{}{}
 
 	var pfx string
 	if !(args.cfg.compressPaths && args.directory.IsFakeRoot) {
-		childpkg := args.protogen.protobufPackage(childmsg.Entry, args.cfg.compressPaths)
+		childpkg := childmsg.PackageName
 		// Add the import to the slice of imports if it is not already
 		// there. This allows the message file to import the required
 		// child packages.
@@ -765,7 +708,7 @@ func addProtoLeafOrLeafListField(fieldDef *protoMsgField, msgDef *protoMsg, args
 
 	d, err := protoLeafDefinition(fieldDef.Name, args, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 	if err != nil {
-		return nil, nil, []error{fmt.Errorf("could not define field %s: %v", args.field.Path(), err)}
+		return nil, nil, []error{fmt.Errorf("could not define field %s: %v", args.field.YANGDetails.SchemaPath, err)}
 	}
 
 	fieldDef.Type = d.protoType
@@ -800,7 +743,7 @@ func addProtoLeafOrLeafListField(fieldDef *protoMsgField, msgDef *protoMsg, args
 		imports = append(imports, importPath(args.cfg.baseImportPath, args.cfg.basePackageName, args.cfg.enumPackageName))
 	}
 
-	if args.field.ListAttr != nil {
+	if args.field.Type == LeafListNode {
 		fieldDef.IsRepeated = true
 	}
 	return repeatedMsg, imports, nil
@@ -857,7 +800,7 @@ func writeProtoEnums(enums map[string]*yangEnum, annotateEnumNames bool) ([]stri
 			p.ValuePrefix = strings.ToUpper(enum.name)
 			p.Description = fmt.Sprintf("YANG identity %s", enum.entry.Type.IdentityBase.Name)
 		case enum.entry.Type.Kind == yang.Yenum:
-			ge, err := genProtoEnum(enum.entry, annotateEnumNames)
+			ge, err := genProtoEnum(enum.entry.Path(), enum.entry.Type, enum.entry.DefaultValue(), annotateEnumNames)
 			if err != nil {
 				errs = append(errs, err)
 				continue
@@ -901,32 +844,32 @@ func writeProtoEnums(enums map[string]*yangEnum, annotateEnumNames bool) ([]stri
 	return genEnums, nil
 }
 
-// genProtoEnum takes an input yang.Entry that contains an enumerated type
-// and returns a protoMsgEnum that contains its definition within the proto
-// schema. If the annotateEnumNames bool is set, then the original YANG name
-// is stored with each enum value.
-func genProtoEnum(field *yang.Entry, annotateEnumNames bool) (*protoMsgEnum, error) {
+// genProtoEnum takes the schema path of a leaf, its enumerated type t, and
+// its default value, and returns a protoMsgEnum that contains the definition
+// of the enumerated type within the proto schema. If the annotateEnumNames
+// bool is set, then the original YANG name is stored with each enum value.
+func genProtoEnum(path string, t *yang.YangType, defaultValue string, annotateEnumNames bool) (*protoMsgEnum, error) {
 	eval := map[int64]protoEnumValue{}
-	names := field.Type.Enum.NameMap()
+	names := t.Enum.NameMap()
 	eval[0] = protoEnumValue{ProtoLabel: protoEnumZeroName}
 
-	if d := field.DefaultValue(); d != "" {
+	if d := defaultValue; d != "" {
 		if _, ok := names[d]; !ok {
-			return nil, fmt.Errorf("enumeration %s specified a default - %s - that was not a valid value", field.Path(), d)
+			return nil, fmt.Errorf("enumeration %s specified a default - %s - that was not a valid value", path, d)
 		}
 
 		eval[0] = toProtoEnumValue(safeProtoIdentifierName(d), d, annotateEnumNames)
 	}
 
 	for n := range names {
-		if n == field.DefaultValue() {
+		if n == defaultValue {
 			// Can't happen if there was not a default, since "" is not
 			// a valid enumeration name in YANG.
 			continue
 		}
 		// Names are converted to upper case to follow the protobuf style guide,
 		// adding one to ensure that the 0 value can represent unused values.
-		eval[field.Type.Enum.Value(n)+1] = toProtoEnumValue(safeProtoIdentifierName(n), n, annotateEnumNames)
+		eval[t.Enum.Value(n)+1] = toProtoEnumValue(safeProtoIdentifierName(n), n, annotateEnumNames)
 	}
 
 	return &protoMsgEnum{Values: eval}, nil
@@ -938,25 +881,21 @@ type protoMsgListField struct {
 	imports  []string // imports is the set of modules that are required by this list message.
 }
 
-// protoListDefinition takes an input field described by a yang.Entry, the generator context (the set of proto messages, and the generator
+// protoListDefinition takes an input field described by an IR node, the generator context (the set of proto messages, and the generator
 // state), along with whether path compression is enabled and generates the proto message definition for the list. It returns the definition
 // of the field representing the list as a protoMsgListField and an optional message which stores the key of a keyed list.
 func protoListDefinition(args *protoDefinitionArgs, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (*protoMsgListField, *protoMsg, error) {
-	listMsg, ok := args.definedDirectories[args.field.Path()]
+	listMsg, ok := args.definedDirectories[args.field.YANGDetails.SchemaPath]
 	if !ok {
-		return nil, nil, fmt.Errorf("proto: could not resolve list %s into a defined message", args.field.Path())
+		return nil, nil, fmt.Errorf("proto: could not resolve list %s into a defined message", args.field.YANGDetails.SchemaPath)
 	}
+	listMsgName := listMsg.Name
 
-	listMsgName, ok := args.protogen.uniqueDirectoryNames[args.field.Path()]
-	if !ok {
-		return nil, nil, fmt.Errorf("proto: could not find unique message name for %s", args.field.Path())
-	}
-
-	childPkg := args.protogen.protobufPackage(listMsg.Entry, args.cfg.compressPaths)
+	childPkg := listMsg.PackageName
 
 	var listKeyMsg *protoMsg
 	var listDef *protoMsgListField
-	if !util.IsKeyedList(listMsg.Entry) {
+	if len(listMsg.ListKeyYANGNames) == 0 {
 		// In proto3 we represent unkeyed lists as a
 		// repeated field of the list message.
 		listDef = &protoMsgListField{
@@ -982,7 +921,7 @@ func protoListDefinition(args *protoDefinitionArgs, useDefiningModuleForTypedefE
 			parentPkg: args.parentPkg,
 		}, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 		if err != nil {
-			return nil, nil, fmt.Errorf("proto: could not build mapping for list entry %s: %v", args.field.Path(), err)
+			return nil, nil, fmt.Errorf("proto: could not build mapping for list entry %s: %v", args.field.YANGDetails.SchemaPath, err)
 		}
 		// The type of this field is just the key message's name, since it
 		// will be in the same package as the field's parent.
@@ -1007,16 +946,12 @@ type protoDefinedLeaf struct {
 // for the leaf definition, and returns a protoDefinedLeaf describing how it is to be mapped within the
 // protobuf parent message.
 func protoLeafDefinition(leafName string, args *protoDefinitionArgs, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (*protoDefinedLeaf, error) {
-	protoType, err := args.protogen.yangTypeToProtoType(resolveTypeArgs{
-		yangType:     args.field.Type,
-		contextEntry: args.field,
-	}, resolveProtoTypeArgs{
-		basePackageName: args.cfg.basePackageName,
-		enumPackageName: args.cfg.enumPackageName,
-	}, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
-	if err != nil {
-		return nil, err
+	// The protobuf type of the leaf has been resolved by the IR.
+	protoType := args.field.LangType
+	if protoType == nil {
+		return nil, fmt.Errorf("no type was mapped for leaf %s", args.field.YANGDetails.SchemaPath)
 	}
+	yangType := args.field.YANGDetails.Type
 
 	d := &protoDefinedLeaf{
 		protoType: protoType.NativeType,
@@ -1024,10 +959,10 @@ func protoLeafDefinition(leafName string, args *protoDefinitionArgs, useDefining
 	}
 
 	switch {
-	case util.IsSimpleEnumerationType(args.field.Type):
+	case util.IsSimpleEnumerationType(yangType):
 		// For fields that are simple enumerations within a message, then we embed an enumeration
 		// within the Protobuf message.
-		e, err := genProtoEnum(args.field, args.cfg.annotateEnumNames)
+		e, err := genProtoEnum(args.field.YANGDetails.SchemaPath, yangType, args.field.YANGDetails.Default, args.cfg.annotateEnumNames)
 		if err != nil {
 			return nil, err
 		}
@@ -1035,10 +970,10 @@ func protoLeafDefinition(leafName string, args *protoDefinitionArgs, useDefining
 		d.protoType = genutil.MakeNameUnique(protoType.NativeType, args.definedFieldNames)
 		d.enums = map[string]*protoMsgEnum{}
 		d.enums[d.protoType] = e
-	case util.IsEnumeratedType(args.field.Type):
+	case util.IsEnumeratedType(yangType):
 		d.globalEnum = true
 	case protoType.UnionTypes != nil:
-		u, err := unionFieldToOneOf(leafName, args.field, protoType, args.cfg.annotateEnumNames, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
+		u, err := unionFieldToOneOf(leafName, &args.field.YANGDetails, args.field.Type == LeafListNode, protoType, args.cfg.annotateEnumNames, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 		if err != nil {
 			return nil, err
 		}
@@ -1089,11 +1024,6 @@ func safeProtoIdentifierName(name string) string {
 	return disallowedInProtoIDRegexp.ReplaceAllLiteralString(name, "_")
 }
 
-// protoTagForEntry returns a protobuf tag value for the entry e.
-func protoTagForEntry(e *yang.Entry) (uint32, error) {
-	return fieldTag(e.Path())
-}
-
 // fieldTag takes an input string and calculates a FNV hash for the value. If the
 // hash is in the range 19,000-19,999 or 1-1,000, the input string has _ appended to
 // it and the hash is calculated.
@@ -1111,14 +1041,14 @@ func fieldTag(s string) (uint32, error) {
 }
 
 // genListKeyProto generates a protoMsg that describes the proto3 message that represents
-// the key of a list for YANG lists. It takes a Directory pointer to the list being
-// described, the name of the list, the package name that the list is within, and the
+// the key of a list for YANG lists. It takes the IR node and directory of the list being
+// described within the args, the name of the list, the package name that the list is within, and the
 // current generator state. It returns the definition of the list key proto.
 func genListKeyProto(listPackage string, listName string, args *protoDefinitionArgs, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (*protoMsg, error) {
 	n := fmt.Sprintf("%s%s", listName, protoListKeyMessageSuffix)
 	km := &protoMsg{
 		Name:     n,
-		YANGPath: args.field.Path(),
+		YANGPath: args.field.YANGDetails.SchemaPath,
 		Enums:    map[string]*protoMsgEnum{},
 	}
 
@@ -1128,45 +1058,30 @@ func genListKeyProto(listPackage string, listName string, args *protoDefinitionA
 
 	definedFieldNames := map[string]bool{}
 	ctag := uint32(1)
-	for _, k := range strings.Fields(args.field.Key) {
-		kf, ok := args.directory.Fields[k]
-		if !ok {
-			return nil, fmt.Errorf("list %s included a key %s that did not exist", args.field.Path(), k)
+	listPath := args.field.YANGDetails.SchemaPath
+	for _, k := range args.directory.ListKeyYANGNames {
+		kn := args.directory.fieldByYANGName(k)
+		if kn == nil {
+			return nil, fmt.Errorf("list %s included a key %s that did not exist", listPath, k)
 		}
+		kf := &kn.YANGDetails
 
-		scalarType, err := args.protogen.yangTypeToProtoScalarType(resolveTypeArgs{
-			yangType:     kf.Type,
-			contextEntry: kf,
-		}, resolveProtoTypeArgs{
-			basePackageName: args.cfg.basePackageName,
-			enumPackageName: args.cfg.enumPackageName,
-			// When there is a union within a list key that has a single type within it
-			// e.g.,:
-			// list foo {
-			//   key "bar";
-			//   leaf bar {
-			//     type union {
-			//       type string { pattern "a.*" }
-			//			 type string { pattern "b.*" }
-			//     }
-			//   }
-			// }
-			// Then we want to use the scalar type rather than the wrapper type in
-			// this message since all keys must be set. We therefore signal this in
-			// the call to the type resolution.
-			scalarTypeInSingleTypeUnion: true,
-		}, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
-		if err != nil {
-			return nil, fmt.Errorf("list %s included a key %s that did not have a valid proto type: %v", args.field.Path(), k, kf.Type)
+		// The IR resolves the types of list keys to scalar types, rather than
+		// wrapper types, since all keys must be set. This is also the case when
+		// there is a union within a list key that has a single type within it.
+		scalarType, ok := args.directory.ListAttr.Keys[k]
+		if !ok {
+			return nil, fmt.Errorf("list %s included a key %s that did not have a valid proto type: %v", listPath, k, kf.Type)
 		}
 
-		var enumEntry *yang.Entry
-		var unionEntry *yang.Entry
+		var enumEntry *YANGNodeDetails
+		var unionEntry *YANGNodeDetails
 		switch {
 		case kf.Type.Kind == yang.Yleafref:
-			target, err := args.protogen.schematree.resolveLeafrefTarget(kf.Type.Path, kf)
-			if err != nil {
-				return nil, fmt.Errorf("error generating type for list %s key %s: type %v", args.field.Path(), k, kf.Type)
+			// The target of the leafref has been resolved by the IR.
+			target := kf.LeafrefTarget
+			if target == nil {
+				return nil, fmt.Errorf("error generating type for list %s key %s: type %v", listPath, k, kf.Type)
 			}
 
 			if util.IsSimpleEnumerationType(target.Type) {
@@ -1177,7 +1092,7 @@ func genListKeyProto(listPackage string, listName string, args *protoDefinitionA
 				unionEntry = target
 			}
 
-			if util.IsIdentityrefLeaf(target) {
+			if target.Type.IdentityBase != nil {
 				km.Imports = append(km.Imports, importPath(args.cfg.baseImportPath, args.cfg.basePackageName, args.cfg.enumPackageName))
 			}
 		case util.IsSimpleEnumerationType(kf.Type):
@@ -1190,7 +1105,7 @@ func genListKeyProto(listPackage string, listName string, args *protoDefinitionA
 		// matches the key field name by appending the protoMatchingListNameKeySuffix
 		// to the field name, as described in the definition of protoMatchingListNameKeySuffix.
 		fName := genutil.MakeNameUnique(safeProtoIdentifierName(k), definedFieldNames)
-		if args.field.Name == k {
+		if args.field.YANGDetails.Name == k {
 			fName = fmt.Sprintf("%s_%s", fName, protoMatchingListNameKeySuffix)
 		}
 
@@ -1200,18 +1115,18 @@ func genListKeyProto(listPackage string, listName string, args *protoDefinitionA
 		}
 		switch {
 		case enumEntry != nil:
-			enum, err := genProtoEnum(enumEntry, args.cfg.annotateEnumNames)
+			enum, err := genProtoEnum(enumEntry.SchemaPath, enumEntry.Type, enumEntry.Default, args.cfg.annotateEnumNames)
 			if err != nil {
-				return nil, fmt.Errorf("error generating type for list %s key %s, type %v", args.field.Path(), k, enumEntry.Type)
+				return nil, fmt.Errorf("error generating type for list %s key %s, type %v", listPath, k, enumEntry.Type)
 			}
 			tn := genutil.MakeNameUnique(scalarType.NativeType, definedFieldNames)
 			fd.Type = tn
 			km.Enums[tn] = enum
 		case unionEntry != nil:
 			fd.IsOneOf = true
-			u, err := unionFieldToOneOf(fd.Name, unionEntry, scalarType, args.cfg.annotateEnumNames, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
+			u, err := unionFieldToOneOf(fd.Name, unionEntry, false, scalarType, args.cfg.annotateEnumNames, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 			if err != nil {
-				return nil, fmt.Errorf("error generating type for union list key %s in list %s", k, args.field.Path())
+				return nil, fmt.Errorf("error generating type for union list key %s in list %s", k, listPath)
 			}
 			fd.OneOfFields = append(fd.OneOfFields, u.oneOfFields...)
 			for n, e := range u.enums {
@@ -1225,11 +1140,7 @@ func genListKeyProto(listPackage string, listName string, args *protoDefinitionA
 		}
 
 		if args.cfg.annotateSchemaPaths {
-			o, err := protoSchemaPathAnnotation(args.directory, k, args.cfg.compressPaths)
-			if err != nil {
-				return nil, err
-			}
-			fd.Options = append(fd.Options, o)
+			fd.Options = append(fd.Options, protoSchemaPathAnnotation(kn))
 		}
 
 		km.Fields = append(km.Fields, fd)
@@ -1250,7 +1161,7 @@ func genListKeyProto(listPackage string, listName string, args *protoDefinitionA
 	}
 
 	km.Fields = append(km.Fields, &protoMsgField{
-		Name: safeProtoIdentifierName(args.field.Name),
+		Name: safeProtoIdentifierName(args.field.YANGDetails.Name),
 		Type: ltype,
 		Tag:  ctag,
 	})
@@ -1258,14 +1169,14 @@ func genListKeyProto(listPackage string, listName string, args *protoDefinitionA
 	return km, nil
 }
 
-// enumInProtoUnionField parses an enum that is within a union and returns the generated
-// enumeration that should be included within a protobuf message for it. If annotateEnumNames
-// is set to true, the enumerated value's original names are stored.
-func enumInProtoUnionField(name string, args resolveTypeArgs, annotateEnumNames, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (map[string]*protoMsgEnum, error) {
+// enumInProtoUnionField parses an enum that is within the union type ut of the leaf n and returns
+// the generated enumeration that should be included within a protobuf message for it. If
+// annotateEnumNames is set to true, the enumerated value's original names are stored.
+func enumInProtoUnionField(name string, ut *yang.YangType, n *YANGNodeDetails, annotateEnumNames, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (map[string]*protoMsgEnum, error) {
 	enums := map[string]*protoMsgEnum{}
-	for _, t := range args.yangType.Type {
+	for _, t := range ut.Type {
 		if util.IsSimpleEnumerationType(t) {
-			definingType, err := util.DefiningType(t, args.contextEntry.Type)
+			definingType, err := util.DefiningType(t, n.Type)
 			if err != nil {
 				return nil, err
 			}
@@ -1275,22 +1186,19 @@ func enumInProtoUnionField(name string, args resolveTypeArgs, annotateEnumNames,
 				// version, and instead use the global version.
 				continue
 			}
-			mappedType, err := yangEnumTypeToProtoType(resolveTypeArgs{yangType: t, contextEntry: args.contextEntry}, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
+			typeName, err := protoEnumTypeName(t, n.Name, n.Type, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 			if err != nil {
 				return nil, err
 			}
-			enum, err := genProtoEnum(&yang.Entry{
-				Name: mappedType.NativeType,
-				Type: t,
-			}, annotateEnumNames)
+			enum, err := genProtoEnum(n.SchemaPath, t, "", annotateEnumNames)
 			if err != nil {
 				return nil, err
 			}
-			enums[mappedType.NativeType] = enum
+			enums[typeName] = enum
 		}
 
 		if util.IsUnionType(t) {
-			es, err := enumInProtoUnionField(name, resolveTypeArgs{yangType: t, contextEntry: args.contextEntry}, annotateEnumNames, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
+			es, err := enumInProtoUnionField(name, t, n, annotateEnumNames, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 			if err != nil {
 				return nil, err
 			}
@@ -1312,12 +1220,12 @@ type protoUnionField struct {
 	hadGlobalEnums bool                     // hadGlobalEnums determines whether there was a global scope enum (typedef, identityref) in the message.
 }
 
-// unionFieldToOneOf takes an input name, a yang.Entry containing a field definition and a MappedType
-// containing the proto type that the entry has been mapped to, and returns a definition of a union
-// field within the protobuf message. If the annotateEnumNames boolean is set, then any enumerated types
-// within the union have their original names within the YANG schema appended.
-func unionFieldToOneOf(fieldName string, e *yang.Entry, mtype *MappedType, annotateEnumNames, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (*protoUnionField, error) {
-	enums, err := enumInProtoUnionField(fieldName, resolveTypeArgs{yangType: e.Type, contextEntry: e}, annotateEnumNames, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
+// unionFieldToOneOf takes an input name, the YANG details of a leaf, whether the leaf is a leaf-list,
+// and a MappedType containing the proto type that the leaf has been mapped to, and returns a definition
+// of a union field within the protobuf message. If the annotateEnumNames boolean is set, then any
+// enumerated types within the union have their original names within the YANG schema appended.
+func unionFieldToOneOf(fieldName string, e *YANGNodeDetails, isLeafList bool, mtype *MappedType, annotateEnumNames, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums bool) (*protoUnionField, error) {
+	enums, err := enumInProtoUnionField(fieldName, e.Type, e, annotateEnumNames, useDefiningModuleForTypedefEnumNames, useConsistentNamesForProtoUnionEnums)
 	if err != nil {
 		return nil, err
 	}
@@ -1344,9 +1252,9 @@ func unionFieldToOneOf(fieldName string, e *yang.Entry, mtype *MappedType, annot
 		// such that we have unique inputs for each option. We make the name lower-case
 		// as it is conventional that protobuf field names are lowercase separated by
 		// underscores.
-		ft, err := fieldTag(fmt.Sprintf("%s_%s", e.Path(), strings.ToLower(tn)))
+		ft, err := fieldTag(fmt.Sprintf("%s_%s", e.SchemaPath, strings.ToLower(tn)))
 		if err != nil {
-			return nil, fmt.Errorf("could not calculate tag number for %s, type %s in oneof", e.Path(), tn)
+			return nil, fmt.Errorf("could not calculate tag number for %s, type %s in oneof", e.SchemaPath, tn)
 		}
 		st := &protoMsgField{
 			Name: fmt.Sprintf("%s_%s", fieldName, strings.ToLower(tn)),
@@ -1356,13 +1264,13 @@ func unionFieldToOneOf(fieldName string, e *yang.Entry, mtype *MappedType, annot
 		oofs = append(oofs, st)
 	}
 
-	if e.IsLeafList() {
+	if isLeafList {
 		// In this case, we cannot return a oneof, since it is not possible to have a repeated
 		// oneof, therefore we return a message that contains the protoMsgFields that are defined
 		// above.
 		p := &protoMsg{
 			Name:     fmt.Sprintf("%sUnion", yang.CamelCase(fieldName)),
-			YANGPath: fmt.Sprintf("%s union field %s", e.Path(), e.Name),
+			YANGPath: fmt.Sprintf("%s union field %s", e.SchemaPath, e.Name),
 			Fields:   oofs,
 		}
 
@@ -1391,14 +1299,11 @@ func protoPackageToFilePath(pkg string) []string {
 	return append(pp, fmt.Sprintf("%s.proto", pp[len(pp)-1]))
 }
 
-// protoSchemaPathAnnotation takes a protobuf message and field, and returns the protobuf
+// protoSchemaPathAnnotation takes a field of a protobuf message, and returns the protobuf
 // field option definitions required to annotate it with its schema path(s).
-func protoSchemaPathAnnotation(msg *Directory, fieldName string, compressPaths bool) (*protoOption, error) {
-	// protobuf paths are always absolute.
-	smapp, _, err := findMapPaths(msg, fieldName, compressPaths, false, true)
-	if err != nil {
-		return nil, err
-	}
+func protoSchemaPathAnnotation(field *NodeDetails) *protoOption {
+	// protobuf paths are always absolute, and are mapped as such by the IR.
+	smapp := field.MapPaths
 	var b bytes.Buffer
 	b.WriteRune('"')
 	for i, p := range smapp {
@@ -1408,7 +1313,7 @@ func protoSchemaPathAnnotation(msg *Directory, fieldName string, compressPaths b
 		}
 	}
 	b.WriteRune('"')
-	return &protoOption{Name: protoSchemaAnnotationOption, Value: b.String()}, nil
+	return &protoOption{Name: protoSchemaAnnotationOption, Value: b.String()}
 }
 
 // stripPackagePrefix removes the prefix of pfx from the path supplied. If pfx
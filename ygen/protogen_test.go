@@ -24,6 +24,23 @@ import (
 	"github.com/openconfig/ygot/testutil"
 )
 
+// protoParsedDirectories maps the supplied message, and the set of messages
+// that it may reference, to their IR representation using the protobuf
+// LangMapper. The names of the referenced messages are taken from the unique
+// directory names stored in s.
+func protoParsedDirectories(msg *Directory, msgs map[string]*Directory, s *protoGenState, basePackageName, enumPackageName string, compressPaths, annotateSchemaPaths bool) (*ParsedDirectory, map[string]*ParsedDirectory, []error) {
+	lm := &protoLangMapper{
+		protoGenState:                        s,
+		basePackageName:                      basePackageName,
+		enumPackageName:                      enumPackageName,
+		useDefiningModuleForTypedefEnumNames: true,
+		useConsistentNamesForProtoUnionEnums: true,
+	}
+	// Schema paths are only resolved when annotations are requested, since
+	// the fixtures used for other tests do not describe their modules.
+	return parsedDirectoryFixtures(msg, msgs, lm, s.schematree, s.uniqueDirectoryNames, compressPaths, true, annotateSchemaPaths)
+}
+
 func protoMsgEq(a, b *protoMsg) bool {
 	if a.Name != b.Name {
 		return false
@@ -690,13 +707,17 @@ func TestGenProto3Msg(t *testing.T) {
 			// Seed the state with the supplied message names that have been provided.
 			s.uniqueDirectoryNames = tt.inUniqueDirectoryNames
 
-			gotMsgs, errs := genProto3Msg(tt.inMsg, tt.inMsgs, s, &protoMsgConfig{
-				compressPaths:       tt.inCompressPaths,
-				basePackageName:     tt.inBasePackage,
-				enumPackageName:     tt.inEnumPackage,
-				baseImportPath:      tt.inBaseImportPath,
-				annotateSchemaPaths: tt.inAnnotateSchemaPaths,
-			}, tt.inParentPackage, tt.inChildMsgs, true, true)
+			inMsg, inMsgs, errs := protoParsedDirectories(tt.inMsg, tt.inMsgs, s, tt.inBasePackage, tt.inEnumPackage, tt.inCompressPaths, tt.inAnnotateSchemaPaths)
+			var gotMsgs []*protoMsg
+			if errs == nil {
+				gotMsgs, errs = genProto3Msg(inMsg, inMsgs, s, &protoMsgConfig{
+					compressPaths:       tt.inCompressPaths,
+					basePackageName:     tt.inBasePackage,
+					enumPackageName:     tt.inEnumPackage,
+					baseImportPath:      tt.inBaseImportPath,
+					annotateSchemaPaths: tt.inAnnotateSchemaPaths,
+				}, tt.inParentPackage, tt.inChildMsgs, true, true)
+			}
 
 			if (errs != nil) != tt.wantErr {
 				t.Errorf("s: genProtoMsg(%#v, %#v, *genState, %v, %v, %s, %s): did not get expected error status, got: %v, wanted err: %v", tt.name, tt.inMsg, tt.inMsgs, tt.inCompressPaths, tt.inBasePackage, tt.inEnumPackage, errs, tt.wantErr)
@@ -1248,6 +1269,8 @@ message AMessage {
 			PackageName: "",
 			MessageCode: `
 message AMessage {
+  message List {
+  }
   message ListKey {
     string keyfield = 1;
     List list = 2;
@@ -1259,6 +1282,8 @@ message AMessage {
 			PackageName: "module",
 			MessageCode: `
 message AMessage {
+  message List {
+  }
   message ListKey {
     string keyfield = 1;
     List list = 2;
@@ -1353,6 +1378,9 @@ message AMessage {
 			PackageName: "",
 			MessageCode: `
 message AMessage {
+  message List {
+    ywrapper.StringValue keyfield = 411968747;
+  }
   repeated List list = 486198550;
 }`,
 		},
@@ -1360,6 +1388,9 @@ message AMessage {
 			PackageName: "module",
 			MessageCode: `
 message AMessage {
+  message List {
+    ywrapper.StringValue keyfield = 411968747;
+  }
   repeated List list = 486198550;
 }`,
 		},
@@ -1450,13 +1481,17 @@ message MessageName {
 				// Seed the message names with the supplied input.
 				s.uniqueDirectoryNames = tt.inUniqueDirectoryNames
 
-				got, errs := writeProto3Msg(tt.inMsg, tt.inMsgs, s, &protoMsgConfig{
-					compressPaths:   compress,
-					basePackageName: tt.inBasePackageName,
-					enumPackageName: tt.inEnumPackageName,
-					baseImportPath:  tt.inBaseImportPath,
-					nestedMessages:  tt.inNestedMessages,
-				}, true, true)
+				inMsg, inMsgs, errs := protoParsedDirectories(tt.inMsg, tt.inMsgs, s, tt.inBasePackageName, tt.inEnumPackageName, compress, false)
+				var got *generatedProto3Message
+				if errs == nil {
+					got, errs = writeProto3Msg(inMsg, inMsgs, s, &protoMsgConfig{
+						compressPaths:   compress,
+						basePackageName: tt.inBasePackageName,
+						enumPackageName: tt.inEnumPackageName,
+						baseImportPath:  tt.inBaseImportPath,
+						nestedMessages:  tt.inNestedMessages,
+					}, true, true)
+				}
 
 				if (errs != nil) != wantErr[compress] {
 					t.Errorf("%s: writeProto3Msg(%v, %v, %v, %v): did not get expected error return status, got: %v, wanted error: %v", tt.name, tt.inMsg, tt.inMsgs, s, compress, errs, wantErr[compress])
@@ -1498,25 +1533,38 @@ func TestGenListKeyProto(t *testing.T) {
 		inListPackage: "pkg",
 		inListName:    "list",
 		inArgs: &protoDefinitionArgs{
-			field: &yang.Entry{
-				Name:     "list",
-				Kind:     yang.DirectoryEntry,
-				ListAttr: &yang.ListAttr{},
-				Key:      "key",
-				Dir:      map[string]*yang.Entry{},
-			},
-			directory: &Directory{
-				Name: "List",
-				Fields: map[string]*yang.Entry{
+			field: &NodeDetails{
+				Name: "list",
+				YANGDetails: YANGNodeDetails{
+					Name:       "list",
+					SchemaPath: "/list",
+				},
+				Type: ListNode,
+			},
+			directory: &ParsedDirectory{
+				Name:             "List",
+				Type:             List,
+				ListKeyYANGNames: []string{"key"},
+				ListAttr: &YangListAttr{
+					Keys: map[string]*MappedType{
+						"key": {NativeType: "string"},
+					},
+				},
+				Fields: map[string]*NodeDetails{
 					"key": {
 						Name: "key",
-						Type: &yang.YangType{
-							Kind: yang.Ystring,
+						YANGDetails: YANGNodeDetails{
+							Name:       "key",
+							SchemaPath: "/key",
+							Type: &yang.YangType{
+								Kind: yang.Ystring,
+							},
 						},
+						Type: LeafNode,
 					},
 				},
 			},
-			definedDirectories: map[string]*Directory{},
+			definedDirectories: map[string]*ParsedDirectory{},
 			protogen: &protoGenState{
 				uniqueDirectoryNames: map[string]string{
 					"/list": "List",
@@ -1547,29 +1595,42 @@ func TestGenListKeyProto(t *testing.T) {
 		inListPackage: "pkg",
 		inListName:    "list",
 		inArgs: &protoDefinitionArgs{
-			field: &yang.Entry{
-				Name:     "list",
-				Kind:     yang.DirectoryEntry,
-				ListAttr: &yang.ListAttr{},
-				Key:      "key",
-				Dir:      map[string]*yang.Entry{},
-			},
-			directory: &Directory{
-				Name: "List",
-				Fields: map[string]*yang.Entry{
+			field: &NodeDetails{
+				Name: "list",
+				YANGDetails: YANGNodeDetails{
+					Name:       "list",
+					SchemaPath: "/list",
+				},
+				Type: ListNode,
+			},
+			directory: &ParsedDirectory{
+				Name:             "List",
+				Type:             List,
+				ListKeyYANGNames: []string{"key"},
+				ListAttr: &YangListAttr{
+					Keys: map[string]*MappedType{
+						"key": {UnionTypes: map[string]int{"string": 0, "sint64": 1}},
+					},
+				},
+				Fields: map[string]*NodeDetails{
 					"key": {
 						Name: "key",
-						Type: &yang.YangType{
-							Kind: yang.Yunion,
-							Type: []*yang.YangType{
-								{Kind: yang.Ystring},
-								{Kind: yang.Yint8},
+						YANGDetails: YANGNodeDetails{
+							Name:       "key",
+							SchemaPath: "/key",
+							Type: &yang.YangType{
+								Kind: yang.Yunion,
+								Type: []*yang.YangType{
+									{Kind: yang.Ystring},
+									{Kind: yang.Yint8},
+								},
 							},
 						},
+						Type: LeafNode,
 					},
 				},
 			},
-			definedDirectories: map[string]*Directory{},
+			definedDirectories: map[string]*ParsedDirectory{},
 			protogen: &protoGenState{
 				uniqueDirectoryNames: map[string]string{
 					"/list": "List",
@@ -1609,29 +1670,42 @@ func TestGenListKeyProto(t *testing.T) {
 		inListPackage: "pkg",
 		inListName:    "list",
 		inArgs: &protoDefinitionArgs{
-			field: &yang.Entry{
-				Name:     "list",
-				Kind:     yang.DirectoryEntry,
-				ListAttr: &yang.ListAttr{},
-				Key:      "key",
-				Dir:      map[string]*yang.Entry{},
-			},
-			directory: &Directory{
-				Name: "List",
-				Fields: map[string]*yang.Entry{
+			field: &NodeDetails{
+				Name: "list",
+				YANGDetails: YANGNodeDetails{
+					Name:       "list",
+					SchemaPath: "/list",
+				},
+				Type: ListNode,
+			},
+			directory: &ParsedDirectory{
+				Name:             "List",
+				Type:             List,
+				ListKeyYANGNames: []string{"key"},
+				ListAttr: &YangListAttr{
+					Keys: map[string]*MappedType{
+						"key": {NativeType: "string"},
+					},
+				},
+				Fields: map[string]*NodeDetails{
 					"key": {
 						Name: "key",
-						Type: &yang.YangType{
-							Kind: yang.Yunion,
-							Type: []*yang.YangType{
-								{Kind: yang.Ystring, POSIXPattern: []string{"^b.*$"}},
-								{Kind: yang.Ystring, POSIXPattern: []string{"^a.*$"}},
+						YANGDetails: YANGNodeDetails{
+							Name:       "key",
+							SchemaPath: "/key",
+							Type: &yang.YangType{
+								Kind: yang.Yunion,
+								Type: []*yang.YangType{
+									{Kind: yang.Ystring, POSIXPattern: []string{"^b.*$"}},
+									{Kind: yang.Ystring, POSIXPattern: []string{"^a.*$"}},
+								},
 							},
 						},
+						Type: LeafNode,
 					},
 				},
 			},
-			definedDirectories: map[string]*Directory{},
+			definedDirectories: map[string]*ParsedDirectory{},
 			protogen: &protoGenState{
 				uniqueDirectoryNames: map[string]string{
 					"/list": "List",
@@ -1824,7 +1898,8 @@ func TestUnionFieldToOneOf(t *testing.T) {
 	tests := []struct {
 		name                string
 		inName              string
-		inEntry             *yang.Entry
+		inDetails           *YANGNodeDetails
+		inIsLeafList        bool
 		inMappedType        *MappedType
 		inAnnotateEnumNames bool
 		wantFields          []*protoMsgField
@@ -1834,8 +1909,9 @@ func TestUnionFieldToOneOf(t *testing.T) {
 	}{{
 		name:   "simple string union",
 		inName: "FieldName",
-		inEntry: &yang.Entry{
-			Name: "field-name",
+		inDetails: &YANGNodeDetails{
+			Name:       "field-name",
+			SchemaPath: "/field-name",
 			Type: &yang.YangType{
 				Type: []*yang.YangType{
 					{Kind: yang.Ystring},
@@ -1862,8 +1938,9 @@ func TestUnionFieldToOneOf(t *testing.T) {
 	}, {
 		name:   "decimal64 union",
 		inName: "FieldName",
-		inEntry: &yang.Entry{
-			Name: "field-name",
+		inDetails: &YANGNodeDetails{
+			Name:       "field-name",
+			SchemaPath: "/field-name",
 			Type: &yang.YangType{
 				Type: []*yang.YangType{
 					{Kind: yang.Ystring},
@@ -1890,8 +1967,9 @@ func TestUnionFieldToOneOf(t *testing.T) {
 	}, {
 		name:   "union with an enumeration",
 		inName: "FieldName",
-		inEntry: &yang.Entry{
-			Name: "field-name",
+		inDetails: &YANGNodeDetails{
+			Name:       "field-name",
+			SchemaPath: "/field-name",
 			Type: &yang.YangType{
 				Name: "union",
 				Kind: yang.Yunion,
@@ -1933,8 +2011,9 @@ func TestUnionFieldToOneOf(t *testing.T) {
 	}, {
 		name:   "union with an enumeration, but union is typedef",
 		inName: "FieldName",
-		inEntry: &yang.Entry{
-			Name: "field-name",
+		inDetails: &YANGNodeDetails{
+			Name:       "field-name",
+			SchemaPath: "/field-name",
 			Type: &yang.YangType{
 				Name: "derived-union",
 				Kind: yang.Yunion,
@@ -1968,17 +2047,17 @@ func TestUnionFieldToOneOf(t *testing.T) {
 	}, {
 		name:   "leaflist of union",
 		inName: "FieldName",
-		inEntry: &yang.Entry{
-			Name: "field-name",
+		inDetails: &YANGNodeDetails{
+			Name:       "field-name",
+			SchemaPath: "/parent/field-name",
 			Type: &yang.YangType{
 				Type: []*yang.YangType{
 					{Kind: yang.Ystring},
 					{Kind: yang.Yuint8},
 				},
 			},
-			Parent:   &yang.Entry{Name: "parent"},
-			ListAttr: &yang.ListAttr{},
 		},
+		inIsLeafList: true,
 		inMappedType: &MappedType{
 			UnionTypes: map[string]int{
 				"string": 0,
@@ -2001,9 +2080,9 @@ func TestUnionFieldToOneOf(t *testing.T) {
 	}}
 
 	for _, tt := range tests {
-		got, err := unionFieldToOneOf(tt.inName, tt.inEntry, tt.inMappedType, tt.inAnnotateEnumNames, true, true)
+		got, err := unionFieldToOneOf(tt.inName, tt.inDetails, tt.inIsLeafList, tt.inMappedType, tt.inAnnotateEnumNames, true, true)
 		if (err != nil) != tt.wantErr {
-			t.Errorf("%s: unionFieldToOneOf(%s, %v, %v, %v): did not get expected error, got: %v, wanted err: %v", tt.name, tt.inName, tt.inEntry, tt.inMappedType, tt.inAnnotateEnumNames, err, tt.wantErr)
+			t.Errorf("%s: unionFieldToOneOf(%s, %v, %v, %v): did not get expected error, got: %v, wanted err: %v", tt.name, tt.inName, tt.inDetails, tt.inMappedType, tt.inAnnotateEnumNames, err, tt.wantErr)
 		}
 
 		if err != nil {
@@ -2011,15 +2090,15 @@ func TestUnionFieldToOneOf(t *testing.T) {
 		}
 
 		if diff := pretty.Compare(got.oneOfFields, tt.wantFields); diff != "" {
-			t.Errorf("%s: unionFieldToOneOf(%s, %v, %v, %v): did not get expected set of fields, diff(-got,+want):\n%s", tt.name, tt.inName, tt.inEntry, tt.inMappedType, tt.inAnnotateEnumNames, diff)
+			t.Errorf("%s: unionFieldToOneOf(%s, %v, %v, %v): did not get expected set of fields, diff(-got,+want):\n%s", tt.name, tt.inName, tt.inDetails, tt.inMappedType, tt.inAnnotateEnumNames, diff)
 		}
 
 		if diff := pretty.Compare(got.enums, tt.wantEnums); diff != "" {
-			t.Errorf("%s: unionFieldToOneOf(%s, %v, %v, %v): did not get expected set of enums, diff(-got,+want):\n%s", tt.name, tt.inName, tt.inEntry, tt.inMappedType, tt.inAnnotateEnumNames, diff)
+			t.Errorf("%s: unionFieldToOneOf(%s, %v, %v, %v): did not get expected set of enums, diff(-got,+want):\n%s", tt.name, tt.inName, tt.inDetails, tt.inMappedType, tt.inAnnotateEnumNames, diff)
 		}
 
 		if diff := pretty.Compare(got.repeatedMsg, tt.wantRepeatedMsg); diff != "" {
-			t.Errorf("%s: unionFieldToOneOf(%s, %v, %v, %v): did not get expected repeated message, diff(-got,+want):\n%s", tt.name, tt.inName, tt.inEntry, tt.inMappedType, tt.inAnnotateEnumNames, diff)
+			t.Errorf("%s: unionFieldToOneOf(%s, %v, %v, %v): did not get expected repeated message, diff(-got,+want):\n%s", tt.name, tt.inName, tt.inDetails, tt.inMappedType, tt.inAnnotateEnumNames, diff)
 		}
 	}
 }
@@ -43,7 +43,18 @@ func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry,
 	}
 	for _, m := range ms {
 		annotateChildren(m, dn, inclDescriptions)
-		for _, ch := range util.Children(m) {
+		for _, ch := range util.DataTreeChildren(m) {
+			if _, ex := rootEntry.Dir[ch.Name]; ex {
+				return nil, fmt.Errorf("overlapping root children for key %s", ch.Name)
+			}
+			rootEntry.Dir[ch.Name] = ch
+		}
+		// RPCs and notifications are not returned by util.DataTreeChildren, but
+		// must be stored within the schema where code was generated for them.
+		for _, ch := range m.Dir {
+			if !isMappedOperation(ch, dn) {
+				continue
+			}
 			if _, ex := rootEntry.Dir[ch.Name]; ex {
 				return nil, fmt.Errorf("overlapping root children for key %s", ch.Name)
 			}
@@ -82,7 +93,7 @@ func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry,
 // The children of e are recursively annotated.
 func annotateChildren(e *yang.Entry, dn map[string]string, inclDescriptions bool) {
 	annotateEntry(e, dn, inclDescriptions)
-	for _, ch := range util.Children(e) {
+	for _, ch := range util.DataTreeChildren(e) {
 		annotateEntry(ch, dn, inclDescriptions)
 		if ch.IsDir() {
 			ch.Annotation["schemapath"] = ch.Path()
@@ -90,6 +101,51 @@ func annotateChildren(e *yang.Entry, dn map[string]string, inclDescriptions bool
 			annotateChildren(ch, dn, inclDescriptions)
 		}
 	}
+	for _, ch := range e.Dir {
+		if isMappedOperation(ch, dn) {
+			annotateOperation(ch, dn, inclDescriptions)
+		}
+	}
+}
+
+// isMappedOperation returns true if e is a YANG notification, or a YANG rpc or
+// action whose input or output, has a name within the supplied dn map, i.e.,
+// code was generated for it.
+func isMappedOperation(e *yang.Entry, dn map[string]string) bool {
+	if util.IsNotification(e) {
+		_, ok := dn[e.Path()]
+		return ok
+	}
+	if e.RPC == nil {
+		return false
+	}
+	for _, io := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+		if io == nil {
+			continue
+		}
+		if _, ok := dn[io.Path()]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// annotateOperation annotates the YANG notification, rpc or action e using the
+// supplied dn map. For an rpc or action, its input and output and their
+// children are annotated, whereas the children of a notification are annotated
+// directly.
+func annotateOperation(e *yang.Entry, dn map[string]string, inclDescriptions bool) {
+	if util.IsNotification(e) {
+		annotateChildren(e, dn, inclDescriptions)
+		return
+	}
+	annotateEntry(e, dn, inclDescriptions)
+	for _, io := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+		if io == nil {
+			continue
+		}
+		annotateChildren(io, dn, inclDescriptions)
+	}
 }
 
 // annotateEntry modifies the yang.Entry e to:
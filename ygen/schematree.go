@@ -96,7 +96,7 @@ func (t *schemaTree) resolveLeafrefTarget(path string, contextEntry *yang.Entry)
 // schemaTreeChildrenAdd adds the children of the supplied yang.Entry to the
 // supplied ctree.Tree recursively.
 func schemaTreeChildrenAdd(t *schemaTree, e *yang.Entry) error {
-	for _, ch := range util.Children(e) {
+	for _, ch := range util.DataTreeChildren(e) {
 		chPath := strings.Split(ch.Path(), "/")
 		// chPath is of the form []string{"", "module", "entity", "child"}
 		if !ch.IsDir() {
@@ -15,14 +15,18 @@
 package ygot
 
 import (
+	"encoding/base64"
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
+	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -208,9 +212,55 @@ func getPathSpec(ni *util.NodeInfo) (*pathSpec, error) {
 // The ForEachDataField helper of the util library is used to perform the iterative
 // walk of the struct - using the out argument to store the set of changed leaves.
 // A specific Annotation is used to store the absolute path of the entity during
-// the walk.
+// the walk. Where a schema is required by the supplied options, the schema entry
+// corresponding to each node is also stored as an Annotation.
+//
+// Leaves that are filtered by the DiffOpts supplied (e.g., those that do not
+// match the IncludePaths, or that are not configuration when IgnoreState is
+// specified) are not included in the returned map.
 func findSetLeaves(s GoStruct, opts ...DiffOpt) (map[*pathSpec]interface{}, error) {
+	sl, err := findOrderedSetLeaves(s, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return sl.values, nil
+}
+
+// setLeaves stores the leaves that are set within a GoStruct, along with the
+// information that is required to preserve the order of the entries of YANG
+// lists that are ordered-by user (represented by ordered maps).
+type setLeaves struct {
+	// values is the value of each set leaf, keyed by its path.
+	values map[*pathSpec]interface{}
+	// index is the position of each set leaf in the walk of the GoStruct.
+	index map[*pathSpec]int
+	// entries is the set of paths of the ordered list entries within which
+	// each set leaf is contained.
+	entries map[*pathSpec][]orderedEntryPath
+	// orderedLists stores the paths of the entries of each ordered list in
+	// order, keyed by the path of the list.
+	orderedLists map[string][]orderedEntryPath
+}
+
+// prunedSubtree is stored as an Annotation of the nodes of a GoStruct that
+// are within a subtree which is not walked when finding set leaves.
+type prunedSubtree struct{}
+
+// orderedEntryPath is the string path of an entry of an ordered list. It is
+// stored as an Annotation of the nodes within the entry during the walk of a
+// GoStruct.
+type orderedEntryPath string
+
+// findOrderedSetLeaves walks the supplied GoStruct in the same way as
+// findSetLeaves, additionally recording the order in which leaves are found
+// and the order of the entries of ordered lists within the GoStruct. The
+// leaves of the containers and list entries whose pointers are within the
+// pruned set are not returned.
+func findOrderedSetLeaves(s GoStruct, pruned map[uintptr]bool, opts ...DiffOpt) (*setLeaves, error) {
 	pathOpt := hasDiffPathOpt(opts)
+	stateOpt := hasIgnoreState(opts)
+	includeOpt := hasIncludePaths(opts)
+	excludeOpt := hasExcludePaths(opts)
 	processedPaths := map[string]bool{}
 
 	findSetIterFunc := func(ni *util.NodeInfo, in, out interface{}) (errs util.Errors) {
@@ -224,6 +274,13 @@ func findSetLeaves(s GoStruct, opts ...DiffOpt) (map[*pathSpec]interface{}, erro
 			return
 		}
 
+		// Skip the nodes of subtrees that have been pruned, such that their
+		// paths and schemas are not computed.
+		if isPrunedNode(ni.Parent) || (util.IsValuePtr(ni.FieldValue) && !ni.FieldValue.IsNil() && pruned[ni.FieldValue.Pointer()]) {
+			ni.Annotation = []interface{}{prunedSubtree{}}
+			return
+		}
+
 		sp, err := util.SchemaPaths(ni.StructField)
 		if err != nil {
 			errs = util.AppendErr(errs, err)
@@ -263,8 +320,44 @@ func findSetLeaves(s GoStruct, opts ...DiffOpt) (map[*pathSpec]interface{}, erro
 
 		ni.Annotation = []interface{}{vp}
 
-		// Ignore non-data, or default data values.
-		if util.IsNilOrInvalidValue(ni.FieldValue) || util.IsValueNilOrDefault(ni.FieldValue.Interface()) || util.IsValueStructPtr(ni.FieldValue) || util.IsValueMap(ni.FieldValue) {
+		// Record the entries of ordered lists, such that the leaves within
+		// each entry can be associated with it.
+		sl := out.(*setLeaves)
+		if ni.Parent != nil {
+			for _, a := range ni.Parent.Annotation {
+				if e, ok := a.(orderedEntryPath); ok {
+					ni.Annotation = append(ni.Annotation, e)
+				}
+			}
+			if util.IsValueOrderedMap(ni.Parent.FieldValue) && len(vp.gNMIPaths) != 0 {
+				lp, err := getPathSpec(ni.Parent)
+				if err != nil {
+					return util.NewErrs(err)
+				}
+				ls, err := PathToString(lp.gNMIPaths[0])
+				if err != nil {
+					return util.NewErrs(err)
+				}
+				es, err := PathToString(vp.gNMIPaths[0])
+				if err != nil {
+					return util.NewErrs(err)
+				}
+				sl.orderedLists[ls] = append(sl.orderedLists[ls], orderedEntryPath(es))
+				ni.Annotation = append(ni.Annotation, orderedEntryPath(es))
+			}
+		}
+
+		var schema *yang.Entry
+		if stateOpt != nil {
+			if schema, err = diffNodeSchema(ni, stateOpt.Schema); err != nil {
+				return util.NewErrs(err)
+			}
+			ni.Annotation = append(ni.Annotation, schema)
+		}
+
+		// Ignore non-data, or default data values. Generic unions are struct
+		// pointers, but are leaf values.
+		if util.IsNilOrInvalidValue(ni.FieldValue) || util.IsValueNilOrDefault(ni.FieldValue.Interface()) || (util.IsValueStructPtr(ni.FieldValue) && !util.IsValueGenericUnion(ni.FieldValue)) || util.IsValueMap(ni.FieldValue) {
 			return
 		}
 
@@ -284,13 +377,35 @@ func findSetLeaves(s GoStruct, opts ...DiffOpt) (map[*pathSpec]interface{}, erro
 			}
 		}
 
-		outs := out.(map[*pathSpec]interface{})
-		outs[vp] = ival
+		if stateOpt != nil && isConfigFalse(schema) {
+			return
+		}
+
+		if includeOpt != nil && !pathSpecMatchesAny(vp, includeOpt.Paths) {
+			return
+		}
+
+		if excludeOpt != nil && pathSpecMatchesAny(vp, excludeOpt.Paths) {
+			return
+		}
+
+		sl.index[vp] = len(sl.values)
+		sl.values[vp] = ival
+		for _, a := range ni.Annotation {
+			if e, ok := a.(orderedEntryPath); ok {
+				sl.entries[vp] = append(sl.entries[vp], e)
+			}
+		}
 
 		return
 	}
 
-	out := map[*pathSpec]interface{}{}
+	out := &setLeaves{
+		values:       map[*pathSpec]interface{}{},
+		index:        map[*pathSpec]int{},
+		entries:      map[*pathSpec][]orderedEntryPath{},
+		orderedLists: map[string][]orderedEntryPath{},
+	}
 	if errs := util.ForEachDataField(s, nil, out, findSetIterFunc); errs != nil {
 		return nil, fmt.Errorf("error from ForEachDataField iteration: %v", errs)
 	}
@@ -298,6 +413,154 @@ func findSetLeaves(s GoStruct, opts ...DiffOpt) (map[*pathSpec]interface{}, erro
 	return out, nil
 }
 
+// isPrunedNode returns true if the supplied node is annotated as being within
+// a pruned subtree.
+func isPrunedNode(ni *util.NodeInfo) bool {
+	if ni == nil {
+		return false
+	}
+	for _, a := range ni.Annotation {
+		if _, ok := a.(prunedSubtree); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// equalSubtrees walks the original and modified GoStructs in parallel, and
+// records the pointers of the containers and list entries of each that are
+// equal according to their generated ΛEqual method in origEq and modEq
+// respectively. Subtrees that are equal are not descended into. The entries
+// of ordered lists are not compared, since their leaves are required to
+// detect whether the list has been reordered.
+func equalSubtrees(original, modified reflect.Value, origEq, modEq map[uintptr]bool) {
+	if !util.IsValueStructPtr(original) || !util.IsValueStructPtr(modified) || original.IsNil() || modified.IsNil() || original.Type() != modified.Type() || !original.CanInterface() {
+		return
+	}
+
+	if e, ok := original.Interface().(EqualHelperGoStruct); ok {
+		if m, ok := modified.Interface().(GoStruct); ok && e.ΛEqual(m) {
+			origEq[original.Pointer()] = true
+			modEq[modified.Pointer()] = true
+			return
+		}
+	}
+
+	ov, mv := original.Elem(), modified.Elem()
+	for i := 0; i < ov.NumField(); i++ {
+		of, mf := ov.Field(i), mv.Field(i)
+		switch {
+		case util.IsValueMap(of):
+			for _, k := range of.MapKeys() {
+				if me := mf.MapIndex(k); me.IsValid() {
+					equalSubtrees(of.MapIndex(k), me, origEq, modEq)
+				}
+			}
+		case util.IsValueStructPtr(of) && !util.IsValueOrderedMap(of) && !util.IsValueGenericUnion(of):
+			equalSubtrees(of, mf, origEq, modEq)
+		}
+	}
+}
+
+// listReorder describes an ordered list whose common entries are in a
+// different relative order within the original and modified GoStructs.
+type listReorder struct {
+	// path is the string path of the list.
+	path string
+	// origOrder and modOrder are the paths of the entries of the list in
+	// the original and modified GoStructs respectively.
+	origOrder, modOrder []orderedEntryPath
+}
+
+// reorderedEntries returns the set of entries of the ordered lists within
+// modified whose relative order differs from that of the same entries within
+// original. All entries of such a list within modified are returned, such that
+// the list can be re-created in its modified order. The reordered lists are
+// also returned, sorted by their path.
+func reorderedEntries(original, modified *setLeaves) (map[orderedEntryPath]bool, []*listReorder) {
+	reordered := map[orderedEntryPath]bool{}
+	var lists []*listReorder
+	for list, modEntries := range modified.orderedLists {
+		origEntries, ok := original.orderedLists[list]
+		if !ok {
+			continue
+		}
+		inMod := map[orderedEntryPath]bool{}
+		for _, e := range modEntries {
+			inMod[e] = true
+		}
+		var common []orderedEntryPath
+		for _, e := range origEntries {
+			if inMod[e] {
+				common = append(common, e)
+			}
+		}
+		i := 0
+		for _, e := range modEntries {
+			if i < len(common) && common[i] == e {
+				i++
+			}
+		}
+		if i == len(common) {
+			// The common entries are in the same relative order.
+			continue
+		}
+		for _, e := range modEntries {
+			reordered[e] = true
+		}
+		lists = append(lists, &listReorder{path: list, origOrder: origEntries, modOrder: modEntries})
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].path < lists[j].path })
+	return reordered, lists
+}
+
+// diffNodeSchema returns the schema entry corresponding to the node described
+// by the supplied NodeInfo, using the schema entry that was stored as an
+// annotation of its parent during the walk of the GoStruct. The root schema
+// is used for nodes that do not have an annotated parent.
+func diffNodeSchema(ni *util.NodeInfo, root *yang.Entry) (*yang.Entry, error) {
+	if root == nil {
+		return nil, fmt.Errorf("nil schema supplied for GoStruct")
+	}
+
+	parent := root
+	if ni.Parent != nil {
+		for _, a := range ni.Parent.Annotation {
+			if e, ok := a.(*yang.Entry); ok {
+				parent = e
+			}
+		}
+
+		// Members of a list share the schema of the list itself.
+		if ni.Parent.StructField.Name == ni.StructField.Name && ni.Parent.StructField.Type == ni.StructField.Type {
+			return parent, nil
+		}
+	}
+
+	schema, err := util.ChildSchema(parent, ni.StructField)
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		return nil, fmt.Errorf("could not find schema for field %s within %s", ni.StructField.Name, parent.Name)
+	}
+	return schema, nil
+}
+
+// pathSpecMatchesAny returns true if any of the paths within the supplied
+// pathSpec are matched by one of the supplied prefixes. Prefixes may contain
+// wildcard names or key values, as per util.PathMatchesQuery.
+func pathSpecMatchesAny(p *pathSpec, prefixes []*gnmipb.Path) bool {
+	for _, path := range p.gNMIPaths {
+		for _, pfx := range prefixes {
+			if util.PathMatchesQuery(path, pfx) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // hasDiffPathOpt extracts a DiffPathOpt from the opts slice provided. In
 // the case that there are multiple DiffPathOpt structs within opts slice, the
 // first is returned.
@@ -389,6 +652,109 @@ type DiffPathOpt struct {
 // IsDiffOpt marks DiffPathOpt as a diff option.
 func (*DiffPathOpt) IsDiffOpt() {}
 
+// IgnoreState is a DiffOpt that indicates that only leaves that represent
+// configuration within the YANG schema should be compared. Leaves that are
+// "config false" are ignored, with the exception of those in compressed
+// GoStructs that have a configuration sibling that was compressed out (i.e.,
+// applied configuration leaves), such that derived state is ignored for
+// compressed GoStructs, as per PruneConfigFalse.
+type IgnoreState struct {
+	// Schema is the schema entry corresponding to the GoStructs that
+	// are being compared.
+	Schema *yang.Entry
+}
+
+// IsDiffOpt marks IgnoreState as a diff option.
+func (*IgnoreState) IsDiffOpt() {}
+
+// hasIgnoreState returns the first IgnoreState from an opts slice, or nil
+// if there isn't one.
+func hasIgnoreState(opts []DiffOpt) *IgnoreState {
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *IgnoreState:
+			return v
+		}
+	}
+	return nil
+}
+
+// IncludePaths is a DiffOpt that restricts the comparison to leaves whose
+// path is prefixed by at least one of the specified paths. The paths may
+// contain wildcard ("*") path element names or key values. Where a path
+// element does not specify a key, it matches all entries of the list.
+type IncludePaths struct {
+	// Paths is the set of path prefixes to be compared.
+	Paths []*gnmipb.Path
+}
+
+// IsDiffOpt marks IncludePaths as a diff option.
+func (*IncludePaths) IsDiffOpt() {}
+
+// hasIncludePaths returns the first IncludePaths from an opts slice, or nil
+// if there isn't one.
+func hasIncludePaths(opts []DiffOpt) *IncludePaths {
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *IncludePaths:
+			return v
+		}
+	}
+	return nil
+}
+
+// ExcludePaths is a DiffOpt that excludes leaves whose path is prefixed by
+// any of the specified paths from the comparison. It can be used to skip
+// volatile leaves such as counters or timestamps. Wildcards are supported as
+// per IncludePaths. Where both IncludePaths and ExcludePaths match a leaf, it
+// is excluded.
+type ExcludePaths struct {
+	// Paths is the set of path prefixes to be excluded.
+	Paths []*gnmipb.Path
+}
+
+// IsDiffOpt marks ExcludePaths as a diff option.
+func (*ExcludePaths) IsDiffOpt() {}
+
+// hasExcludePaths returns the first ExcludePaths from an opts slice, or nil
+// if there isn't one.
+func hasExcludePaths(opts []DiffOpt) *ExcludePaths {
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *ExcludePaths:
+			return v
+		}
+	}
+	return nil
+}
+
+// LeafEqual is a DiffOpt that allows a custom equality function to be
+// used to compare the values of leaves that are set in both the original
+// and modified GoStructs, rather than the default comparison.
+type LeafEqual struct {
+	// Equal is called with the path of the leaf, and its value within the
+	// original and modified GoStructs. It returns true if the values should
+	// be considered equal, in which case no difference is reported for the
+	// leaf. Where the leaf maps to more than one path, the first path is
+	// supplied.
+	Equal func(path *gnmipb.Path, original, modified interface{}) bool
+}
+
+// IsDiffOpt marks LeafEqual as a diff option.
+func (*LeafEqual) IsDiffOpt() {}
+
+// hasLeafEqual returns the first LeafEqual from an opts slice, or nil if
+// there isn't one.
+func hasLeafEqual(opts []DiffOpt) *LeafEqual {
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *LeafEqual:
+			return v
+		}
+	}
+	return nil
+}
+
 // Diff takes an original and modified GoStruct, which must be of the same type
 // and returns a gNMI Notification that contains the diff between them. The original
 // struct is considered as the "from" data, with the modified struct the "to" such that:
@@ -402,6 +768,19 @@ func (*DiffPathOpt) IsDiffOpt() {}
 // Annotation fields that are contained within the supplied original or modified
 // GoStruct are skipped.
 //
+// The order of the entries of YANG lists that are ordered-by user, and are
+// represented by ordered maps, is preserved. Deletions are ordered according to
+// the position of the deleted leaves in the original struct, and updates are
+// ordered according to their position in the modified struct, such that new
+// list entries are updated in order. Where the relative order of the entries of
+// an ordered list differs between original and modified, updates for all leaves
+// of the entries of the list in modified are included.
+//
+// Where the GoStructs implement the EqualHelperGoStruct interface, the
+// generated ΛEqual method of each container and list entry that is present in
+// both original and modified is used to determine whether the subtree differs.
+// Subtrees that are equal are not walked using reflection.
+//
 // A set of options for diff's behaviour, as specified by the supplied DiffOpts
 // can be used to modify the behaviour of the Diff function per the individual
 // option's specification.
@@ -411,23 +790,85 @@ func (*DiffPathOpt) IsDiffOpt() {}
 // to the fields specified if a GoStruct that does not represent the root of
 // a YANG schema tree is not supplied as original and modified.
 func Diff(original, modified GoStruct, opts ...DiffOpt) (*gnmipb.Notification, error) {
+	changes, _, err := diffLeaves(original, modified, opts...)
+	if err != nil {
+		return nil, err
+	}
 
+	n := &gnmipb.Notification{}
+	for _, c := range changes {
+		if c.modVal == nil {
+			// This leaf was set in the original struct, but not in the modified
+			// struct, therefore it has been deleted.
+			n.Delete = append(n.Delete, c.path.gNMIPaths...)
+			continue
+		}
+		// The contents of the value should indicate that value a has changed
+		// to value b, or that the value was added.
+		if err := appendUpdate(n, c.path, c.modVal); err != nil {
+			return nil, err
+		}
+	}
+
+	return n, nil
+}
+
+// leafChange describes a single leaf or leaf-list that differs between an
+// original and modified GoStruct.
+type leafChange struct {
+	// path is the set of paths that the changed leaf corresponds to.
+	path *pathSpec
+	// origVal is the value of the leaf in the original GoStruct, it is nil
+	// if the leaf was not set in the original.
+	origVal interface{}
+	// modVal is the value of the leaf in the modified GoStruct, it is nil
+	// if the leaf was not set in the modified struct.
+	modVal interface{}
+	// reordered is true if the value of the leaf did not change, and it is
+	// only returned because the entry of the ordered list containing it
+	// was reordered.
+	reordered bool
+}
+
+// diffLeaves compares the set leaves of the original and modified GoStructs
+// and returns the set of leaves that were added, removed or modified between
+// them, along with the ordered lists whose entries were reordered. The leaves
+// of the entries of reordered lists are returned as changed, such that the
+// lists can be re-created in their modified order. The supplied DiffOpts are
+// honoured, such that additions are not returned when IgnoreAdditions is
+// specified.
+func diffLeaves(original, modified GoStruct, opts ...DiffOpt) ([]*leafChange, []*listReorder, error) {
 	if reflect.TypeOf(original) != reflect.TypeOf(modified) {
-		return nil, fmt.Errorf("cannot diff structs of different types, original: %T, modified: %T", original, modified)
+		return nil, nil, fmt.Errorf("cannot diff structs of different types, original: %T, modified: %T", original, modified)
+	}
+
+	// Where generated equality methods are available, avoid walking the
+	// subtrees of the structs that are equal.
+	var origEq, modEq map[uintptr]bool
+	if _, ok := original.(EqualHelperGoStruct); ok {
+		origEq, modEq = map[uintptr]bool{}, map[uintptr]bool{}
+		equalSubtrees(reflect.ValueOf(original), reflect.ValueOf(modified), origEq, modEq)
+		if origEq[reflect.ValueOf(original).Pointer()] {
+			return nil, nil, nil
+		}
 	}
 
-	origLeaves, err := findSetLeaves(original, opts...)
+	origSet, err := findOrderedSetLeaves(original, origEq, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("could not extract set leaves from original struct: %v", err)
+		return nil, nil, fmt.Errorf("could not extract set leaves from original struct: %v", err)
 	}
+	origLeaves := origSet.values
 
-	modLeaves, err := findSetLeaves(modified, opts...)
+	modSet, err := findOrderedSetLeaves(modified, modEq, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("could not extract set leaves from modified struct: %v", err)
+		return nil, nil, fmt.Errorf("could not extract set leaves from modified struct: %v", err)
 	}
+	modLeaves := modSet.values
+	reordered, reorders := reorderedEntries(origSet, modSet)
 
+	eqOpt := hasLeafEqual(opts)
 	matched := map[*pathSpec]bool{}
-	n := &gnmipb.Notification{}
+	changes := []*leafChange{}
 	for origPath, origVal := range origLeaves {
 		var origMatched bool
 		for modPath, modVal := range modLeaves {
@@ -436,33 +877,317 @@ func Diff(original, modified GoStruct, opts ...DiffOpt) (*gnmipb.Notification, e
 				// is equal.
 				matched[modPath] = true
 				origMatched = true
-				if !cmp.Equal(origVal, modVal) {
-					// The contents of the value should indicate that value a has changed
-					// to value b.
-					if err := appendUpdate(n, origPath, modVal); err != nil {
-						return nil, err
-					}
+				switch {
+				case !leafValuesEqual(origPath, origVal, modVal, eqOpt):
+					changes = append(changes, &leafChange{path: modPath, origVal: origVal, modVal: modVal})
+				case inReorderedEntry(modSet.entries[modPath], reordered):
+					changes = append(changes, &leafChange{path: modPath, origVal: origVal, modVal: modVal, reordered: true})
 				}
 			}
 		}
 		if !origMatched {
-			// This leaf was set in the original struct, but not in the modified
-			// struct, therefore it has been deleted.
-			n.Delete = append(n.Delete, origPath.gNMIPaths...)
+			changes = append(changes, &leafChange{path: origPath, origVal: origVal})
+		}
+	}
+	if hasIgnoreAdditions(opts) == nil {
+		// Check that all paths that are in the modified struct have been examined, if
+		// not they are updates.
+		for modPath, modVal := range modLeaves {
+			if !matched[modPath] {
+				changes = append(changes, &leafChange{path: modPath, modVal: modVal})
+			}
+		}
+	}
+
+	// Order the changes such that deletions precede updates, with deletions
+	// following the order of the original struct, and updates that of the
+	// modified struct.
+	sort.SliceStable(changes, func(i, j int) bool {
+		ci, cj := changes[i], changes[j]
+		if di, dj := ci.modVal == nil, cj.modVal == nil; di != dj {
+			return di
+		}
+		if ci.modVal == nil {
+			return origSet.index[ci.path] < origSet.index[cj.path]
+		}
+		return modSet.index[ci.path] < modSet.index[cj.path]
+	})
+
+	return changes, reorders, nil
+}
+
+// inReorderedEntry returns true if any of the supplied ordered list entries are
+// within the reordered set.
+func inReorderedEntry(entries []orderedEntryPath, reordered map[orderedEntryPath]bool) bool {
+	for _, e := range entries {
+		if reordered[e] {
+			return true
 		}
 	}
-	if hasIgnoreAdditions(opts) != nil {
-		return n, nil
+	return false
+}
+
+// leafValuesEqual compares the values a and b of the leaf with the supplied
+// path, returning true if they are equal. If a LeafEqual option is supplied,
+// its Equal function is used to perform the comparison.
+func leafValuesEqual(p *pathSpec, a, b interface{}, eq *LeafEqual) bool {
+	if eq != nil && eq.Equal != nil && len(p.gNMIPaths) != 0 {
+		return eq.Equal(p.gNMIPaths[0], a, b)
 	}
-	// Check that all paths that are in the modified struct have been examined, if
-	// not they are updates.
-	for modPath, modVal := range modLeaves {
-		if !matched[modPath] {
-			if err := appendUpdate(n, modPath, modVal); err != nil {
-				return nil, err
+	return cmp.Equal(a, b)
+}
+
+// DiffString takes an original and modified GoStruct, which must be of the
+// same type, and returns a human-readable representation of the differences
+// between them. Each changed leaf or leaf-list is rendered on a single line
+// prefixed by:
+//
+//  - "+" indicating that the leaf was not set in original, and is set in
+//    modified, followed by the new value.
+//  - "-" indicating that the leaf was set in original, but is not set in
+//    modified, followed by the removed value.
+//  - "~" indicating that the leaf is set in both structs, but to different
+//    values, followed by the original and modified values.
+//
+// The entries of an ordered-by user list whose order changed are not
+// rendered as changes of their leaves. Instead, a single line prefixed by "~"
+// is rendered for the list, followed by the keys of its entries in the
+// original and modified order.
+//
+// Leaves that are not within a YANG list are rendered using their full path.
+// Leaves that are within a YANG list are grouped by the list entry (including
+// its keys) that they belong to, which is written on a header line, with the
+// path of each leaf relative to that entry. The output is sorted by path such
+// that it is deterministic for the same inputs. An empty string is returned if
+// there are no differences.
+//
+// The supplied DiffOpts are handled in the same way as for Diff.
+func DiffString(original, modified GoStruct, opts ...DiffOpt) (string, error) {
+	changes, reorders, err := diffLeaves(original, modified, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	type diffLine struct {
+		path *gnmipb.Path
+		op   string
+		val  string
+	}
+	var lines []*diffLine
+	for _, r := range reorders {
+		p, err := StringToStructuredPath(r.path)
+		if err != nil {
+			return "", err
+		}
+		ov, err := entryKeysString(r.origOrder)
+		if err != nil {
+			return "", err
+		}
+		mv, err := entryKeysString(r.modOrder)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, &diffLine{path: p, op: "~", val: fmt.Sprintf("order %s -> %s", ov, mv)})
+	}
+	for _, c := range changes {
+		// The values of leaves that are only returned because their list
+		// entry was reordered did not change.
+		if c.reordered {
+			continue
+		}
+		var op, val string
+		switch {
+		case c.origVal == nil:
+			v, err := diffValueString(c.modVal)
+			if err != nil {
+				return "", err
+			}
+			op, val = "+", v
+		case c.modVal == nil:
+			v, err := diffValueString(c.origVal)
+			if err != nil {
+				return "", err
 			}
+			op, val = "-", v
+		default:
+			ov, err := diffValueString(c.origVal)
+			if err != nil {
+				return "", err
+			}
+			mv, err := diffValueString(c.modVal)
+			if err != nil {
+				return "", err
+			}
+			op, val = "~", fmt.Sprintf("%s -> %s", ov, mv)
+		}
+		for _, p := range c.path.gNMIPaths {
+			lines = append(lines, &diffLine{path: p, op: op, val: val})
 		}
 	}
 
-	return n, nil
+	sort.SliceStable(lines, func(i, j int) bool {
+		return comparePathElems(lines[i].path.Elem, lines[j].path.Elem) < 0
+	})
+
+	var b strings.Builder
+	var group *gnmipb.Path
+	for _, l := range lines {
+		// The group of a leaf is the innermost list entry within its path.
+		var groupLen int
+		for i, e := range l.path.Elem {
+			if len(e.Key) != 0 {
+				groupLen = i + 1
+			}
+		}
+
+		if groupLen == 0 {
+			group = nil
+			ps, err := PathToString(l.path)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&b, "%s %s: %s\n", l.op, ps, l.val)
+			continue
+		}
+
+		lg := &gnmipb.Path{Elem: l.path.Elem[:groupLen]}
+		if group == nil || !proto.Equal(group, lg) {
+			group = lg
+			gs, err := PathToString(group)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&b, "%s:\n", gs)
+		}
+		rs, err := PathToString(&gnmipb.Path{Elem: l.path.Elem[groupLen:]})
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "  %s %s: %s\n", l.op, strings.TrimPrefix(rs, "/"), l.val)
+	}
+
+	return b.String(), nil
+}
+
+// entryKeysString returns a representation of the keys of the supplied
+// entries of an ordered list, in order, e.g. "[name=a] [name=b]".
+func entryKeysString(entries []orderedEntryPath) (string, error) {
+	var ks []string
+	for _, e := range entries {
+		p, err := StringToStructuredPath(string(e))
+		if err != nil {
+			return "", err
+		}
+		if len(p.Elem) == 0 {
+			return "", fmt.Errorf("invalid path %q of ordered list entry", e)
+		}
+		ks = append(ks, keyString(p.Elem[len(p.Elem)-1].Key))
+	}
+	return strings.Join(ks, " "), nil
+}
+
+// comparePathElems compares the two supplied slices of gNMI PathElems, such
+// that they can be sorted. Elements are compared in order by their name, and
+// subsequently by their keys, such that all paths that share a common prefix
+// are adjacent to one another when sorted. It returns -1 if a sorts before b,
+// 1 if b sorts before a, and 0 if they are equal.
+func comparePathElems(a, b []*gnmipb.PathElem) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i].Name != b[i].Name {
+			return strings.Compare(a[i].Name, b[i].Name)
+		}
+		if c := strings.Compare(keyString(a[i].Key), keyString(b[i].Key)); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+// keyString returns a deterministic string representation of the supplied
+// gNMI PathElem key map.
+func keyString(keys map[string]string) string {
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, k := range names {
+		fmt.Fprintf(&b, "[%s=%s]", k, keys[k])
+	}
+	return b.String()
+}
+
+// diffValueString returns a human-readable representation of the supplied
+// leaf or leaf-list value from a GoStruct.
+func diffValueString(val interface{}) (string, error) {
+	tv, err := EncodeTypedValue(val, gnmipb.Encoding_JSON)
+	if err != nil {
+		return "", fmt.Errorf("cannot represent field value %v as TypedValue: %v", val, err)
+	}
+	return typedValueString(tv), nil
+}
+
+// typedValueString returns a human-readable representation of the supplied
+// gNMI TypedValue.
+func typedValueString(tv *gnmipb.TypedValue) string {
+	switch v := tv.GetValue().(type) {
+	case *gnmipb.TypedValue_StringVal:
+		return strconv.Quote(v.StringVal)
+	case *gnmipb.TypedValue_IntVal:
+		return strconv.FormatInt(v.IntVal, 10)
+	case *gnmipb.TypedValue_UintVal:
+		return strconv.FormatUint(v.UintVal, 10)
+	case *gnmipb.TypedValue_BoolVal:
+		return strconv.FormatBool(v.BoolVal)
+	case *gnmipb.TypedValue_FloatVal:
+		return strconv.FormatFloat(float64(v.FloatVal), 'g', -1, 32)
+	case *gnmipb.TypedValue_DecimalVal:
+		return decimal64String(v.DecimalVal)
+	case *gnmipb.TypedValue_BytesVal:
+		return base64.StdEncoding.EncodeToString(v.BytesVal)
+	case *gnmipb.TypedValue_AsciiVal:
+		return strconv.Quote(v.AsciiVal)
+	case *gnmipb.TypedValue_JsonVal:
+		return string(v.JsonVal)
+	case *gnmipb.TypedValue_JsonIetfVal:
+		return string(v.JsonIetfVal)
+	case *gnmipb.TypedValue_LeaflistVal:
+		elems := make([]string, 0, len(v.LeaflistVal.GetElement()))
+		for _, e := range v.LeaflistVal.GetElement() {
+			elems = append(elems, typedValueString(e))
+		}
+		return fmt.Sprintf("[%s]", strings.Join(elems, ", "))
+	default:
+		return prototext.Format(tv)
+	}
+}
+
+// decimal64String returns the string representation of the supplied gNMI
+// Decimal64 value.
+func decimal64String(d *gnmipb.Decimal64) string {
+	digits := strconv.FormatInt(d.GetDigits(), 10)
+	if d.GetPrecision() == 0 {
+		return digits
+	}
+	var neg bool
+	if strings.HasPrefix(digits, "-") {
+		neg = true
+		digits = digits[1:]
+	}
+	prec := int(d.GetPrecision())
+	if len(digits) <= prec {
+		digits = strings.Repeat("0", prec-len(digits)+1) + digits
+	}
+	s := digits[:len(digits)-prec] + "." + digits[len(digits)-prec:]
+	if neg {
+		s = "-" + s
+	}
+	return s
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/testutil"
 	"github.com/openconfig/ygot/util"
 	"google.golang.org/protobuf/proto"
@@ -1547,6 +1548,277 @@ func TestDiff(t *testing.T) {
 	}
 }
 
+// basicListMemberOrderedMap is a minimal hand-written equivalent of a
+// generated ordered map, whose entries are basicListMember structs keyed by
+// their ListKey.
+type basicListMemberOrderedMap struct {
+	keys     []string
+	valueMap map[string]*basicListMember
+}
+
+func (*basicListMemberOrderedMap) IsYANGOrderedList() {}
+
+func (o *basicListMemberOrderedMap) Keys() []string {
+	if o == nil {
+		return nil
+	}
+	return append([]string{}, o.keys...)
+}
+
+func (o *basicListMemberOrderedMap) Values() []*basicListMember {
+	if o == nil {
+		return nil
+	}
+	var vs []*basicListMember
+	for _, k := range o.keys {
+		vs = append(vs, o.valueMap[k])
+	}
+	return vs
+}
+
+func (o *basicListMemberOrderedMap) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.keys)
+}
+
+func (o *basicListMemberOrderedMap) Get(key string) *basicListMember {
+	if o == nil {
+		return nil
+	}
+	return o.valueMap[key]
+}
+
+func (o *basicListMemberOrderedMap) Delete(key string) bool {
+	if _, ok := o.valueMap[key]; !ok {
+		return false
+	}
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+	delete(o.valueMap, key)
+	return true
+}
+
+func (o *basicListMemberOrderedMap) Append(v *basicListMember) error {
+	if v == nil || v.ListKey == nil {
+		return fmt.Errorf("nil value or key")
+	}
+	if o.valueMap == nil {
+		o.valueMap = map[string]*basicListMember{}
+	}
+	if _, ok := o.valueMap[*v.ListKey]; ok {
+		return fmt.Errorf("duplicate key %s", *v.ListKey)
+	}
+	o.keys = append(o.keys, *v.ListKey)
+	o.valueMap[*v.ListKey] = v
+	return nil
+}
+
+// newBasicListMemberOrderedMap returns an ordered map containing entries with
+// the supplied keys, in order.
+func newBasicListMemberOrderedMap(keys ...string) *basicListMemberOrderedMap {
+	o := &basicListMemberOrderedMap{}
+	for _, k := range keys {
+		if err := o.Append(&basicListMember{ListKey: String(k)}); err != nil {
+			panic(err)
+		}
+	}
+	return o
+}
+
+type orderedListStruct struct {
+	StringValue *string                    `path:"string-value"`
+	List        *basicListMemberOrderedMap `path:"ordered-list"`
+}
+
+func (*orderedListStruct) IsYANGGoStruct()                         {}
+func (*orderedListStruct) Validate(...ValidationOption) error      { return nil }
+func (*orderedListStruct) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+
+// orderedListKeyPath returns the path of the key leaf of the entry of the
+// ordered list with the key k.
+func orderedListKeyPath(k string) *gnmipb.Path {
+	return &gnmipb.Path{
+		Elem: []*gnmipb.PathElem{{
+			Name: "ordered-list",
+			Key:  map[string]string{"list-key": k},
+		}, {
+			Name: "list-key",
+		}},
+	}
+}
+
+func TestDiffOrderedMap(t *testing.T) {
+	keyUpdate := func(k string) *gnmipb.Update {
+		return &gnmipb.Update{
+			Path: orderedListKeyPath(k),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{k}},
+		}
+	}
+
+	tests := []struct {
+		desc          string
+		inOrig, inMod GoStruct
+		inOpts        []DiffOpt
+		want          *gnmipb.Notification
+	}{{
+		desc:   "new list, updates in order",
+		inOrig: &orderedListStruct{},
+		inMod:  &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a", "b")},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{keyUpdate("c"), keyUpdate("a"), keyUpdate("b")},
+		},
+	}, {
+		desc:   "identical lists",
+		inOrig: &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a", "b")},
+		inMod:  &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a", "b")},
+		want:   &gnmipb.Notification{},
+	}, {
+		desc:   "entry appended",
+		inOrig: &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a")},
+		inMod:  &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a", "b")},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{keyUpdate("b")},
+		},
+	}, {
+		desc:   "entry deleted, order of remaining entries retained",
+		inOrig: &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a", "b")},
+		inMod:  &orderedListStruct{List: newBasicListMemberOrderedMap("c", "b")},
+		want: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{orderedListKeyPath("a")},
+		},
+	}, {
+		desc:   "entries reordered",
+		inOrig: &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a", "b")},
+		inMod:  &orderedListStruct{List: newBasicListMemberOrderedMap("b", "c", "a")},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{keyUpdate("b"), keyUpdate("c"), keyUpdate("a")},
+		},
+	}, {
+		desc:   "entries reordered and deleted",
+		inOrig: &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a", "b")},
+		inMod:  &orderedListStruct{List: newBasicListMemberOrderedMap("b", "c")},
+		want: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{orderedListKeyPath("a")},
+			Update: []*gnmipb.Update{keyUpdate("b"), keyUpdate("c")},
+		},
+	}, {
+		desc:   "entries reordered with IgnoreAdditions",
+		inOrig: &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a")},
+		inMod:  &orderedListStruct{List: newBasicListMemberOrderedMap("a", "b", "c")},
+		inOpts: []DiffOpt{&IgnoreAdditions{}},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{keyUpdate("a"), keyUpdate("c")},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Diff(tt.inOrig, tt.inMod, tt.inOpts...)
+			if err != nil {
+				t.Fatalf("Diff(%s, %s): unexpected error: %v", pretty.Sprint(tt.inOrig), pretty.Sprint(tt.inMod), err)
+			}
+			// The order of the updates and deletes is significant, and hence
+			// the Notifications are compared directly.
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Diff(%s, %s): did not get expected Notification, diff(-want,+got):\n%s", pretty.Sprint(tt.inOrig), pretty.Sprint(tt.inMod), diff)
+			}
+		})
+	}
+}
+
+// equalHelperChild is a container whose ΛEqual method compares only its
+// Value field, such that changes to its Ignored field are only reported by
+// Diff if the container is walked.
+type equalHelperChild struct {
+	Value   *string `path:"config/value"`
+	Ignored *string `path:"config/ignored"`
+}
+
+func (*equalHelperChild) IsYANGGoStruct()                         {}
+func (*equalHelperChild) Validate(...ValidationOption) error      { return nil }
+func (*equalHelperChild) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+
+func (t *equalHelperChild) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"value": *t.Value}, nil
+}
+
+func (t *equalHelperChild) ΛEqual(other GoStruct) bool {
+	s, ok := other.(*equalHelperChild)
+	return ok && cmp.Equal(t.Value, s.Value)
+}
+
+// equalHelperParent is a container with children that implement ΛEqual.
+type equalHelperParent struct {
+	A    *equalHelperChild            `path:"a"`
+	B    *equalHelperChild            `path:"b"`
+	List map[string]*equalHelperChild `path:"list"`
+}
+
+func (*equalHelperParent) IsYANGGoStruct()                         {}
+func (*equalHelperParent) Validate(...ValidationOption) error      { return nil }
+func (*equalHelperParent) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+
+func (t *equalHelperParent) ΛEqual(other GoStruct) bool {
+	s, ok := other.(*equalHelperParent)
+	if !ok || len(t.List) != len(s.List) || !t.A.ΛEqual(s.A) || !t.B.ΛEqual(s.B) {
+		return false
+	}
+	for k, v := range t.List {
+		if e, ok := s.List[k]; !ok || !v.ΛEqual(e) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDiffEqualHelper(t *testing.T) {
+	child := func(v, i string) *equalHelperChild {
+		return &equalHelperChild{Value: String(v), Ignored: String(i)}
+	}
+	update := func(path, v string) *gnmipb.Update {
+		p, err := StringToStructuredPath(path)
+		if err != nil {
+			t.Fatalf("cannot parse path %s: %v", path, err)
+		}
+		return &gnmipb.Update{Path: p, Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: v}}}
+	}
+
+	orig := &equalHelperParent{
+		A:    child("a", "one"),
+		B:    child("b", "one"),
+		List: map[string]*equalHelperChild{"x": child("x", "one")},
+	}
+	mod := &equalHelperParent{
+		A:    child("a", "two"),
+		B:    child("c", "two"),
+		List: map[string]*equalHelperChild{"x": child("x", "two"), "z": child("z", "two")},
+	}
+
+	got, err := Diff(orig, mod)
+	if err != nil {
+		t.Fatalf("Diff(%s, %s): unexpected error: %v", pretty.Sprint(orig), pretty.Sprint(mod), err)
+	}
+	// The A container and the x list entry are equal according to their ΛEqual
+	// methods, and hence are not walked.
+	want := &gnmipb.Notification{
+		Update: []*gnmipb.Update{
+			update("b/config/value", "c"),
+			update("b/config/ignored", "two"),
+			update("list[value=z]/config/value", "z"),
+			update("list[value=z]/config/ignored", "two"),
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+		t.Errorf("Diff(%s, %s): did not get expected Notification, diff(-want,+got):\n%s", pretty.Sprint(orig), pretty.Sprint(mod), diff)
+	}
+}
+
 func TestLeastSpecificPath(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1584,3 +1856,306 @@ func TestLeastSpecificPath(t *testing.T) {
 		}
 	}
 }
+
+type diffStringRoot struct {
+	Hostname  *string                         `path:"system/hostname"`
+	Interface map[string]*diffStringInterface `path:"interfaces/interface"`
+	LeafList  []string                        `path:"system/leaf-list"`
+	Enum      EnumTest                        `path:"system/enum"`
+}
+
+func (*diffStringRoot) IsYANGGoStruct() {}
+
+type diffStringInterface struct {
+	Name        *string `path:"config/name|name"`
+	Mtu         *uint16 `path:"config/mtu"`
+	Description *string `path:"config/description"`
+	InPkts      *uint64 `path:"state/counters/in-pkts"`
+}
+
+func (*diffStringInterface) IsYANGGoStruct() {}
+
+func (d *diffStringInterface) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"name": *d.Name}, nil
+}
+
+func TestDiffString(t *testing.T) {
+	tests := []struct {
+		desc          string
+		inOrig, inMod GoStruct
+		inOpts        []DiffOpt
+		want          string
+		wantErrSubStr string
+	}{{
+		desc:   "no change",
+		inOrig: &diffStringRoot{},
+		inMod:  &diffStringRoot{},
+		want:   "",
+	}, {
+		desc:   "addition, deletion and modification of top-level leaves",
+		inOrig: &diffStringRoot{Hostname: String("r1"), LeafList: []string{"a", "b"}},
+		inMod:  &diffStringRoot{Hostname: String("r2"), Enum: EnumTestVALONE},
+		want: `+ /system/enum: "VAL_ONE"
+~ /system/hostname: "r1" -> "r2"
+- /system/leaf-list: ["a", "b"]
+`,
+	}, {
+		desc: "changes grouped by list key",
+		inOrig: &diffStringRoot{
+			Hostname: String("r1"),
+			Interface: map[string]*diffStringInterface{
+				"eth0": {Name: String("eth0"), Mtu: Uint16(1500)},
+				"eth1": {Name: String("eth1"), Mtu: Uint16(1500), Description: String("old")},
+			},
+		},
+		inMod: &diffStringRoot{
+			Interface: map[string]*diffStringInterface{
+				"eth0": {Name: String("eth0"), Mtu: Uint16(9000), Description: String("uplink")},
+				"eth2": {Name: String("eth2")},
+			},
+		},
+		inOpts: []DiffOpt{&DiffPathOpt{MapToSinglePath: true}},
+		want: `/interfaces/interface[name=eth0]:
+  + config/description: "uplink"
+  ~ config/mtu: 1500 -> 9000
+/interfaces/interface[name=eth1]:
+  - config/description: "old"
+  - config/mtu: 1500
+  - name: "eth1"
+/interfaces/interface[name=eth2]:
+  + name: "eth2"
+- /system/hostname: "r1"
+`,
+	}, {
+		desc:   "ignore additions",
+		inOrig: &diffStringRoot{Hostname: String("r1")},
+		inMod:  &diffStringRoot{LeafList: []string{"a"}},
+		inOpts: []DiffOpt{&IgnoreAdditions{}},
+		want:   "- /system/hostname: \"r1\"\n",
+	}, {
+		desc:   "ordered list only reordered",
+		inOrig: &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a", "b")},
+		inMod:  &orderedListStruct{List: newBasicListMemberOrderedMap("b", "c", "a")},
+		want:   "~ /ordered-list: order [list-key=c] [list-key=a] [list-key=b] -> [list-key=b] [list-key=c] [list-key=a]\n",
+	}, {
+		desc:   "ordered list reordered with other changes",
+		inOrig: &orderedListStruct{StringValue: String("x"), List: newBasicListMemberOrderedMap("c", "a", "b")},
+		inMod:  &orderedListStruct{StringValue: String("y"), List: newBasicListMemberOrderedMap("a", "c")},
+		want: `~ /ordered-list: order [list-key=c] [list-key=a] [list-key=b] -> [list-key=a] [list-key=c]
+/ordered-list[list-key=b]:
+  - list-key: "b"
+~ /string-value: "x" -> "y"
+`,
+	}, {
+		desc:          "different types",
+		inOrig:        &diffStringRoot{},
+		inMod:         &renderExample{},
+		wantErrSubStr: "cannot diff structs of different types",
+	}}
+
+	for _, tt := range tests {
+		got, err := DiffString(tt.inOrig, tt.inMod, tt.inOpts...)
+		if diff := errdiff.Substring(err, tt.wantErrSubStr); diff != "" {
+			t.Errorf("%s: DiffString(%s, %s): did not get expected error, %s", tt.desc, pretty.Sprint(tt.inOrig), pretty.Sprint(tt.inMod), diff)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s: DiffString(%s, %s): did not get expected output, (-want, +got):\n%s", tt.desc, pretty.Sprint(tt.inOrig), pretty.Sprint(tt.inMod), diff)
+		}
+	}
+}
+
+// diffStringRootSchema returns the schema corresponding to the diffStringRoot
+// GoStruct.
+func diffStringRootSchema() *yang.Entry {
+	leaf := func(name string, kind yang.TypeKind) *yang.Entry {
+		return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: &yang.YangType{Kind: kind}}
+	}
+	schema := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"system": {
+				Name: "system",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"hostname": leaf("hostname", yang.Ystring),
+					"enum":     leaf("enum", yang.Yenum),
+					"leaf-list": {
+						Name:     "leaf-list",
+						Kind:     yang.LeafEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Type:     &yang.YangType{Kind: yang.Ystring},
+					},
+				},
+			},
+			"interfaces": {
+				Name: "interfaces",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"interface": {
+						Name:     "interface",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Key:      "name",
+						Dir: map[string]*yang.Entry{
+							"name": leaf("name", yang.Yleafref),
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir: map[string]*yang.Entry{
+									"name":        leaf("name", yang.Ystring),
+									"mtu":         leaf("mtu", yang.Yuint16),
+									"description": leaf("description", yang.Ystring),
+								},
+							},
+							"state": {
+								Name:   "state",
+								Kind:   yang.DirectoryEntry,
+								Config: yang.TSFalse,
+								Dir: map[string]*yang.Entry{
+									"counters": {
+										Name: "counters",
+										Kind: yang.DirectoryEntry,
+										Dir: map[string]*yang.Entry{
+											"in-pkts": leaf("in-pkts", yang.Yuint64),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(schema)
+	return schema
+}
+
+func TestDiffFilterOpts(t *testing.T) {
+	mustPath := func(s string) *gnmipb.Path {
+		p, err := StringToStructuredPath(s)
+		if err != nil {
+			t.Fatalf("cannot parse path %s, %v", s, err)
+		}
+		return p
+	}
+
+	orig := &diffStringRoot{
+		Hostname: String("r1"),
+		Interface: map[string]*diffStringInterface{
+			"eth0": {Name: String("eth0"), Mtu: Uint16(1500), InPkts: Uint64(10)},
+			"eth1": {Name: String("eth1"), Mtu: Uint16(1500), InPkts: Uint64(20)},
+		},
+	}
+	mod := &diffStringRoot{
+		Hostname: String("r2"),
+		Interface: map[string]*diffStringInterface{
+			"eth0": {Name: String("eth0"), Mtu: Uint16(9000), InPkts: Uint64(42)},
+			"eth1": {Name: String("eth1"), Mtu: Uint16(1500), InPkts: Uint64(84)},
+		},
+	}
+
+	tests := []struct {
+		desc          string
+		inOrig, inMod GoStruct
+		inOpts        []DiffOpt
+		want          string
+		wantErrSubStr string
+	}{{
+		desc:   "ignore state",
+		inOrig: orig,
+		inMod:  mod,
+		inOpts: []DiffOpt{&DiffPathOpt{MapToSinglePath: true}, &IgnoreState{Schema: diffStringRootSchema()}},
+		want: `/interfaces/interface[name=eth0]:
+  ~ config/mtu: 1500 -> 9000
+~ /system/hostname: "r1" -> "r2"
+`,
+	}, {
+		desc:          "ignore state with nil schema",
+		inOrig:        orig,
+		inMod:         mod,
+		inOpts:        []DiffOpt{&IgnoreState{}},
+		wantErrSubStr: "nil schema",
+	}, {
+		desc:   "include subtree",
+		inOrig: orig,
+		inMod:  mod,
+		inOpts: []DiffOpt{&DiffPathOpt{MapToSinglePath: true}, &IncludePaths{Paths: []*gnmipb.Path{mustPath("/interfaces/interface[name=eth1]")}}},
+		want: `/interfaces/interface[name=eth1]:
+  ~ state/counters/in-pkts: 20 -> 84
+`,
+	}, {
+		desc:   "exclude with wildcard",
+		inOrig: orig,
+		inMod:  mod,
+		inOpts: []DiffOpt{&DiffPathOpt{MapToSinglePath: true}, &ExcludePaths{Paths: []*gnmipb.Path{mustPath("/interfaces/interface[name=*]/state")}}},
+		want: `/interfaces/interface[name=eth0]:
+  ~ config/mtu: 1500 -> 9000
+~ /system/hostname: "r1" -> "r2"
+`,
+	}, {
+		desc:   "include and exclude",
+		inOrig: orig,
+		inMod:  mod,
+		inOpts: []DiffOpt{
+			&DiffPathOpt{MapToSinglePath: true},
+			&IncludePaths{Paths: []*gnmipb.Path{mustPath("/interfaces")}},
+			&ExcludePaths{Paths: []*gnmipb.Path{mustPath("/*/*/state/counters")}},
+		},
+		want: `/interfaces/interface[name=eth0]:
+  ~ config/mtu: 1500 -> 9000
+`,
+	}, {
+		desc:   "custom leaf equality",
+		inOrig: orig,
+		inMod:  mod,
+		inOpts: []DiffOpt{
+			&DiffPathOpt{MapToSinglePath: true},
+			&LeafEqual{Equal: func(p *gnmipb.Path, a, b interface{}) bool {
+				if p.GetElem()[len(p.GetElem())-1].GetName() == "in-pkts" {
+					return true
+				}
+				return cmp.Equal(a, b)
+			}},
+		},
+		want: `/interfaces/interface[name=eth0]:
+  ~ config/mtu: 1500 -> 9000
+~ /system/hostname: "r1" -> "r2"
+`,
+	}}
+
+	for _, tt := range tests {
+		got, err := DiffString(tt.inOrig, tt.inMod, tt.inOpts...)
+		if diff := errdiff.Substring(err, tt.wantErrSubStr); diff != "" {
+			t.Errorf("%s: DiffString(%s, %s): did not get expected error, %s", tt.desc, pretty.Sprint(tt.inOrig), pretty.Sprint(tt.inMod), diff)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s: DiffString(%s, %s): did not get expected output, (-want, +got):\n%s", tt.desc, pretty.Sprint(tt.inOrig), pretty.Sprint(tt.inMod), diff)
+		}
+	}
+}
+
+func TestDecimal64String(t *testing.T) {
+	tests := []struct {
+		in   *gnmipb.Decimal64
+		want string
+	}{{
+		in:   &gnmipb.Decimal64{Digits: 42},
+		want: "42",
+	}, {
+		in:   &gnmipb.Decimal64{Digits: 4242, Precision: 2},
+		want: "42.42",
+	}, {
+		in:   &gnmipb.Decimal64{Digits: -5, Precision: 3},
+		want: "-0.005",
+	}}
+
+	for _, tt := range tests {
+		if got := decimal64String(tt.in); got != tt.want {
+			t.Errorf("decimal64String(%v): did not get expected value, got: %s, want: %s", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// EqualOpt is an interface that is implemented by the options to the Equal
+// and Hash functions. It allows user specified options to be propagated to
+// the comparison.
+type EqualOpt interface {
+	// IsEqualOpt is a marker method for each EqualOpt.
+	IsEqualOpt()
+}
+
+// IsEqualOpt marks IgnoreState as an option to Equal, such that only leaves
+// that represent configuration are compared.
+func (*IgnoreState) IsEqualOpt() {}
+
+// FloatTolerance is an EqualOpt that specifies that floating point leaves
+// and leaf-lists (i.e., those with YANG types of decimal64, or float32 and
+// float64 values within GoStructs) should be considered equal if they differ
+// by no more than the specified absolute tolerance.
+//
+// FloatTolerance is not considered by Hash.
+type FloatTolerance struct {
+	// Tolerance is the maximum absolute difference between two floating
+	// point values that are considered equal.
+	Tolerance float64
+}
+
+// IsEqualOpt marks FloatTolerance as an option to Equal.
+func (*FloatTolerance) IsEqualOpt() {}
+
+// IgnoreLeafListOrder is an EqualOpt that specifies that the order of the
+// values within leaf-lists should not be considered when comparing them. If
+// a Schema is specified, leaf-lists that are "ordered-by user" within the
+// schema are still compared in order, such that only the order of system
+// ordered leaf-lists is ignored.
+type IgnoreLeafListOrder struct {
+	// Schema is the optional schema entry corresponding to the GoStructs
+	// being compared.
+	Schema *yang.Entry
+}
+
+// IsEqualOpt marks IgnoreLeafListOrder as an option to Equal.
+func (*IgnoreLeafListOrder) IsEqualOpt() {}
+
+// hasFloatTolerance returns the first FloatTolerance from an opts slice, or
+// nil if there isn't one.
+func hasFloatTolerance(opts []EqualOpt) *FloatTolerance {
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *FloatTolerance:
+			return v
+		}
+	}
+	return nil
+}
+
+// hasIgnoreLeafListOrder returns the first IgnoreLeafListOrder from an opts
+// slice, or nil if there isn't one.
+func hasIgnoreLeafListOrder(opts []EqualOpt) *IgnoreLeafListOrder {
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *IgnoreLeafListOrder:
+			return v
+		}
+	}
+	return nil
+}
+
+// equalDiffOpts returns the set of DiffOpts that correspond to the supplied
+// EqualOpts.
+func equalDiffOpts(opts []EqualOpt) []DiffOpt {
+	dOpts := []DiffOpt{&DiffPathOpt{MapToSinglePath: true}}
+	for _, o := range opts {
+		if d, ok := o.(DiffOpt); ok {
+			dOpts = append(dOpts, d)
+		}
+	}
+	return dOpts
+}
+
+// Equal compares the two supplied GoStructs, returning true if they are equal
+// according to their YANG schema. The comparison considers only the leaves and
+// leaf-lists that are populated within each struct, such that containers and
+// lists that are nil are considered equal to those that are empty (i.e., as
+// would be the case after PruneEmptyBranches is called). GoStructs of different
+// types are never equal.
+//
+// The behaviour of the comparison can be modified by the supplied EqualOpts,
+// allowing state leaves to be ignored (IgnoreState), floating point values to
+// be compared with a tolerance (FloatTolerance), or the order of leaf-lists to
+// be ignored (IgnoreLeafListOrder). An error is returned if the GoStructs
+// cannot be compared.
+//
+// If the GoStructs implement the EqualHelperGoStruct interface, then the
+// generated ΛEqual method is used to avoid walking the structs using
+// reflection where they are equal.
+func Equal(a, b GoStruct, opts ...EqualOpt) (bool, error) {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false, nil
+	}
+
+	tol := hasFloatTolerance(opts)
+	order := hasIgnoreLeafListOrder(opts)
+
+	dOpts := equalDiffOpts(opts)
+	if tol != nil || order != nil {
+		dOpts = append(dOpts, &LeafEqual{
+			Equal: func(path *gnmipb.Path, x, y interface{}) bool {
+				return leafEqual(x, y, tol, ignoreOrder(order, path))
+			},
+		})
+	}
+
+	changes, _, err := diffLeaves(a, b, dOpts...)
+	if err != nil {
+		return false, err
+	}
+	return len(changes) == 0, nil
+}
+
+// Hash returns a stable fingerprint of the supplied GoStruct, which can be used
+// to determine whether the contents of the struct have changed, or as a cache
+// key. Two GoStructs that are considered equal by Equal with the same options
+// have the same hash, with the exception of the FloatTolerance option, which is
+// not considered. The IgnoreState and IgnoreLeafListOrder options are honoured
+// such that state leaves and the order of unordered leaf-lists do not affect
+// the returned fingerprint. The order of the entries of lists that are
+// represented by ordered maps affects the returned fingerprint.
+func Hash(s GoStruct, opts ...EqualOpt) (string, error) {
+	order := hasIgnoreLeafListOrder(opts)
+	sl, err := findOrderedSetLeaves(s, nil, equalDiffOpts(opts)...)
+	if err != nil {
+		return "", err
+	}
+	leaves := sl.values
+
+	entries := make([]string, 0, len(leaves))
+	for list, es := range sl.orderedLists {
+		ps := make([]string, 0, len(es))
+		for _, e := range es {
+			ps = append(ps, string(e))
+		}
+		entries = append(entries, fmt.Sprintf("%s#order=[%s]", list, strings.Join(ps, ", ")))
+	}
+	for p, v := range leaves {
+		for _, gp := range p.gNMIPaths {
+			ps, err := PathToString(gp)
+			if err != nil {
+				return "", err
+			}
+			vs, err := hashValueString(v, ignoreOrder(order, gp))
+			if err != nil {
+				return "", fmt.Errorf("cannot hash value of %s: %v", ps, err)
+			}
+			entries = append(entries, fmt.Sprintf("%s=%s", ps, vs))
+		}
+	}
+	sort.Strings(entries)
+
+	h := sha256.New()
+	for _, e := range entries {
+		// The length is written to ensure that the encoding is unambiguous.
+		fmt.Fprintf(h, "%d:%s", len(e), e)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// hashValueString returns the string representation of the supplied leaf or
+// leaf-list value that is used in the hash. If ignoreOrder is true, and the
+// value is a leaf-list, the values of the leaf-list are sorted.
+func hashValueString(v interface{}, ignoreOrder bool) (string, error) {
+	tv, err := EncodeTypedValue(v, gnmipb.Encoding_JSON)
+	if err != nil {
+		return "", err
+	}
+	ll, ok := tv.GetValue().(*gnmipb.TypedValue_LeaflistVal)
+	if !ok || !ignoreOrder {
+		return typedValueString(tv), nil
+	}
+	elems := make([]string, 0, len(ll.LeaflistVal.GetElement()))
+	for _, e := range ll.LeaflistVal.GetElement() {
+		elems = append(elems, typedValueString(e))
+	}
+	sort.Strings(elems)
+	return fmt.Sprintf("[%s]", strings.Join(elems, ", ")), nil
+}
+
+// ignoreOrder determines whether the order of the leaf-list at the supplied
+// path should be ignored, according to the IgnoreLeafListOrder option supplied.
+func ignoreOrder(o *IgnoreLeafListOrder, path *gnmipb.Path) bool {
+	if o == nil {
+		return false
+	}
+	if o.Schema == nil {
+		return true
+	}
+	e := schemaForPath(o.Schema, path)
+	if e == nil || e.ListAttr == nil || e.ListAttr.OrderedBy == nil {
+		return true
+	}
+	return e.ListAttr.OrderedBy.Name != "user"
+}
+
+// schemaForPath returns the schema entry at the supplied path relative to the
+// root schema entry supplied, or nil if it cannot be found. Choice and case
+// nodes, which do not appear in the data tree, are skipped.
+func schemaForPath(root *yang.Entry, path *gnmipb.Path) *yang.Entry {
+	e := root
+	for _, pe := range path.GetElem() {
+		if e = util.FirstChild(e, []string{pe.GetName()}); e == nil {
+			return nil
+		}
+	}
+	return e
+}
+
+// isLeafListValue determines whether the supplied value is a leaf-list value
+// within a GoStruct. Binary leaves, which are represented as byte slices, are
+// not considered leaf-lists.
+func isLeafListValue(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice && v.Type().Name() != "Binary"
+}
+
+// leafEqual compares the two supplied leaf or leaf-list values. If tol is
+// non-nil, then floating point values are compared with the tolerance that it
+// specifies. If ignoreOrder is true, leaf-lists are considered equal if they
+// contain the same elements irrespective of order.
+func leafEqual(a, b interface{}, tol *FloatTolerance, ignoreOrder bool) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !isLeafListValue(va) || !isLeafListValue(vb) {
+		return scalarEqual(va, vb, tol)
+	}
+	if va.Len() != vb.Len() {
+		return false
+	}
+
+	if !ignoreOrder {
+		for i := 0; i < va.Len(); i++ {
+			if !scalarEqual(va.Index(i), vb.Index(i), tol) {
+				return false
+			}
+		}
+		return true
+	}
+
+	used := make([]bool, vb.Len())
+	for i := 0; i < va.Len(); i++ {
+		var found bool
+		for j := 0; j < vb.Len(); j++ {
+			if !used[j] && scalarEqual(va.Index(i), vb.Index(j), tol) {
+				used[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// scalarEqual compares the two supplied scalar values from a GoStruct, which
+// may be pointers or interfaces. If tol is non-nil, floating point values are
+// compared using the tolerance that it specifies.
+func scalarEqual(a, b reflect.Value, tol *FloatTolerance) bool {
+	for a.IsValid() && (a.Kind() == reflect.Ptr || a.Kind() == reflect.Interface) && !a.IsNil() {
+		a = a.Elem()
+	}
+	for b.IsValid() && (b.Kind() == reflect.Ptr || b.Kind() == reflect.Interface) && !b.IsNil() {
+		b = b.Elem()
+	}
+	if tol != nil && a.IsValid() && b.IsValid() && a.Type() == b.Type() {
+		switch a.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return math.Abs(a.Float()-b.Float()) <= tol.Tolerance
+		}
+	}
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	return cmp.Equal(a.Interface(), b.Interface())
+}
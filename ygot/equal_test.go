@@ -0,0 +1,274 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+type equalTestStruct struct {
+	Str      *string                         `path:"str"`
+	Dec      *float64                        `path:"dec"`
+	LeafList []string                        `path:"leaf-list"`
+	UserList []string                        `path:"user-list"`
+	Child    *equalTestChild                 `path:"child"`
+	List     map[string]*equalTestListMember `path:"list"`
+}
+
+func (*equalTestStruct) IsYANGGoStruct() {}
+
+type equalTestChild struct {
+	Val     *string `path:"config/val"`
+	Counter *uint64 `path:"state/counter"`
+}
+
+func (*equalTestChild) IsYANGGoStruct() {}
+
+type equalTestListMember struct {
+	Key *string `path:"key"`
+}
+
+func (*equalTestListMember) IsYANGGoStruct() {}
+
+func (e *equalTestListMember) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"key": *e.Key}, nil
+}
+
+func equalTestSchema() *yang.Entry {
+	leaf := func(name string, kind yang.TypeKind) *yang.Entry {
+		return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: &yang.YangType{Kind: kind}}
+	}
+	userOrdered := yang.NewDefaultListAttr()
+	userOrdered.OrderedBy = &yang.Value{Name: "user"}
+	schema := &yang.Entry{
+		Name: "root",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"str": leaf("str", yang.Ystring),
+			"dec": leaf("dec", yang.Ydecimal64),
+			"leaf-list": {
+				Name:     "leaf-list",
+				Kind:     yang.LeafEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Type:     &yang.YangType{Kind: yang.Ystring},
+			},
+			"user-list": {
+				Name:     "user-list",
+				Kind:     yang.LeafEntry,
+				ListAttr: userOrdered,
+				Type:     &yang.YangType{Kind: yang.Ystring},
+			},
+			"child": {
+				Name: "child",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"config": {
+						Name: "config",
+						Kind: yang.DirectoryEntry,
+						Dir: map[string]*yang.Entry{
+							"val": leaf("val", yang.Ystring),
+						},
+					},
+					"state": {
+						Name:   "state",
+						Kind:   yang.DirectoryEntry,
+						Config: yang.TSFalse,
+						Dir: map[string]*yang.Entry{
+							"counter": leaf("counter", yang.Yuint64),
+						},
+					},
+				},
+			},
+			"list": {
+				Name:     "list",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Key:      "key",
+				Dir: map[string]*yang.Entry{
+					"key": leaf("key", yang.Ystring),
+				},
+			},
+		},
+	}
+	addParents(schema)
+	return schema
+}
+
+func TestEqual(t *testing.T) {
+	schema := equalTestSchema()
+
+	tests := []struct {
+		desc          string
+		inA, inB      GoStruct
+		inOpts        []EqualOpt
+		want          bool
+		wantErrSubStr string
+	}{{
+		desc: "equal structs",
+		inA:  &equalTestStruct{Str: String("a"), LeafList: []string{"x", "y"}},
+		inB:  &equalTestStruct{Str: String("a"), LeafList: []string{"x", "y"}},
+		want: true,
+	}, {
+		desc: "different leaf values",
+		inA:  &equalTestStruct{Str: String("a")},
+		inB:  &equalTestStruct{Str: String("b")},
+		want: false,
+	}, {
+		desc: "different types",
+		inA:  &equalTestStruct{},
+		inB:  &renderExample{},
+		want: false,
+	}, {
+		desc: "nil and empty containers and lists are equal",
+		inA:  &equalTestStruct{},
+		inB:  &equalTestStruct{Child: &equalTestChild{}, List: map[string]*equalTestListMember{}},
+		want: true,
+	}, {
+		desc: "list entries differ",
+		inA:  &equalTestStruct{List: map[string]*equalTestListMember{"one": {Key: String("one")}}},
+		inB:  &equalTestStruct{List: map[string]*equalTestListMember{"two": {Key: String("two")}}},
+		want: false,
+	}, {
+		desc:   "ignore state",
+		inA:    &equalTestStruct{Child: &equalTestChild{Val: String("a"), Counter: Uint64(1)}},
+		inB:    &equalTestStruct{Child: &equalTestChild{Val: String("a"), Counter: Uint64(2)}},
+		inOpts: []EqualOpt{&IgnoreState{Schema: schema}},
+		want:   true,
+	}, {
+		desc: "state not ignored",
+		inA:  &equalTestStruct{Child: &equalTestChild{Val: String("a"), Counter: Uint64(1)}},
+		inB:  &equalTestStruct{Child: &equalTestChild{Val: String("a"), Counter: Uint64(2)}},
+		want: false,
+	}, {
+		desc:          "ignore state with missing schema",
+		inA:           &equalTestStruct{Str: String("a")},
+		inB:           &equalTestStruct{Str: String("a")},
+		inOpts:        []EqualOpt{&IgnoreState{}},
+		wantErrSubStr: "nil schema",
+	}, {
+		desc:   "float within tolerance",
+		inA:    &equalTestStruct{Dec: Float64(1.001)},
+		inB:    &equalTestStruct{Dec: Float64(1.002)},
+		inOpts: []EqualOpt{&FloatTolerance{Tolerance: 0.01}},
+		want:   true,
+	}, {
+		desc:   "float outside tolerance",
+		inA:    &equalTestStruct{Dec: Float64(1.0)},
+		inB:    &equalTestStruct{Dec: Float64(1.1)},
+		inOpts: []EqualOpt{&FloatTolerance{Tolerance: 0.01}},
+		want:   false,
+	}, {
+		desc: "leaf-list order differs",
+		inA:  &equalTestStruct{LeafList: []string{"x", "y"}},
+		inB:  &equalTestStruct{LeafList: []string{"y", "x"}},
+		want: false,
+	}, {
+		desc:   "leaf-list order ignored",
+		inA:    &equalTestStruct{LeafList: []string{"x", "y", "x"}},
+		inB:    &equalTestStruct{LeafList: []string{"y", "x", "x"}},
+		inOpts: []EqualOpt{&IgnoreLeafListOrder{}},
+		want:   true,
+	}, {
+		desc:   "leaf-list order ignored, different elements",
+		inA:    &equalTestStruct{LeafList: []string{"x", "x"}},
+		inB:    &equalTestStruct{LeafList: []string{"x", "y"}},
+		inOpts: []EqualOpt{&IgnoreLeafListOrder{}},
+		want:   false,
+	}, {
+		desc:   "ordered-by user leaf-list order not ignored with schema",
+		inA:    &equalTestStruct{LeafList: []string{"x", "y"}, UserList: []string{"a", "b"}},
+		inB:    &equalTestStruct{LeafList: []string{"y", "x"}, UserList: []string{"b", "a"}},
+		inOpts: []EqualOpt{&IgnoreLeafListOrder{Schema: schema}},
+		want:   false,
+	}, {
+		desc:   "ordered-by system leaf-list order ignored with schema",
+		inA:    &equalTestStruct{LeafList: []string{"x", "y"}, UserList: []string{"a", "b"}},
+		inB:    &equalTestStruct{LeafList: []string{"y", "x"}, UserList: []string{"a", "b"}},
+		inOpts: []EqualOpt{&IgnoreLeafListOrder{Schema: schema}},
+		want:   true,
+	}}
+
+	for _, tt := range tests {
+		got, err := Equal(tt.inA, tt.inB, tt.inOpts...)
+		if diff := errdiff.Substring(err, tt.wantErrSubStr); diff != "" {
+			t.Errorf("%s: Equal(%s, %s): did not get expected error, %s", tt.desc, pretty.Sprint(tt.inA), pretty.Sprint(tt.inB), diff)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: Equal(%s, %s): did not get expected result, got: %v, want: %v", tt.desc, pretty.Sprint(tt.inA), pretty.Sprint(tt.inB), got, tt.want)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	schema := equalTestSchema()
+
+	tests := []struct {
+		desc     string
+		inA, inB GoStruct
+		inOpts   []EqualOpt
+		wantSame bool
+	}{{
+		desc:     "equal structs",
+		inA:      &equalTestStruct{Str: String("a"), List: map[string]*equalTestListMember{"one": {Key: String("one")}, "two": {Key: String("two")}}},
+		inB:      &equalTestStruct{Str: String("a"), List: map[string]*equalTestListMember{"two": {Key: String("two")}, "one": {Key: String("one")}}},
+		wantSame: true,
+	}, {
+		desc:     "nil and empty containers",
+		inA:      &equalTestStruct{},
+		inB:      &equalTestStruct{Child: &equalTestChild{}},
+		wantSame: true,
+	}, {
+		desc: "different values",
+		inA:  &equalTestStruct{Str: String("a")},
+		inB:  &equalTestStruct{Str: String("b")},
+	}, {
+		desc: "leaf-list order",
+		inA:  &equalTestStruct{LeafList: []string{"x", "y"}},
+		inB:  &equalTestStruct{LeafList: []string{"y", "x"}},
+	}, {
+		desc:     "leaf-list order ignored",
+		inA:      &equalTestStruct{LeafList: []string{"x", "y"}},
+		inB:      &equalTestStruct{LeafList: []string{"y", "x"}},
+		inOpts:   []EqualOpt{&IgnoreLeafListOrder{Schema: schema}},
+		wantSame: true,
+	}, {
+		desc:     "state ignored",
+		inA:      &equalTestStruct{Child: &equalTestChild{Counter: Uint64(1)}},
+		inB:      &equalTestStruct{Child: &equalTestChild{Counter: Uint64(2)}},
+		inOpts:   []EqualOpt{&IgnoreState{Schema: schema}},
+		wantSame: true,
+	}}
+
+	for _, tt := range tests {
+		a, err := Hash(tt.inA, tt.inOpts...)
+		if err != nil {
+			t.Errorf("%s: Hash(%s): got unexpected error: %v", tt.desc, pretty.Sprint(tt.inA), err)
+			continue
+		}
+		b, err := Hash(tt.inB, tt.inOpts...)
+		if err != nil {
+			t.Errorf("%s: Hash(%s): got unexpected error: %v", tt.desc, pretty.Sprint(tt.inB), err)
+			continue
+		}
+		if got := a == b; got != tt.wantSame {
+			t.Errorf("%s: Hash(%s) == Hash(%s): got: %v, want: %v", tt.desc, pretty.Sprint(tt.inA), pretty.Sprint(tt.inB), got, tt.wantSame)
+		}
+	}
+}
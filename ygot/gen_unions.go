@@ -0,0 +1,173 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ignore
+// +build ignore
+
+// Binary gen_unions generates the generic union types Union2 through
+// Union<MaxGenericUnionTypes> that are defined within the ygot package. It is
+// run using go generate from within the ygot directory.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"strings"
+	"text/template"
+)
+
+const (
+	// minTypes is the number of member types of the smallest union that
+	// is generated.
+	minTypes = 2
+	// maxTypes is the number of member types of the largest union that is
+	// generated. It must be equal to ygot.MaxGenericUnionTypes.
+	maxTypes = 8
+	// outputFile is the file to which the generated code is written.
+	outputFile = "union_types.go"
+)
+
+// numberWords maps the number of member types of a union to the word that is
+// used for it within the union's documentation.
+var numberWords = map[int]string{
+	2: "two",
+	3: "three",
+	4: "four",
+	5: "five",
+	6: "six",
+	7: "seven",
+	8: "eight",
+}
+
+// union describes a generic union type that is to be generated.
+type union struct {
+	// Name is the name of the union type, e.g., Union2.
+	Name string
+	// Count is the number of member types of the union, in words.
+	Count string
+	// Params is the list of type parameters of the union.
+	Params []string
+}
+
+// TypeList returns the type parameters of the union as a comma-separated
+// list, e.g., "T1, T2".
+func (u union) TypeList() string { return strings.Join(u.Params, ", ") }
+
+// Described returns the type parameters of the union as they are listed
+// within its documentation, e.g., "T1, T2 or T3".
+func (u union) Described() string {
+	n := len(u.Params)
+	return fmt.Sprintf("%s or %s", strings.Join(u.Params[:n-1], ", "), u.Params[n-1])
+}
+
+// TypeOfs returns the list of typeOf calls for the union's type parameters.
+func (u union) TypeOfs() string {
+	var s []string
+	for _, p := range u.Params {
+		s = append(s, fmt.Sprintf("typeOf[%s]()", p))
+	}
+	return strings.Join(s, ", ")
+}
+
+var unionTemplate = template.Must(template.New("unions").Parse(`// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gen_unions.go. DO NOT EDIT.
+
+package ygot
+
+import "reflect"
+{{ range $u := . }}
+// {{ $u.Name }} is a generic union whose value is of one of the {{ $u.Count }} types {{ $u.Described }}.
+type {{ $u.Name }}[{{ $u.TypeList }} any] struct {
+	unionValue
+}
+{{ range $i, $p := $u.Params }}
+// Of{{ slice $p 1 }} returns a new union whose value is v, of type {{ $p }}.
+func ({{ $u.Name }}[{{ $u.TypeList }}]) Of{{ slice $p 1 }}(v {{ $p }}) *{{ $u.Name }}[{{ $u.TypeList }}] {
+	return &{{ $u.Name }}[{{ $u.TypeList }}]{unionValue{v}}
+}
+{{ end }}{{ range $i, $p := $u.Params }}
+// Get{{ slice $p 1 }} returns the value of the union and true if it is of type {{ $p }},
+// otherwise it returns the zero value of {{ $p }} and false.
+func (u {{ $u.Name }}[{{ $u.TypeList }}]) Get{{ slice $p 1 }}() ({{ $p }}, bool) {
+	v, ok := u.v.({{ $p }})
+	return v, ok
+}
+
+// Set{{ slice $p 1 }} sets the value of the union to v, of type {{ $p }}.
+func (u *{{ $u.Name }}[{{ $u.TypeList }}]) Set{{ slice $p 1 }}(v {{ $p }}) { u.v = v }
+{{ end }}
+// Types returns the Go types of the union's members, in order.
+func ({{ $u.Name }}[{{ $u.TypeList }}]) Types() []reflect.Type {
+	return []reflect.Type{ {{- $u.TypeOfs -}} }
+}
+
+// SetValue sets the value of the union to v, which must be of one of the
+// union's member types.
+func (u *{{ $u.Name }}[{{ $u.TypeList }}]) SetValue(v interface{}) error {
+	return u.set(v, u.Types())
+}
+
+// MarshalJSON marshals the value of the union to JSON.
+func (u {{ $u.Name }}[{{ $u.TypeList }}]) MarshalJSON() ([]byte, error) { return u.marshalJSON() }
+
+// Equal reports whether the union holds the same value as o.
+func (u {{ $u.Name }}[{{ $u.TypeList }}]) Equal(o {{ $u.Name }}[{{ $u.TypeList }}]) bool {
+	return reflect.DeepEqual(u.v, o.v)
+}
+
+// UnmarshalJSON sets the value of the union to the supplied JSON value.
+func (u *{{ $u.Name }}[{{ $u.TypeList }}]) UnmarshalJSON(b []byte) error {
+	return u.unmarshalJSON(b, u.Types())
+}
+{{ end -}}
+`))
+
+func main() {
+	var unions []union
+	for n := minTypes; n <= maxTypes; n++ {
+		u := union{Name: fmt.Sprintf("Union%d", n), Count: numberWords[n]}
+		for i := 1; i <= n; i++ {
+			u.Params = append(u.Params, fmt.Sprintf("T%d", i))
+		}
+		unions = append(unions, u)
+	}
+
+	var b bytes.Buffer
+	if err := unionTemplate.Execute(&b, unions); err != nil {
+		log.Fatalf("cannot generate unions: %v", err)
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("cannot format generated unions: %v\n%s", err, b.String())
+	}
+	if err := ioutil.WriteFile(outputFile, src, 0644); err != nil {
+		log.Fatalf("cannot write %s: %v", outputFile, err)
+	}
+}
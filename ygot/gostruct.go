@@ -46,10 +46,7 @@ func PruneConfigFalse(schema *yang.Entry, s GoStruct) error {
 		if ni == nil || util.IsNilOrInvalidValue(ni.FieldValue) || ni.FieldValue.IsZero() {
 			return nil
 		}
-		if util.IsConfig(ni.Schema) {
-			return nil
-		}
-		if ni.Schema.Annotation[GoCompressedLeafAnnotation] != nil {
+		if !isConfigFalse(ni.Schema) {
 			return nil
 		}
 		// The top-level GoStruct cannot be written to since it is
@@ -66,3 +63,14 @@ func PruneConfigFalse(schema *yang.Entry, s GoStruct) error {
 	}
 	return nil
 }
+
+// isConfigFalse returns true if the supplied schema entry represents a "config
+// false" node in an uncompressed schema, or a derived state node in a
+// compressed schema. Leaves that are annotated as having had a configuration
+// sibling compressed out are considered configuration.
+func isConfigFalse(e *yang.Entry) bool {
+	if util.IsConfig(e) {
+		return false
+	}
+	return e.Annotation[GoCompressedLeafAnnotation] == nil
+}
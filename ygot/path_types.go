@@ -16,6 +16,9 @@ package ygot
 
 import (
 	"fmt"
+	"reflect"
+
+	"github.com/openconfig/ygot/util"
 
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
@@ -30,6 +33,9 @@ const (
 	// FakeRootBaseTypeName is the type name of the fake root struct which
 	// should be embedded within the fake root path struct.
 	FakeRootBaseTypeName = "DeviceRootBase"
+	// LeafPathBaseTypeName is the type name of the generic embedded struct
+	// containing the path information for a typed leaf path struct.
+	LeafPathBaseTypeName = "LeafPath"
 )
 
 // PathStruct is an interface that is implemented by any generated path struct
@@ -44,6 +50,13 @@ func NewNodePath(relSchemaPath []string, keys map[string]interface{}, p PathStru
 	return &NodePath{relSchemaPath: relSchemaPath, keys: keys, p: p}
 }
 
+// NewDescendantsPath returns a NodePath that refers to all the descendants of
+// the node referred to by p, i.e., whose path is that of p followed by the
+// multilevel wildcard "...".
+func NewDescendantsPath(p PathStruct) *NodePath {
+	return NewNodePath([]string{"..."}, map[string]interface{}{}, p)
+}
+
 // NodePath is a common embedded type within all path structs. It
 // keeps track of the necessary information to create the relative schema path
 // as a []*gpb.PathElem during later processing using the Resolve() method,
@@ -54,6 +67,42 @@ type NodePath struct {
 	p             PathStruct
 }
 
+// TypedLeafPathStruct is an interface that is implemented by the path structs
+// of leaves whose values have the Go type V, and which are fields of the
+// GoStruct type S. It allows functions that handle the value of a leaf to
+// require, at compile time, that the type of the value matches the path.
+type TypedLeafPathStruct[V any, S GoStruct] interface {
+	PathStruct
+	typedLeaf(V, S)
+}
+
+// NewLeafPath is the constructor for LeafPath.
+func NewLeafPath[V any, S GoStruct](relSchemaPath []string, keys map[string]interface{}, p PathStruct) *LeafPath[V, S] {
+	return &LeafPath[V, S]{NodePath: NewNodePath(relSchemaPath, keys, p)}
+}
+
+// LeafPath is a common embedded type within the path structs of leaves when
+// typed leaf path structs are generated. In addition to the path information
+// stored in its NodePath, it records the Go type of the leaf's value, V, and
+// the type of the GoStruct that the leaf is a field of, S.
+type LeafPath[V any, S GoStruct] struct {
+	*NodePath
+}
+
+// typedLeaf is a marker method that indicates that the path struct implements
+// TypedLeafPathStruct for the types V and S.
+func (*LeafPath[V, S]) typedLeaf(V, S) {}
+
+// ValueType returns the Go type of the value of the leaf.
+func (*LeafPath[V, S]) ValueType() reflect.Type {
+	return reflect.TypeOf((*V)(nil)).Elem()
+}
+
+// GoStructType returns the type of the GoStruct that the leaf is a field of.
+func (*LeafPath[V, S]) GoStructType() reflect.Type {
+	return reflect.TypeOf((*S)(nil)).Elem()
+}
+
 // fakeRootPathStruct is an interface that is implemented by the fake root path
 // struct type.
 type fakeRootPathStruct interface {
@@ -115,6 +164,127 @@ func ResolvePath(n PathStruct) (*gpb.Path, map[string]interface{}, []error) {
 	return &gpb.Path{Target: root.Id(), Elem: p}, root.CustomData(), nil
 }
 
+// ResolvePaths returns the resolved *gpb.Paths of the PathStruct nodes ps,
+// in the same order, as ResolvePath does for each of them. The path of each
+// ancestor that is shared by several of the nodes, e.g., the list entry that
+// a set of leaf nodes are constructed from, is resolved only once. Since the
+// returned paths may share their PathElems, they must not be modified.
+func ResolvePaths(ps []PathStruct) ([]*gpb.Path, []error) {
+	r := &pathResolver{resolved: map[PathStruct]*gpb.Path{}}
+	paths := make([]*gpb.Path, 0, len(ps))
+	var errs []error
+	for _, n := range ps {
+		p, es := r.resolve(n)
+		if es != nil {
+			errs = append(errs, es...)
+			continue
+		}
+		paths = append(paths, &gpb.Path{Target: p.Target, Elem: p.Elem[:len(p.Elem):len(p.Elem)]})
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return paths, nil
+}
+
+// ResolvePathsWithPrefix resolves the PathStruct nodes ps as ResolvePaths
+// does, and returns the longest common prefix of their paths, including their
+// target, along with their paths relative to the prefix, in the same order.
+// These are suitable for the prefix and the paths of the updates of a gNMI
+// Notification. All of the nodes must have the same target.
+func ResolvePathsWithPrefix(ps []PathStruct) (*gpb.Path, []*gpb.Path, []error) {
+	paths, errs := ResolvePaths(ps)
+	if errs != nil {
+		return nil, nil, errs
+	}
+	if len(paths) == 0 {
+		return &gpb.Path{}, nil, nil
+	}
+
+	prefix := paths[0].Elem
+	for _, p := range paths[1:] {
+		if p.Target != paths[0].Target {
+			return nil, nil, []error{fmt.Errorf("ygot.ResolvePathsWithPrefix: path %v has target %q, which is not the target %q of the other paths", p, p.Target, paths[0].Target)}
+		}
+		i := 0
+		for ; i < len(prefix) && i < len(p.Elem) && util.PathElemsEqual(prefix[i], p.Elem[i]); i++ {
+		}
+		prefix = prefix[:i]
+	}
+
+	relPaths := make([]*gpb.Path, 0, len(paths))
+	for _, p := range paths {
+		relPaths = append(relPaths, &gpb.Path{Elem: p.Elem[len(prefix):]})
+	}
+	return &gpb.Path{Target: paths[0].Target, Elem: prefix[:len(prefix):len(prefix)]}, relPaths, nil
+}
+
+// pathResolver resolves the paths of PathStruct nodes, caching the resolved
+// path of each node such that the path of an ancestor shared by several
+// nodes is resolved only once.
+type pathResolver struct {
+	// resolved maps each resolved node to its path. Nodes whose type is
+	// not comparable are not cached.
+	resolved map[PathStruct]*gpb.Path
+}
+
+// resolve returns the resolved path of the node n, whose PathElems may be
+// shared with those of the other paths resolved by r.
+func (r *pathResolver) resolve(n PathStruct) (*gpb.Path, []error) {
+	// Walk up the tree until the root, or a node whose path has already
+	// been resolved, is found.
+	var unresolved []PathStruct
+	var path *gpb.Path
+	for m := n; ; m = m.parent() {
+		if p, ok := r.cached(m); ok {
+			path = p
+			break
+		}
+		if m.parent() == nil {
+			root, ok := m.(fakeRootPathStruct)
+			if !ok {
+				return nil, []error{fmt.Errorf("ygot.ResolvePaths(ygot.PathStruct): got unexpected root of (type, value) (%T, %v)", m, m)}
+			}
+			path = &gpb.Path{Target: root.Id()}
+			r.cache(m, path)
+			break
+		}
+		unresolved = append(unresolved, m)
+	}
+
+	// Resolve the nodes from the one closest to the found ancestor, such
+	// that the path of each is the path of its parent with its relative
+	// path appended.
+	for i := len(unresolved) - 1; i >= 0; i-- {
+		rel, errs := unresolved[i].relPath()
+		if errs != nil {
+			return nil, errs
+		}
+		// The elements of the parent's path are never appended to in
+		// place, since they are shared.
+		elems := make([]*gpb.PathElem, 0, len(path.Elem)+len(rel))
+		path = &gpb.Path{Target: path.Target, Elem: append(append(elems, path.Elem...), rel...)}
+		r.cache(unresolved[i], path)
+	}
+	return path, nil
+}
+
+// cached returns the cached path of the node n, if it exists.
+func (r *pathResolver) cached(n PathStruct) (*gpb.Path, bool) {
+	if !reflect.TypeOf(n).Comparable() {
+		return nil, false
+	}
+	p, ok := r.resolved[n]
+	return p, ok
+}
+
+// cache caches the resolved path p of the node n.
+func (r *pathResolver) cache(n PathStruct, p *gpb.Path) {
+	if reflect.TypeOf(n).Comparable() {
+		r.resolved[n] = p
+	}
+}
+
 // ResolveRelPath returns the partial []*gpb.PathElem representing the
 // PathStruct's relative path.
 func ResolveRelPath(n PathStruct) ([]*gpb.PathElem, []error) {
@@ -141,7 +311,8 @@ func (n *NodePath) relPath() ([]*gpb.PathElem, []error) {
 	keys := make(map[string]string)
 	for name, val := range n.keys {
 		var err error
-		// TODO(wenbli): It is ideal to also implement leaf restriction validation.
+		// The restrictions of the key leaves are validated by
+		// ytypes.ResolvePathWithSchema, since they require the schema.
 		if keys[name], err = KeyValueAsString(val); err != nil {
 			errs = append(errs, err)
 		}
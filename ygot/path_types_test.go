@@ -15,10 +15,15 @@
 package ygot
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 type deviceRoot struct {
@@ -64,6 +69,14 @@ func TestResolvePath(t *testing.T) {
 			},
 		},
 		wantPathStr: "/parent/values/value[ID=5]",
+	}, {
+		name: "descendants",
+		in: NewDescendantsPath(&NodePath{
+			relSchemaPath: []string{"parent"},
+			keys:          map[string]interface{}{},
+			p:             root,
+		}),
+		wantPathStr: "/parent/...",
 	}, {
 		name: "list with unconvertible key value",
 		in: &NodePath{
@@ -169,3 +182,134 @@ func TestResolveRelPath(t *testing.T) {
 		})
 	}
 }
+
+// leafValueType returns the Go type of the value of the typed leaf path p.
+func leafValueType[V any, S GoStruct](p TypedLeafPathStruct[V, S]) reflect.Type {
+	return reflect.TypeOf((*V)(nil)).Elem()
+}
+
+func TestLeafPath(t *testing.T) {
+	root := deviceRoot{NewDeviceRootBase("dev")}
+	parent := NewNodePath([]string{"parent"}, map[string]interface{}{}, root)
+	leaf := NewLeafPath[string, *basicStruct]([]string{"string-value"}, map[string]interface{}{}, parent)
+
+	gotPath, _, errs := ResolvePath(leaf)
+	if errs != nil {
+		t.Fatalf("ResolvePath: got unexpected errors: %v", errs)
+	}
+	wantPath, err := StringToStructuredPath("/parent/string-value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPath.Target = "dev"
+	if diff := cmp.Diff(wantPath, gotPath, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("ResolvePath returned diff (-want, +got):\n%s", diff)
+	}
+
+	if got, want := leaf.ValueType(), reflect.TypeOf(""); got != want {
+		t.Errorf("ValueType: got %v, want %v", got, want)
+	}
+	if got, want := leaf.GoStructType(), reflect.TypeOf(&basicStruct{}); got != want {
+		t.Errorf("GoStructType: got %v, want %v", got, want)
+	}
+	if got, want := leafValueType[string, *basicStruct](leaf), reflect.TypeOf(""); got != want {
+		t.Errorf("leafValueType: got %v, want %v", got, want)
+	}
+}
+
+// uncomparablePath is a path struct whose type is not comparable, such that
+// its path cannot be cached by ResolvePaths.
+type uncomparablePath struct {
+	*NodePath
+	extra []string
+}
+
+func TestResolvePaths(t *testing.T) {
+	root := deviceRoot{NewDeviceRootBase("dev")}
+	entry := NewNodePath([]string{"values", "value"}, map[string]interface{}{"ID": 5}, NewNodePath([]string{"parent"}, map[string]interface{}{}, root))
+	leafA := NewNodePath([]string{"a"}, map[string]interface{}{}, entry)
+	leafB := NewNodePath([]string{"state", "b"}, map[string]interface{}{}, entry)
+	uncomparable := uncomparablePath{NodePath: NewNodePath([]string{"c"}, map[string]interface{}{}, entry)}
+	otherRoot := NewNodePath([]string{"a"}, map[string]interface{}{}, deviceRoot{NewDeviceRootBase("other")})
+	badKey := NewNodePath([]string{"values", "value"}, map[string]interface{}{"ID": complex(1, 2)}, root)
+
+	mustPath := func(s, target string) *gpb.Path {
+		p, err := StringToStructuredPath(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.Target = target
+		return p
+	}
+
+	tests := []struct {
+		name          string
+		in            []PathStruct
+		want          []*gpb.Path
+		wantPrefix    *gpb.Path
+		wantRelPaths  []*gpb.Path
+		wantErr       bool
+		wantErrPrefix bool
+	}{{
+		name: "leaves sharing a list entry",
+		in:   []PathStruct{leafA, leafB, uncomparable, entry},
+		want: []*gpb.Path{
+			mustPath("/parent/values/value[ID=5]/a", "dev"),
+			mustPath("/parent/values/value[ID=5]/state/b", "dev"),
+			mustPath("/parent/values/value[ID=5]/c", "dev"),
+			mustPath("/parent/values/value[ID=5]", "dev"),
+		},
+		wantPrefix: mustPath("/parent/values/value[ID=5]", "dev"),
+		wantRelPaths: []*gpb.Path{
+			mustPath("/a", ""),
+			mustPath("/state/b", ""),
+			mustPath("/c", ""),
+			mustPath("/", ""),
+		},
+	}, {
+		name:          "different targets",
+		in:            []PathStruct{leafA, otherRoot},
+		want:          []*gpb.Path{mustPath("/parent/values/value[ID=5]/a", "dev"), mustPath("/a", "other")},
+		wantErrPrefix: true,
+	}, {
+		name:          "unconvertible key value",
+		in:            []PathStruct{leafA, badKey},
+		wantErr:       true,
+		wantErrPrefix: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := ResolvePaths(tt.in)
+			if (errs != nil) != tt.wantErr {
+				t.Fatalf("ResolvePaths: got errors %v, want errors: %v", errs, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ResolvePaths returned diff (-want, +got):\n%s", diff)
+			}
+			for i, p := range tt.in {
+				if tt.wantErr {
+					break
+				}
+				want, _, errs := ResolvePath(p)
+				if errs != nil {
+					t.Fatal(errs)
+				}
+				if diff := cmp.Diff(want, got[i], protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("ResolvePaths returned diff from ResolvePath for path %d (-want, +got):\n%s", i, diff)
+				}
+			}
+
+			gotPrefix, gotRelPaths, errs := ResolvePathsWithPrefix(tt.in)
+			if (errs != nil) != tt.wantErrPrefix {
+				t.Fatalf("ResolvePathsWithPrefix: got errors %v, want errors: %v", errs, tt.wantErrPrefix)
+			}
+			if diff := cmp.Diff(tt.wantPrefix, gotPrefix, protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ResolvePathsWithPrefix returned diff in prefix (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantRelPaths, gotRelPaths, protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ResolvePathsWithPrefix returned diff in relative paths (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -406,9 +406,35 @@ func findUpdatedLeaves(leaves map[*path]interface{}, s GoStruct, parent *gnmiPat
 				errs.Add(findUpdatedLeaves(leaves, goStruct, childPath))
 			}
 		case reflect.Ptr:
-			// Determine whether this is a pointer to a struct (another YANG container), or a leaf.
-			switch fval.Elem().Kind() {
-			case reflect.Struct:
+			// Determine whether this is an ordered map (a YANG list that is
+			// ordered-by user), a pointer to a struct (another YANG container),
+			// or a leaf.
+			if util.IsValueOrderedMap(fval) {
+				for _, k := range util.OrderedMapKeys(fval) {
+					v := util.OrderedMapGet(fval, k)
+					childPath, err := mapValuePath(k, v, mapPaths[0])
+					if err != nil {
+						errs.Add(err)
+						continue
+					}
+
+					goStruct, ok := v.Interface().(GoStruct)
+					if !ok {
+						errs.Add(fmt.Errorf("%v: was not a valid GoStruct", mapPaths[0]))
+						continue
+					}
+					errs.Add(findUpdatedLeaves(leaves, goStruct, childPath))
+				}
+				continue
+			}
+			switch {
+			case util.IsValueGenericUnion(fval):
+				// This is a union leaf, which is handled as though it were
+				// a scalar.
+				for _, p := range mapPaths {
+					leaves[&path{p}] = fval.Interface()
+				}
+			case fval.Elem().Kind() == reflect.Struct:
 				goStruct, ok := fval.Interface().(GoStruct)
 				if !ok {
 					errs.Add(fmt.Errorf("%v: was not a valid GoStruct", mapPaths[0]))
@@ -591,7 +617,7 @@ func KeyValueAsString(v interface{}) (string, error) {
 		return fmt.Sprintf("%s", v), nil
 	case reflect.Bool:
 		return fmt.Sprintf("%t", v), nil
-	case reflect.Ptr:
+	case reflect.Ptr, reflect.Struct:
 		iv, err := unionPtrValue(kv, false)
 		if err != nil {
 			return "", err
@@ -708,6 +734,10 @@ func EncodeTypedValue(val interface{}, enc gnmipb.Encoding) (*gnmipb.TypedValue,
 		if err != nil {
 			return nil, fmt.Errorf("cannot resolve union field value: %v", err)
 		}
+		if nv == nil {
+			// An unset generic union has no value to encode.
+			return nil, nil
+		}
 		vv = reflect.ValueOf(nv)
 		// Apart from binary, all other possible union subtypes are scalars or typedefs of scalars.
 		if vv.Type().Name() == BinaryTypeName {
@@ -847,6 +877,18 @@ func leaflistToSlice(val reflect.Value, appendModuleName bool) ([]interface{}, e
 					return nil, err
 				}
 			}
+		case reflect.Struct:
+			// Occurs where there is a leaflist of generic unions.
+			uval, err := unwrapUnionInterfaceValue(e, appendModuleName)
+			if err != nil {
+				return nil, err
+			}
+			if uval == nil {
+				return nil, fmt.Errorf("unset union value at index %d in leaflist", i)
+			}
+			if sval, err = appendTypedValue(sval, reflect.ValueOf(uval), appendModuleName); err != nil {
+				return nil, err
+			}
 		case reflect.Slice:
 			// The only time we can have a slice within a leaf-list is when
 			// the type of the field is a binary - such that we have a [][]byte field.
@@ -1221,8 +1263,12 @@ func writeIETFScalarJSON(i interface{}) interface{} {
 // in a key for a YANG list. If the value is an enumerated type then its string
 // representation is returned, otherwise the value is returned as an interface{}.
 // If appendModuleName is set to true keys that are identity values in the YANG
-// schema are prepended with the module that defines them.
+// schema are prepended with the module that defines them. Generic union keys
+// are represented by the value that they hold.
 func keyValue(v reflect.Value, appendModuleName bool) (interface{}, error) {
+	if util.IsValueGenericUnion(v) {
+		return unionPtrValue(v, appendModuleName)
+	}
 	if _, isEnum := v.Interface().(GoEnum); !isEnum {
 		return v.Interface(), nil
 	}
@@ -1243,6 +1289,23 @@ func keyValue(v reflect.Value, appendModuleName bool) (interface{}, error) {
 // The module within which the map is defined is specified by the parentMod
 // argument.
 func mapJSON(field reflect.Value, parentMod string, args jsonOutputConfig) (interface{}, error) {
+	return keyedListJSON(field, field.MapKeys(), true, parentMod, args)
+}
+
+// orderedMapJSON takes an input reflect.Value containing an ordered map, and
+// constructs the representation for JSON marshalling that corresponds to it.
+// The entries of the list are output in the order in which they are stored in
+// the ordered map when RFC7951 JSON is being output. The module within which
+// the ordered map is defined is specified by the parentMod argument.
+func orderedMapJSON(field reflect.Value, parentMod string, args jsonOutputConfig) (interface{}, error) {
+	return keyedListJSON(util.OrderedMapAsMap(field), util.OrderedMapKeys(field), false, parentMod, args)
+}
+
+// keyedListJSON constructs the representation for JSON marshalling of the
+// keyed list stored in the map field, processing its entries in the order of
+// the supplied keys. If sortKeys is true, the entries are instead processed in
+// the alphabetical order of the string representation of their keys.
+func keyedListJSON(field reflect.Value, keys []reflect.Value, sortKeys bool, parentMod string, args jsonOutputConfig) (interface{}, error) {
 	var errs errlist.List
 	mapKeyMap := map[string]reflect.Value{}
 	// Order of elements determines the order in which keys will be processed.
@@ -1250,9 +1313,10 @@ func mapJSON(field reflect.Value, parentMod string, args jsonOutputConfig) (inte
 	switch args.jType {
 	case RFC7951:
 		// YANG lists are marshalled into a JSON object array for IETF
-		// JSON. We handle the keys in alphabetical order to ensure that
-		// deterministic ordering is achieved in the output JSON.
-		for _, k := range field.MapKeys() {
+		// JSON. Unless the list's order is specified, we handle the keys
+		// in alphabetical order to ensure that deterministic ordering is
+		// achieved in the output JSON.
+		for _, k := range keys {
 			keyval, err := keyValue(k, false)
 			if err != nil {
 				errs.Add(fmt.Errorf("invalid enumerated key: %v", err))
@@ -1265,10 +1329,10 @@ func mapJSON(field reflect.Value, parentMod string, args jsonOutputConfig) (inte
 	case Internal:
 		// In non-IETF JSON, then we output a list as a JSON object. The keys
 		// are stored as strings.
-		for _, k := range field.MapKeys() {
+		for _, k := range keys {
 			var kn string
-			switch k.Kind() {
-			case reflect.Struct:
+			switch {
+			case k.Kind() == reflect.Struct && !util.IsValueGenericUnion(k):
 				// Handle the case of a multikey list.
 				var kp []string
 				for j := 0; j < k.NumField(); j++ {
@@ -1280,7 +1344,7 @@ func mapJSON(field reflect.Value, parentMod string, args jsonOutputConfig) (inte
 					kp = append(kp, fmt.Sprintf("%v", keyval))
 				}
 				kn = strings.Join(kp, " ")
-			case reflect.Int64:
+			case k.Kind() == reflect.Int64, util.IsValueGenericUnion(k):
 				keyval, err := keyValue(k, false)
 				if err != nil {
 					errs.Add(fmt.Errorf("invalid enumerated key: %v", err))
@@ -1296,7 +1360,9 @@ func mapJSON(field reflect.Value, parentMod string, args jsonOutputConfig) (inte
 	default:
 		return nil, fmt.Errorf("unknown JSON type: %v", args.jType)
 	}
-	sort.Strings(mapKeys)
+	if sortKeys {
+		sort.Strings(mapKeys)
+	}
 
 	if len(mapKeys) == 0 {
 		// empty list should be encoded as empty list
@@ -1380,8 +1446,25 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (in
 			errs.Add(err)
 		}
 	case reflect.Ptr:
-		switch field.Elem().Kind() {
-		case reflect.Struct:
+		switch {
+		case util.IsValueOrderedMap(field):
+			var err error
+			value, err = orderedMapJSON(field, parentMod, args)
+			if err != nil {
+				errs.Add(err)
+			}
+		case util.IsValueGenericUnion(field):
+			var err error
+			if value, err = unwrapUnionInterfaceValue(field, appmod); err != nil {
+				return nil, err
+			}
+			if value != nil && reflect.TypeOf(value).Name() == BinaryTypeName {
+				return jsonSlice(reflect.ValueOf(value), parentMod, args)
+			}
+			if args.jType == RFC7951 {
+				value = writeIETFScalarJSON(value)
+			}
+		case field.Elem().Kind() == reflect.Struct:
 			goStruct, ok := field.Interface().(GoStruct)
 			if !ok {
 				return nil, fmt.Errorf("cannot map struct %v, invalid GoStruct", field)
@@ -1595,10 +1678,13 @@ func jsonAnnotationSlice(v reflect.Value) (interface{}, error) {
 //	func (*Foo_Bar_Union_Int32) Is_Foo_Bar_Union() {}
 //
 // This function extracts field index 0 of the struct within the interface and returns
-// the value.
+// the value. Generic unions, or pointers to them, are also accepted, in which case
+// the value that they hold is returned.
 func unwrapUnionInterfaceValue(v reflect.Value, appendModuleName bool) (interface{}, error) {
 	var s reflect.Value
 	switch {
+	case util.IsValueGenericUnion(v):
+		return resolveUnionVal(util.GenericUnionValue(v), appendModuleName)
 	case util.IsValueInterfaceToStructPtr(v):
 		s = v.Elem().Elem()
 	case util.IsValueStructPtr(v):
@@ -1616,8 +1702,12 @@ func unwrapUnionInterfaceValue(v reflect.Value, appendModuleName bool) (interfac
 
 // unionPtrValue returns the value of a union when it is stored as a pointer. The
 // type of the union field is as per the description in unwrapUnionInterfaceValue. Union
-// pointer values are used when a list is keyed by a union.
+// pointer values are used when a list is keyed by a union. Lists that are keyed
+// by a generic union store the union as a value, which is also accepted.
 func unionPtrValue(v reflect.Value, appendModuleName bool) (interface{}, error) {
+	if util.IsValueGenericUnion(v) {
+		return resolveUnionVal(util.GenericUnionValue(v), appendModuleName)
+	}
 	if !util.IsValueStructPtr(v) {
 		return nil, fmt.Errorf("received a union pointer that didn't contain a struct, got: %v", v.Kind())
 	}
@@ -1868,6 +1868,25 @@ func TestConstructJSON(t *testing.T) {
 			InvalidEnum: int64(42),
 		},
 		wantErr: true,
+	}, {
+		name: "ordered list",
+		in: &orderedListStruct{
+			List: newBasicListMemberOrderedMap("c", "a", "b"),
+		},
+		wantIETF: map[string]interface{}{
+			"ordered-list": []interface{}{
+				map[string]interface{}{"list-key": "c"},
+				map[string]interface{}{"list-key": "a"},
+				map[string]interface{}{"list-key": "b"},
+			},
+		},
+		wantInternal: map[string]interface{}{
+			"ordered-list": map[string]interface{}{
+				"c": map[string]interface{}{"list-key": "c"},
+				"a": map[string]interface{}{"list-key": "a"},
+				"b": map[string]interface{}{"list-key": "b"},
+			},
+		},
 	}, {
 		name: "different modules at root",
 		in: &diffModAtRoot{
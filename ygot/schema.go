@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
 )
 
 // GzipToSchema takes an input byte slice, and returns it as
@@ -52,15 +53,40 @@ func GzipToSchema(gzj []byte) (map[string]*yang.Entry, error) {
 // schema map. The key of the map is the stored name of the generated
 // struct which is stored in the Annotation field of the yang.Entry when
 // serialised.
+//
+// The notifications, and the inputs and outputs of rpc and action statements,
+// for which structs are generated are changed to containers, such that they
+// are handled as the containers that their structs represent. Notifications
+// are annotated such that they continue to be identified as notifications.
 func rebuildSchemaMap(e, parent *yang.Entry, schema map[string]*yang.Entry) {
 	if n, ok := e.Annotation["structname"]; ok {
 		if s, ok := n.(string); ok {
 			schema[s] = e
 		}
+		switch e.Kind {
+		case yang.NotificationEntry:
+			e.Annotation[util.NotificationSchemaAnnotation] = true
+			e.Kind = yang.DirectoryEntry
+		case yang.InputEntry, yang.OutputEntry:
+			e.Kind = yang.DirectoryEntry
+		}
+		if e.Kind == yang.DirectoryEntry && e.Dir == nil {
+			e.Dir = map[string]*yang.Entry{}
+		}
 	}
 	e.Parent = parent
 
 	for _, ch := range e.Dir {
 		rebuildSchemaMap(ch, e, schema)
 	}
+
+	// The input and output of YANG rpc and action statements are not stored
+	// within the Dir of the entry, but may have structs generated for them.
+	if e.RPC != nil {
+		for _, ch := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+			if ch != nil {
+				rebuildSchemaMap(ch, e, schema)
+			}
+		}
+	}
 }
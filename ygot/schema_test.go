@@ -22,6 +22,7 @@ import (
 
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/util"
 )
 
 func TestGzipToSchema(t *testing.T) {
@@ -104,3 +105,76 @@ func TestGzipToSchema(t *testing.T) {
 		}
 	}
 }
+
+func TestRebuildSchemaMapRPC(t *testing.T) {
+	input := &yang.Entry{
+		Name:       "input",
+		Kind:       yang.InputEntry,
+		Annotation: map[string]interface{}{"structname": "Reset_Input"},
+		Dir:        map[string]*yang.Entry{"delay": {Name: "delay"}},
+	}
+	output := &yang.Entry{
+		Name:       "output",
+		Kind:       yang.OutputEntry,
+		Annotation: map[string]interface{}{"structname": "Reset_Output"},
+	}
+	rpc := &yang.Entry{
+		Name: "reset",
+		RPC:  &yang.RPCEntry{Input: input, Output: output},
+	}
+	root := &yang.Entry{Dir: map[string]*yang.Entry{"reset": rpc}}
+
+	got := map[string]*yang.Entry{}
+	rebuildSchemaMap(root, nil, got)
+
+	want := map[string]*yang.Entry{
+		"Reset_Input":  input,
+		"Reset_Output": output,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("rebuildSchemaMap(%v): did not get expected schema map, got: %v, want: %v", root, got, want)
+	}
+
+	if input.Parent != rpc || output.Parent != rpc {
+		t.Errorf("rebuildSchemaMap(%v): did not set parent of input and output to the rpc entry", root)
+	}
+	if input.Dir["delay"].Parent != input {
+		t.Errorf("rebuildSchemaMap(%v): did not set parent of input child to the input entry", root)
+	}
+	if !input.IsContainer() || !output.IsContainer() || output.Dir == nil {
+		t.Errorf("rebuildSchemaMap(%v): did not change input and output to containers, got kinds %v and %v", root, input.Kind, output.Kind)
+	}
+}
+
+func TestRebuildSchemaMapNotification(t *testing.T) {
+	notification := &yang.Entry{
+		Name:       "link-flap",
+		Kind:       yang.NotificationEntry,
+		Annotation: map[string]interface{}{"structname": "Interface_LinkFlap"},
+		Dir:        map[string]*yang.Entry{"timestamp": {Name: "timestamp"}},
+	}
+	intf := &yang.Entry{
+		Name:       "interface",
+		Kind:       yang.DirectoryEntry,
+		Annotation: map[string]interface{}{"structname": "Interface"},
+		Dir:        map[string]*yang.Entry{"link-flap": notification},
+	}
+	root := &yang.Entry{Dir: map[string]*yang.Entry{"interface": intf}}
+
+	got := map[string]*yang.Entry{}
+	rebuildSchemaMap(root, nil, got)
+
+	want := map[string]*yang.Entry{
+		"Interface":          intf,
+		"Interface_LinkFlap": notification,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("rebuildSchemaMap(%v): did not get expected schema map, got: %v, want: %v", root, got, want)
+	}
+	if !notification.IsContainer() {
+		t.Errorf("rebuildSchemaMap(%v): did not change notification to a container, got kind %v", root, notification.Kind)
+	}
+	if !util.IsNotification(notification) {
+		t.Errorf("rebuildSchemaMap(%v): notification is no longer identified as a notification", root)
+	}
+}
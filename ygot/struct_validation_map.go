@@ -31,6 +31,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 const (
@@ -209,7 +211,7 @@ func initialiseTree(t reflect.Type, v reflect.Value) {
 		fVal := v.Field(i)
 		fType := t.Field(i)
 
-		if util.IsTypeStructPtr(fType.Type) {
+		if util.IsTypeStructPtr(fType.Type) && !util.IsTypeOrderedMap(fType.Type) {
 			// Only initialise nested struct pointers, since all struct fields within
 			// a GoStruct are expected to be pointers, and we do not want to initialise
 			// non-struct values. If the struct pointer is not nil, it is skipped.
@@ -250,6 +252,24 @@ func pruneBranchesInternal(t reflect.Type, v reflect.Value) bool {
 	for i := 0; i < v.NumField(); i++ {
 		fVal := v.Field(i)
 		fType := t.Field(i)
+		if util.IsTypeOrderedMap(fType.Type) {
+			// An ordered map is handled in the same way as a map, such that it
+			// is pruned only if it has no entries.
+			if fVal.IsNil() {
+				continue
+			}
+			if fVal.Interface().(GoOrderedMap).Len() == 0 {
+				fVal.Set(reflect.Zero(fType.Type))
+				continue
+			}
+			allChildrenPruned = false
+			for _, mi := range util.OrderedMapValues(fVal) {
+				if util.IsValueStructPtr(mi) && !mi.IsNil() {
+					_ = pruneBranchesInternal(mi.Elem().Type(), mi.Elem())
+				}
+			}
+			continue
+		}
 		if util.IsTypeStructPtr(fType.Type) {
 			// Create an empty version of the struct that is within the struct pointer.
 			// We can safely call Elem() here since we verified above that this type
@@ -554,6 +574,40 @@ type MergeOverwriteExistingFields struct{}
 // IsMergeOpt marks MergeStructOpt as a MergeOpt.
 func (*MergeOverwriteExistingFields) IsMergeOpt() {}
 
+// MergeDeletePaths is a MergeOpt that specifies a set of nodes that should be
+// deleted from the destination struct by MergeStructs and MergeStructInto. It
+// acts as a set of tombstones for the source struct, such that the source
+// struct and the MergeDeletePaths option together can express a delta to the
+// destination struct that both removes and adds data.
+//
+// Each path is deleted from the destination struct before the contents of the
+// source struct are merged into it, such that a node that is both deleted
+// and populated in the source struct is replaced by the source value. Paths are
+// specified relative to the struct being merged into, using PathElem. Keys
+// that are omitted from a list element, or are specified as "*", match all
+// list entries. Paths to nodes that are not populated in the destination are
+// ignored. Where a path refers to a container that has been removed by schema
+// compression, all fields beneath the container are deleted.
+type MergeDeletePaths struct {
+	// Paths is the set of paths that should be deleted.
+	Paths []*gnmipb.Path
+}
+
+// IsMergeOpt marks MergeDeletePaths as a MergeOpt.
+func (*MergeDeletePaths) IsMergeOpt() {}
+
+// hasMergeDeletePaths returns the first MergeDeletePaths from an opts slice, or
+// nil if there isn't one.
+func hasMergeDeletePaths(opts []MergeOpt) *MergeDeletePaths {
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *MergeDeletePaths:
+			return v
+		}
+	}
+	return nil
+}
+
 // MergeStructs takes two input ValidatedGoStructs and merges their contents,
 // returning a new ValidatedGoStruct. If the input structs a and b are of
 // different types, an error is returned.
@@ -586,21 +640,51 @@ func MergeStructs(a, b ValidatedGoStruct, opts ...MergeOpt) (ValidatedGoStruct,
 // MergeStructInto takes the provided input ValidatedGoStructs and merges the
 // contents from src into dst. Unlike MergeStructs, the supplied dst is mutated.
 //
-// The merge semantics are the same as those for MergeStructs.
+// The merge semantics are the same as those for MergeStructs. If the
+// MergeDeletePaths option is supplied, the specified paths are deleted from
+// dst before src is merged into it.
+//
+// If dst implements the MergeHelperGoStruct interface, then its generated
+// ΛMerge method is used to perform the merge without reflection.
 func MergeStructInto(dst, src ValidatedGoStruct, opts ...MergeOpt) error {
 	if reflect.TypeOf(dst) != reflect.TypeOf(src) {
 		return fmt.Errorf("cannot merge structs that are not of matching types, %T != %T", dst, src)
 	}
 
+	if d := hasMergeDeletePaths(opts); d != nil {
+		for _, p := range d.Paths {
+			if len(p.GetElement()) != 0 {
+				return fmt.Errorf("cannot delete path %v, paths must be specified using PathElem", p)
+			}
+			if err := deleteStructPath(reflect.ValueOf(dst), p.GetElem()); err != nil {
+				return fmt.Errorf("cannot delete path %v: %v", p, err)
+			}
+		}
+	}
+
+	if m, ok := dst.(MergeHelperGoStruct); ok {
+		return m.ΛMerge(src, opts...)
+	}
+
 	return copyStruct(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem(), opts...)
 }
 
 // DeepCopy returns a deep copy of the supplied GoStruct. A new copy
 // of the GoStruct is created, along with any underlying values.
+//
+// If s implements the DeepCopyHelperGoStruct interface, then its generated
+// ΛDeepCopy method is used to perform the copy without reflection.
 func DeepCopy(s GoStruct) (GoStruct, error) {
 	if util.IsNilOrInvalidValue(reflect.ValueOf(s)) {
 		return nil, fmt.Errorf("invalid input to DeepCopy, got nil value: %v", s)
 	}
+	if c, ok := s.(DeepCopyHelperGoStruct); ok {
+		n, err := c.ΛDeepCopy()
+		if err != nil {
+			return nil, fmt.Errorf("cannot DeepCopy struct: %v", err)
+		}
+		return n, nil
+	}
 	n := reflect.New(reflect.TypeOf(s).Elem())
 	if err := copyStruct(n.Elem(), reflect.ValueOf(s).Elem()); err != nil {
 		return nil, fmt.Errorf("cannot DeepCopy struct: %v", err)
@@ -608,6 +692,166 @@ func DeepCopy(s GoStruct) (GoStruct, error) {
 	return n.Interface().(GoStruct), nil
 }
 
+// deleteStructPath deletes the node at the path described by elems from the
+// struct pointer v. Nodes that are not populated are ignored, and an error is
+// returned if the path does not correspond to a field of the struct.
+func deleteStructPath(v reflect.Value, elems []*gnmipb.PathElem) error {
+	if util.IsNilOrInvalidValue(v) || len(elems) == 0 {
+		return nil
+	}
+	if !util.IsValueStructPtr(v) {
+		return fmt.Errorf("cannot delete path %v from non-struct type %T", elems, v.Interface())
+	}
+
+	sv, st := v.Elem(), v.Elem().Type()
+	for i := 0; i < sv.NumField(); i++ {
+		ft := st.Field(i)
+		if util.IsYgotAnnotation(ft) {
+			continue
+		}
+		schPaths, err := util.SchemaPaths(ft)
+		if err != nil {
+			return err
+		}
+		for _, sp := range schPaths {
+			if !pathElemsHavePrefix(elems, sp) {
+				continue
+			}
+			return deleteFieldPath(sv.Field(i), elems[len(sp)-1], elems[len(sp):])
+		}
+	}
+
+	// The path may refer to a container that does not have a corresponding
+	// struct as a result of schema compression, in which case all fields that
+	// are beneath the path are deleted.
+	var found bool
+	for i := 0; i < sv.NumField(); i++ {
+		ft := st.Field(i)
+		if util.IsYgotAnnotation(ft) {
+			continue
+		}
+		schPaths, err := util.SchemaPaths(ft)
+		if err != nil {
+			return err
+		}
+		for _, sp := range schPaths {
+			if schemaPathHasPrefix(sp, elems) {
+				sv.Field(i).Set(reflect.Zero(ft.Type))
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("no field matching path %v in %T", elems, v.Interface())
+	}
+	return nil
+}
+
+// schemaPathHasPrefix returns true if the schema path sp is longer than, and
+// starts with the names of, the path elements in elems.
+func schemaPathHasPrefix(sp []string, elems []*gnmipb.PathElem) bool {
+	if len(elems) >= len(sp) {
+		return false
+	}
+	for i, e := range elems {
+		if sp[i] != e.GetName() {
+			return false
+		}
+	}
+	return true
+}
+
+// pathElemsHavePrefix returns true if the names of the elements in elems
+// start with the names in the schema path sp.
+func pathElemsHavePrefix(elems []*gnmipb.PathElem, sp []string) bool {
+	if len(sp) > len(elems) {
+		return false
+	}
+	for i, n := range sp {
+		if elems[i].GetName() != n {
+			return false
+		}
+	}
+	return true
+}
+
+// deleteFieldPath deletes the node described by the remaining path elements,
+// rem, from the struct field f. last is the path element that corresponds to
+// the field itself, and is used to select entries within a keyed list.
+func deleteFieldPath(f reflect.Value, last *gnmipb.PathElem, rem []*gnmipb.PathElem) error {
+	switch {
+	case util.IsValueOrderedMap(f):
+		if f.IsNil() {
+			return nil
+		}
+		for _, k := range util.OrderedMapKeys(f) {
+			e := util.OrderedMapGet(f, k)
+			match, err := listEntryMatchesKeys(e, last.GetKey())
+			if err != nil {
+				return err
+			}
+			if !match {
+				continue
+			}
+			if len(rem) == 0 {
+				util.OrderedMapDelete(f, k)
+				continue
+			}
+			if err := deleteStructPath(e, rem); err != nil {
+				return err
+			}
+		}
+	case util.IsValueMap(f):
+		for _, k := range f.MapKeys() {
+			e := f.MapIndex(k)
+			match, err := listEntryMatchesKeys(e, last.GetKey())
+			if err != nil {
+				return err
+			}
+			if !match {
+				continue
+			}
+			if len(rem) == 0 {
+				f.SetMapIndex(k, reflect.Value{})
+				continue
+			}
+			if err := deleteStructPath(e, rem); err != nil {
+				return err
+			}
+		}
+	case len(rem) == 0:
+		f.Set(reflect.Zero(f.Type()))
+	case util.IsTypeStructPtr(f.Type()):
+		return deleteStructPath(f, rem)
+	default:
+		return fmt.Errorf("path %v continues beyond field of type %v", rem, f.Type())
+	}
+	return nil
+}
+
+// listEntryMatchesKeys returns true if the list member e has the key values
+// specified in keys. Keys that are specified as "*" match any value.
+func listEntryMatchesKeys(e reflect.Value, keys map[string]string) (bool, error) {
+	if len(keys) == 0 {
+		return true, nil
+	}
+	ek, err := PathKeyFromStruct(e)
+	if err != nil {
+		return false, err
+	}
+	for k, v := range keys {
+		ev, ok := ek[k]
+		if !ok {
+			return false, fmt.Errorf("key %s does not exist in list member %T", k, e.Interface())
+		}
+		if v != "*" && ev != v {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // fieldOverwriteEnabled returns true if MergeOverwriteExistingFields
 // is present in the slice of MergeOpt.
 func fieldOverwriteEnabled(opts []MergeOpt) bool {
@@ -631,43 +875,62 @@ func copyStruct(dstVal, srcVal reflect.Value, opts ...MergeOpt) error {
 	}
 
 	for i := 0; i < srcVal.NumField(); i++ {
-		srcField := srcVal.Field(i)
-		dstField := dstVal.Field(i)
+		if err := copyField(dstVal.Field(i), srcVal.Field(i), opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-		switch srcField.Kind() {
-		case reflect.Ptr:
-			if err := copyPtrField(dstField, srcField, opts...); err != nil {
-				return err
-			}
-		case reflect.Interface:
-			if err := copyInterfaceField(dstField, srcField, opts...); err != nil {
-				return err
-			}
-		case reflect.Map:
-			if err := copyMapField(dstField, srcField, opts...); err != nil {
-				return err
-			}
-		case reflect.Slice:
-			if err := copySliceField(dstField, srcField, opts...); err != nil {
-				return err
-			}
-		case reflect.Int64:
-			// In the case of an int64 field, which represents a YANG enumeration
-			// we should only set the value in the destination if it is not set
-			// to the default value in the source.
-			vSrc, vDst := srcField.Int(), dstField.Int()
-			switch {
-			case vSrc != 0 && vDst != 0 && vSrc != vDst:
-				if !fieldOverwriteEnabled(opts) {
-					return fmt.Errorf("destination and source values were set when merging enum field, dst: %d, src: %d", vSrc, vDst)
-				}
-				dstField.Set(srcField)
-			case vSrc != 0 && vDst == 0:
-				dstField.Set(srcField)
+// mergeField merges the value of the field pointed to by src into the field
+// pointed to by dst, using the same semantics as MergeStructInto. Both dst and
+// src must be pointers to fields of the same type.
+func mergeField(dst, src interface{}, opts ...MergeOpt) error {
+	dv, sv := reflect.ValueOf(dst), reflect.ValueOf(src)
+	if !util.IsValuePtr(dv) || !util.IsValuePtr(sv) || dv.IsNil() || sv.IsNil() {
+		return fmt.Errorf("invalid fields supplied to mergeField, must be non-nil pointers, dst: %T, src: %T", dst, src)
+	}
+	if dv.Type() != sv.Type() {
+		return fmt.Errorf("cannot merge fields that are not of matching types, %T != %T", dst, src)
+	}
+	return copyField(dv.Elem(), sv.Elem(), opts...)
+}
+
+// copyField copies the field srcField into the dstField in-place.
+func copyField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
+	switch srcField.Kind() {
+	case reflect.Ptr:
+		if err := copyPtrField(dstField, srcField, opts...); err != nil {
+			return err
+		}
+	case reflect.Interface:
+		if err := copyInterfaceField(dstField, srcField, opts...); err != nil {
+			return err
+		}
+	case reflect.Map:
+		if err := copyMapField(dstField, srcField, opts...); err != nil {
+			return err
+		}
+	case reflect.Slice:
+		if err := copySliceField(dstField, srcField, opts...); err != nil {
+			return err
+		}
+	case reflect.Int64:
+		// In the case of an int64 field, which represents a YANG enumeration
+		// we should only set the value in the destination if it is not set
+		// to the default value in the source.
+		vSrc, vDst := srcField.Int(), dstField.Int()
+		switch {
+		case vSrc != 0 && vDst != 0 && vSrc != vDst:
+			if !fieldOverwriteEnabled(opts) {
+				return fmt.Errorf("destination and source values were set when merging enum field, dst: %d, src: %d", vSrc, vDst)
 			}
-		default:
+			dstField.Set(srcField)
+		case vSrc != 0 && vDst == 0:
 			dstField.Set(srcField)
 		}
+	default:
+		dstField.Set(srcField)
 	}
 	return nil
 }
@@ -689,6 +952,26 @@ func copyPtrField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
 		return fmt.Errorf("received non-ptr type: %v", srcField.Kind())
 	}
 
+	// Ordered maps are struct pointers, but are merged in the same way as maps.
+	if util.IsValueOrderedMap(srcField) {
+		return copyOrderedMapField(dstField, srcField, opts...)
+	}
+
+	// Generic unions are struct pointers, but are copied as leaf values.
+	if util.IsValueGenericUnion(srcField) {
+		if !util.IsNilOrInvalidValue(dstField) {
+			if diff := cmp.Diff(srcField.Interface(), dstField.Interface()); !fieldOverwriteEnabled(opts) && diff != "" {
+				return fmt.Errorf("destination value was set, but was not equal to source value when merging union field, (-src, +dst):\n%s", diff)
+			}
+		}
+		u, err := copyGenericUnion(srcField)
+		if err != nil {
+			return err
+		}
+		dstField.Set(u)
+		return nil
+	}
+
 	// Check for struct ptr, or ptr to avoid panic.
 	if util.IsValueStructPtr(srcField) {
 		var d reflect.Value
@@ -825,6 +1108,42 @@ func copyMapField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
 	return nil
 }
 
+// copyOrderedMapField copies srcField into dstField. Both srcField and
+// dstField are reflect.Value structs which contain an ordered map of the same
+// type. Entries whose keys exist in both srcField and dstField are merged in the
+// same way as copyMapField, and retain their position in dstField. Entries
+// that exist only in srcField are appended to dstField, in the order that
+// they appear in srcField.
+func copyOrderedMapField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
+	if srcField.Type() != dstField.Type() {
+		return fmt.Errorf("cannot merge ordered maps of different types, src: %v, dst: %v", srcField.Type(), dstField.Type())
+	}
+	if srcField.Interface().(GoOrderedMap).Len() == 0 {
+		return nil
+	}
+
+	if dstField.IsNil() {
+		dstField.Set(reflect.New(dstField.Type().Elem()))
+	}
+	for _, k := range util.OrderedMapKeys(srcField) {
+		v := util.OrderedMapGet(srcField, k)
+		d := util.OrderedMapGet(dstField, k)
+		isNew := d.IsNil()
+		if isNew {
+			d = reflect.New(v.Elem().Type())
+		}
+		if err := copyStruct(d.Elem(), v.Elem(), opts...); err != nil {
+			return err
+		}
+		if isNew {
+			if err := util.OrderedMapAppend(dstField, d); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // mapTypes provides a specification of a map.
 type mapType struct {
 	key   reflect.Type // key is the type of the key of the map.
@@ -885,6 +1204,17 @@ func copySliceField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
 		}
 	}
 
+	if util.IsTypeGenericUnion(srcField.Type().Elem()) {
+		for i := 0; i < srcField.Len(); i++ {
+			v, err := copyGenericUnion(srcField.Index(i))
+			if err != nil {
+				return err
+			}
+			dstField.Set(reflect.Append(dstField, v))
+		}
+		return nil
+	}
+
 	if !util.IsTypeStructPtr(srcField.Type().Elem()) {
 		for i := 0; i < srcField.Len(); i++ {
 			v := srcField.Index(i)
@@ -904,6 +1234,19 @@ func copySliceField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
 	return nil
 }
 
+// copyGenericUnion returns a copy of the generic union v, which may be a
+// pointer to a union. Binary values held by the union are copied such that
+// they are not shared with v.
+func copyGenericUnion(v reflect.Value) (reflect.Value, error) {
+	uv := util.GenericUnionValue(v)
+	if bv := reflect.ValueOf(uv); bv.Kind() == reflect.Slice {
+		nb := reflect.MakeSlice(bv.Type(), bv.Len(), bv.Len())
+		reflect.Copy(nb, bv)
+		uv = nb.Interface()
+	}
+	return util.NewGenericUnion(v.Type(), uv)
+}
+
 // uniqueSlices takes two reflect.Values which must represent slices, and determines
 // whether a and b are disjoint. It returns true if the slices have unique
 // members, and false if not.
@@ -2360,6 +2360,58 @@ func TestMergeStructInto(t *testing.T) {
 	}
 }
 
+func TestMergeStructIntoOrderedMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		inA      *orderedListStruct
+		inB      *orderedListStruct
+		wantKeys []string
+		wantErr  string
+	}{{
+		name:     "nil ordered map in destination",
+		inA:      &orderedListStruct{},
+		inB:      &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a")},
+		wantKeys: []string{"c", "a"},
+	}, {
+		name:     "nil ordered map in source",
+		inA:      &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a")},
+		inB:      &orderedListStruct{StringValue: String("foo")},
+		wantKeys: []string{"c", "a"},
+	}, {
+		name:     "new entries appended in source order",
+		inA:      &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a")},
+		inB:      &orderedListStruct{List: newBasicListMemberOrderedMap("b", "a", "d")},
+		wantKeys: []string{"c", "a", "b", "d"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MergeStructInto(tt.inA, tt.inB)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("MergeStructInto(%v, %v): did not get expected error status, %s", tt.inA, tt.inB, diff)
+			}
+			if diff := cmp.Diff(tt.wantKeys, tt.inA.List.Keys()); diff != "" {
+				t.Errorf("MergeStructInto(%v, %v): did not get expected keys, diff(-want,+got):\n%s", tt.inA, tt.inB, diff)
+			}
+		})
+	}
+}
+
+func TestDeepCopyOrderedMap(t *testing.T) {
+	in := &orderedListStruct{List: newBasicListMemberOrderedMap("c", "a", "b")}
+	got, err := DeepCopy(in)
+	if err != nil {
+		t.Fatalf("DeepCopy(%v): unexpected error: %v", in, err)
+	}
+	gotList := got.(*orderedListStruct).List
+	if diff := cmp.Diff([]string{"c", "a", "b"}, gotList.Keys()); diff != "" {
+		t.Errorf("DeepCopy(%v): did not get expected keys, diff(-want,+got):\n%s", in, diff)
+	}
+	if gotList == in.List || gotList.Get("a") == in.List.Get("a") {
+		t.Errorf("DeepCopy(%v): returned ordered map shares values with the input", in)
+	}
+}
+
 func TestValidateMap(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -2750,3 +2802,329 @@ func TestUniqueSlices(t *testing.T) {
 		})
 	}
 }
+
+// helperTest is a GoStruct which implements the ΛDeepCopy, ΛMerge and ΛEqual
+// helper methods, recording the number of times each is called.
+type helperTest struct {
+	StringField *string `path:"string-field"`
+
+	calls *int
+}
+
+func (*helperTest) IsYANGGoStruct()                         {}
+func (*helperTest) Validate(...ValidationOption) error      { return nil }
+func (*helperTest) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+
+func (t *helperTest) ΛDeepCopy() (GoStruct, error) {
+	n := &helperTest{calls: t.calls}
+	if err := n.ΛMerge(t); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (t *helperTest) ΛMerge(src GoStruct, opts ...MergeOpt) error {
+	*t.calls++
+	s, ok := src.(*helperTest)
+	if !ok {
+		return fmt.Errorf("cannot merge structs that are not of matching types, %T != %T", t, src)
+	}
+	return mergeField(&t.StringField, &s.StringField, opts...)
+}
+
+func (t *helperTest) ΛEqual(other GoStruct) bool {
+	*t.calls++
+	s, ok := other.(*helperTest)
+	return ok && cmp.Equal(t.StringField, s.StringField)
+}
+
+func TestHelperMethods(t *testing.T) {
+	calls := 0
+	a := &helperTest{StringField: String("a"), calls: &calls}
+
+	c, err := DeepCopy(a)
+	if err != nil {
+		t.Fatalf("DeepCopy(%v): got unexpected error: %v", a, err)
+	}
+	if calls != 1 {
+		t.Errorf("DeepCopy(%v): did not use ΛDeepCopy, got calls: %d, want: 1", a, calls)
+	}
+	if got := c.(*helperTest); got == a || !cmp.Equal(got.StringField, a.StringField) {
+		t.Errorf("DeepCopy(%v): did not get expected copy, got: %v", a, got)
+	}
+
+	b := &helperTest{StringField: String("b"), calls: &calls}
+	if err := MergeStructInto(b, a); err == nil {
+		t.Errorf("MergeStructInto(%v, %v): did not get expected error for conflicting fields", b, a)
+	}
+	if err := MergeStructInto(b, a, &MergeOverwriteExistingFields{}); err != nil {
+		t.Errorf("MergeStructInto(%v, %v, MergeOverwriteExistingFields): got unexpected error: %v", b, a, err)
+	}
+	if calls != 3 {
+		t.Errorf("MergeStructInto: did not use ΛMerge, got calls: %d, want: 3", calls)
+	}
+
+	eq, err := Equal(a, b)
+	if err != nil {
+		t.Fatalf("Equal(%v, %v): got unexpected error: %v", a, b, err)
+	}
+	if !eq || calls != 4 {
+		t.Errorf("Equal(%v, %v): did not use ΛEqual, got: %v, calls: %d, want: true, calls: 4", a, b, eq, calls)
+	}
+}
+
+func TestMergeFieldHelper(t *testing.T) {
+	tests := []struct {
+		name             string
+		inDst            interface{}
+		inSrc            interface{}
+		inOpts           []MergeOpt
+		want             interface{}
+		wantErrSubstring string
+	}{{
+		name:  "leaf-list",
+		inDst: &[]string{"one"},
+		inSrc: &[]string{"two"},
+		want:  &[]string{"one", "two"},
+	}, {
+		name:  "string pointer",
+		inDst: func() **string { var s *string; return &s }(),
+		inSrc: func() **string { s := String("one"); return &s }(),
+		want:  func() **string { s := String("one"); return &s }(),
+	}, {
+		name:             "conflicting string pointer",
+		inDst:            func() **string { s := String("one"); return &s }(),
+		inSrc:            func() **string { s := String("two"); return &s }(),
+		wantErrSubstring: "destination value was set",
+	}, {
+		name:   "conflicting string pointer with overwrite",
+		inDst:  func() **string { s := String("one"); return &s }(),
+		inSrc:  func() **string { s := String("two"); return &s }(),
+		inOpts: []MergeOpt{&MergeOverwriteExistingFields{}},
+		want:   func() **string { s := String("two"); return &s }(),
+	}, {
+		name:             "non-pointer inputs",
+		inDst:            "one",
+		inSrc:            "two",
+		wantErrSubstring: "must be non-nil pointers",
+	}, {
+		name:             "mismatched types",
+		inDst:            &[]string{},
+		inSrc:            &[]int{},
+		wantErrSubstring: "not of matching types",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mergeField(tt.inDst, tt.inSrc, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("mergeField(%v, %v): did not get expected error, %s", tt.inDst, tt.inSrc, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.inDst); diff != "" {
+				t.Errorf("mergeField(%v, %v): did not get expected result, diff(-want, +got):\n%s", tt.inDst, tt.inSrc, diff)
+			}
+		})
+	}
+}
+
+type deleteMergeTest struct {
+	Hostname  *string                            `path:"system/config/hostname|system/hostname"`
+	Domain    *string                            `path:"system/config/domain|system/domain"`
+	Container *deleteMergeTestContainer          `path:"container"`
+	List      map[string]*deleteMergeTestListMem `path:"lists/list"`
+}
+
+func (*deleteMergeTest) Validate(...ValidationOption) error      { return nil }
+func (*deleteMergeTest) IsYANGGoStruct()                         {}
+func (*deleteMergeTest) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+
+type deleteMergeTestContainer struct {
+	Leaf     *string  `path:"leaf"`
+	LeafList []string `path:"leaf-list"`
+}
+
+func (*deleteMergeTestContainer) IsYANGGoStruct() {}
+
+type deleteMergeTestListMem struct {
+	Name  *string                   `path:"config/name|name"`
+	Value *uint32                   `path:"config/value"`
+	Child *deleteMergeTestContainer `path:"child"`
+}
+
+func (*deleteMergeTestListMem) IsYANGGoStruct() {}
+
+func (l *deleteMergeTestListMem) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"name": *l.Name}, nil
+}
+
+func TestMergeDeletePaths(t *testing.T) {
+	newDst := func() *deleteMergeTest {
+		return &deleteMergeTest{
+			Hostname: String("dev1"),
+			Domain:   String("example.com"),
+			Container: &deleteMergeTestContainer{
+				Leaf:     String("leaf"),
+				LeafList: []string{"one", "two"},
+			},
+			List: map[string]*deleteMergeTestListMem{
+				"one": {Name: String("one"), Value: Uint32(1), Child: &deleteMergeTestContainer{Leaf: String("c1")}},
+				"two": {Name: String("two"), Value: Uint32(2)},
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		inSrc            *deleteMergeTest
+		inPaths          []*gnmipb.Path
+		want             *deleteMergeTest
+		wantErrSubstring string
+	}{{
+		name:    "delete leaf using uncompressed path",
+		inSrc:   &deleteMergeTest{},
+		inPaths: []*gnmipb.Path{{Elem: mustPathElem("/system/config/hostname")}},
+		want: func() *deleteMergeTest {
+			d := newDst()
+			d.Hostname = nil
+			return d
+		}(),
+	}, {
+		name:    "delete leaf using compressed path",
+		inSrc:   &deleteMergeTest{},
+		inPaths: []*gnmipb.Path{{Elem: mustPathElem("/system/domain")}},
+		want: func() *deleteMergeTest {
+			d := newDst()
+			d.Domain = nil
+			return d
+		}(),
+	}, {
+		name:    "delete and replace leaf",
+		inSrc:   &deleteMergeTest{Hostname: String("dev2")},
+		inPaths: []*gnmipb.Path{{Elem: mustPathElem("/system/config/hostname")}},
+		want: func() *deleteMergeTest {
+			d := newDst()
+			d.Hostname = String("dev2")
+			return d
+		}(),
+	}, {
+		name:    "delete container",
+		inSrc:   &deleteMergeTest{},
+		inPaths: []*gnmipb.Path{{Elem: mustPathElem("/container")}},
+		want: func() *deleteMergeTest {
+			d := newDst()
+			d.Container = nil
+			return d
+		}(),
+	}, {
+		name:    "delete leaf-list, and list entry",
+		inSrc:   &deleteMergeTest{},
+		inPaths: []*gnmipb.Path{{Elem: mustPathElem("/container/leaf-list")}, {Elem: mustPathElem("/lists/list[name=one]")}},
+		want: func() *deleteMergeTest {
+			d := newDst()
+			d.Container.LeafList = nil
+			delete(d.List, "one")
+			return d
+		}(),
+	}, {
+		name:    "delete leaf within all list entries",
+		inSrc:   &deleteMergeTest{},
+		inPaths: []*gnmipb.Path{{Elem: mustPathElem("/lists/list[name=*]/config/value")}},
+		want: func() *deleteMergeTest {
+			d := newDst()
+			d.List["one"].Value = nil
+			d.List["two"].Value = nil
+			return d
+		}(),
+	}, {
+		name:    "delete container within list entry",
+		inSrc:   &deleteMergeTest{},
+		inPaths: []*gnmipb.Path{{Elem: mustPathElem("/lists/list[name=one]/child/leaf")}},
+		want: func() *deleteMergeTest {
+			d := newDst()
+			d.List["one"].Child.Leaf = nil
+			return d
+		}(),
+	}, {
+		name:    "delete all list entries, and replace one",
+		inSrc:   &deleteMergeTest{List: map[string]*deleteMergeTestListMem{"three": {Name: String("three")}}},
+		inPaths: []*gnmipb.Path{{Elem: mustPathElem("/lists/list")}},
+		want: func() *deleteMergeTest {
+			d := newDst()
+			d.List = map[string]*deleteMergeTestListMem{"three": {Name: String("three")}}
+			return d
+		}(),
+	}, {
+		name:    "delete compressed container",
+		inSrc:   &deleteMergeTest{},
+		inPaths: []*gnmipb.Path{{Elem: mustPathElem("/system/config")}},
+		want: func() *deleteMergeTest {
+			d := newDst()
+			d.Hostname = nil
+			d.Domain = nil
+			return d
+		}(),
+	}, {
+		name:    "delete compressed container within list entry",
+		inSrc:   &deleteMergeTest{},
+		inPaths: []*gnmipb.Path{{Elem: mustPathElem("/lists/list[name=two]/config")}},
+		want: func() *deleteMergeTest {
+			d := newDst()
+			d.List["two"].Name = nil
+			d.List["two"].Value = nil
+			return d
+		}(),
+	}, {
+		name:    "delete compressed container of list",
+		inSrc:   &deleteMergeTest{},
+		inPaths: []*gnmipb.Path{{Elem: mustPathElem("/lists")}},
+		want: func() *deleteMergeTest {
+			d := newDst()
+			d.List = nil
+			return d
+		}(),
+	}, {
+		name:    "delete missing list entry",
+		inSrc:   &deleteMergeTest{},
+		inPaths: []*gnmipb.Path{{Elem: mustPathElem("/lists/list[name=three]/config/value")}},
+		want:    newDst(),
+	}, {
+		name:             "unknown path",
+		inSrc:            &deleteMergeTest{},
+		inPaths:          []*gnmipb.Path{{Elem: mustPathElem("/system/config/location")}},
+		wantErrSubstring: "no field matching path",
+	}, {
+		name:             "unknown key",
+		inSrc:            &deleteMergeTest{},
+		inPaths:          []*gnmipb.Path{{Elem: mustPathElem("/lists/list[id=one]")}},
+		wantErrSubstring: "key id does not exist",
+	}, {
+		name:             "path beyond leaf",
+		inSrc:            &deleteMergeTest{},
+		inPaths:          []*gnmipb.Path{{Elem: mustPathElem("/system/config/hostname/value")}},
+		wantErrSubstring: "continues beyond field",
+	}, {
+		name:             "string slice path",
+		inSrc:            &deleteMergeTest{},
+		inPaths:          []*gnmipb.Path{{Element: []string{"system", "hostname"}}},
+		wantErrSubstring: "must be specified using PathElem",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newDst()
+			err := MergeStructInto(got, tt.inSrc, &MergeDeletePaths{Paths: tt.inPaths})
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MergeStructInto(%v, %v, %v): did not get expected error, %s", got, tt.inSrc, tt.inPaths, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MergeStructInto(%v, %v, %v): did not get expected result, diff(-want, +got):\n%s", got, tt.inSrc, tt.inPaths, diff)
+			}
+		})
+	}
+}
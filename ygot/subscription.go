@@ -0,0 +1,146 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"time"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// SubscriptionOpt is an interface that is implemented by the options to
+// NewSubscription and NewSubscriptionList.
+type SubscriptionOpt interface {
+	// IsSubscriptionOpt is a marker method for each SubscriptionOpt.
+	IsSubscriptionOpt()
+}
+
+// SubscriptionMode is a SubscriptionOpt that specifies the mode of the
+// subscriptions. When it is not specified, the mode is TARGET_DEFINED.
+type SubscriptionMode struct {
+	Mode gpb.SubscriptionMode
+}
+
+// IsSubscriptionOpt marks SubscriptionMode as a SubscriptionOpt.
+func (*SubscriptionMode) IsSubscriptionOpt() {}
+
+// SampleInterval is a SubscriptionOpt that specifies the interval at which
+// the target sends the values of SAMPLE subscriptions.
+type SampleInterval struct {
+	Interval time.Duration
+}
+
+// IsSubscriptionOpt marks SampleInterval as a SubscriptionOpt.
+func (*SampleInterval) IsSubscriptionOpt() {}
+
+// HeartbeatInterval is a SubscriptionOpt that specifies the maximum interval
+// after which the target sends the values of ON_CHANGE subscriptions, or of
+// SAMPLE subscriptions that suppress redundant updates, even if they did not
+// change.
+type HeartbeatInterval struct {
+	Interval time.Duration
+}
+
+// IsSubscriptionOpt marks HeartbeatInterval as a SubscriptionOpt.
+func (*HeartbeatInterval) IsSubscriptionOpt() {}
+
+// SuppressRedundant is a SubscriptionOpt that specifies that the target does
+// not send the values of SAMPLE subscriptions that did not change since they
+// were last sent.
+type SuppressRedundant struct{}
+
+// IsSubscriptionOpt marks SuppressRedundant as a SubscriptionOpt.
+func (*SuppressRedundant) IsSubscriptionOpt() {}
+
+// SubscriptionListMode is a SubscriptionOpt that specifies the mode of a
+// SubscriptionList. When it is not specified, the mode is STREAM. It is
+// ignored by NewSubscription.
+type SubscriptionListMode struct {
+	Mode gpb.SubscriptionList_Mode
+}
+
+// IsSubscriptionOpt marks SubscriptionListMode as a SubscriptionOpt.
+func (*SubscriptionListMode) IsSubscriptionOpt() {}
+
+// NewSubscription returns a gNMI Subscription to the path referred to by the
+// path struct p, configured according to the supplied options. The target of
+// p is retained in the path of the Subscription.
+func NewSubscription(p PathStruct, opts ...SubscriptionOpt) (*gpb.Subscription, error) {
+	path, _, errs := ResolvePath(p)
+	if errs != nil {
+		return nil, fmt.Errorf("cannot resolve path struct %T: %v", p, errs)
+	}
+	return newSubscription(path, opts)
+}
+
+// NewSubscriptionList returns a gNMI SubscriptionList subscribing to the
+// paths referred to by the path structs ps, each of which is configured
+// according to the supplied options. The target of the path structs, which
+// must be the same for all of them, is set in the prefix of the
+// SubscriptionList, and removed from the path of each Subscription.
+func NewSubscriptionList(ps []PathStruct, opts ...SubscriptionOpt) (*gpb.SubscriptionList, error) {
+	if len(ps) == 0 {
+		return nil, fmt.Errorf("no path structs to subscribe to")
+	}
+	sl := &gpb.SubscriptionList{}
+	for _, o := range opts {
+		if m, ok := o.(*SubscriptionListMode); ok {
+			sl.Mode = m.Mode
+		}
+	}
+	for i, p := range ps {
+		path, _, errs := ResolvePath(p)
+		if errs != nil {
+			return nil, fmt.Errorf("cannot resolve path struct %T: %v", p, errs)
+		}
+		switch {
+		case i == 0:
+			sl.Prefix = &gpb.Path{Target: path.Target}
+		case path.Target != sl.Prefix.Target:
+			return nil, fmt.Errorf("path struct %T has target %q, which is not the target %q of the other path structs", p, path.Target, sl.Prefix.Target)
+		}
+		path.Target = ""
+		sub, err := newSubscription(path, opts)
+		if err != nil {
+			return nil, err
+		}
+		sl.Subscription = append(sl.Subscription, sub)
+	}
+	return sl, nil
+}
+
+// newSubscription returns a Subscription to path configured according to
+// opts. It returns an error if the options are not valid for the mode of the
+// subscription.
+func newSubscription(path *gpb.Path, opts []SubscriptionOpt) (*gpb.Subscription, error) {
+	sub := &gpb.Subscription{Path: path}
+	for _, o := range opts {
+		switch o := o.(type) {
+		case *SubscriptionMode:
+			sub.Mode = o.Mode
+		case *SampleInterval:
+			sub.SampleInterval = uint64(o.Interval.Nanoseconds())
+		case *HeartbeatInterval:
+			sub.HeartbeatInterval = uint64(o.Interval.Nanoseconds())
+		case *SuppressRedundant:
+			sub.SuppressRedundant = true
+		}
+	}
+	if sub.Mode == gpb.SubscriptionMode_ON_CHANGE && (sub.SampleInterval != 0 || sub.SuppressRedundant) {
+		return nil, fmt.Errorf("sample interval and suppress redundant cannot be specified for ON_CHANGE subscription to %v", path)
+	}
+	return sub, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestNewSubscription(t *testing.T) {
+	root := deviceRoot{NewDeviceRootBase("dev")}
+	leaf := NewNodePath([]string{"state", "counter"}, nil, root)
+	leafPath := &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "state"}, {Name: "counter"}}}
+
+	tests := []struct {
+		desc             string
+		inOpts           []SubscriptionOpt
+		want             *gpb.Subscription
+		wantErrSubstring string
+	}{{
+		desc: "default target defined",
+		want: &gpb.Subscription{Path: leafPath},
+	}, {
+		desc:   "on change with heartbeat",
+		inOpts: []SubscriptionOpt{&SubscriptionMode{Mode: gpb.SubscriptionMode_ON_CHANGE}, &HeartbeatInterval{Interval: time.Minute}},
+		want: &gpb.Subscription{
+			Path:              leafPath,
+			Mode:              gpb.SubscriptionMode_ON_CHANGE,
+			HeartbeatInterval: uint64(time.Minute),
+		},
+	}, {
+		desc: "sample with suppress redundant",
+		inOpts: []SubscriptionOpt{
+			&SubscriptionMode{Mode: gpb.SubscriptionMode_SAMPLE},
+			&SampleInterval{Interval: 10 * time.Second},
+			&HeartbeatInterval{Interval: time.Minute},
+			&SuppressRedundant{},
+		},
+		want: &gpb.Subscription{
+			Path:              leafPath,
+			Mode:              gpb.SubscriptionMode_SAMPLE,
+			SampleInterval:    uint64(10 * time.Second),
+			HeartbeatInterval: uint64(time.Minute),
+			SuppressRedundant: true,
+		},
+	}, {
+		desc:             "on change with sample interval",
+		inOpts:           []SubscriptionOpt{&SubscriptionMode{Mode: gpb.SubscriptionMode_ON_CHANGE}, &SampleInterval{Interval: time.Second}},
+		wantErrSubstring: "cannot be specified for ON_CHANGE subscription",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := NewSubscription(leaf, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("NewSubscription returned diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewSubscriptionList(t *testing.T) {
+	root := deviceRoot{NewDeviceRootBase("dev")}
+	a := NewNodePath([]string{"a"}, nil, root)
+	b := NewNodePath([]string{"values", "value"}, map[string]interface{}{"id": 5}, root)
+	other := NewNodePath([]string{"a"}, nil, deviceRoot{NewDeviceRootBase("other")})
+
+	tests := []struct {
+		desc             string
+		inPaths          []PathStruct
+		inOpts           []SubscriptionOpt
+		want             *gpb.SubscriptionList
+		wantErrSubstring string
+	}{{
+		desc:    "stream",
+		inPaths: []PathStruct{a, b},
+		inOpts:  []SubscriptionOpt{&SubscriptionMode{Mode: gpb.SubscriptionMode_ON_CHANGE}},
+		want: &gpb.SubscriptionList{
+			Prefix: &gpb.Path{Target: "dev"},
+			Subscription: []*gpb.Subscription{{
+				Path: &gpb.Path{Elem: []*gpb.PathElem{{Name: "a"}}},
+				Mode: gpb.SubscriptionMode_ON_CHANGE,
+			}, {
+				Path: &gpb.Path{Elem: []*gpb.PathElem{{Name: "values"}, {Name: "value", Key: map[string]string{"id": "5"}}}},
+				Mode: gpb.SubscriptionMode_ON_CHANGE,
+			}},
+		},
+	}, {
+		desc:    "once",
+		inPaths: []PathStruct{a},
+		inOpts:  []SubscriptionOpt{&SubscriptionListMode{Mode: gpb.SubscriptionList_ONCE}},
+		want: &gpb.SubscriptionList{
+			Prefix:       &gpb.Path{Target: "dev"},
+			Mode:         gpb.SubscriptionList_ONCE,
+			Subscription: []*gpb.Subscription{{Path: &gpb.Path{Elem: []*gpb.PathElem{{Name: "a"}}}}},
+		},
+	}, {
+		desc:             "different targets",
+		inPaths:          []PathStruct{a, other},
+		wantErrSubstring: `has target "other"`,
+	}, {
+		desc:             "no paths",
+		wantErrSubstring: "no path structs",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := NewSubscriptionList(tt.inPaths, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("NewSubscriptionList returned diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
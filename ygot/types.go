@@ -65,6 +65,58 @@ type KeyHelperGoStruct interface {
 	ΛListKeyMap() (map[string]interface{}, error)
 }
 
+// DeepCopyHelperGoStruct is an interface which can be implemented by Go
+// structs that are generated to represent a YANG container or list member
+// that have a generated method to copy the struct without using reflection.
+type DeepCopyHelperGoStruct interface {
+	// GoStruct ensures that the interface for a standard GoStruct
+	// is embedded.
+	GoStruct
+	// ΛDeepCopy returns a deep copy of the struct.
+	ΛDeepCopy() (GoStruct, error)
+}
+
+// MergeHelperGoStruct is an interface which can be implemented by Go structs
+// that are generated to represent a YANG container or list member that have
+// a generated method to merge another struct of the same type into them
+// without using reflection.
+type MergeHelperGoStruct interface {
+	// GoStruct ensures that the interface for a standard GoStruct
+	// is embedded.
+	GoStruct
+	// ΛMerge merges the contents of src into the struct, using the
+	// semantics of MergeStructInto.
+	ΛMerge(src GoStruct, opts ...MergeOpt) error
+}
+
+// EqualHelperGoStruct is an interface which can be implemented by Go structs
+// that are generated to represent a YANG container or list member that have
+// a generated method to compare the struct to another without using
+// reflection.
+type EqualHelperGoStruct interface {
+	// GoStruct ensures that the interface for a standard GoStruct
+	// is embedded.
+	GoStruct
+	// ΛEqual returns true if the supplied GoStruct is of the same type,
+	// and has the same contents as the struct. Containers and lists that
+	// are nil are considered equal to those that are empty.
+	ΛEqual(GoStruct) bool
+}
+
+// GoOrderedMap is an interface which is implemented by the ordered map types
+// that are generated to represent keyed YANG lists that are "ordered-by user".
+// In addition to the methods of the interface, each ordered map type has
+// Keys, Values, Get, Delete and Append methods, whose argument and return
+// types are specific to the list, which are used by the ygot libraries via
+// reflection.
+type GoOrderedMap interface {
+	// IsYANGOrderedList is a marker method that indicates that the type
+	// implements the GoOrderedMap interface.
+	IsYANGOrderedList()
+	// Len returns the number of entries in the list.
+	Len() int
+}
+
 // GoEnum is an interface which can be implemented by derived types which
 // represent an enumerated value within a YANG schema. This allows handling
 // code that finds struct fields that implement this interface to do specific
@@ -98,6 +150,19 @@ type EnumDefinition struct {
 	DefiningModule string
 }
 
+// RPCTypes is used to store the details of the Go types that are generated
+// to represent the input and output of a YANG rpc or action. Each type is
+// the type of a pointer to the generated GoStruct, such that a new instance
+// of the input or output can be created using reflect.New(t.Elem()).
+type RPCTypes struct {
+	// Input is the type of the GoStruct representing the input of the
+	// operation. It is nil if the operation has no input.
+	Input reflect.Type
+	// Output is the type of the GoStruct representing the output of the
+	// operation. It is nil if the operation has no output.
+	Output reflect.Type
+}
+
 // Annotation defines an interface that is implemented by optional metadata
 // fields within a GoStruct. Annotations are stored within each struct, and
 // for a struct field, for example:
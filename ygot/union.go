@@ -0,0 +1,269 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Generate the generic union types Union2 through Union8.
+//go:generate go run gen_unions.go
+
+// MaxGenericUnionTypes is the maximum number of member types of a YANG union
+// that can be represented by one of the generic union types.
+const MaxGenericUnionTypes = 8
+
+// GenericUnion is an interface which is implemented by the generic union
+// types (Union2 through Union8, defined in union_types.go) that are used to represent YANG unions within
+// GoStructs generated with generic unions enabled. Each union type is
+// parameterised by the Go types of the union's members, in the order in which
+// they are defined in the YANG schema, and stores a value of exactly one of
+// these types. The zero value of a union is unset.
+//
+// Generic unions are stored as pointers within the fields of a GoStruct that
+// represent leaves, such that an unset leaf is nil, and as values within
+// leaf-lists and list keys. The GenericUnion methods must not be called on a
+// nil pointer.
+type GenericUnion interface {
+	// IsYANGGenericUnion is a marker method that indicates that the type
+	// implements the GenericUnion interface.
+	IsYANGGenericUnion()
+	// Value returns the value that is stored in the union, or nil if the
+	// union is unset. It can be used within a type switch to determine
+	// which of the union's members is set.
+	Value() interface{}
+	// Types returns the Go types of the union's members, in order.
+	Types() []reflect.Type
+}
+
+// GenericUnionSetter is an interface which is implemented by pointers to the
+// generic union types. It allows the value of a union to be set where its
+// member types are not known at compile time.
+type GenericUnionSetter interface {
+	GenericUnion
+	// SetValue sets the value of the union to v, which must be of one of
+	// the union's member types, or be convertible to one of them without
+	// changing its kind (e.g., a []byte value can be stored as a Binary
+	// member). Setting the value to nil unsets the union.
+	SetValue(v interface{}) error
+}
+
+// unionValue stores the value of a generic union, and implements the methods
+// that are common to all of the generic union types.
+type unionValue struct {
+	v interface{}
+}
+
+// IsYANGGenericUnion ensures that the generic union types implement the
+// GenericUnion interface.
+func (unionValue) IsYANGGenericUnion() {}
+
+// Value returns the value stored in the union, or nil if it is unset.
+func (u unionValue) Value() interface{} { return u.v }
+
+// String returns a string representation of the union's value.
+func (u unionValue) String() string {
+	if e, ok := u.v.(GoEnum); ok {
+		return e.String()
+	}
+	return fmt.Sprintf("%v", u.v)
+}
+
+// set sets the value of the union to v, which must be of, or be convertible
+// to, one of the supplied types. Values are converted only where they are of
+// the same kind as the member type, and never to an enumerated type.
+func (u *unionValue) set(v interface{}, types []reflect.Type) error {
+	if v == nil {
+		u.v = nil
+		return nil
+	}
+	vt := reflect.TypeOf(v)
+	for _, t := range types {
+		if vt == t {
+			u.v = v
+			return nil
+		}
+	}
+	for _, t := range types {
+		if t.Kind() != reflect.Interface && t.Kind() == vt.Kind() && vt.ConvertibleTo(t) && !t.Implements(goEnumType) {
+			u.v = reflect.ValueOf(v).Convert(t).Interface()
+			return nil
+		}
+	}
+	for _, t := range types {
+		if t.Kind() == reflect.Interface && vt.Implements(t) {
+			u.v = v
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot set union of types %v to value %v of type %T", types, v, v)
+}
+
+// marshalJSON marshals the value of the union to RFC7951 JSON, using the same
+// rules as ConstructIETFJSON with module names appended. 64-bit integer and
+// decimal64 values are marshalled as strings, and identityref values are
+// qualified by the name of their defining module.
+func (u unionValue) marshalJSON() ([]byte, error) {
+	v, err := resolveUnionVal(u.v, true)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(writeIETFScalarJSON(v))
+}
+
+// unmarshalJSON sets the value of the union to the RFC7951 JSON value b, using
+// the first of the supplied member types that the value can be unmarshalled
+// into. Enumerated types are tried first, since their values are also valid
+// strings.
+func (u *unionValue) unmarshalJSON(b []byte, types []reflect.Type) error {
+	if string(b) == "null" {
+		u.v = nil
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var jv interface{}
+	if err := dec.Decode(&jv); err != nil {
+		return fmt.Errorf("cannot unmarshal %s into union of types %v: %v", b, types, err)
+	}
+	if name, ok := jv.(string); ok {
+		if i := strings.LastIndex(name, ":"); i != -1 {
+			name = name[i+1:]
+		}
+		for _, t := range types {
+			if t.Implements(goEnumType) {
+				if ev, ok := enumValueFromName(t, name); ok {
+					u.v = ev.Interface()
+					return nil
+				}
+			}
+		}
+	}
+	for _, t := range types {
+		if t.Implements(goEnumType) {
+			continue
+		}
+		if v, ok := unionMemberFromJSON(t, jv); ok {
+			u.v = v.Interface()
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot unmarshal %s into union of types %v", b, types)
+}
+
+// unionMemberFromJSON returns the value of type t that is represented by the
+// decoded RFC7951 JSON value jv, in which numbers are json.Numbers. It returns
+// false if jv is not a valid value of type t, such as where a 64-bit integer
+// or decimal64 value is not a string, as RFC7951 requires.
+func unionMemberFromJSON(t reflect.Type, jv interface{}) (reflect.Value, bool) {
+	v := reflect.New(t).Elem()
+	s, isString := jv.(string)
+	n, isNumber := jv.(json.Number)
+	switch t.Kind() {
+	case reflect.String:
+		if !isString {
+			return v, false
+		}
+		v.SetString(s)
+	case reflect.Bool:
+		b, ok := jv.(bool)
+		if !ok {
+			return v, false
+		}
+		v.SetBool(b)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		num, ok := jsonIntegerString(t, s, isString, n, isNumber)
+		if !ok {
+			return v, false
+		}
+		i, err := strconv.ParseInt(num, 10, t.Bits())
+		if err != nil {
+			return v, false
+		}
+		v.SetInt(i)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		num, ok := jsonIntegerString(t, s, isString, n, isNumber)
+		if !ok {
+			return v, false
+		}
+		i, err := strconv.ParseUint(num, 10, t.Bits())
+		if err != nil {
+			return v, false
+		}
+		v.SetUint(i)
+	case reflect.Float64:
+		if !isString {
+			return v, false
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return v, false
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 || !isString {
+			return v, false
+		}
+		bs, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return v, false
+		}
+		v.SetBytes(bs)
+	default:
+		return v, false
+	}
+	return v, true
+}
+
+// jsonIntegerString returns the string form of the RFC7951 JSON integer that
+// is to be parsed into type t. 64-bit integers are encoded as JSON strings,
+// and all other integers as JSON numbers.
+func jsonIntegerString(t reflect.Type, s string, isString bool, n json.Number, isNumber bool) (string, bool) {
+	if t.Bits() == 64 {
+		return s, isString
+	}
+	return string(n), isNumber
+}
+
+// goEnumType is the reflect.Type of the GoEnum interface.
+var goEnumType = reflect.TypeOf((*GoEnum)(nil)).Elem()
+
+// enumValueFromName returns the value of the enumerated type t, which must
+// implement GoEnum, with the YANG name name. It returns false if there is no
+// such value.
+func enumValueFromName(t reflect.Type, name string) (reflect.Value, bool) {
+	e := reflect.Zero(t).Interface().(GoEnum)
+	for i, def := range e.ΛMap()[t.Name()] {
+		if def.Name == name {
+			return reflect.ValueOf(i).Convert(t), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// typeOf returns the reflect.Type of T, including where T is an interface
+// type.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,395 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// exampleGenericUnion is a generic union of the types used within the tests
+// in this file.
+type exampleGenericUnion = Union4[string, uint32, EnumTest, Binary]
+
+// genericUnionExample is a GoStruct that uses generic unions for a leaf, a
+// leaf-list and a list key.
+type genericUnionExample struct {
+	Leaf     *exampleGenericUnion                                   `path:"leaf"`
+	LeafList []exampleGenericUnion                                  `path:"leaf-list"`
+	List     map[exampleGenericUnion]*genericUnionExampleListMember `path:"list"`
+}
+
+// IsYANGGoStruct ensures that genericUnionExample implements the GoStruct
+// interface.
+func (*genericUnionExample) IsYANGGoStruct() {}
+
+// genericUnionExampleListMember is a member of a list keyed by a generic
+// union.
+type genericUnionExampleListMember struct {
+	Key *exampleGenericUnion `path:"key"`
+}
+
+// IsYANGGoStruct ensures that genericUnionExampleListMember implements the
+// GoStruct interface.
+func (*genericUnionExampleListMember) IsYANGGoStruct() {}
+
+// ΛListKeyMap ensures that genericUnionExampleListMember implements the
+// KeyHelperGoStruct interface.
+func (e *genericUnionExampleListMember) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"key": *e.Key}, nil
+}
+
+func TestGenericUnion(t *testing.T) {
+	u := exampleGenericUnion{}.Of1("forty-two")
+	if got, ok := u.Get1(); !ok || got != "forty-two" {
+		t.Errorf("Get1: got (%v, %v), want (forty-two, true)", got, ok)
+	}
+	if got, ok := u.Get2(); ok {
+		t.Errorf("Get2: got (%v, %v), want (0, false)", got, ok)
+	}
+
+	u.Set3(EnumTestVALTWO)
+	if got, ok := u.Get3(); !ok || got != EnumTestVALTWO {
+		t.Errorf("Get3: got (%v, %v), want (VAL_TWO, true)", got, ok)
+	}
+	if got, want := u.String(), "VAL_TWO"; got != want {
+		t.Errorf("String: got %s, want %s", got, want)
+	}
+
+	var gu GenericUnion = u
+	switch v := gu.Value().(type) {
+	case EnumTest:
+		if v != EnumTestVALTWO {
+			t.Errorf("Value: got %v, want VAL_TWO", v)
+		}
+	default:
+		t.Errorf("Value: got type %T, want EnumTest", v)
+	}
+
+	wantTypes := []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(uint32(0)), reflect.TypeOf(EnumTest(0)), reflect.TypeOf(Binary{})}
+	if got := u.Types(); !reflect.DeepEqual(got, wantTypes) {
+		t.Errorf("Types: got %v, want %v", got, wantTypes)
+	}
+
+	if !u.Equal(*exampleGenericUnion{}.Of3(EnumTestVALTWO)) {
+		t.Errorf("Equal: got false for unions holding the same value")
+	}
+	if u.Equal(*exampleGenericUnion{}.Of2(2)) {
+		t.Errorf("Equal: got true for unions holding different values")
+	}
+}
+
+func TestGenericUnionSetValue(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               interface{}
+		want             interface{}
+		wantErrSubstring string
+	}{{
+		desc: "exact type",
+		in:   uint32(42),
+		want: uint32(42),
+	}, {
+		desc: "enumerated type",
+		in:   EnumTestVALONE,
+		want: EnumTestVALONE,
+	}, {
+		desc: "byte slice converted to binary",
+		in:   []byte{1, 2},
+		want: Binary{1, 2},
+	}, {
+		desc: "nil unsets",
+		in:   nil,
+	}, {
+		desc:             "int64 is not converted to an enumerated type",
+		in:               int64(1),
+		wantErrSubstring: "cannot set union",
+	}, {
+		desc:             "value of a different kind",
+		in:               true,
+		wantErrSubstring: "cannot set union",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var s GenericUnionSetter = exampleGenericUnion{}.Of1("initial")
+			err := s.SetValue(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("SetValue(%v): %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, s.Value()); diff != "" {
+				t.Errorf("SetValue(%v): (-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestGenericUnionJSON(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               *exampleGenericUnion
+		wantJSON         string
+		inJSON           string
+		want             *exampleGenericUnion
+		wantErrSubstring string
+	}{{
+		desc:     "string",
+		in:       exampleGenericUnion{}.Of1("foo"),
+		wantJSON: `"foo"`,
+		want:     exampleGenericUnion{}.Of1("foo"),
+	}, {
+		desc:     "number",
+		in:       exampleGenericUnion{}.Of2(42),
+		wantJSON: `42`,
+		want:     exampleGenericUnion{}.Of2(42),
+	}, {
+		desc:     "enumerated value",
+		in:       exampleGenericUnion{}.Of3(EnumTestVALONE),
+		wantJSON: `"foo:VAL_ONE"`,
+		want:     exampleGenericUnion{}.Of3(EnumTestVALONE),
+	}, {
+		desc:   "enumerated value with module prefix",
+		inJSON: `"foo:VAL_TWO"`,
+		want:   exampleGenericUnion{}.Of3(EnumTestVALTWO),
+	}, {
+		desc:     "unset",
+		in:       &exampleGenericUnion{},
+		wantJSON: `null`,
+		want:     &exampleGenericUnion{},
+	}, {
+		desc:             "invalid value",
+		inJSON:           `true`,
+		wantErrSubstring: "cannot unmarshal",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inJSON := tt.inJSON
+			if tt.in != nil {
+				b, err := tt.in.MarshalJSON()
+				if err != nil {
+					t.Fatalf("MarshalJSON: unexpected error: %v", err)
+				}
+				if got := string(b); got != tt.wantJSON {
+					t.Errorf("MarshalJSON: got %s, want %s", got, tt.wantJSON)
+				}
+				inJSON = string(b)
+			}
+
+			got := &exampleGenericUnion{}
+			err := got.UnmarshalJSON([]byte(inJSON))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalJSON(%s): %s", inJSON, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalJSON(%s): (-want, +got):\n%s", inJSON, diff)
+			}
+		})
+	}
+}
+
+// exampleRFC7951Union is a union whose members have RFC7951 encodings that
+// differ from their default encoding/json encoding.
+type exampleRFC7951Union = Union3[int64, float64, EnumTest]
+
+func TestGenericUnionRFC7951JSON(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               *exampleRFC7951Union
+		wantJSON         string
+		inJSON           string
+		want             *exampleRFC7951Union
+		wantErrSubstring string
+	}{{
+		desc:     "int64",
+		in:       exampleRFC7951Union{}.Of1(-9223372036854775808),
+		wantJSON: `"-9223372036854775808"`,
+		want:     exampleRFC7951Union{}.Of1(-9223372036854775808),
+	}, {
+		desc:     "decimal64",
+		in:       exampleRFC7951Union{}.Of2(10.5),
+		wantJSON: `"10.5"`,
+		want:     exampleRFC7951Union{}.Of2(10.5),
+	}, {
+		desc:     "identityref",
+		in:       exampleRFC7951Union{}.Of3(EnumTestVALTWO),
+		wantJSON: `"bar:VAL_TWO"`,
+		want:     exampleRFC7951Union{}.Of3(EnumTestVALTWO),
+	}, {
+		desc:   "string-encoded decimal64",
+		inJSON: `"10.5"`,
+		want:   exampleRFC7951Union{}.Of2(10.5),
+	}, {
+		desc:             "unquoted int64",
+		inJSON:           `42`,
+		wantErrSubstring: "cannot unmarshal",
+	}, {
+		desc:             "unquoted decimal64",
+		inJSON:           `10.5`,
+		wantErrSubstring: "cannot unmarshal",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inJSON := tt.inJSON
+			if tt.in != nil {
+				b, err := tt.in.MarshalJSON()
+				if err != nil {
+					t.Fatalf("MarshalJSON: unexpected error: %v", err)
+				}
+				if got := string(b); got != tt.wantJSON {
+					t.Errorf("MarshalJSON: got %s, want %s", got, tt.wantJSON)
+				}
+				inJSON = string(b)
+			}
+
+			got := &exampleRFC7951Union{}
+			err := got.UnmarshalJSON([]byte(inJSON))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalJSON(%s): %s", inJSON, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalJSON(%s): (-want, +got):\n%s", inJSON, diff)
+			}
+		})
+	}
+}
+
+func TestGenericUnionGoStruct(t *testing.T) {
+	in := &genericUnionExample{
+		Leaf:     exampleGenericUnion{}.Of4(Binary{42}),
+		LeafList: []exampleGenericUnion{*exampleGenericUnion{}.Of1("foo"), *exampleGenericUnion{}.Of3(EnumTestVALTWO)},
+		List: map[exampleGenericUnion]*genericUnionExampleListMember{
+			*exampleGenericUnion{}.Of2(42): {Key: exampleGenericUnion{}.Of2(42)},
+		},
+	}
+
+	t.Run("ConstructIETFJSON", func(t *testing.T) {
+		got, err := ConstructIETFJSON(in, &RFC7951JSONConfig{AppendModuleName: true})
+		if err != nil {
+			t.Fatalf("ConstructIETFJSON: unexpected error: %v", err)
+		}
+		want := map[string]interface{}{
+			"leaf":      "Kg==",
+			"leaf-list": []interface{}{"foo", "bar:VAL_TWO"},
+			"list": []interface{}{
+				map[string]interface{}{"key": uint32(42)},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("ConstructIETFJSON: (-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ConstructInternalJSON", func(t *testing.T) {
+		got, err := ConstructInternalJSON(in)
+		if err != nil {
+			t.Fatalf("ConstructInternalJSON: unexpected error: %v", err)
+		}
+		want := map[string]interface{}{
+			"leaf":      "Kg==",
+			"leaf-list": []interface{}{"foo", "VAL_TWO"},
+			"list": map[string]interface{}{
+				"42": map[string]interface{}{"key": uint32(42)},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("ConstructInternalJSON: (-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("TogNMINotifications", func(t *testing.T) {
+		got, err := TogNMINotifications(in, 42, GNMINotificationsConfig{UsePathElem: true})
+		if err != nil {
+			t.Fatalf("TogNMINotifications: unexpected error: %v", err)
+		}
+		want := []*gnmipb.Notification{{
+			Timestamp: 42,
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "leaf"}}},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{[]byte{42}}},
+			}, {
+				Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "leaf-list"}}},
+				Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{&gnmipb.ScalarArray{
+					Element: []*gnmipb.TypedValue{
+						{Value: &gnmipb.TypedValue_StringVal{"foo"}},
+						{Value: &gnmipb.TypedValue_StringVal{"VAL_TWO"}},
+					},
+				}}},
+			}, {
+				Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "list", Key: map[string]string{"key": "42"}}, {Name: "key"}}},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{42}},
+			}},
+		}}
+		if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+			t.Errorf("TogNMINotifications: (-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("DeepCopy and Diff", func(t *testing.T) {
+		c, err := DeepCopy(in)
+		if err != nil {
+			t.Fatalf("DeepCopy: unexpected error: %v", err)
+		}
+		cp := c.(*genericUnionExample)
+		if diff := cmp.Diff(in, cp, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("DeepCopy: (-want, +got):\n%s", diff)
+		}
+		if cp.Leaf == in.Leaf {
+			t.Errorf("DeepCopy: union leaf was not copied")
+		}
+
+		cp.Leaf.Set2(84)
+		got, err := Diff(in, cp)
+		if err != nil {
+			t.Fatalf("Diff: unexpected error: %v", err)
+		}
+		want := &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "leaf"}}},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{84}},
+			}},
+		}
+		if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("Diff: (-want, +got):\n%s", diff)
+		}
+
+		if err := copyStruct(reflect.ValueOf(in).Elem(), reflect.ValueOf(cp).Elem()); err == nil {
+			t.Errorf("copyStruct: did not get expected error for conflicting union leaves")
+		}
+		if err := copyStruct(reflect.ValueOf(in).Elem(), reflect.ValueOf(cp).Elem(), &MergeOverwriteExistingFields{}); err != nil {
+			t.Fatalf("copyStruct: unexpected error: %v", err)
+		}
+		if got, ok := in.Leaf.Get2(); !ok || got != 84 {
+			t.Errorf("copyStruct: got leaf (%v, %v), want (84, true)", got, ok)
+		}
+	})
+}
@@ -0,0 +1,747 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gen_unions.go. DO NOT EDIT.
+
+package ygot
+
+import "reflect"
+
+// Union2 is a generic union whose value is of one of the two types T1 or T2.
+type Union2[T1, T2 any] struct {
+	unionValue
+}
+
+// Of1 returns a new union whose value is v, of type T1.
+func (Union2[T1, T2]) Of1(v T1) *Union2[T1, T2] {
+	return &Union2[T1, T2]{unionValue{v}}
+}
+
+// Of2 returns a new union whose value is v, of type T2.
+func (Union2[T1, T2]) Of2(v T2) *Union2[T1, T2] {
+	return &Union2[T1, T2]{unionValue{v}}
+}
+
+// Get1 returns the value of the union and true if it is of type T1,
+// otherwise it returns the zero value of T1 and false.
+func (u Union2[T1, T2]) Get1() (T1, bool) {
+	v, ok := u.v.(T1)
+	return v, ok
+}
+
+// Set1 sets the value of the union to v, of type T1.
+func (u *Union2[T1, T2]) Set1(v T1) { u.v = v }
+
+// Get2 returns the value of the union and true if it is of type T2,
+// otherwise it returns the zero value of T2 and false.
+func (u Union2[T1, T2]) Get2() (T2, bool) {
+	v, ok := u.v.(T2)
+	return v, ok
+}
+
+// Set2 sets the value of the union to v, of type T2.
+func (u *Union2[T1, T2]) Set2(v T2) { u.v = v }
+
+// Types returns the Go types of the union's members, in order.
+func (Union2[T1, T2]) Types() []reflect.Type {
+	return []reflect.Type{typeOf[T1](), typeOf[T2]()}
+}
+
+// SetValue sets the value of the union to v, which must be of one of the
+// union's member types.
+func (u *Union2[T1, T2]) SetValue(v interface{}) error {
+	return u.set(v, u.Types())
+}
+
+// MarshalJSON marshals the value of the union to JSON.
+func (u Union2[T1, T2]) MarshalJSON() ([]byte, error) { return u.marshalJSON() }
+
+// Equal reports whether the union holds the same value as o.
+func (u Union2[T1, T2]) Equal(o Union2[T1, T2]) bool {
+	return reflect.DeepEqual(u.v, o.v)
+}
+
+// UnmarshalJSON sets the value of the union to the supplied JSON value.
+func (u *Union2[T1, T2]) UnmarshalJSON(b []byte) error {
+	return u.unmarshalJSON(b, u.Types())
+}
+
+// Union3 is a generic union whose value is of one of the three types T1, T2 or T3.
+type Union3[T1, T2, T3 any] struct {
+	unionValue
+}
+
+// Of1 returns a new union whose value is v, of type T1.
+func (Union3[T1, T2, T3]) Of1(v T1) *Union3[T1, T2, T3] {
+	return &Union3[T1, T2, T3]{unionValue{v}}
+}
+
+// Of2 returns a new union whose value is v, of type T2.
+func (Union3[T1, T2, T3]) Of2(v T2) *Union3[T1, T2, T3] {
+	return &Union3[T1, T2, T3]{unionValue{v}}
+}
+
+// Of3 returns a new union whose value is v, of type T3.
+func (Union3[T1, T2, T3]) Of3(v T3) *Union3[T1, T2, T3] {
+	return &Union3[T1, T2, T3]{unionValue{v}}
+}
+
+// Get1 returns the value of the union and true if it is of type T1,
+// otherwise it returns the zero value of T1 and false.
+func (u Union3[T1, T2, T3]) Get1() (T1, bool) {
+	v, ok := u.v.(T1)
+	return v, ok
+}
+
+// Set1 sets the value of the union to v, of type T1.
+func (u *Union3[T1, T2, T3]) Set1(v T1) { u.v = v }
+
+// Get2 returns the value of the union and true if it is of type T2,
+// otherwise it returns the zero value of T2 and false.
+func (u Union3[T1, T2, T3]) Get2() (T2, bool) {
+	v, ok := u.v.(T2)
+	return v, ok
+}
+
+// Set2 sets the value of the union to v, of type T2.
+func (u *Union3[T1, T2, T3]) Set2(v T2) { u.v = v }
+
+// Get3 returns the value of the union and true if it is of type T3,
+// otherwise it returns the zero value of T3 and false.
+func (u Union3[T1, T2, T3]) Get3() (T3, bool) {
+	v, ok := u.v.(T3)
+	return v, ok
+}
+
+// Set3 sets the value of the union to v, of type T3.
+func (u *Union3[T1, T2, T3]) Set3(v T3) { u.v = v }
+
+// Types returns the Go types of the union's members, in order.
+func (Union3[T1, T2, T3]) Types() []reflect.Type {
+	return []reflect.Type{typeOf[T1](), typeOf[T2](), typeOf[T3]()}
+}
+
+// SetValue sets the value of the union to v, which must be of one of the
+// union's member types.
+func (u *Union3[T1, T2, T3]) SetValue(v interface{}) error {
+	return u.set(v, u.Types())
+}
+
+// MarshalJSON marshals the value of the union to JSON.
+func (u Union3[T1, T2, T3]) MarshalJSON() ([]byte, error) { return u.marshalJSON() }
+
+// Equal reports whether the union holds the same value as o.
+func (u Union3[T1, T2, T3]) Equal(o Union3[T1, T2, T3]) bool {
+	return reflect.DeepEqual(u.v, o.v)
+}
+
+// UnmarshalJSON sets the value of the union to the supplied JSON value.
+func (u *Union3[T1, T2, T3]) UnmarshalJSON(b []byte) error {
+	return u.unmarshalJSON(b, u.Types())
+}
+
+// Union4 is a generic union whose value is of one of the four types T1, T2, T3 or T4.
+type Union4[T1, T2, T3, T4 any] struct {
+	unionValue
+}
+
+// Of1 returns a new union whose value is v, of type T1.
+func (Union4[T1, T2, T3, T4]) Of1(v T1) *Union4[T1, T2, T3, T4] {
+	return &Union4[T1, T2, T3, T4]{unionValue{v}}
+}
+
+// Of2 returns a new union whose value is v, of type T2.
+func (Union4[T1, T2, T3, T4]) Of2(v T2) *Union4[T1, T2, T3, T4] {
+	return &Union4[T1, T2, T3, T4]{unionValue{v}}
+}
+
+// Of3 returns a new union whose value is v, of type T3.
+func (Union4[T1, T2, T3, T4]) Of3(v T3) *Union4[T1, T2, T3, T4] {
+	return &Union4[T1, T2, T3, T4]{unionValue{v}}
+}
+
+// Of4 returns a new union whose value is v, of type T4.
+func (Union4[T1, T2, T3, T4]) Of4(v T4) *Union4[T1, T2, T3, T4] {
+	return &Union4[T1, T2, T3, T4]{unionValue{v}}
+}
+
+// Get1 returns the value of the union and true if it is of type T1,
+// otherwise it returns the zero value of T1 and false.
+func (u Union4[T1, T2, T3, T4]) Get1() (T1, bool) {
+	v, ok := u.v.(T1)
+	return v, ok
+}
+
+// Set1 sets the value of the union to v, of type T1.
+func (u *Union4[T1, T2, T3, T4]) Set1(v T1) { u.v = v }
+
+// Get2 returns the value of the union and true if it is of type T2,
+// otherwise it returns the zero value of T2 and false.
+func (u Union4[T1, T2, T3, T4]) Get2() (T2, bool) {
+	v, ok := u.v.(T2)
+	return v, ok
+}
+
+// Set2 sets the value of the union to v, of type T2.
+func (u *Union4[T1, T2, T3, T4]) Set2(v T2) { u.v = v }
+
+// Get3 returns the value of the union and true if it is of type T3,
+// otherwise it returns the zero value of T3 and false.
+func (u Union4[T1, T2, T3, T4]) Get3() (T3, bool) {
+	v, ok := u.v.(T3)
+	return v, ok
+}
+
+// Set3 sets the value of the union to v, of type T3.
+func (u *Union4[T1, T2, T3, T4]) Set3(v T3) { u.v = v }
+
+// Get4 returns the value of the union and true if it is of type T4,
+// otherwise it returns the zero value of T4 and false.
+func (u Union4[T1, T2, T3, T4]) Get4() (T4, bool) {
+	v, ok := u.v.(T4)
+	return v, ok
+}
+
+// Set4 sets the value of the union to v, of type T4.
+func (u *Union4[T1, T2, T3, T4]) Set4(v T4) { u.v = v }
+
+// Types returns the Go types of the union's members, in order.
+func (Union4[T1, T2, T3, T4]) Types() []reflect.Type {
+	return []reflect.Type{typeOf[T1](), typeOf[T2](), typeOf[T3](), typeOf[T4]()}
+}
+
+// SetValue sets the value of the union to v, which must be of one of the
+// union's member types.
+func (u *Union4[T1, T2, T3, T4]) SetValue(v interface{}) error {
+	return u.set(v, u.Types())
+}
+
+// MarshalJSON marshals the value of the union to JSON.
+func (u Union4[T1, T2, T3, T4]) MarshalJSON() ([]byte, error) { return u.marshalJSON() }
+
+// Equal reports whether the union holds the same value as o.
+func (u Union4[T1, T2, T3, T4]) Equal(o Union4[T1, T2, T3, T4]) bool {
+	return reflect.DeepEqual(u.v, o.v)
+}
+
+// UnmarshalJSON sets the value of the union to the supplied JSON value.
+func (u *Union4[T1, T2, T3, T4]) UnmarshalJSON(b []byte) error {
+	return u.unmarshalJSON(b, u.Types())
+}
+
+// Union5 is a generic union whose value is of one of the five types T1, T2, T3, T4 or T5.
+type Union5[T1, T2, T3, T4, T5 any] struct {
+	unionValue
+}
+
+// Of1 returns a new union whose value is v, of type T1.
+func (Union5[T1, T2, T3, T4, T5]) Of1(v T1) *Union5[T1, T2, T3, T4, T5] {
+	return &Union5[T1, T2, T3, T4, T5]{unionValue{v}}
+}
+
+// Of2 returns a new union whose value is v, of type T2.
+func (Union5[T1, T2, T3, T4, T5]) Of2(v T2) *Union5[T1, T2, T3, T4, T5] {
+	return &Union5[T1, T2, T3, T4, T5]{unionValue{v}}
+}
+
+// Of3 returns a new union whose value is v, of type T3.
+func (Union5[T1, T2, T3, T4, T5]) Of3(v T3) *Union5[T1, T2, T3, T4, T5] {
+	return &Union5[T1, T2, T3, T4, T5]{unionValue{v}}
+}
+
+// Of4 returns a new union whose value is v, of type T4.
+func (Union5[T1, T2, T3, T4, T5]) Of4(v T4) *Union5[T1, T2, T3, T4, T5] {
+	return &Union5[T1, T2, T3, T4, T5]{unionValue{v}}
+}
+
+// Of5 returns a new union whose value is v, of type T5.
+func (Union5[T1, T2, T3, T4, T5]) Of5(v T5) *Union5[T1, T2, T3, T4, T5] {
+	return &Union5[T1, T2, T3, T4, T5]{unionValue{v}}
+}
+
+// Get1 returns the value of the union and true if it is of type T1,
+// otherwise it returns the zero value of T1 and false.
+func (u Union5[T1, T2, T3, T4, T5]) Get1() (T1, bool) {
+	v, ok := u.v.(T1)
+	return v, ok
+}
+
+// Set1 sets the value of the union to v, of type T1.
+func (u *Union5[T1, T2, T3, T4, T5]) Set1(v T1) { u.v = v }
+
+// Get2 returns the value of the union and true if it is of type T2,
+// otherwise it returns the zero value of T2 and false.
+func (u Union5[T1, T2, T3, T4, T5]) Get2() (T2, bool) {
+	v, ok := u.v.(T2)
+	return v, ok
+}
+
+// Set2 sets the value of the union to v, of type T2.
+func (u *Union5[T1, T2, T3, T4, T5]) Set2(v T2) { u.v = v }
+
+// Get3 returns the value of the union and true if it is of type T3,
+// otherwise it returns the zero value of T3 and false.
+func (u Union5[T1, T2, T3, T4, T5]) Get3() (T3, bool) {
+	v, ok := u.v.(T3)
+	return v, ok
+}
+
+// Set3 sets the value of the union to v, of type T3.
+func (u *Union5[T1, T2, T3, T4, T5]) Set3(v T3) { u.v = v }
+
+// Get4 returns the value of the union and true if it is of type T4,
+// otherwise it returns the zero value of T4 and false.
+func (u Union5[T1, T2, T3, T4, T5]) Get4() (T4, bool) {
+	v, ok := u.v.(T4)
+	return v, ok
+}
+
+// Set4 sets the value of the union to v, of type T4.
+func (u *Union5[T1, T2, T3, T4, T5]) Set4(v T4) { u.v = v }
+
+// Get5 returns the value of the union and true if it is of type T5,
+// otherwise it returns the zero value of T5 and false.
+func (u Union5[T1, T2, T3, T4, T5]) Get5() (T5, bool) {
+	v, ok := u.v.(T5)
+	return v, ok
+}
+
+// Set5 sets the value of the union to v, of type T5.
+func (u *Union5[T1, T2, T3, T4, T5]) Set5(v T5) { u.v = v }
+
+// Types returns the Go types of the union's members, in order.
+func (Union5[T1, T2, T3, T4, T5]) Types() []reflect.Type {
+	return []reflect.Type{typeOf[T1](), typeOf[T2](), typeOf[T3](), typeOf[T4](), typeOf[T5]()}
+}
+
+// SetValue sets the value of the union to v, which must be of one of the
+// union's member types.
+func (u *Union5[T1, T2, T3, T4, T5]) SetValue(v interface{}) error {
+	return u.set(v, u.Types())
+}
+
+// MarshalJSON marshals the value of the union to JSON.
+func (u Union5[T1, T2, T3, T4, T5]) MarshalJSON() ([]byte, error) { return u.marshalJSON() }
+
+// Equal reports whether the union holds the same value as o.
+func (u Union5[T1, T2, T3, T4, T5]) Equal(o Union5[T1, T2, T3, T4, T5]) bool {
+	return reflect.DeepEqual(u.v, o.v)
+}
+
+// UnmarshalJSON sets the value of the union to the supplied JSON value.
+func (u *Union5[T1, T2, T3, T4, T5]) UnmarshalJSON(b []byte) error {
+	return u.unmarshalJSON(b, u.Types())
+}
+
+// Union6 is a generic union whose value is of one of the six types T1, T2, T3, T4, T5 or T6.
+type Union6[T1, T2, T3, T4, T5, T6 any] struct {
+	unionValue
+}
+
+// Of1 returns a new union whose value is v, of type T1.
+func (Union6[T1, T2, T3, T4, T5, T6]) Of1(v T1) *Union6[T1, T2, T3, T4, T5, T6] {
+	return &Union6[T1, T2, T3, T4, T5, T6]{unionValue{v}}
+}
+
+// Of2 returns a new union whose value is v, of type T2.
+func (Union6[T1, T2, T3, T4, T5, T6]) Of2(v T2) *Union6[T1, T2, T3, T4, T5, T6] {
+	return &Union6[T1, T2, T3, T4, T5, T6]{unionValue{v}}
+}
+
+// Of3 returns a new union whose value is v, of type T3.
+func (Union6[T1, T2, T3, T4, T5, T6]) Of3(v T3) *Union6[T1, T2, T3, T4, T5, T6] {
+	return &Union6[T1, T2, T3, T4, T5, T6]{unionValue{v}}
+}
+
+// Of4 returns a new union whose value is v, of type T4.
+func (Union6[T1, T2, T3, T4, T5, T6]) Of4(v T4) *Union6[T1, T2, T3, T4, T5, T6] {
+	return &Union6[T1, T2, T3, T4, T5, T6]{unionValue{v}}
+}
+
+// Of5 returns a new union whose value is v, of type T5.
+func (Union6[T1, T2, T3, T4, T5, T6]) Of5(v T5) *Union6[T1, T2, T3, T4, T5, T6] {
+	return &Union6[T1, T2, T3, T4, T5, T6]{unionValue{v}}
+}
+
+// Of6 returns a new union whose value is v, of type T6.
+func (Union6[T1, T2, T3, T4, T5, T6]) Of6(v T6) *Union6[T1, T2, T3, T4, T5, T6] {
+	return &Union6[T1, T2, T3, T4, T5, T6]{unionValue{v}}
+}
+
+// Get1 returns the value of the union and true if it is of type T1,
+// otherwise it returns the zero value of T1 and false.
+func (u Union6[T1, T2, T3, T4, T5, T6]) Get1() (T1, bool) {
+	v, ok := u.v.(T1)
+	return v, ok
+}
+
+// Set1 sets the value of the union to v, of type T1.
+func (u *Union6[T1, T2, T3, T4, T5, T6]) Set1(v T1) { u.v = v }
+
+// Get2 returns the value of the union and true if it is of type T2,
+// otherwise it returns the zero value of T2 and false.
+func (u Union6[T1, T2, T3, T4, T5, T6]) Get2() (T2, bool) {
+	v, ok := u.v.(T2)
+	return v, ok
+}
+
+// Set2 sets the value of the union to v, of type T2.
+func (u *Union6[T1, T2, T3, T4, T5, T6]) Set2(v T2) { u.v = v }
+
+// Get3 returns the value of the union and true if it is of type T3,
+// otherwise it returns the zero value of T3 and false.
+func (u Union6[T1, T2, T3, T4, T5, T6]) Get3() (T3, bool) {
+	v, ok := u.v.(T3)
+	return v, ok
+}
+
+// Set3 sets the value of the union to v, of type T3.
+func (u *Union6[T1, T2, T3, T4, T5, T6]) Set3(v T3) { u.v = v }
+
+// Get4 returns the value of the union and true if it is of type T4,
+// otherwise it returns the zero value of T4 and false.
+func (u Union6[T1, T2, T3, T4, T5, T6]) Get4() (T4, bool) {
+	v, ok := u.v.(T4)
+	return v, ok
+}
+
+// Set4 sets the value of the union to v, of type T4.
+func (u *Union6[T1, T2, T3, T4, T5, T6]) Set4(v T4) { u.v = v }
+
+// Get5 returns the value of the union and true if it is of type T5,
+// otherwise it returns the zero value of T5 and false.
+func (u Union6[T1, T2, T3, T4, T5, T6]) Get5() (T5, bool) {
+	v, ok := u.v.(T5)
+	return v, ok
+}
+
+// Set5 sets the value of the union to v, of type T5.
+func (u *Union6[T1, T2, T3, T4, T5, T6]) Set5(v T5) { u.v = v }
+
+// Get6 returns the value of the union and true if it is of type T6,
+// otherwise it returns the zero value of T6 and false.
+func (u Union6[T1, T2, T3, T4, T5, T6]) Get6() (T6, bool) {
+	v, ok := u.v.(T6)
+	return v, ok
+}
+
+// Set6 sets the value of the union to v, of type T6.
+func (u *Union6[T1, T2, T3, T4, T5, T6]) Set6(v T6) { u.v = v }
+
+// Types returns the Go types of the union's members, in order.
+func (Union6[T1, T2, T3, T4, T5, T6]) Types() []reflect.Type {
+	return []reflect.Type{typeOf[T1](), typeOf[T2](), typeOf[T3](), typeOf[T4](), typeOf[T5](), typeOf[T6]()}
+}
+
+// SetValue sets the value of the union to v, which must be of one of the
+// union's member types.
+func (u *Union6[T1, T2, T3, T4, T5, T6]) SetValue(v interface{}) error {
+	return u.set(v, u.Types())
+}
+
+// MarshalJSON marshals the value of the union to JSON.
+func (u Union6[T1, T2, T3, T4, T5, T6]) MarshalJSON() ([]byte, error) { return u.marshalJSON() }
+
+// Equal reports whether the union holds the same value as o.
+func (u Union6[T1, T2, T3, T4, T5, T6]) Equal(o Union6[T1, T2, T3, T4, T5, T6]) bool {
+	return reflect.DeepEqual(u.v, o.v)
+}
+
+// UnmarshalJSON sets the value of the union to the supplied JSON value.
+func (u *Union6[T1, T2, T3, T4, T5, T6]) UnmarshalJSON(b []byte) error {
+	return u.unmarshalJSON(b, u.Types())
+}
+
+// Union7 is a generic union whose value is of one of the seven types T1, T2, T3, T4, T5, T6 or T7.
+type Union7[T1, T2, T3, T4, T5, T6, T7 any] struct {
+	unionValue
+}
+
+// Of1 returns a new union whose value is v, of type T1.
+func (Union7[T1, T2, T3, T4, T5, T6, T7]) Of1(v T1) *Union7[T1, T2, T3, T4, T5, T6, T7] {
+	return &Union7[T1, T2, T3, T4, T5, T6, T7]{unionValue{v}}
+}
+
+// Of2 returns a new union whose value is v, of type T2.
+func (Union7[T1, T2, T3, T4, T5, T6, T7]) Of2(v T2) *Union7[T1, T2, T3, T4, T5, T6, T7] {
+	return &Union7[T1, T2, T3, T4, T5, T6, T7]{unionValue{v}}
+}
+
+// Of3 returns a new union whose value is v, of type T3.
+func (Union7[T1, T2, T3, T4, T5, T6, T7]) Of3(v T3) *Union7[T1, T2, T3, T4, T5, T6, T7] {
+	return &Union7[T1, T2, T3, T4, T5, T6, T7]{unionValue{v}}
+}
+
+// Of4 returns a new union whose value is v, of type T4.
+func (Union7[T1, T2, T3, T4, T5, T6, T7]) Of4(v T4) *Union7[T1, T2, T3, T4, T5, T6, T7] {
+	return &Union7[T1, T2, T3, T4, T5, T6, T7]{unionValue{v}}
+}
+
+// Of5 returns a new union whose value is v, of type T5.
+func (Union7[T1, T2, T3, T4, T5, T6, T7]) Of5(v T5) *Union7[T1, T2, T3, T4, T5, T6, T7] {
+	return &Union7[T1, T2, T3, T4, T5, T6, T7]{unionValue{v}}
+}
+
+// Of6 returns a new union whose value is v, of type T6.
+func (Union7[T1, T2, T3, T4, T5, T6, T7]) Of6(v T6) *Union7[T1, T2, T3, T4, T5, T6, T7] {
+	return &Union7[T1, T2, T3, T4, T5, T6, T7]{unionValue{v}}
+}
+
+// Of7 returns a new union whose value is v, of type T7.
+func (Union7[T1, T2, T3, T4, T5, T6, T7]) Of7(v T7) *Union7[T1, T2, T3, T4, T5, T6, T7] {
+	return &Union7[T1, T2, T3, T4, T5, T6, T7]{unionValue{v}}
+}
+
+// Get1 returns the value of the union and true if it is of type T1,
+// otherwise it returns the zero value of T1 and false.
+func (u Union7[T1, T2, T3, T4, T5, T6, T7]) Get1() (T1, bool) {
+	v, ok := u.v.(T1)
+	return v, ok
+}
+
+// Set1 sets the value of the union to v, of type T1.
+func (u *Union7[T1, T2, T3, T4, T5, T6, T7]) Set1(v T1) { u.v = v }
+
+// Get2 returns the value of the union and true if it is of type T2,
+// otherwise it returns the zero value of T2 and false.
+func (u Union7[T1, T2, T3, T4, T5, T6, T7]) Get2() (T2, bool) {
+	v, ok := u.v.(T2)
+	return v, ok
+}
+
+// Set2 sets the value of the union to v, of type T2.
+func (u *Union7[T1, T2, T3, T4, T5, T6, T7]) Set2(v T2) { u.v = v }
+
+// Get3 returns the value of the union and true if it is of type T3,
+// otherwise it returns the zero value of T3 and false.
+func (u Union7[T1, T2, T3, T4, T5, T6, T7]) Get3() (T3, bool) {
+	v, ok := u.v.(T3)
+	return v, ok
+}
+
+// Set3 sets the value of the union to v, of type T3.
+func (u *Union7[T1, T2, T3, T4, T5, T6, T7]) Set3(v T3) { u.v = v }
+
+// Get4 returns the value of the union and true if it is of type T4,
+// otherwise it returns the zero value of T4 and false.
+func (u Union7[T1, T2, T3, T4, T5, T6, T7]) Get4() (T4, bool) {
+	v, ok := u.v.(T4)
+	return v, ok
+}
+
+// Set4 sets the value of the union to v, of type T4.
+func (u *Union7[T1, T2, T3, T4, T5, T6, T7]) Set4(v T4) { u.v = v }
+
+// Get5 returns the value of the union and true if it is of type T5,
+// otherwise it returns the zero value of T5 and false.
+func (u Union7[T1, T2, T3, T4, T5, T6, T7]) Get5() (T5, bool) {
+	v, ok := u.v.(T5)
+	return v, ok
+}
+
+// Set5 sets the value of the union to v, of type T5.
+func (u *Union7[T1, T2, T3, T4, T5, T6, T7]) Set5(v T5) { u.v = v }
+
+// Get6 returns the value of the union and true if it is of type T6,
+// otherwise it returns the zero value of T6 and false.
+func (u Union7[T1, T2, T3, T4, T5, T6, T7]) Get6() (T6, bool) {
+	v, ok := u.v.(T6)
+	return v, ok
+}
+
+// Set6 sets the value of the union to v, of type T6.
+func (u *Union7[T1, T2, T3, T4, T5, T6, T7]) Set6(v T6) { u.v = v }
+
+// Get7 returns the value of the union and true if it is of type T7,
+// otherwise it returns the zero value of T7 and false.
+func (u Union7[T1, T2, T3, T4, T5, T6, T7]) Get7() (T7, bool) {
+	v, ok := u.v.(T7)
+	return v, ok
+}
+
+// Set7 sets the value of the union to v, of type T7.
+func (u *Union7[T1, T2, T3, T4, T5, T6, T7]) Set7(v T7) { u.v = v }
+
+// Types returns the Go types of the union's members, in order.
+func (Union7[T1, T2, T3, T4, T5, T6, T7]) Types() []reflect.Type {
+	return []reflect.Type{typeOf[T1](), typeOf[T2](), typeOf[T3](), typeOf[T4](), typeOf[T5](), typeOf[T6](), typeOf[T7]()}
+}
+
+// SetValue sets the value of the union to v, which must be of one of the
+// union's member types.
+func (u *Union7[T1, T2, T3, T4, T5, T6, T7]) SetValue(v interface{}) error {
+	return u.set(v, u.Types())
+}
+
+// MarshalJSON marshals the value of the union to JSON.
+func (u Union7[T1, T2, T3, T4, T5, T6, T7]) MarshalJSON() ([]byte, error) { return u.marshalJSON() }
+
+// Equal reports whether the union holds the same value as o.
+func (u Union7[T1, T2, T3, T4, T5, T6, T7]) Equal(o Union7[T1, T2, T3, T4, T5, T6, T7]) bool {
+	return reflect.DeepEqual(u.v, o.v)
+}
+
+// UnmarshalJSON sets the value of the union to the supplied JSON value.
+func (u *Union7[T1, T2, T3, T4, T5, T6, T7]) UnmarshalJSON(b []byte) error {
+	return u.unmarshalJSON(b, u.Types())
+}
+
+// Union8 is a generic union whose value is of one of the eight types T1, T2, T3, T4, T5, T6, T7 or T8.
+type Union8[T1, T2, T3, T4, T5, T6, T7, T8 any] struct {
+	unionValue
+}
+
+// Of1 returns a new union whose value is v, of type T1.
+func (Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Of1(v T1) *Union8[T1, T2, T3, T4, T5, T6, T7, T8] {
+	return &Union8[T1, T2, T3, T4, T5, T6, T7, T8]{unionValue{v}}
+}
+
+// Of2 returns a new union whose value is v, of type T2.
+func (Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Of2(v T2) *Union8[T1, T2, T3, T4, T5, T6, T7, T8] {
+	return &Union8[T1, T2, T3, T4, T5, T6, T7, T8]{unionValue{v}}
+}
+
+// Of3 returns a new union whose value is v, of type T3.
+func (Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Of3(v T3) *Union8[T1, T2, T3, T4, T5, T6, T7, T8] {
+	return &Union8[T1, T2, T3, T4, T5, T6, T7, T8]{unionValue{v}}
+}
+
+// Of4 returns a new union whose value is v, of type T4.
+func (Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Of4(v T4) *Union8[T1, T2, T3, T4, T5, T6, T7, T8] {
+	return &Union8[T1, T2, T3, T4, T5, T6, T7, T8]{unionValue{v}}
+}
+
+// Of5 returns a new union whose value is v, of type T5.
+func (Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Of5(v T5) *Union8[T1, T2, T3, T4, T5, T6, T7, T8] {
+	return &Union8[T1, T2, T3, T4, T5, T6, T7, T8]{unionValue{v}}
+}
+
+// Of6 returns a new union whose value is v, of type T6.
+func (Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Of6(v T6) *Union8[T1, T2, T3, T4, T5, T6, T7, T8] {
+	return &Union8[T1, T2, T3, T4, T5, T6, T7, T8]{unionValue{v}}
+}
+
+// Of7 returns a new union whose value is v, of type T7.
+func (Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Of7(v T7) *Union8[T1, T2, T3, T4, T5, T6, T7, T8] {
+	return &Union8[T1, T2, T3, T4, T5, T6, T7, T8]{unionValue{v}}
+}
+
+// Of8 returns a new union whose value is v, of type T8.
+func (Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Of8(v T8) *Union8[T1, T2, T3, T4, T5, T6, T7, T8] {
+	return &Union8[T1, T2, T3, T4, T5, T6, T7, T8]{unionValue{v}}
+}
+
+// Get1 returns the value of the union and true if it is of type T1,
+// otherwise it returns the zero value of T1 and false.
+func (u Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Get1() (T1, bool) {
+	v, ok := u.v.(T1)
+	return v, ok
+}
+
+// Set1 sets the value of the union to v, of type T1.
+func (u *Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Set1(v T1) { u.v = v }
+
+// Get2 returns the value of the union and true if it is of type T2,
+// otherwise it returns the zero value of T2 and false.
+func (u Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Get2() (T2, bool) {
+	v, ok := u.v.(T2)
+	return v, ok
+}
+
+// Set2 sets the value of the union to v, of type T2.
+func (u *Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Set2(v T2) { u.v = v }
+
+// Get3 returns the value of the union and true if it is of type T3,
+// otherwise it returns the zero value of T3 and false.
+func (u Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Get3() (T3, bool) {
+	v, ok := u.v.(T3)
+	return v, ok
+}
+
+// Set3 sets the value of the union to v, of type T3.
+func (u *Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Set3(v T3) { u.v = v }
+
+// Get4 returns the value of the union and true if it is of type T4,
+// otherwise it returns the zero value of T4 and false.
+func (u Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Get4() (T4, bool) {
+	v, ok := u.v.(T4)
+	return v, ok
+}
+
+// Set4 sets the value of the union to v, of type T4.
+func (u *Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Set4(v T4) { u.v = v }
+
+// Get5 returns the value of the union and true if it is of type T5,
+// otherwise it returns the zero value of T5 and false.
+func (u Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Get5() (T5, bool) {
+	v, ok := u.v.(T5)
+	return v, ok
+}
+
+// Set5 sets the value of the union to v, of type T5.
+func (u *Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Set5(v T5) { u.v = v }
+
+// Get6 returns the value of the union and true if it is of type T6,
+// otherwise it returns the zero value of T6 and false.
+func (u Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Get6() (T6, bool) {
+	v, ok := u.v.(T6)
+	return v, ok
+}
+
+// Set6 sets the value of the union to v, of type T6.
+func (u *Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Set6(v T6) { u.v = v }
+
+// Get7 returns the value of the union and true if it is of type T7,
+// otherwise it returns the zero value of T7 and false.
+func (u Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Get7() (T7, bool) {
+	v, ok := u.v.(T7)
+	return v, ok
+}
+
+// Set7 sets the value of the union to v, of type T7.
+func (u *Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Set7(v T7) { u.v = v }
+
+// Get8 returns the value of the union and true if it is of type T8,
+// otherwise it returns the zero value of T8 and false.
+func (u Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Get8() (T8, bool) {
+	v, ok := u.v.(T8)
+	return v, ok
+}
+
+// Set8 sets the value of the union to v, of type T8.
+func (u *Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Set8(v T8) { u.v = v }
+
+// Types returns the Go types of the union's members, in order.
+func (Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Types() []reflect.Type {
+	return []reflect.Type{typeOf[T1](), typeOf[T2](), typeOf[T3](), typeOf[T4](), typeOf[T5](), typeOf[T6](), typeOf[T7](), typeOf[T8]()}
+}
+
+// SetValue sets the value of the union to v, which must be of one of the
+// union's member types.
+func (u *Union8[T1, T2, T3, T4, T5, T6, T7, T8]) SetValue(v interface{}) error {
+	return u.set(v, u.Types())
+}
+
+// MarshalJSON marshals the value of the union to JSON.
+func (u Union8[T1, T2, T3, T4, T5, T6, T7, T8]) MarshalJSON() ([]byte, error) { return u.marshalJSON() }
+
+// Equal reports whether the union holds the same value as o.
+func (u Union8[T1, T2, T3, T4, T5, T6, T7, T8]) Equal(o Union8[T1, T2, T3, T4, T5, T6, T7, T8]) bool {
+	return reflect.DeepEqual(u.v, o.v)
+}
+
+// UnmarshalJSON sets the value of the union to the supplied JSON value.
+func (u *Union8[T1, T2, T3, T4, T5, T6, T7, T8]) UnmarshalJSON(b []byte) error {
+	return u.unmarshalJSON(b, u.Types())
+}
@@ -14,12 +14,9 @@
 
 // Package ypathgen contains a library to generate gNMI paths from a YANG model.
 // The ygen library is used to parse YANG and obtain intermediate and some final
-// information. The output always assumes the OpenConfig-specific conventions
-// for a compressed schema.
-//
-// TODO(wenbli): This package is written with only compressed schemas in mind.
-// If needed, can write tests, verify, and enhance it to support uncompressed
-// ygen structs.
+// information. By default, the output assumes the OpenConfig-specific
+// conventions for a compressed schema; path structs can alternatively be
+// generated for an uncompressed schema, for use with uncompressed ygen structs.
 package ypathgen
 
 import (
@@ -65,6 +62,14 @@ const (
 	// NOTE: This cannot be "", as the builder method name would conflict
 	// with the child constructor method for the keys.
 	BuilderKeyPrefix = "With"
+	// DescendantsMethodName is the name of the method of each non-leaf
+	// PathStruct that returns the path of all of its descendants when
+	// GenerateDescendantPaths is set.
+	DescendantsMethodName = "Descendants"
+	// OperationRootCtorSuffix is the suffix applied to the name of the
+	// function that constructs the root path struct of an operation tree
+	// when GenerateOperationPaths is set.
+	OperationRootCtorSuffix = "Root"
 )
 
 // NewDefaultConfig creates a GenConfig with default configuration.
@@ -78,6 +83,7 @@ func NewDefaultConfig(schemaStructPkgPath string) *GenConfig {
 		GoImports: GoImports{
 			SchemaStructPkgPath: schemaStructPkgPath,
 			YgotImportPath:      genutil.GoDefaultYgotImportPath,
+			YtypesImportPath:    genutil.GoDefaultYtypesImportPath,
 		},
 		FakeRootName:     defaultFakeRootName,
 		PathStructSuffix: defaultPathStructSuffix,
@@ -91,6 +97,12 @@ type GenConfig struct {
 	PackageName string
 	// GoImports contains package import options.
 	GoImports GoImports
+	// UncompressedPaths specifies whether the path structs are generated
+	// for the uncompressed form of the schema, in which the config and
+	// state containers of the YANG schema are retained, rather than the
+	// compressed form that follows the OpenConfig conventions. It must
+	// match the compression used when generating the GoStructs with ygen.
+	UncompressedPaths bool
 	// PreferOperationalState generates path-build methods for only the
 	// "state" version of a field when it exists under both "config" and
 	// "state" containers of its parent YANG model. If it is false, then
@@ -148,6 +160,14 @@ type GenConfig struct {
 	// github.com/openconfig/goyang/pkg/yang library. These specify how the
 	// input YANG files should be parsed.
 	YANGParseOptions yang.Options
+	// EnabledFeatures specifies the set of YANG features that are enabled,
+	// in the form "module:feature" or "module:all". If it is non-nil, path
+	// structs are not generated for nodes whose if-feature statements are
+	// not satisfied. See ygen.ParseOpts for further details.
+	EnabledFeatures []string
+	// DeviationModules specifies the paths to a set of YANG modules that
+	// contain deviations that should be applied to the input schema.
+	DeviationModules []string
 	// GeneratingBinary is the name of the binary calling the generator library, it is
 	// included in the header of output files for debugging purposes. If a
 	// string is not specified, the location of the library is utilised.
@@ -160,6 +180,39 @@ type GenConfig struct {
 	ListBuilderKeyThreshold uint
 	// GenerateWildcardPaths means to generate wildcard nodes and paths.
 	GenerateWildcardPaths bool
+	// GenerateTypedLeafPaths means to generate the path structs of leaves
+	// such that they embed a ygot.LeafPath, rather than a ygot.NodePath,
+	// whose type parameters are the Go type of the leaf's value and the
+	// type of the GoStruct that the leaf is a field of. This links each
+	// path to the type of its value at compile time.
+	GenerateTypedLeafPaths bool
+	// GenerateParsePath means to generate a ParsePath function, which
+	// returns the path struct corresponding to a gNMI path, along with a
+	// ΛChildren method for each path struct of a non-leaf node that
+	// describes how to construct the path structs of its children.
+	GenerateParsePath bool
+	// GenerateDescendantPaths means to generate a Descendants method for
+	// each path struct of a non-leaf node, which returns the path of all
+	// of the node's descendants using the multilevel wildcard "...".
+	GenerateDescendantPaths bool
+	// GenerateConfigStatePaths means to generate, for each path struct of
+	// a node that has leaves under both its "config" and "state"
+	// containers, Config and State methods returning path structs of
+	// these containers, which are otherwise compressed out of the schema.
+	// These allow both the config and the state path of a leaf to be
+	// constructed, regardless of PreferOperationalState. It is only
+	// meaningful for compressed paths.
+	GenerateConfigStatePaths bool
+	// GenerateOperationPaths means to generate path structs for the trees
+	// of the input and output of YANG rpc and action statements, and of
+	// YANG notification statements, which are otherwise ignored. Each
+	// such tree has its own root path struct, which is constructed by a
+	// function named after it with the suffix "Root", and from which
+	// paths relative to the root of the tree are constructed. The
+	// GoStructs must be generated with ygen's GenerateRPCTypes and
+	// GenerateNotificationTypes for the generated code to be compatible
+	// with them.
+	GenerateOperationPaths bool
 	// SimplifyWildcardPaths causes non-builder-style generated wildcard
 	// nodes, where all key values are wildcards, to omit the [key="*"] in
 	// the generated path.
@@ -196,6 +249,10 @@ type GoImports struct {
 	// YgotImportPath specifies the path to the ygot library that should be used
 	// in the generated code.
 	YgotImportPath string
+	// YtypesImportPath specifies the path to the ytypes library that should
+	// be used in the generated code. It is only used if ParsePath is
+	// generated.
+	YtypesImportPath string
 }
 
 // GeneratePathCode takes a slice of strings containing the path to a set of YANG
@@ -223,16 +280,20 @@ func (cg *GenConfig) GeneratePathCode(yangFiles, includePaths []string) (map[str
 	// many ways in which compilation may fail, coupled with the plethora
 	// of configurations, means there is an argument to force the user to
 	// debug instead of making ypathgen having to catch every error.
-	compressBehaviour, err := genutil.TranslateToCompressBehaviour(true, cg.ExcludeState, cg.PreferOperationalState)
+	compressBehaviour, err := genutil.TranslateToCompressBehaviour(!cg.UncompressedPaths, cg.ExcludeState, cg.PreferOperationalState)
 	if err != nil {
 		return nil, nil, util.NewErrs(fmt.Errorf("ypathgen: unable to translate compress behaviour: %v", err))
 	}
 
 	dcg := &ygen.DirectoryGenConfig{
 		ParseOptions: ygen.ParseOpts{
-			YANGParseOptions:      cg.YANGParseOptions,
-			ExcludeModules:        cg.ExcludeModules,
-			SkipEnumDeduplication: cg.SkipEnumDeduplication,
+			YANGParseOptions:          cg.YANGParseOptions,
+			ExcludeModules:            cg.ExcludeModules,
+			SkipEnumDeduplication:     cg.SkipEnumDeduplication,
+			EnabledFeatures:           cg.EnabledFeatures,
+			DeviationModules:          cg.DeviationModules,
+			GenerateRPCTypes:          cg.GenerateOperationPaths,
+			GenerateNotificationTypes: cg.GenerateOperationPaths,
 		},
 		TransformationOptions: ygen.TransformationOpts{
 			CompressBehaviour:                    compressBehaviour,
@@ -265,12 +326,36 @@ func (cg *GenConfig) GeneratePathCode(yangFiles, includePaths []string) (map[str
 		schemaStructPkgAccessor = schemaStructPkgAlias + "."
 	}
 
+	opts := pathStructOpts{
+		schemaStructPkgAccessor:  schemaStructPkgAccessor,
+		pathStructSuffix:         cg.PathStructSuffix,
+		generateWildcardPaths:    cg.GenerateWildcardPaths,
+		simplifyWildcardPaths:    cg.SimplifyWildcardPaths,
+		splitByModule:            cg.SplitByModule,
+		trimOCPackage:            cg.TrimOCPackage,
+		compressPaths:            !cg.UncompressedPaths,
+		generateParsePath:        cg.GenerateParsePath,
+		generateDescendantPaths:  cg.GenerateDescendantPaths,
+		generateConfigStatePaths: cg.GenerateConfigStatePaths,
+		packageName:              cg.PackageName,
+		packageSuffix:            cg.PackageSuffix,
+	}
+	if cg.GenerateWildcardPaths {
+		opts.listBuilderKeyThreshold = cg.ListBuilderKeyThreshold
+	}
+
 	// Get NodeDataMap for the schema.
-	nodeDataMap, es := getNodeDataMap(directories, leafTypeMap, cg.FakeRootName, schemaStructPkgAccessor, cg.PathStructSuffix, cg.PackageName, cg.PackageSuffix, cg.SplitByModule, cg.TrimOCPackage)
+	nodeDataMap, es := getNodeDataMap(directories, leafTypeMap, cg.FakeRootName, opts)
 	if es != nil {
 		errs = util.AppendErrs(errs, es)
 	}
 
+	// The types of leaves are only needed when typed leaf paths are
+	// generated.
+	if cg.GenerateTypedLeafPaths {
+		opts.leafNodeData = nodeDataMap
+	}
+
 	// Generate struct code.
 	var structSnippets []GoPathStructCodeSnippet
 	for _, directoryName := range orderedDirNames {
@@ -280,12 +365,7 @@ func (cg *GenConfig) GeneratePathCode(yangFiles, includePaths []string) (map[str
 				util.NewErrs(fmt.Errorf("GeneratePathCode: Implementation bug -- node %s not found in dirNameMap", directoryName)))
 		}
 
-		var listBuilderKeyThreshold uint
-		if cg.GenerateWildcardPaths {
-			listBuilderKeyThreshold = cg.ListBuilderKeyThreshold
-		}
-
-		structSnippet, es := generateDirectorySnippet(directory, directories, schemaStructPkgAccessor, cg.PathStructSuffix, listBuilderKeyThreshold, cg.GenerateWildcardPaths, cg.SimplifyWildcardPaths, cg.SplitByModule, cg.TrimOCPackage, cg.PackageName, cg.PackageSuffix)
+		structSnippet, es := generateDirectorySnippet(directory, directories, opts)
 		if es != nil {
 			errs = util.AppendErrs(errs, es)
 		}
@@ -498,7 +578,7 @@ var (
 /*
 Package {{ .PackageName }} is a generated package which contains definitions
 of structs which generate gNMI paths for a YANG schema. The generated paths are
-based on a compressed form of the schema.
+based on {{ if .UncompressedPaths }}an uncompressed{{ else }}a compressed{{ end }} form of the schema.
 
 This package was generated by {{ .GeneratingBinary }}
 using the following YANG input files:
@@ -516,7 +596,13 @@ import (
 	{{- if .SchemaStructPkgPath }}
 	{{ .SchemaStructPkgAlias }} "{{ .SchemaStructPkgPath }}"
 	{{- end }}
+	{{- if .GNMIImportPath }}
+	gpb "{{ .GNMIImportPath }}"
+	{{- end }}
 	"{{ .YgotImportPath }}"
+	{{- if .GenerateParsePath }}
+	"{{ .YtypesImportPath }}"
+	{{- end }}
 {{- range $import := .ExtraImports }}
 	"{{ $import }}"
 {{- end }}
@@ -538,6 +624,25 @@ type {{ .TypeName }} struct {
 func DeviceRoot(id string) *{{ .TypeName }} {
 	return &{{ .TypeName }}{ygot.New{{- .FakeRootBaseTypeName }}(id)}
 }
+`)
+
+	// goPathOperationRootTemplate defines a template for the type
+	// definition and constructor of the root of the tree of the input or
+	// output of an rpc or action, or of a notification. Like the fakeroot,
+	// it embeds FakeRootBaseTypeName, such that the paths of its
+	// descendents are resolved relative to the root of the tree.
+	goPathOperationRootTemplate = mustTemplate("operationRoot", `
+// {{ .TypeName }} represents the {{ .YANGPath }} YANG schema element, the
+// root of a YANG {{ .TreeKind }} tree.
+type {{ .TypeName }} struct {
+	*ygot.{{ .FakeRootBaseTypeName }}
+}
+
+// {{ .ConstructorName }} returns a new path object from which YANG paths within the
+// {{ .TreeKind }} tree can be constructed, relative to the root of the tree.
+func {{ .ConstructorName }}(id string) *{{ .TypeName }} {
+	return &{{ .TypeName }}{ygot.New{{- .FakeRootBaseTypeName }}(id)}
+}
 `)
 
 	// goPathStructTemplate defines the template for the type definition of
@@ -551,15 +656,21 @@ func DeviceRoot(id string) *{{ .TypeName }} {
 	// holds contains a wildcard, but is otherwise the exact same.
 	goPathStructTemplate = mustTemplate("struct", `
 // {{ .TypeName }} represents the {{ .YANGPath }} YANG schema element.
+{{- if .ShadowYANGPath }}
+// It also represents the shadowed {{ .ShadowYANGPath }} YANG schema element.
+{{- end }}
 type {{ .TypeName }} struct {
-	*ygot.{{ .PathBaseTypeName }}
+	*ygot.{{ .PathBaseTypeName }}{{ .TypeArgs }}
 }
 
 {{- if .GenerateWildcardPaths }}
 
 // {{ .TypeName }}{{ .WildcardSuffix }} represents the wildcard version of the {{ .YANGPath }} YANG schema element.
+{{- if .ShadowYANGPath }}
+// It also represents the wildcard version of the shadowed {{ .ShadowYANGPath }} YANG schema element.
+{{- end }}
 type {{ .TypeName }}{{ .WildcardSuffix }} struct {
-	*ygot.{{ .PathBaseTypeName }}
+	*ygot.{{ .PathBaseTypeName }}{{ .TypeArgs }}
 }
 {{- end }}
 `)
@@ -574,13 +685,95 @@ type {{ .TypeName }}{{ .WildcardSuffix }} struct {
 {{- end }}
 func (n *{{ .Struct.TypeName }}) {{ .MethodName -}} ({{ .KeyParamListStr }}) *{{ .ChildPkgAccessor }}{{ .TypeName }} {
 	return &{{ .ChildPkgAccessor }}{{ .TypeName }}{
-		{{ .Struct.PathBaseTypeName }}: ygot.New{{ .Struct.PathBaseTypeName }}(
+		{{ .PathBaseTypeName }}: ygot.New{{ .PathBaseTypeName }}{{ .TypeArgs }}(
 			[]string{ {{- .RelPathList -}} },
 			map[string]interface{}{ {{- .KeyEntriesStr -}} },
 			n,
 		),
 	}
 }
+`)
+
+	// goPathChildrenTemplate generates the ΛChildren method of a path
+	// struct, which returns the descriptions of the path structs of its
+	// children that are used by ytypes.ParsePathStruct.
+	goPathChildrenTemplate = mustTemplate("children", `
+// ΛChildren returns the descriptions of the children of {{ .TypeName }}, which
+// are used by ParsePath.
+func (n *{{ .TypeName }}) ΛChildren() []*ytypes.PathStructChild {
+	return []*ytypes.PathStructChild{
+	{{- range $child := .Children }}
+		{
+			Path: []string{ {{- $child.RelPathList -}} },
+			{{- if $child.Keys }}
+			Keys: []ytypes.PathStructKey{
+			{{- range $key := $child.Keys }}
+				{{- if $key.UnionZeros }}
+				{
+					Name:       "{{ $key.Name }}",
+					Zero:       *new({{ $key.TypeName }}),
+					UnionZeros: []interface{}{ {{- $key.UnionZeros -}} },
+					ToUnion: func(v interface{}) (interface{}, error) {
+						return (&{{ $key.ListTypeName }}{}).To_{{ $key.UnionTypeName }}(v)
+					},
+				},
+				{{- else }}
+				{Name: "{{ $key.Name }}", Zero: *new({{ $key.TypeName }})},
+				{{- end }}
+			{{- end }}
+			},
+			{{- end }}
+			{{- if $child.NewMethodName }}
+			New: func(keys []interface{}) ygot.PathStruct {
+				return n.{{ $child.NewMethodName }}(
+				{{- range $i, $key := $child.Keys }}
+					{{- if $i }}, {{ end }}keys[{{ $i }}].({{ $key.TypeName }})
+				{{- end -}}
+				)
+			},
+			{{- else if $child.ShadowTypeName }}
+			New: func(keys []interface{}) ygot.PathStruct {
+				return &{{ $child.ShadowTypeName }}{{ $.TypeSuffix }}{
+					{{ $child.PathBaseTypeName }}: ygot.New{{ $child.PathBaseTypeName }}{{ $child.TypeArgs }}(
+						[]string{ {{- $child.RelPathList -}} },
+						map[string]interface{}{},
+						n,
+					),
+				}
+			},
+			{{- end }}
+			{{- if $child.WildcardMethodName }}
+			NewWildcard: func() ygot.PathStruct { return n.{{ $child.WildcardMethodName }}() },
+			{{- end }}
+		},
+	{{- end }}
+	}
+}
+`)
+
+	// goParsePathTemplate generates the ParsePath function of the package
+	// containing the fakeroot.
+	goParsePathTemplate = mustTemplate("parsePath", `
+// ParsePath returns the path struct corresponding to the gNMI path p, whose
+// target is used as the ID of its {{ .TypeName }}. The keys of p are converted
+// to the Go types of the keys of the path structs, and lists that have
+// wildcard or unspecified keys result in wildcard path structs. A path ending
+// with the multilevel wildcard "..." results in the path struct that refers to
+// all descendants of the preceding node.
+func ParsePath(p *gpb.Path) (ygot.PathStruct, error) {
+	return ytypes.ParsePathStruct(DeviceRoot(p.GetTarget()), p)
+}
+`)
+
+	// goDescendantsTemplate generates the method of a path struct that
+	// returns the path of all the descendants of its node, which ends with
+	// the multilevel wildcard "...".
+	goDescendantsTemplate = mustTemplate("descendants", `
+// {{ .MethodName }} returns from {{ .TypeName }} the path struct that refers
+// to all of its descendants, using the multilevel wildcard "...".
+func (n *{{ .TypeName }}) {{ .MethodName }}() *ygot.NodePath {
+	return ygot.NewDescendantsPath(n)
+}
 `)
 
 	// goKeyBuilderTemplate generates a setter for a list key. This is used in the
@@ -607,18 +800,18 @@ func mustTemplate(name, src string) *template.Template {
 // schema field names of that directory entry (i.e. the same keys as the
 // "Fields" map of the Directory entry). Since ygen provides a *MappedType for
 // every leaf node only, leafTypeMap's value is nil for non-leaf nodes.
-// packageName, splitByModule, and trimOCPackage are used to determine
-// the generated Go package name for the generated PathStructs.
+// opts determines the generated Go package name for the generated
+// PathStructs, and whether the directories are those of a compressed schema.
 // If a directory or field doesn't exist in the leafTypeMap, then an error is returned.
-func getNodeDataMap(directories map[string]*ygen.Directory, leafTypeMap map[string]map[string]*ygen.MappedType, fakeRootName, schemaStructPkgAccessor, pathStructSuffix, packageName, packageSuffix string, splitByModule, trimOCPackage bool) (NodeDataMap, util.Errors) {
+func getNodeDataMap(directories map[string]*ygen.Directory, leafTypeMap map[string]map[string]*ygen.MappedType, fakeRootName string, opts pathStructOpts) (NodeDataMap, util.Errors) {
 	nodeDataMap := NodeDataMap{}
 	var errs util.Errors
 	for path, dir := range directories {
 		if ygen.IsFakeRoot(dir.Entry) {
 			// Since we always generate the fake root, we add the
 			// fake root GoStruct to the data map as well.
-			nodeDataMap[dir.Name+pathStructSuffix] = &NodeData{
-				GoTypeName:            "*" + schemaStructPkgAccessor + yang.CamelCase(fakeRootName),
+			nodeDataMap[dir.Name+opts.pathStructSuffix] = &NodeData{
+				GoTypeName:            "*" + opts.schemaStructPkgAccessor + yang.CamelCase(fakeRootName),
 				LocalGoTypeName:       "*" + yang.CamelCase(fakeRootName),
 				GoFieldName:           "",
 				SubsumingGoStructName: yang.CamelCase(fakeRootName),
@@ -627,7 +820,18 @@ func getNodeDataMap(directories map[string]*ygen.Directory, leafTypeMap map[stri
 				HasDefault:            false,
 				YANGTypeName:          "",
 				YANGPath:              "/",
-				GoPathPackageName:     goPackageName(dir.Entry, splitByModule, trimOCPackage, packageName, packageSuffix),
+				GoPathPackageName:     goPackageName(dir.Entry, opts.splitByModule, opts.trimOCPackage, opts.packageName, opts.packageSuffix),
+			}
+		}
+		if util.IsOperationRoot(dir.Entry) {
+			// The roots of the operation trees are not fields of any
+			// directory, so they are added to the data map here.
+			nodeDataMap[dir.Name+opts.pathStructSuffix] = &NodeData{
+				GoTypeName:            "*" + opts.schemaStructPkgAccessor + dir.Name,
+				LocalGoTypeName:       "*" + dir.Name,
+				SubsumingGoStructName: dir.Name,
+				YANGPath:              dir.Entry.Path(),
+				GoPathPackageName:     goPackageName(dir.Entry, opts.splitByModule, opts.trimOCPackage, opts.packageName, opts.packageSuffix),
 			}
 		}
 
@@ -638,7 +842,7 @@ func getNodeDataMap(directories map[string]*ygen.Directory, leafTypeMap map[stri
 			continue
 		}
 		for fieldName, field := range dir.Fields {
-			pathStructName, err := getFieldTypeName(dir, fieldName, goFieldNameMap[fieldName], directories, pathStructSuffix)
+			pathStructName, err := getFieldTypeName(dir, fieldName, goFieldNameMap[fieldName], directories, opts.pathStructSuffix, opts.compressPaths)
 			if err != nil {
 				errs = util.AppendErr(errs, err)
 				continue
@@ -659,13 +863,13 @@ func getNodeDataMap(directories map[string]*ygen.Directory, leafTypeMap map[stri
 			var goTypeName, localGoTypeName string
 			switch {
 			case !isLeaf:
-				goTypeName = "*" + schemaStructPkgAccessor + subsumingGoStructName
+				goTypeName = "*" + opts.schemaStructPkgAccessor + subsumingGoStructName
 				localGoTypeName = "*" + subsumingGoStructName
 			case field.ListAttr != nil && ygen.IsYgenDefinedGoType(mType):
-				goTypeName = "[]" + schemaStructPkgAccessor + mType.NativeType
+				goTypeName = "[]" + opts.schemaStructPkgAccessor + mType.NativeType
 				localGoTypeName = "[]" + mType.NativeType
 			case ygen.IsYgenDefinedGoType(mType):
-				goTypeName = schemaStructPkgAccessor + mType.NativeType
+				goTypeName = opts.schemaStructPkgAccessor + mType.NativeType
 				localGoTypeName = mType.NativeType
 			case field.ListAttr != nil:
 				goTypeName = "[]" + mType.NativeType
@@ -690,7 +894,7 @@ func getNodeDataMap(directories map[string]*ygen.Directory, leafTypeMap map[stri
 				HasDefault:            isLeaf && (field.Default != "" || mType.DefaultValue != nil),
 				YANGTypeName:          yangTypeName,
 				YANGPath:              field.Path(),
-				GoPathPackageName:     goPackageName(field, splitByModule, trimOCPackage, packageName, packageSuffix),
+				GoPathPackageName:     goPackageName(field, opts.splitByModule, opts.trimOCPackage, opts.packageName, opts.packageSuffix),
 			}
 		}
 	}
@@ -717,6 +921,9 @@ func writeHeader(yangFiles, includePaths []string, packageName string, cg *GenCo
 		PathStructInterfaceName string   // PathStructInterfaceName is the name of the interface which all path structs implement.
 		FakeRootTypeName        string   // FakeRootTypeName is the type name of the fakeroot node in the generated code.
 		ExtraImports            []string // ExtraImports for path structs that are in a different package.
+		UncompressedPaths       bool     // UncompressedPaths specifies whether the paths are based on an uncompressed schema.
+		GenerateParsePath       bool     // GenerateParsePath specifies whether the ΛChildren methods, which use ytypes, are generated.
+		GNMIImportPath          string   // GNMIImportPath is the import path of the gNMI protobuf, if it is used by the package.
 	}{
 		GoImports:               cg.GoImports,
 		PackageName:             packageName,
@@ -727,6 +934,17 @@ func writeHeader(yangFiles, includePaths []string, packageName string, cg *GenCo
 		PathBaseTypeName:        ygot.PathBaseTypeName,
 		PathStructInterfaceName: ygot.PathStructInterfaceName,
 		FakeRootTypeName:        yang.CamelCase(cg.FakeRootName),
+		UncompressedPaths:       cg.UncompressedPaths,
+		GenerateParsePath:       cg.GenerateParsePath,
+	}
+	if s.GenerateParsePath {
+		if s.YtypesImportPath == "" {
+			s.YtypesImportPath = genutil.GoDefaultYtypesImportPath
+		}
+		// ParsePath is generated alongside the fakeroot.
+		if packageName == cg.PackageName {
+			s.GNMIImportPath = genutil.GoDefaultGNMIImportPath
+		}
 	}
 	// Create an ordered list of imports to include in the header.
 	for dep := range genCode.Deps {
@@ -750,8 +968,14 @@ type goPathStructData struct {
 	TypeName string
 	// YANGPath is the schema path of the struct being output.
 	YANGPath string
+	// ShadowYANGPath is the schema path of the shadowed leaf that is also
+	// represented by the struct being output, if any.
+	ShadowYANGPath string
 	// PathBaseTypeName is the type name of the common embedded path struct.
 	PathBaseTypeName string
+	// TypeArgs is the list of type arguments of the embedded path struct,
+	// including its enclosing brackets, if it is generic.
+	TypeArgs string
 	// PathStructInterfaceName is the name of the interface which all path structs implement.
 	PathStructInterfaceName string
 	// FakeRootBaseTypeName is the type name of the fake root struct which
@@ -791,6 +1015,54 @@ type goPathFieldData struct {
 	KeyEntriesStr    string           // KeyEntriesStr is an ordered list of comma-separated ("schemaName": unique camel-case name) for a list's keys.
 	KeyParamDocStrs  []string         // KeyParamDocStrs is an ordered slice of docstrings documenting the types of each list key parameter.
 	ChildPkgAccessor string           // ChildPkgAccessor is used if the child path struct exists in another package.
+	PathBaseTypeName string           // PathBaseTypeName is the type name of the path struct embedded within the returned struct.
+	TypeArgs         string           // TypeArgs is the list of type arguments of the embedded path struct, if it is generic.
+}
+
+// pathStructOpts stores the options that control the generation of the path
+// structs for a schema. It is derived from the GenerateConfig used by
+// GeneratePathCode.
+type pathStructOpts struct {
+	// schemaStructPkgAccessor is the accessor (e.g. "oc.") used to refer to
+	// the package containing the generated GoStructs.
+	schemaStructPkgAccessor string
+	// pathStructSuffix is the suffix appended to the names of path structs.
+	pathStructSuffix string
+	// listBuilderKeyThreshold is the number of keys of a list above which
+	// builder methods are generated rather than a single constructor.
+	listBuilderKeyThreshold uint
+	// generateWildcardPaths specifies whether wildcard path structs and
+	// their constructors are generated.
+	generateWildcardPaths bool
+	// simplifyWildcardPaths specifies whether wildcard paths of lists whose
+	// keys are all wildcards are simplified.
+	simplifyWildcardPaths bool
+	// splitByModule specifies whether a Go package is generated per YANG
+	// module.
+	splitByModule bool
+	// trimOCPackage specifies whether the "openconfig-" prefix is trimmed
+	// from the names of generated packages.
+	trimOCPackage bool
+	// compressPaths specifies whether the directories are those of a
+	// compressed schema.
+	compressPaths bool
+	// generateParsePath specifies whether the ΛChildren methods, and
+	// ParsePath for the fakeroot, are generated.
+	generateParsePath bool
+	// generateDescendantPaths specifies whether the methods returning all
+	// descendant leaf paths are generated.
+	generateDescendantPaths bool
+	// generateConfigStatePaths specifies whether path structs are generated
+	// for the config and state containers that are compressed out of the
+	// schema.
+	generateConfigStatePaths bool
+	// packageName and packageSuffix determine the names of the generated
+	// Go packages.
+	packageName   string
+	packageSuffix string
+	// leafNodeData, if non-nil, records the information used to type the
+	// path structs of leaves.
+	leafNodeData NodeDataMap
 }
 
 // generateDirectorySnippet generates all Go code associated with a schema node
@@ -804,9 +1076,9 @@ type goPathFieldData struct {
 // The code comprises of the type definition for the struct, and all accessors to
 // the fields of the struct. directory is the parsed information of a schema
 // node, and directories is a map from path to a parsed schema node for all
-// nodes in the schema.
-func generateDirectorySnippet(directory *ygen.Directory, directories map[string]*ygen.Directory, schemaStructPkgAccessor, pathStructSuffix string, listBuilderKeyThreshold uint,
-	generateWildcardPaths, simplifyWildcardPaths, splitByModule, trimOCPkg bool, pkgName, pkgSuffix string) ([]GoPathStructCodeSnippet, util.Errors) {
+// nodes in the schema. opts specifies the options of the path structs that
+// are generated.
+func generateDirectorySnippet(directory *ygen.Directory, directories map[string]*ygen.Directory, opts pathStructOpts) ([]GoPathStructCodeSnippet, util.Errors) {
 
 	var errs util.Errors
 	// structBuf is used to store the code associated with the struct defined for
@@ -815,14 +1087,21 @@ func generateDirectorySnippet(directory *ygen.Directory, directories map[string]
 	var methodBuf strings.Builder
 
 	// Output struct snippets.
-	structData := getStructData(directory, pathStructSuffix, generateWildcardPaths)
-	if ygen.IsFakeRoot(directory.Entry) {
+	structData := getStructData(directory, opts.pathStructSuffix, opts.generateWildcardPaths)
+	switch {
+	case ygen.IsFakeRoot(directory.Entry):
 		// Fakeroot has its unique output.
 		if err := goPathFakeRootTemplate.Execute(&structBuf, structData); err != nil {
 			return nil, util.AppendErr(errs, err)
 		}
-	} else if err := goPathStructTemplate.Execute(&structBuf, structData); err != nil {
-		return nil, util.AppendErr(errs, err)
+	case util.IsOperationRoot(directory.Entry):
+		if err := generateOperationRootSnippet(&structBuf, directory, directories, structData, opts.pathStructSuffix); err != nil {
+			return nil, util.AppendErr(errs, err)
+		}
+	default:
+		if err := goPathStructTemplate.Execute(&structBuf, structData); err != nil {
+			return nil, util.AppendErr(errs, err)
+		}
 	}
 
 	goFieldNameMap := ygen.GoFieldNameMap(directory)
@@ -847,8 +1126,8 @@ func generateDirectorySnippet(directory *ygen.Directory, directories map[string]
 		// If it is, add that package as a dependency and set the accessor.
 		if ygen.IsFakeRoot(directory.Entry) {
 			if fieldDirectory := directories[field.Path()]; fieldDirectory != nil {
-				parentPackge := goPackageName(directory.Entry, splitByModule, trimOCPkg, pkgName, pkgSuffix)
-				childPackage := goPackageName(field, splitByModule, trimOCPkg, pkgName, pkgSuffix)
+				parentPackge := goPackageName(directory.Entry, opts.splitByModule, opts.trimOCPackage, opts.packageName, opts.packageSuffix)
+				childPackage := goPackageName(field, opts.splitByModule, opts.trimOCPackage, opts.packageName, opts.packageSuffix)
 				if parentPackge != childPackage {
 					deps[childPackage] = true
 					childPkgAccessor = childPackage + "."
@@ -862,7 +1141,7 @@ func generateDirectorySnippet(directory *ygen.Directory, directories map[string]
 			}
 		}
 
-		if es := generateChildConstructors(&methodBuf, buildBuf, directory, fieldName, goFieldName, directories, schemaStructPkgAccessor, pathStructSuffix, listBuilderKeyThreshold, generateWildcardPaths, simplifyWildcardPaths, childPkgAccessor); es != nil {
+		if es := generateChildConstructors(&methodBuf, buildBuf, directory, fieldName, goFieldName, directories, childPkgAccessor, opts); es != nil {
 			errs = util.AppendErrs(errs, es)
 		}
 
@@ -870,7 +1149,7 @@ func generateDirectorySnippet(directory *ygen.Directory, directories map[string]
 		// to output their struct snippets somewhere, and here is
 		// convenient.
 		if field.IsLeaf() || field.IsLeafList() {
-			leafTypeName, err := getFieldTypeName(directory, fieldName, goFieldName, directories, pathStructSuffix)
+			leafTypeName, err := getFieldTypeName(directory, fieldName, goFieldName, directories, opts.pathStructSuffix, opts.compressPaths)
 			if err != nil {
 				errs = util.AppendErr(errs, err)
 			} else {
@@ -880,15 +1159,49 @@ func generateDirectorySnippet(directory *ygen.Directory, directories map[string]
 					PathBaseTypeName:        ygot.PathBaseTypeName,
 					PathStructInterfaceName: ygot.PathStructInterfaceName,
 					WildcardSuffix:          WildcardSuffix,
-					GenerateWildcardPaths:   generateWildcardPaths,
+					GenerateWildcardPaths:   opts.generateWildcardPaths,
 				}
-				if err := goPathStructTemplate.Execute(&structBuf, structData); err != nil {
+				// The path struct of the leaf is reused for its shadowed
+				// leaf by ParsePath and the Config and State methods.
+				if shadow, ok := directory.ShadowedFields[fieldName]; ok && (opts.generateParsePath || opts.generateConfigStatePaths) {
+					structData.ShadowYANGPath = shadow.Path()
+				}
+				if opts.leafNodeData != nil {
+					structData.PathBaseTypeName = ygot.LeafPathBaseTypeName
+					structData.TypeArgs, err = leafTypeArgs(opts.leafNodeData, leafTypeName, opts.schemaStructPkgAccessor)
+				}
+				if err != nil {
+					errs = util.AppendErr(errs, err)
+				} else if err := goPathStructTemplate.Execute(&structBuf, structData); err != nil {
 					errs = util.AppendErr(errs, err)
 				}
 			}
 		}
 	}
 
+	if opts.generateConfigStatePaths {
+		if es := generateConfigStateSnippets(&structBuf, &methodBuf, directory, directories, structData, opts); es != nil {
+			errs = util.AppendErrs(errs, es)
+		}
+	}
+
+	if opts.generateParsePath {
+		if es := generateChildrenMethods(&methodBuf, directory, directories, structData, opts); es != nil {
+			errs = util.AppendErrs(errs, es)
+		}
+		if ygen.IsFakeRoot(directory.Entry) {
+			if err := goParsePathTemplate.Execute(&methodBuf, structData); err != nil {
+				errs = util.AppendErr(errs, err)
+			}
+		}
+	}
+
+	if opts.generateDescendantPaths {
+		if es := generateDescendantsMethods(&methodBuf, directory, structData, opts.generateWildcardPaths); es != nil {
+			errs = util.AppendErrs(errs, es)
+		}
+	}
+
 	if len(errs) == 0 {
 		errs = nil
 	}
@@ -896,7 +1209,7 @@ func generateDirectorySnippet(directory *ygen.Directory, directories map[string]
 		PathStructName:    structData.TypeName,
 		StructBase:        structBuf.String(),
 		ChildConstructors: methodBuf.String(),
-		Package:           goPackageName(directory.Entry, splitByModule, trimOCPkg, pkgName, pkgSuffix),
+		Package:           goPackageName(directory.Entry, opts.splitByModule, opts.trimOCPackage, opts.packageName, opts.packageSuffix),
 	}
 	for dep := range deps {
 		snippet.Deps = append(snippet.Deps, dep)
@@ -915,6 +1228,51 @@ func generateDirectorySnippet(directory *ygen.Directory, directories map[string]
 	return snippets, errs
 }
 
+// isRootDirectory returns true if the directory whose entry is e is the root
+// of a tree of path structs, i.e. the fakeroot or the root of an operation
+// tree. The path struct of a root has no wildcard version.
+func isRootDirectory(e *yang.Entry) bool {
+	return ygen.IsFakeRoot(e) || util.IsOperationRoot(e)
+}
+
+// operationTreeKind returns a description of the kind of the operation tree
+// whose root is e, e.g. "notification" or "rpc input".
+func operationTreeKind(e *yang.Entry) string {
+	if util.IsNotification(e) {
+		return "notification"
+	}
+	// The parent of an rpc is its module, whereas an action is defined
+	// within a container or list of the data tree.
+	if op := e.Parent; op.Parent != nil && op.Parent.Parent != nil {
+		return "action " + e.Name
+	}
+	return "rpc " + e.Name
+}
+
+// generateOperationRootSnippet writes into structBuf the root path struct of
+// the operation tree whose root is directory, along with the function that
+// constructs it, whose name is that of directory with the suffix "Root".
+// structData is the template information of the path struct of directory.
+// An error is returned if the name of the function collides with that of a
+// path struct generated for the schema.
+func generateOperationRootSnippet(structBuf *strings.Builder, directory *ygen.Directory, directories map[string]*ygen.Directory, structData goPathStructData, pathStructSuffix string) error {
+	constructorName := directory.Name + OperationRootCtorSuffix
+	for _, d := range directories {
+		if d.Name+pathStructSuffix == constructorName {
+			return fmt.Errorf("generateOperationRootSnippet: constructor %s of the root of %s collides with the path struct of %s", constructorName, util.SlicePathToString(directory.Path), util.SlicePathToString(d.Path))
+		}
+	}
+	return goPathOperationRootTemplate.Execute(structBuf, struct {
+		goPathStructData
+		ConstructorName string
+		TreeKind        string
+	}{
+		goPathStructData: structData,
+		ConstructorName:  constructorName,
+		TreeKind:         operationTreeKind(directory.Entry),
+	})
+}
+
 // generateChildConstructors generates and writes to methodBuf the Go methods
 // that returns an instantiation of the child node's path struct object.
 // When this is called on the fakeroot, the list builder API's methods
@@ -924,18 +1282,19 @@ func generateDirectorySnippet(directory *ygen.Directory, directories map[string]
 // of the directory identifying the child yang.Entry, a directory-level unique
 // field name to be used as the generated method's name and the incremental
 // type name of of the child path struct, and a map of all directories of the
-// whole schema keyed by their schema paths.
-func generateChildConstructors(methodBuf *strings.Builder, builderBuf *strings.Builder, directory *ygen.Directory, directoryFieldName string, goFieldName string, directories map[string]*ygen.Directory, schemaStructPkgAccessor, pathStructSuffix string, listBuilderKeyThreshold uint, generateWildcardPaths, simplifyWildcardPaths bool, childPkgAccessor string) []error {
+// whole schema keyed by their schema paths. opts specifies the options of
+// the path structs that are generated.
+func generateChildConstructors(methodBuf *strings.Builder, builderBuf *strings.Builder, directory *ygen.Directory, directoryFieldName string, goFieldName string, directories map[string]*ygen.Directory, childPkgAccessor string, opts pathStructOpts) []error {
 	field, ok := directory.Fields[directoryFieldName]
 	if !ok {
 		return []error{fmt.Errorf("generateChildConstructors: field %s not found in directory %v", directoryFieldName, directory)}
 	}
-	fieldTypeName, err := getFieldTypeName(directory, directoryFieldName, goFieldName, directories, pathStructSuffix)
+	fieldTypeName, err := getFieldTypeName(directory, directoryFieldName, goFieldName, directories, opts.pathStructSuffix, opts.compressPaths)
 	if err != nil {
 		return []error{err}
 	}
 
-	structData := getStructData(directory, pathStructSuffix, generateWildcardPaths)
+	structData := getStructData(directory, opts.pathStructSuffix, opts.generateWildcardPaths)
 	relPath, err := ygen.FindSchemaPath(directory, directoryFieldName, false)
 	if err != nil {
 		return []error{err}
@@ -947,16 +1306,23 @@ func generateChildConstructors(methodBuf *strings.Builder, builderBuf *strings.B
 		Struct:           structData,
 		RelPathList:      `"` + strings.Join(relPath, `", "`) + `"`,
 		ChildPkgAccessor: childPkgAccessor,
+		PathBaseTypeName: ygot.PathBaseTypeName,
+	}
+	if opts.leafNodeData != nil && (field.IsLeaf() || field.IsLeafList()) {
+		fieldData.PathBaseTypeName = ygot.LeafPathBaseTypeName
+		if fieldData.TypeArgs, err = leafTypeArgs(opts.leafNodeData, fieldTypeName, opts.schemaStructPkgAccessor); err != nil {
+			return []error{err}
+		}
 	}
 
-	isUnderFakeRoot := ygen.IsFakeRoot(directory.Entry)
+	isUnderRoot := isRootDirectory(directory.Entry)
 
 	// This is expected to be nil for leaf fields.
 	fieldDirectory := directories[field.Path()]
 
 	switch {
 	case !field.IsList():
-		return generateChildConstructorsForLeafOrContainer(methodBuf, fieldData, isUnderFakeRoot, generateWildcardPaths)
+		return generateChildConstructorsForLeafOrContainer(methodBuf, fieldData, isUnderRoot, opts.generateWildcardPaths)
 	case fieldDirectory.ListAttr == nil || len(fieldDirectory.ListAttr.Keys) == 0:
 		// TODO(wenbli): keyless lists as a path are not supported by gNMI, but this
 		// library is currently intended for gNMI, so need to decide on a long-term solution.
@@ -966,21 +1332,21 @@ func generateChildConstructors(methodBuf *strings.Builder, builderBuf *strings.B
 		return nil
 		// Erroring out, on the other hand, is impractical due to their existence in the current OpenConfig models.
 		// return fmt.Errorf("generateChildConstructors: schemas containing keyless lists are unsupported, path: %s", field.Path())
-	case listBuilderKeyThreshold != 0 && uint(len(fieldDirectory.ListAttr.KeyElems)) >= listBuilderKeyThreshold:
+	case opts.listBuilderKeyThreshold != 0 && uint(len(fieldDirectory.ListAttr.KeyElems)) >= opts.listBuilderKeyThreshold:
 		// If the number of keys is equal to or over the builder API threshold,
 		// then use the builder API format to make the list path API less
 		// confusing for the user.
 		// The generated const
-		return generateChildConstructorsForListBuilderFormat(methodBuf, builderBuf, fieldDirectory.ListAttr, fieldData, isUnderFakeRoot, schemaStructPkgAccessor)
+		return generateChildConstructorsForListBuilderFormat(methodBuf, builderBuf, fieldDirectory.ListAttr, fieldData, isUnderRoot, opts.schemaStructPkgAccessor)
 	default:
-		return generateChildConstructorsForList(methodBuf, fieldDirectory.ListAttr, fieldData, isUnderFakeRoot, generateWildcardPaths, simplifyWildcardPaths, schemaStructPkgAccessor)
+		return generateChildConstructorsForList(methodBuf, fieldDirectory.ListAttr, fieldData, isUnderRoot, opts.generateWildcardPaths, opts.simplifyWildcardPaths, opts.schemaStructPkgAccessor)
 	}
 }
 
 // generateChildConstructorsForLeafOrContainer writes into methodBuf the child
 // constructor snippets for the container or leaf template output information
 // contained in fieldData.
-func generateChildConstructorsForLeafOrContainer(methodBuf *strings.Builder, fieldData goPathFieldData, isUnderFakeRoot, generateWildcardPaths bool) []error {
+func generateChildConstructorsForLeafOrContainer(methodBuf *strings.Builder, fieldData goPathFieldData, isUnderRoot, generateWildcardPaths bool) []error {
 	// Generate child constructor for the non-wildcard version of the parent struct.
 	var errors []error
 	if err := goPathChildConstructorTemplate.Execute(methodBuf, fieldData); err != nil {
@@ -988,7 +1354,7 @@ func generateChildConstructorsForLeafOrContainer(methodBuf *strings.Builder, fie
 	}
 
 	// The root node doesn't have a wildcard version of itself.
-	if isUnderFakeRoot {
+	if isUnderRoot {
 		return errors
 	}
 
@@ -1010,7 +1376,7 @@ func generateChildConstructorsForLeafOrContainer(methodBuf *strings.Builder, fie
 // if the child belongs in its own package. fieldData contains the childConstructor template
 // output information for if the node were a container (which contains a subset
 // of the basic information required for the list constructor methods).
-func generateChildConstructorsForListBuilderFormat(methodBuf *strings.Builder, builderBuf *strings.Builder, listAttr *ygen.YangListAttr, fieldData goPathFieldData, isUnderFakeRoot bool, schemaStructPkgAccessor string) []error {
+func generateChildConstructorsForListBuilderFormat(methodBuf *strings.Builder, builderBuf *strings.Builder, listAttr *ygen.YangListAttr, fieldData goPathFieldData, isUnderRoot bool, schemaStructPkgAccessor string) []error {
 	var errors []error
 	// List of function parameters as would appear in the method definition.
 	keyParams, err := makeKeyParams(listAttr, schemaStructPkgAccessor)
@@ -1041,7 +1407,7 @@ func generateChildConstructorsForListBuilderFormat(methodBuf *strings.Builder, b
 	}
 
 	// The root node doesn't have a wildcard version of itself.
-	if !isUnderFakeRoot {
+	if !isUnderRoot {
 		// Generate builder constructor method for wildcard version of parent struct.
 		fieldData.Struct.TypeName += WildcardSuffix
 		if err := goPathChildConstructorTemplate.Execute(methodBuf, fieldData); err != nil {
@@ -1080,7 +1446,7 @@ func generateChildConstructorsForListBuilderFormat(methodBuf *strings.Builder, b
 // childConstructor template output information for if the node were a
 // container (which contains a subset of the basic information required for
 // the list constructor methods).
-func generateChildConstructorsForList(methodBuf *strings.Builder, listAttr *ygen.YangListAttr, fieldData goPathFieldData, isUnderFakeRoot, generateWildcardPaths, simplifyWildcardPaths bool, schemaStructPkgAccessor string) []error {
+func generateChildConstructorsForList(methodBuf *strings.Builder, listAttr *ygen.YangListAttr, fieldData goPathFieldData, isUnderRoot, generateWildcardPaths, simplifyWildcardPaths bool, schemaStructPkgAccessor string) []error {
 	var errors []error
 	// List of function parameters as would appear in the method definition.
 	keyParams, err := makeKeyParams(listAttr, schemaStructPkgAccessor)
@@ -1163,7 +1529,7 @@ func generateChildConstructorsForList(methodBuf *strings.Builder, listAttr *ygen
 		}
 
 		// The root node doesn't have a wildcard version of itself.
-		if isUnderFakeRoot {
+		if isUnderRoot {
 			continue
 		}
 
@@ -1180,14 +1546,355 @@ func generateChildConstructorsForList(methodBuf *strings.Builder, listAttr *ygen
 	return errors
 }
 
+// goPathChildData stores the template information needed to describe a child
+// of a path struct within its ΛChildren method.
+type goPathChildData struct {
+	RelPathList        string          // RelPathList is the list of strings that form the relative path from its containing struct.
+	Keys               []goPathKeyData // Keys are the keys of the child, in order, if it is a list.
+	NewMethodName      string          // NewMethodName is the name of the child constructor method taking all keys, if any, as parameters.
+	WildcardMethodName string          // WildcardMethodName is the name of the child constructor method taking no parameters for a list.
+	ShadowTypeName     string          // ShadowTypeName is the type name of the path struct of a shadowed leaf, which has no child constructor method.
+	PathBaseTypeName   string          // PathBaseTypeName is the type name of the path struct embedded within the path struct of a shadowed leaf.
+	TypeArgs           string          // TypeArgs is the list of type arguments of the embedded path struct of a shadowed leaf, if it is generic.
+}
+
+// goPathChildrenData stores the template information needed to generate the
+// ΛChildren method of a path struct.
+type goPathChildrenData struct {
+	TypeName   string            // TypeName is the type name of the path struct.
+	TypeSuffix string            // TypeSuffix is appended to the type names of the path structs of shadowed leaves.
+	Children   []goPathChildData // Children are the descriptions of the children of the path struct.
+}
+
+// goPathKeyData stores the template information needed to describe a key of
+// a list within the ΛChildren method of its parent.
+type goPathKeyData struct {
+	Name          string // Name is the name of the key in the schema.
+	TypeName      string // TypeName is the Go type of the key.
+	UnionZeros    string // UnionZeros is the list of zero values of the Go types of the members of the union type of the key, if it is a union.
+	ListTypeName  string // ListTypeName is the type name of the GoStruct of the list, whose method converts values to the union type of the key.
+	UnionTypeName string // UnionTypeName is the unqualified name of the union type of the key.
+}
+
+// unionKeyZeros returns the list of zero values of the Go types of the
+// members of the union type mappedType of a list key, which the values of the
+// key within a gNMI path are converted to by ParsePath. Members whose Go types
+// cannot be the type of a key value are not included.
+func unionKeyZeros(mappedType *ygen.MappedType, schemaStructPkgAccessor string) string {
+	var zeros []string
+	for _, name := range mappedType.OrderedUnionTypes() {
+		switch name {
+		case "interface{}", ygot.BinaryTypeName, ygot.EmptyTypeName:
+			continue
+		}
+		if _, ok := ygot.SimpleUnionBuiltinGoTypes[name]; !ok {
+			name = schemaStructPkgAccessor + name
+		}
+		zeros = append(zeros, fmt.Sprintf("*new(%s)", name))
+	}
+	return strings.Join(zeros, ", ")
+}
+
+// generateChildrenMethods writes into methodBuf the ΛChildren methods of the
+// path struct of directory whose template information is structData, and of
+// its wildcard version if opts.generateWildcardPaths is set. The methods describe
+// the child constructor methods of the path struct that are generated by
+// generateChildConstructors. The shadowed paths of leaves whose config or
+// state leaf is compressed out of the schema are also described, such that
+// ParsePath accepts both the config and state paths of a leaf, as are the
+// Config and State methods if opts.generateConfigStatePaths is set.
+func generateChildrenMethods(methodBuf *strings.Builder, directory *ygen.Directory, directories map[string]*ygen.Directory, structData goPathStructData, opts pathStructOpts) []error {
+	var errs []error
+	var children []goPathChildData
+	goFieldNameMap := ygen.GoFieldNameMap(directory)
+	for _, fieldName := range ygen.GetOrderedFieldNames(directory) {
+		field := directory.Fields[fieldName]
+		goFieldName := goFieldNameMap[fieldName]
+		relPath, err := ygen.FindSchemaPath(directory, fieldName, false)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		child := goPathChildData{
+			RelPathList:   `"` + strings.Join(relPath, `", "`) + `"`,
+			NewMethodName: goFieldName,
+		}
+
+		if field.IsList() {
+			fieldDirectory := directories[field.Path()]
+			// Keyless lists do not have child constructor methods.
+			if fieldDirectory == nil || fieldDirectory.ListAttr == nil || len(fieldDirectory.ListAttr.Keys) == 0 {
+				continue
+			}
+			keyParams, err := makeKeyParams(fieldDirectory.ListAttr, opts.schemaStructPkgAccessor)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, k := range keyParams {
+				key := goPathKeyData{Name: k.name, TypeName: k.typeName}
+				if mappedType := fieldDirectory.ListAttr.Keys[k.name]; len(mappedType.UnionTypes) > 1 {
+					key.UnionZeros = unionKeyZeros(mappedType, opts.schemaStructPkgAccessor)
+					key.ListTypeName = opts.schemaStructPkgAccessor + fieldDirectory.Name
+					key.UnionTypeName = mappedType.NativeType
+				}
+				child.Keys = append(child.Keys, key)
+			}
+			switch {
+			case opts.listBuilderKeyThreshold != 0 && uint(len(fieldDirectory.ListAttr.KeyElems)) >= opts.listBuilderKeyThreshold:
+				// The builder API only has a constructor with wildcard keys.
+				child.NewMethodName = ""
+				child.WildcardMethodName = goFieldName + BuilderCtorSuffix
+			case opts.generateWildcardPaths:
+				child.WildcardMethodName = goFieldName + WildcardSuffix
+			}
+		}
+		children = append(children, child)
+
+		if !field.IsLeaf() && !field.IsLeafList() {
+			continue
+		}
+		shadowPath, err := ygen.FindShadowedSchemaPath(directory, fieldName, false)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if shadowPath == nil {
+			continue
+		}
+		// The shadowed leaf has no child constructor method, so its path
+		// struct, which is that of the leaf, is constructed directly.
+		leafTypeName, err := getFieldTypeName(directory, fieldName, goFieldName, directories, opts.pathStructSuffix, opts.compressPaths)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		shadow := goPathChildData{
+			RelPathList:      `"` + strings.Join(shadowPath, `", "`) + `"`,
+			ShadowTypeName:   leafTypeName,
+			PathBaseTypeName: ygot.PathBaseTypeName,
+		}
+		if opts.leafNodeData != nil {
+			shadow.PathBaseTypeName = ygot.LeafPathBaseTypeName
+			if shadow.TypeArgs, err = leafTypeArgs(opts.leafNodeData, leafTypeName, opts.schemaStructPkgAccessor); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		children = append(children, shadow)
+	}
+
+	// The path structs of the config and state containers are described
+	// last, such that the leaves within them are matched directly.
+	if opts.generateConfigStatePaths && len(directory.ShadowedFields) != 0 && !ygen.IsFakeRoot(directory.Entry) {
+		for _, c := range configStateContainers {
+			children = append(children, goPathChildData{
+				RelPathList:   `"` + c.schemaName + `"`,
+				NewMethodName: c.methodName,
+			})
+		}
+	}
+
+	data := goPathChildrenData{
+		TypeName: structData.TypeName,
+		Children: children,
+	}
+	if err := goPathChildrenTemplate.Execute(methodBuf, data); err != nil {
+		errs = append(errs, err)
+	}
+	// The root node doesn't have a wildcard version of itself.
+	if opts.generateWildcardPaths && !isRootDirectory(directory.Entry) {
+		data.TypeName += WildcardSuffix
+		data.TypeSuffix = WildcardSuffix
+		if err := goPathChildrenTemplate.Execute(methodBuf, data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// configStateContainers are the schema names of the config and state
+// containers of the OpenConfig schema, along with the names of the methods that
+// return their path structs when GenerateConfigStatePaths is set.
+var configStateContainers = []struct {
+	schemaName string
+	methodName string
+}{{
+	schemaName: "config",
+	methodName: "Config",
+}, {
+	schemaName: "state",
+	methodName: "State",
+}}
+
+// generateConfigStateSnippets writes into structBuf the path structs of the
+// config and state containers of directory, which are compressed out of the
+// schema, if directory has fields whose config or state leaf is shadowed by
+// compression. The Config and State methods constructing them from the path
+// struct of directory, whose template information is structData, as well as
+// the methods constructing the path structs of their leaves, are written into
+// methodBuf. The leaves reuse the path structs generated for the fields of
+// directory. If opts.generateParsePath is set, the ΛChildren methods of the
+// path structs of the containers are also written into methodBuf. An error is
+// returned if the names of the generated methods or path structs collide with
+// those generated for the schema.
+func generateConfigStateSnippets(structBuf, methodBuf *strings.Builder, directory *ygen.Directory, directories map[string]*ygen.Directory, structData goPathStructData, opts pathStructOpts) []error {
+	if len(directory.ShadowedFields) == 0 || ygen.IsFakeRoot(directory.Entry) {
+		return nil
+	}
+
+	// Collisions are checked before any code is generated, such that no
+	// partial output is produced.
+	goFieldNameMap := ygen.GoFieldNameMap(directory)
+	for _, c := range configStateContainers {
+		typeName := directory.Name + "_" + c.methodName + opts.pathStructSuffix
+		for _, fieldName := range ygen.GetOrderedFieldNames(directory) {
+			if goFieldNameMap[fieldName] == c.methodName {
+				return []error{fmt.Errorf("generateConfigStateSnippets: the %s method of %s conflicts with the child constructor method of field %s", c.methodName, structData.TypeName, fieldName)}
+			}
+		}
+		for _, d := range directories {
+			if d.Name+opts.pathStructSuffix == typeName {
+				return []error{fmt.Errorf("generateConfigStateSnippets: path struct %s of the %s container of %s conflicts with that of %s", typeName, c.schemaName, util.SlicePathToString(directory.Path), util.SlicePathToString(d.Path))}
+			}
+		}
+	}
+
+	var errs []error
+	for _, c := range configStateContainers {
+		typeName := directory.Name + "_" + c.methodName + opts.pathStructSuffix
+		containerData := structData
+		containerData.TypeName = typeName
+		containerData.YANGPath = structData.YANGPath + "/" + c.schemaName
+		if err := goPathStructTemplate.Execute(structBuf, containerData); err != nil {
+			errs = append(errs, err)
+		}
+		if es := generateChildConstructorsForLeafOrContainer(methodBuf, goPathFieldData{
+			MethodName:       c.methodName,
+			TypeName:         typeName,
+			SchemaName:       c.schemaName,
+			Struct:           structData,
+			RelPathList:      `"` + c.schemaName + `"`,
+			PathBaseTypeName: ygot.PathBaseTypeName,
+		}, false, opts.generateWildcardPaths); es != nil {
+			errs = append(errs, es...)
+		}
+
+		var children []goPathChildData
+
+		for _, fieldName := range ygen.GetOrderedFieldNames(directory) {
+			field := directory.Fields[fieldName]
+			if !field.IsLeaf() && !field.IsLeafList() {
+				continue
+			}
+			relPath, err := ygen.FindSchemaPath(directory, fieldName, false)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			shadowPath, err := ygen.FindShadowedSchemaPath(directory, fieldName, false)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			isChild := func(p []string) bool { return len(p) == 2 && p[0] == c.schemaName }
+			if !isChild(relPath) && !isChild(shadowPath) {
+				continue
+			}
+
+			goFieldName := goFieldNameMap[fieldName]
+			leafTypeName, err := getFieldTypeName(directory, fieldName, goFieldName, directories, opts.pathStructSuffix, opts.compressPaths)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			fieldData := goPathFieldData{
+				MethodName:       goFieldName,
+				TypeName:         leafTypeName,
+				SchemaName:       field.Name,
+				Struct:           containerData,
+				RelPathList:      `"` + field.Name + `"`,
+				PathBaseTypeName: ygot.PathBaseTypeName,
+			}
+			if opts.leafNodeData != nil {
+				fieldData.PathBaseTypeName = ygot.LeafPathBaseTypeName
+				if fieldData.TypeArgs, err = leafTypeArgs(opts.leafNodeData, leafTypeName, opts.schemaStructPkgAccessor); err != nil {
+					errs = append(errs, err)
+					continue
+				}
+			}
+			if es := generateChildConstructorsForLeafOrContainer(methodBuf, fieldData, false, opts.generateWildcardPaths); es != nil {
+				errs = append(errs, es...)
+			}
+			children = append(children, goPathChildData{
+				RelPathList:   `"` + field.Name + `"`,
+				NewMethodName: goFieldName,
+			})
+		}
+
+		if !opts.generateParsePath {
+			continue
+		}
+		data := goPathChildrenData{
+			TypeName: typeName,
+			Children: children,
+		}
+		if err := goPathChildrenTemplate.Execute(methodBuf, data); err != nil {
+			errs = append(errs, err)
+		}
+		if opts.generateWildcardPaths {
+			data.TypeName += WildcardSuffix
+			if err := goPathChildrenTemplate.Execute(methodBuf, data); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// generateDescendantsMethods writes into methodBuf the Descendants methods of
+// the path struct of directory whose template information is structData, and
+// of its wildcard version if generateWildcardPaths is set. An error is
+// returned if the method would conflict with a child constructor method.
+func generateDescendantsMethods(methodBuf *strings.Builder, directory *ygen.Directory, structData goPathStructData, generateWildcardPaths bool) []error {
+	for fieldName, goFieldName := range ygen.GoFieldNameMap(directory) {
+		if goFieldName == DescendantsMethodName {
+			return []error{fmt.Errorf("generateDescendantsMethods: the %s method of %s conflicts with the child constructor method of field %s", DescendantsMethodName, structData.TypeName, fieldName)}
+		}
+	}
+
+	var errs []error
+	data := struct {
+		TypeName   string
+		MethodName string
+	}{
+		TypeName:   structData.TypeName,
+		MethodName: DescendantsMethodName,
+	}
+	if err := goDescendantsTemplate.Execute(methodBuf, data); err != nil {
+		errs = append(errs, err)
+	}
+	// The root node doesn't have a wildcard version of itself.
+	if generateWildcardPaths && !isRootDirectory(directory.Entry) {
+		data.TypeName += WildcardSuffix
+		if err := goDescendantsTemplate.Execute(methodBuf, data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
 // getFieldTypeName returns the type name for a field node of a directory -
 // handling the case where the field supplied is a leaf or directory. The input
 // directories is a map from paths to directory entries, and goFieldName is the
 // incremental type name to be used for the case that the directory field is a
 // leaf. For non-leaves, their corresponding directories' "Name"s, which are the
 // same names as their corresponding ygen Go struct type names, are re-used as
-// their type names; for leaves, type names are synthesized.
-func getFieldTypeName(directory *ygen.Directory, directoryFieldName string, goFieldName string, directories map[string]*ygen.Directory, pathStructSuffix string) (string, error) {
+// their type names; for leaves, type names are synthesized. compressPaths
+// specifies whether the directories are those of a compressed schema, in
+// which case the names of leaves at the root are not qualified by the name of
+// their module.
+func getFieldTypeName(directory *ygen.Directory, directoryFieldName string, goFieldName string, directories map[string]*ygen.Directory, pathStructSuffix string, compressPaths bool) (string, error) {
 	field, ok := directory.Fields[directoryFieldName]
 	if !ok {
 		return "", fmt.Errorf("getFieldTypeName: field %s not found in directory %v", directoryFieldName, directory)
@@ -1204,11 +1911,31 @@ func getFieldTypeName(directory *ygen.Directory, directoryFieldName string, goFi
 	// Leaves do not have corresponding Directory entries, so their names need to be constructed.
 	if isTopLevelLeaf := directory.Entry.Parent == nil; isTopLevelLeaf {
 		// When a leaf resides at the root, its type name is its whole name -- we never want fakeroot's name as a prefix.
+		if !compressPaths {
+			// The names of the structs of an uncompressed schema are
+			// qualified by the name of their module, so the same is
+			// done for leaves at the root such that their names
+			// cannot collide with those of other modules' nodes.
+			return genutil.EntryCamelCaseName(util.SchemaTreeRoot(field)) + "_" + goFieldName + pathStructSuffix, nil
+		}
 		return goFieldName + pathStructSuffix, nil
 	}
 	return directory.Name + "_" + goFieldName + pathStructSuffix, nil
 }
 
+// leafTypeArgs returns the type arguments of the ygot.LeafPath embedded within
+// the typed path struct of the leaf whose path struct type name is
+// pathStructName, using the information about the leaf recorded in
+// nodeDataMap. The type arguments are the Go type of the leaf's value, and
+// the type of the GoStruct that the leaf is a field of.
+func leafTypeArgs(nodeDataMap NodeDataMap, pathStructName, schemaStructPkgAccessor string) (string, error) {
+	nodeData, ok := nodeDataMap[pathStructName]
+	if !ok {
+		return "", fmt.Errorf("leafTypeArgs: leaf %s not found in NodeDataMap", pathStructName)
+	}
+	return fmt.Sprintf("[%s, *%s%s]", nodeData.GoTypeName, schemaStructPkgAccessor, nodeData.SubsumingGoStructName), nil
+}
+
 type keyParam struct {
 	name          string
 	varName       string
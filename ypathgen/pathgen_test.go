@@ -52,6 +52,8 @@ func TestGeneratePathCode(t *testing.T) {
 		inPreferOperationalState bool
 		// inExcludeState determines whether derived state leaves are excluded from the path-building methods.
 		inExcludeState bool
+		// inUncompressedPaths determines whether the path structs are generated for the uncompressed schema.
+		inUncompressedPaths bool
 		// inListBuilderKeyThreshold determines the minimum number of keys beyond which the builder API is used for building the paths.
 		inListBuilderKeyThreshold uint
 		// inShortenEnumLeafNames says whether the enum leaf names are shortened (i.e. module name removed) in the generated Go code corresponding to the generated path library.
@@ -60,9 +62,22 @@ func TestGeneratePathCode(t *testing.T) {
 		inUseDefiningModuleForTypedefEnumNames bool
 		// inGenerateWildcardPaths determines whether wildcard paths are generated.
 		inGenerateWildcardPaths bool
-		inSchemaStructPkgPath   string
-		inPathStructSuffix      string
-		inSimplifyWildcardPaths bool
+		// inGenerateTypedLeafPaths determines whether the path structs of leaves are typed.
+		inGenerateTypedLeafPaths bool
+		// inGenerateParsePath determines whether ParsePath is generated.
+		inGenerateParsePath bool
+		// inGenerateDescendantPaths determines whether the Descendants
+		// methods are generated.
+		inGenerateDescendantPaths bool
+		// inGenerateConfigStatePaths determines whether the Config and
+		// State methods are generated.
+		inGenerateConfigStatePaths bool
+		// inGenerateOperationPaths determines whether the path structs of
+		// rpc, action and notification trees are generated.
+		inGenerateOperationPaths bool
+		inSchemaStructPkgPath    string
+		inPathStructSuffix       string
+		inSimplifyWildcardPaths  bool
 		// checkYANGPath says whether to check for the YANG path in the NodeDataMap.
 		checkYANGPath bool
 		// wantStructsCodeFile is the path of the generated Go code that the output of the test should be compared to.
@@ -935,6 +950,323 @@ func TestGeneratePathCode(t *testing.T) {
 		inSchemaStructPkgPath:                  "",
 		inPathStructSuffix:                     "Path",
 		wantStructsCodeFile:                    filepath.Join(TestRoot, "testdata/structs/openconfig-camelcase-compress.path-txt"),
+	}, {
+		name:                    "uncompressed openconfig test with list",
+		inFiles:                 []string{filepath.Join(datapath, "openconfig-withlist.yang")},
+		inUncompressedPaths:     true,
+		inGenerateWildcardPaths: true,
+		inSchemaStructPkgPath:   "",
+		inPathStructSuffix:      "Path",
+		wantStructsCodeFile:     filepath.Join(TestRoot, "testdata/structs/openconfig-withlist.uncompressed.path-txt"),
+	}, {
+		name:                     "simple openconfig test with typed leaf paths",
+		inFiles:                  []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inPreferOperationalState: true,
+		inShortenEnumLeafNames:   true,
+		inGenerateWildcardPaths:  true,
+		inGenerateTypedLeafPaths: true,
+		inSchemaStructPkgPath:    "",
+		inPathStructSuffix:       "Path",
+		wantStructsCodeFile:      filepath.Join(TestRoot, "testdata/structs/openconfig-simple.typedleaves.path-txt"),
+	}, {
+		name:                                   "enum test with typed leaf paths in a separate package",
+		inFiles:                                []string{filepath.Join(datapath, "enum-module.yang")},
+		inPreferOperationalState:               true,
+		inShortenEnumLeafNames:                 true,
+		inUseDefiningModuleForTypedefEnumNames: true,
+		inGenerateTypedLeafPaths:               true,
+		inSchemaStructPkgPath:                  "github.com/openconfig/ygot/ypathgen/testdata/exampleoc",
+		inPathStructSuffix:                     "Path",
+		wantStructsCodeFile:                    filepath.Join(TestRoot, "testdata/structs/enum-module.typedleaves.path-txt"),
+	}, {
+		name:                     "openconfig test with list and ParsePath",
+		inFiles:                  []string{filepath.Join(datapath, "openconfig-withlist.yang")},
+		inPreferOperationalState: true,
+		inGenerateWildcardPaths:  true,
+		inGenerateParsePath:      true,
+		inSchemaStructPkgPath:    "",
+		inPathStructSuffix:       "Path",
+		wantStructsCodeFile:      filepath.Join(TestRoot, "testdata/structs/openconfig-withlist.parsepath.path-txt"),
+	}, {
+		name:                     "list with enum key and ParsePath in a separate package",
+		inFiles:                  []string{filepath.Join(datapath, "openconfig-list-enum-key.yang")},
+		inPreferOperationalState: true,
+		inShortenEnumLeafNames:   true,
+		inGenerateParsePath:      true,
+		inSchemaStructPkgPath:    "github.com/openconfig/ygot/ypathgen/testdata/exampleoc",
+		inPathStructSuffix:       "Path",
+		wantStructsCodeFile:      filepath.Join(TestRoot, "testdata/structs/openconfig-list-enum-key.parsepath.path-txt"),
+	}, {
+		name:                     "list with union key and ParsePath",
+		inFiles:                  []string{filepath.Join(datapath, "openconfig-list-union-key.yang")},
+		inPreferOperationalState: true,
+		inShortenEnumLeafNames:   true,
+		inGenerateWildcardPaths:  true,
+		inGenerateParsePath:      true,
+		inSchemaStructPkgPath:    "",
+		inPathStructSuffix:       "Path",
+		wantStructsCodeFile:      filepath.Join(TestRoot, "testdata/structs/openconfig-list-union-key.parsepath.path-txt"),
+	}, {
+		name:                      "openconfig test with list and descendant paths",
+		inFiles:                   []string{filepath.Join(datapath, "openconfig-withlist.yang")},
+		inPreferOperationalState:  true,
+		inGenerateWildcardPaths:   true,
+		inGenerateDescendantPaths: true,
+		inSchemaStructPkgPath:     "",
+		inPathStructSuffix:        "Path",
+		wantStructsCodeFile:       filepath.Join(TestRoot, "testdata/structs/openconfig-withlist.descendants.path-txt"),
+	}, {
+		name:                       "openconfig test with typed leaves and config and state paths",
+		inFiles:                    []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inPreferOperationalState:   true,
+		inGenerateWildcardPaths:    true,
+		inGenerateTypedLeafPaths:   true,
+		inGenerateConfigStatePaths: true,
+		inSchemaStructPkgPath:      "",
+		inPathStructSuffix:         "Path",
+		wantStructsCodeFile:        filepath.Join(TestRoot, "testdata/structs/openconfig-simple.configstate.path-txt"),
+	}, {
+		name:                       "openconfig test with list and config and state paths and ParsePath",
+		inFiles:                    []string{filepath.Join(datapath, "openconfig-withlist.yang")},
+		inGenerateWildcardPaths:    true,
+		inGenerateParsePath:        true,
+		inGenerateConfigStatePaths: true,
+		inSchemaStructPkgPath:      "",
+		inPathStructSuffix:         "Path",
+		wantStructsCodeFile:        filepath.Join(TestRoot, "testdata/structs/openconfig-withlist.configstate-parsepath.path-txt"),
+	}, {
+		name:                     "openconfig test with rpc and action paths",
+		inFiles:                  []string{filepath.Join(datapath, "openconfig-rpc.yang")},
+		inPreferOperationalState: true,
+		inGenerateWildcardPaths:  true,
+		inGenerateTypedLeafPaths: true,
+		inGenerateParsePath:      true,
+		inGenerateOperationPaths: true,
+		inSchemaStructPkgPath:    "",
+		inPathStructSuffix:       "Path",
+		wantStructsCodeFile:      filepath.Join(TestRoot, "testdata/structs/openconfig-rpc.operations.path-txt"),
+	}, {
+		name:                      "openconfig test with notification paths",
+		inFiles:                   []string{filepath.Join(datapath, "openconfig-notification.yang")},
+		inGenerateWildcardPaths:   true,
+		inGenerateDescendantPaths: true,
+		inGenerateOperationPaths:  true,
+		inSchemaStructPkgPath:     "",
+		inPathStructSuffix:        "Path",
+		wantStructsCodeFile:       filepath.Join(TestRoot, "testdata/structs/openconfig-notification.operations.path-txt"),
+	}, {
+		name:                    "uncompressed openconfig test excluding state",
+		inFiles:                 []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inUncompressedPaths:     true,
+		inExcludeState:          true,
+		inGenerateWildcardPaths: true,
+		inSchemaStructPkgPath:   "",
+		inPathStructSuffix:      "Path",
+		wantStructsCodeFile:     filepath.Join(TestRoot, "testdata/structs/openconfig-simple.uncompressed-excludestate.path-txt"),
+	}, {
+		name:                    "uncompressed test with choice and cases",
+		inFiles:                 []string{filepath.Join(datapath, "choice-case-example.yang")},
+		inUncompressedPaths:     true,
+		inGenerateWildcardPaths: true,
+		inSchemaStructPkgPath:   "",
+		inPathStructSuffix:      "Path",
+		wantStructsCodeFile:     filepath.Join(TestRoot, "testdata/structs/choice-case-example.uncompressed.path-txt"),
+	}, {
+		name: "uncompressed test with augmentations and root entities",
+		inFiles: []string{
+			filepath.Join(datapath, "root-entities.yang"),
+			filepath.Join(datapath, "openconfig-simple-target.yang"),
+			filepath.Join(datapath, "openconfig-simple-augment.yang"),
+		},
+		inUncompressedPaths:     true,
+		inGenerateWildcardPaths: true,
+		inSchemaStructPkgPath:   "github.com/openconfig/ygot/ypathgen/testdata/uexampleoc",
+		inPathStructSuffix:      "Path",
+		checkYANGPath:           true,
+		wantStructsCodeFile:     filepath.Join(TestRoot, "testdata/structs/root-entities.uncompressed.path-txt"),
+		wantNodeDataMap: NodeDataMap{
+			"DevicePath": {
+				GoTypeName:            "*oc.Device",
+				LocalGoTypeName:       "*Device",
+				SubsumingGoStructName: "Device",
+				IsLeaf:                false,
+				IsScalarField:         false,
+				HasDefault:            false,
+				YANGPath:              "/",
+				GoPathPackageName:     "ocstructs",
+			},
+			"OpenconfigSimpleTarget_NativePath": {
+				GoTypeName:            "*oc.OpenconfigSimpleTarget_Native",
+				LocalGoTypeName:       "*OpenconfigSimpleTarget_Native",
+				GoFieldName:           "Native",
+				SubsumingGoStructName: "OpenconfigSimpleTarget_Native",
+				IsLeaf:                false,
+				IsScalarField:         false,
+				HasDefault:            false,
+				YANGPath:              "/openconfig-simple-target/native",
+				GoPathPackageName:     "ocstructs",
+			},
+			"OpenconfigSimpleTarget_Native_ConfigPath": {
+				GoTypeName:            "*oc.OpenconfigSimpleTarget_Native_Config",
+				LocalGoTypeName:       "*OpenconfigSimpleTarget_Native_Config",
+				GoFieldName:           "Config",
+				SubsumingGoStructName: "OpenconfigSimpleTarget_Native_Config",
+				IsLeaf:                false,
+				IsScalarField:         false,
+				HasDefault:            false,
+				YANGPath:              "/openconfig-simple-target/native/config",
+				GoPathPackageName:     "ocstructs",
+			},
+			"OpenconfigSimpleTarget_Native_Config_APath": {
+				GoTypeName:            "string",
+				LocalGoTypeName:       "string",
+				GoFieldName:           "A",
+				SubsumingGoStructName: "OpenconfigSimpleTarget_Native_Config",
+				IsLeaf:                true,
+				IsScalarField:         true,
+				HasDefault:            false,
+				YANGTypeName:          "string",
+				YANGPath:              "/openconfig-simple-target/native/config/a",
+				GoPathPackageName:     "ocstructs",
+			},
+			"OpenconfigSimpleTarget_Native_StatePath": {
+				GoTypeName:            "*oc.OpenconfigSimpleTarget_Native_State",
+				LocalGoTypeName:       "*OpenconfigSimpleTarget_Native_State",
+				GoFieldName:           "State",
+				SubsumingGoStructName: "OpenconfigSimpleTarget_Native_State",
+				IsLeaf:                false,
+				IsScalarField:         false,
+				HasDefault:            false,
+				YANGPath:              "/openconfig-simple-target/native/state",
+				GoPathPackageName:     "ocstructs",
+			},
+			"OpenconfigSimpleTarget_Native_State_APath": {
+				GoTypeName:            "string",
+				LocalGoTypeName:       "string",
+				GoFieldName:           "A",
+				SubsumingGoStructName: "OpenconfigSimpleTarget_Native_State",
+				IsLeaf:                true,
+				IsScalarField:         true,
+				HasDefault:            false,
+				YANGTypeName:          "string",
+				YANGPath:              "/openconfig-simple-target/native/state/a",
+				GoPathPackageName:     "ocstructs",
+			},
+			"OpenconfigSimpleTarget_Native_State_BPath": {
+				GoTypeName:            "string",
+				LocalGoTypeName:       "string",
+				GoFieldName:           "B",
+				SubsumingGoStructName: "OpenconfigSimpleTarget_Native_State",
+				IsLeaf:                true,
+				IsScalarField:         true,
+				HasDefault:            false,
+				YANGTypeName:          "string",
+				YANGPath:              "/openconfig-simple-target/native/state/b",
+				GoPathPackageName:     "ocstructs",
+			},
+			"OpenconfigSimpleTarget_TargetPath": {
+				GoTypeName:            "*oc.OpenconfigSimpleTarget_Target",
+				LocalGoTypeName:       "*OpenconfigSimpleTarget_Target",
+				GoFieldName:           "Target",
+				SubsumingGoStructName: "OpenconfigSimpleTarget_Target",
+				IsLeaf:                false,
+				IsScalarField:         false,
+				HasDefault:            false,
+				YANGPath:              "/openconfig-simple-target/target",
+				GoPathPackageName:     "ocstructs",
+			},
+			"OpenconfigSimpleTarget_Target_FooPath": {
+				GoTypeName:            "*oc.OpenconfigSimpleTarget_Target_Foo",
+				LocalGoTypeName:       "*OpenconfigSimpleTarget_Target_Foo",
+				GoFieldName:           "Foo",
+				SubsumingGoStructName: "OpenconfigSimpleTarget_Target_Foo",
+				IsLeaf:                false,
+				IsScalarField:         false,
+				HasDefault:            false,
+				YANGPath:              "/openconfig-simple-target/target/foo",
+				GoPathPackageName:     "ocstructs",
+			},
+			"OpenconfigSimpleTarget_Target_Foo_ConfigPath": {
+				GoTypeName:            "*oc.OpenconfigSimpleTarget_Target_Foo_Config",
+				LocalGoTypeName:       "*OpenconfigSimpleTarget_Target_Foo_Config",
+				GoFieldName:           "Config",
+				SubsumingGoStructName: "OpenconfigSimpleTarget_Target_Foo_Config",
+				IsLeaf:                false,
+				IsScalarField:         false,
+				HasDefault:            false,
+				YANGPath:              "/openconfig-simple-target/target/foo/config",
+				GoPathPackageName:     "ocstructs",
+			},
+			"OpenconfigSimpleTarget_Target_Foo_Config_APath": {
+				GoTypeName:            "string",
+				LocalGoTypeName:       "string",
+				GoFieldName:           "A",
+				SubsumingGoStructName: "OpenconfigSimpleTarget_Target_Foo_Config",
+				IsLeaf:                true,
+				IsScalarField:         true,
+				HasDefault:            false,
+				YANGTypeName:          "string",
+				YANGPath:              "/openconfig-simple-target/target/foo/config/a",
+				GoPathPackageName:     "ocstructs",
+			},
+			"OpenconfigSimpleTarget_Target_Foo_StatePath": {
+				GoTypeName:            "*oc.OpenconfigSimpleTarget_Target_Foo_State",
+				LocalGoTypeName:       "*OpenconfigSimpleTarget_Target_Foo_State",
+				GoFieldName:           "State",
+				SubsumingGoStructName: "OpenconfigSimpleTarget_Target_Foo_State",
+				IsLeaf:                false,
+				IsScalarField:         false,
+				HasDefault:            false,
+				YANGPath:              "/openconfig-simple-target/target/foo/state",
+				GoPathPackageName:     "ocstructs",
+			},
+			"OpenconfigSimpleTarget_Target_Foo_State_APath": {
+				GoTypeName:            "string",
+				LocalGoTypeName:       "string",
+				GoFieldName:           "A",
+				SubsumingGoStructName: "OpenconfigSimpleTarget_Target_Foo_State",
+				IsLeaf:                true,
+				IsScalarField:         true,
+				HasDefault:            false,
+				YANGTypeName:          "string",
+				YANGPath:              "/openconfig-simple-target/target/foo/state/a",
+				GoPathPackageName:     "ocstructs",
+			},
+			"RootEntities_EntryPath": {
+				GoTypeName:            "*oc.RootEntities_Entry",
+				LocalGoTypeName:       "*RootEntities_Entry",
+				GoFieldName:           "Entry",
+				SubsumingGoStructName: "RootEntities_Entry",
+				IsLeaf:                false,
+				IsScalarField:         false,
+				HasDefault:            false,
+				YANGPath:              "/root-entities/entry",
+				GoPathPackageName:     "ocstructs",
+			},
+			"RootEntities_Entry_KeyPath": {
+				GoTypeName:            "string",
+				LocalGoTypeName:       "string",
+				GoFieldName:           "Key",
+				SubsumingGoStructName: "RootEntities_Entry",
+				IsLeaf:                true,
+				IsScalarField:         true,
+				HasDefault:            false,
+				YANGTypeName:          "string",
+				YANGPath:              "/root-entities/entry/key",
+				GoPathPackageName:     "ocstructs",
+			},
+			"RootEntities_NamePath": {
+				GoTypeName:            "string",
+				LocalGoTypeName:       "string",
+				GoFieldName:           "Name",
+				SubsumingGoStructName: "Device",
+				IsLeaf:                true,
+				IsScalarField:         true,
+				HasDefault:            false,
+				YANGTypeName:          "string",
+				YANGPath:              "/root-entities/name",
+				GoPathPackageName:     "ocstructs",
+			}},
 	}}
 
 	for _, tt := range tests {
@@ -948,10 +1280,16 @@ func TestGeneratePathCode(t *testing.T) {
 				cg.PathStructSuffix = tt.inPathStructSuffix
 				cg.PreferOperationalState = tt.inPreferOperationalState
 				cg.ExcludeState = tt.inExcludeState
+				cg.UncompressedPaths = tt.inUncompressedPaths
 				cg.ListBuilderKeyThreshold = tt.inListBuilderKeyThreshold
 				cg.ShortenEnumLeafNames = tt.inShortenEnumLeafNames
 				cg.UseDefiningModuleForTypedefEnumNames = tt.inUseDefiningModuleForTypedefEnumNames
 				cg.GenerateWildcardPaths = tt.inGenerateWildcardPaths
+				cg.GenerateTypedLeafPaths = tt.inGenerateTypedLeafPaths
+				cg.GenerateParsePath = tt.inGenerateParsePath
+				cg.GenerateDescendantPaths = tt.inGenerateDescendantPaths
+				cg.GenerateConfigStatePaths = tt.inGenerateConfigStatePaths
+				cg.GenerateOperationPaths = tt.inGenerateOperationPaths
 				cg.SimplifyWildcardPaths = tt.inSimplifyWildcardPaths
 				cg.PackageName = "ocstructs"
 
@@ -1826,7 +2164,14 @@ func TestGetNodeDataMap(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, gotErrs := getNodeDataMap(tt.inDirectories, tt.inLeafTypeMap, tt.inFakeRootName, tt.inSchemaStructPkgAccessor, tt.inPathStructSuffix, tt.inPackageName, tt.inPackageSuffix, tt.inSplitByModule, false)
+			got, gotErrs := getNodeDataMap(tt.inDirectories, tt.inLeafTypeMap, tt.inFakeRootName, pathStructOpts{
+				schemaStructPkgAccessor: tt.inSchemaStructPkgAccessor,
+				pathStructSuffix:        tt.inPathStructSuffix,
+				packageName:             tt.inPackageName,
+				packageSuffix:           tt.inPackageSuffix,
+				splitByModule:           tt.inSplitByModule,
+				compressPaths:           true,
+			})
 			// TODO(wenbli): Enhance gNMI's errdiff with checking a slice of substrings and use here.
 			var gotErrStrs []string
 			for _, err := range gotErrs {
@@ -2659,7 +3004,16 @@ func (n *ListWithStatePathAny) WithKey(Key float64) *ListWithStatePathAny {
 	for _, tt := range tests {
 		if tt.want != nil {
 			t.Run(tt.name, func(t *testing.T) {
-				got, gotErr := generateDirectorySnippet(tt.inDirectory, directories, "oc.", tt.inPathStructSuffix, tt.inListBuilderKeyThreshold, true, false, tt.inSplitByModule, false, tt.inPackageName, tt.inPackageSuffix)
+				got, gotErr := generateDirectorySnippet(tt.inDirectory, directories, pathStructOpts{
+					schemaStructPkgAccessor: "oc.",
+					pathStructSuffix:        tt.inPathStructSuffix,
+					listBuilderKeyThreshold: tt.inListBuilderKeyThreshold,
+					generateWildcardPaths:   true,
+					splitByModule:           tt.inSplitByModule,
+					compressPaths:           true,
+					packageName:             tt.inPackageName,
+					packageSuffix:           tt.inPackageSuffix,
+				})
 				if gotErr != nil {
 					t.Fatalf("func generateDirectorySnippet, unexpected error: %v", gotErr)
 				}
@@ -2672,7 +3026,15 @@ func (n *ListWithStatePathAny) WithKey(Key float64) *ListWithStatePathAny {
 
 		if tt.wantNoWildcard != nil {
 			t.Run(tt.name+" no wildcard", func(t *testing.T) {
-				got, gotErr := generateDirectorySnippet(tt.inDirectory, directories, "oc.", tt.inPathStructSuffix, tt.inListBuilderKeyThreshold, false, false, tt.inSplitByModule, false, tt.inPackageName, tt.inPackageSuffix)
+				got, gotErr := generateDirectorySnippet(tt.inDirectory, directories, pathStructOpts{
+					schemaStructPkgAccessor: "oc.",
+					pathStructSuffix:        tt.inPathStructSuffix,
+					listBuilderKeyThreshold: tt.inListBuilderKeyThreshold,
+					splitByModule:           tt.inSplitByModule,
+					compressPaths:           true,
+					packageName:             tt.inPackageName,
+					packageSuffix:           tt.inPackageSuffix,
+				})
 				t.Log(got)
 				if gotErr != nil {
 					t.Fatalf("func generateDirectorySnippet, unexpected error: %v", gotErr)
@@ -3210,7 +3572,14 @@ func (n *ListPathAny) WithUnionKey(UnionKey oc.RootModule_List_UnionKey_Union) *
 		t.Run(tt.name, func(t *testing.T) {
 			var methodBuf strings.Builder
 			var builderBuf strings.Builder
-			if errs := generateChildConstructors(&methodBuf, &builderBuf, tt.inDirectory, tt.inFieldName, tt.inUniqueFieldName, tt.inDirectories, "oc.", tt.inPathStructSuffix, tt.inListBuilderKeyThreshold, tt.inGenerateWildcardPaths, tt.inSimplifyWildcardPaths, tt.inChildAccessor); errs != nil {
+			if errs := generateChildConstructors(&methodBuf, &builderBuf, tt.inDirectory, tt.inFieldName, tt.inUniqueFieldName, tt.inDirectories, tt.inChildAccessor, pathStructOpts{
+				schemaStructPkgAccessor: "oc.",
+				pathStructSuffix:        tt.inPathStructSuffix,
+				listBuilderKeyThreshold: tt.inListBuilderKeyThreshold,
+				generateWildcardPaths:   tt.inGenerateWildcardPaths,
+				simplifyWildcardPaths:   tt.inSimplifyWildcardPaths,
+				compressPaths:           true,
+			}); errs != nil {
 				t.Fatal(errs)
 			}
 
@@ -3226,6 +3595,62 @@ func (n *ListPathAny) WithUnionKey(UnionKey oc.RootModule_List_UnionKey_Union) *
 	}
 }
 
+func TestGenerateConfigStateSnippetsCollisions(t *testing.T) {
+	container := &yang.Entry{Name: "container", Kind: yang.DirectoryEntry, Dir: map[string]*yang.Entry{}}
+	config := &yang.Entry{Name: "config", Kind: yang.DirectoryEntry, Parent: container, Dir: map[string]*yang.Entry{}}
+	state := &yang.Entry{Name: "state", Kind: yang.DirectoryEntry, Parent: container, Dir: map[string]*yang.Entry{}}
+	configLeaf := &yang.Entry{Name: "leaf", Kind: yang.LeafEntry, Parent: config, Type: &yang.YangType{Kind: yang.Ystring}}
+	stateLeaf := &yang.Entry{Name: "leaf", Kind: yang.LeafEntry, Parent: state, Type: &yang.YangType{Kind: yang.Ystring}}
+	stateDir := &yang.Entry{Name: "state", Kind: yang.DirectoryEntry, Parent: container, Dir: map[string]*yang.Entry{}}
+
+	tests := []struct {
+		name             string
+		inDirectory      *ygen.Directory
+		inDirectories    map[string]*ygen.Directory
+		wantErrSubstring string
+	}{{
+		name: "method collides with field",
+		inDirectory: &ygen.Directory{
+			Name:           "Container",
+			Entry:          container,
+			Path:           []string{"", "container"},
+			Fields:         map[string]*yang.Entry{"leaf": stateLeaf, "state": stateDir},
+			ShadowedFields: map[string]*yang.Entry{"leaf": configLeaf},
+		},
+		wantErrSubstring: "the State method of ContainerPath conflicts with the child constructor method of field state",
+	}, {
+		name: "path struct collides with directory",
+		inDirectory: &ygen.Directory{
+			Name:           "Container",
+			Entry:          container,
+			Path:           []string{"", "container"},
+			Fields:         map[string]*yang.Entry{"leaf": stateLeaf},
+			ShadowedFields: map[string]*yang.Entry{"leaf": configLeaf},
+		},
+		inDirectories: map[string]*ygen.Directory{
+			"/container/other": {Name: "Container_Config", Path: []string{"", "container", "other"}},
+		},
+		wantErrSubstring: "path struct Container_ConfigPath of the config container",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var structBuf, methodBuf strings.Builder
+			errs := generateConfigStateSnippets(&structBuf, &methodBuf, tt.inDirectory, tt.inDirectories, getStructData(tt.inDirectory, "Path", false), pathStructOpts{
+				pathStructSuffix: "Path",
+				compressPaths:    true,
+			})
+			var err error
+			if len(errs) > 0 {
+				err = errs[0]
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Errorf("generateConfigStateSnippets: %s", diff)
+			}
+		})
+	}
+}
+
 func TestMakeKeyParams(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -220,6 +220,10 @@ func validateUnion(schema *yang.Entry, value interface{}) util.Errors {
 
 	util.DbgPrint("validateUnion %s", schema.Name)
 	v := reflect.ValueOf(value)
+	if util.IsValueGenericUnion(v) {
+		// Generic unions are validated using the value that they hold.
+		return validateMatchingSchemas(schema, util.GenericUnionValue(v))
+	}
 	if v.Kind() == reflect.Ptr {
 		// The union could be a ptr - either a struct ptr or Go value ptr like *string.
 		v = v.Elem()
@@ -541,6 +545,15 @@ func getUnionVal(parentT reflect.Type, destElemT reflect.Type, v interface{}) (r
 		// leaf-list case
 		destElemT = destElemT.Elem()
 	}
+	if util.IsTypeGenericUnion(destElemT) {
+		// Generic unions have no conversion function in the parent, and are
+		// instead constructed directly from their member types.
+		ev, err := util.NewGenericUnion(destElemT, v)
+		if err != nil {
+			return reflect.ValueOf(nil), fmt.Errorf("unmarshaled %v type %T does not have a union type: %v", v, v, err)
+		}
+		return ev, nil
+	}
 	mn := "To_" + destElemT.Name()
 	mapMethod := reflect.New(parentT).Elem().MethodByName(mn)
 	if !mapMethod.IsValid() {
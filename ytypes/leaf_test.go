@@ -1683,6 +1683,112 @@ func TestUnmarshalLeafJSONEncoding(t *testing.T) {
 	}
 }
 
+// GenericUnionContainer is a container whose union leaf and leaf-list are
+// represented by generic unions.
+type GenericUnionContainer struct {
+	UnionLeaf     *ygot.Union3[string, uint32, EnumType]  `path:"union-leaf"`
+	UnionLeafList []ygot.Union3[string, uint32, EnumType] `path:"union-leaflist"`
+}
+
+func (*GenericUnionContainer) IsYANGGoStruct() {}
+
+func (*GenericUnionContainer) ΛEnumTypeMap() map[string][]reflect.Type {
+	return map[string][]reflect.Type{
+		"/generic-union-container/union-leaf":     {reflect.TypeOf(EnumType(0))},
+		"/generic-union-container/union-leaflist": {reflect.TypeOf(EnumType(0))},
+	}
+}
+
+func TestUnmarshalGenericUnion(t *testing.T) {
+	unionType := func() *yang.YangType {
+		return &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Ystring, Pattern: []string{"a+"}, POSIXPattern: []string{"^a+$"}},
+				{Kind: yang.Yuint32},
+				{Kind: yang.Yenum},
+			},
+		}
+	}
+	containerSchema := &yang.Entry{
+		Name: "generic-union-container",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"union-leaf": {
+				Name: "union-leaf",
+				Kind: yang.LeafEntry,
+				Type: unionType(),
+			},
+			"union-leaflist": {
+				Name:     "union-leaflist",
+				Kind:     yang.LeafEntry,
+				ListAttr: &yang.ListAttr{},
+				Type:     unionType(),
+			},
+		},
+	}
+	for _, s := range containerSchema.Dir {
+		s.Parent = containerSchema
+	}
+
+	type unionT = ygot.Union3[string, uint32, EnumType]
+	tests := []struct {
+		desc            string
+		json            string
+		want            *GenericUnionContainer
+		wantErr         string
+		wantValidateErr bool
+	}{{
+		desc: "string leaf",
+		json: `{"union-leaf": "aaa"}`,
+		want: &GenericUnionContainer{UnionLeaf: unionT{}.Of1("aaa")},
+	}, {
+		desc: "uint32 leaf",
+		json: `{"union-leaf": 42}`,
+		want: &GenericUnionContainer{UnionLeaf: unionT{}.Of2(42)},
+	}, {
+		desc: "enum leaf",
+		json: `{"union-leaf": "E_VALUE_FORTY_TWO"}`,
+		want: &GenericUnionContainer{UnionLeaf: unionT{}.Of3(42)},
+	}, {
+		desc:            "string leaf that does not match pattern",
+		json:            `{"union-leaf": "bbb"}`,
+		want:            &GenericUnionContainer{UnionLeaf: unionT{}.Of1("bbb")},
+		wantValidateErr: true,
+	}, {
+		desc: "leaf-list",
+		json: `{"union-leaflist": ["aaa", 42, "E_VALUE_FORTY_TWO"]}`,
+		want: &GenericUnionContainer{UnionLeafList: []unionT{*unionT{}.Of1("aaa"), *unionT{}.Of2(42), *unionT{}.Of3(42)}},
+	}, {
+		desc:    "value of no member type",
+		json:    `{"union-leaf": true}`,
+		wantErr: "could not find suitable union type",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var jsonTree interface{}
+			if err := json.Unmarshal([]byte(tt.json), &jsonTree); err != nil {
+				t.Fatalf("cannot unmarshal JSON: %v", err)
+			}
+			got := &GenericUnionContainer{}
+			err := Unmarshal(containerSchema, got, jsonTree)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("Unmarshal: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Unmarshal (-want, +got):\n%s", diff)
+			}
+			if errs := Validate(containerSchema, got); (errs != nil) != tt.wantValidateErr {
+				t.Errorf("Validate: got errors %v, want error? %v", errs, tt.wantValidateErr)
+			}
+		})
+	}
+}
+
 func TestUnmarshalLeafRef(t *testing.T) {
 	containerSchema := &yang.Entry{
 		Name: "container",
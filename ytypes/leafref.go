@@ -190,7 +190,7 @@ func dataNodesAtPath(ni *util.NodeInfo, path *gpb.Path, pathQueryNode *util.Path
 			if root.Parent == nil {
 				return nil, fmt.Errorf("no parent for leafref path at %v, with remaining path %s", ni.Schema.Path(), path)
 			}
-			if !util.IsCompressedSchema(root.Schema) && root.Parent.Schema.IsList() && util.IsValueMap(root.Parent.FieldValue) {
+			if !util.IsCompressedSchema(root.Schema) && root.Parent.Schema.IsList() && (util.IsValueMap(root.Parent.FieldValue) || util.IsValueOrderedMap(root.Parent.FieldValue)) {
 				// If we are in an uncompressed schema, then we have one more level of the data tree than
 				// the YANG expects, since our data tree layout is:
 				// struct (parent container)
@@ -314,6 +314,11 @@ func matchesNodes(ni *util.NodeInfo, matchNodes []interface{}) (bool, error) {
 						return true, nil
 					}
 				}
+			case util.IsValueGenericUnion(ov):
+				// Generic unions are compared by the value that they hold.
+				if sv := reflect.ValueOf(sourceNode); util.IsValueGenericUnion(sv) && reflect.DeepEqual(util.GenericUnionValue(ov), util.GenericUnionValue(sv)) {
+					return true, nil
+				}
 			case util.IsValueStructPtr(ov):
 				// TODO(robjs): clean this up.
 				// This is an interface value, which is represented as a struct pointer.
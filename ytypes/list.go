@@ -41,6 +41,12 @@ func validateList(schema *yang.Entry, value interface{}) util.Errors {
 
 	util.DbgPrint("validateList with value %v, type %T, schema name %s", value, value, schema.Name)
 
+	// An ordered map is validated in the same way as a map, since the order
+	// of the list's entries does not affect its validity.
+	if v := reflect.ValueOf(value); util.IsValueOrderedMap(v) {
+		value = util.OrderedMapAsMap(v).Interface()
+	}
+
 	kind := reflect.TypeOf(value).Kind()
 	if kind == reflect.Slice || kind == reflect.Map {
 		// Check list attributes: size constraints etc.
@@ -287,10 +293,11 @@ func unmarshalList(schema *yang.Entry, parent interface{}, jsonList interface{},
 
 	util.DbgPrint("unmarshalList jsonList %v, type %T, into parent type %T, schema name %s", util.ValueStrDebug(jsonList), jsonList, parent, schema.Name)
 
-	// Parent must be a map, slice ptr, or struct ptr.
+	// Parent must be a map, ordered map, slice ptr, or struct ptr.
 	t := reflect.TypeOf(parent)
+	isOrderedMap := util.IsTypeOrderedMap(t)
 
-	if util.IsTypeStructPtr(t) {
+	if util.IsTypeStructPtr(t) && !isOrderedMap {
 		// May be trying to unmarshal a single list element rather than the
 		// whole list.
 		return unmarshalContainerWithListSchema(schema, parent, jsonList, opts...)
@@ -303,13 +310,16 @@ func unmarshalList(schema *yang.Entry, parent interface{}, jsonList interface{},
 			schema.Name, util.ValueStr(jsonList), jsonList)
 	}
 
-	if !(util.IsTypeMap(t) || util.IsTypeSlicePtr(t)) {
+	if !(util.IsTypeMap(t) || util.IsTypeSlicePtr(t) || isOrderedMap) {
 		return fmt.Errorf("unmarshalList for %s got parent type %s, expect map, slice ptr or struct ptr", schema.Name, t.Kind())
 	}
 
 	listElementType := t.Elem()
-	if util.IsTypeSlicePtr(t) {
+	switch {
+	case util.IsTypeSlicePtr(t):
 		listElementType = t.Elem().Elem()
+	case isOrderedMap:
+		listElementType = util.OrderedMapElemType(t)
 	}
 	if !util.IsTypeStructPtr(listElementType) {
 		return fmt.Errorf("unmarshalList for %s parent type %T, has bad field type %v", listElementType, parent, listElementType)
@@ -340,6 +350,8 @@ func unmarshalList(schema *yang.Entry, parent interface{}, jsonList interface{},
 				return err
 			}
 			err = util.InsertIntoMap(parent, newKey.Interface(), newVal.Interface())
+		case isOrderedMap:
+			err = insertIntoOrderedMap(schema, reflect.ValueOf(parent), newVal)
 		case util.IsTypeSlicePtr(t):
 			err = util.InsertIntoSlice(parent, newVal.Interface())
 		default:
@@ -354,6 +366,22 @@ func unmarshalList(schema *yang.Entry, parent interface{}, jsonList interface{},
 	return nil
 }
 
+// insertIntoOrderedMap appends newVal, which is a new list element, to the
+// end of the ordered map om. If an element with the same key already exists
+// in the list, it is replaced, such that the order of the list follows the
+// order in which elements are inserted.
+func insertIntoOrderedMap(schema *yang.Entry, om reflect.Value, newVal reflect.Value) error {
+	// The key is constructed using a map type with the same key type as the
+	// ordered map.
+	mt := reflect.MapOf(util.OrderedMapKeyType(om.Type()), util.OrderedMapElemType(om.Type()))
+	newKey, err := makeKeyForInsert(schema, reflect.Zero(mt).Interface(), newVal)
+	if err != nil {
+		return err
+	}
+	util.OrderedMapDelete(om, newKey)
+	return util.OrderedMapAppend(om, newVal)
+}
+
 // makeValForInsert is used to create a value with the type extracted from
 // given map. The returned value is populated according to the supplied "keys"
 // map, which is assumed to be the map[string]string keys field from a gNMI
@@ -430,7 +458,7 @@ func makeValForInsert(schema *yang.Entry, parent interface{}, keys map[string]st
 		return util.InsertIntoStruct(val.Interface(), fn, nv.Interface())
 	}
 
-	if util.IsTypeStruct(keyT) {
+	if util.IsTypeStruct(keyT) && !util.IsTypeGenericUnion(keyT) {
 		for i := 0; i < keyT.NumField(); i++ {
 			schKey, err := directDescendantSchema(keyT.Field(i))
 			if err != nil {
@@ -457,7 +485,7 @@ func makeKeyForInsert(schema *yang.Entry, parentMap interface{}, newVal reflect.
 	listKeyType := reflect.TypeOf(parentMap).Key()
 	newKey := reflect.New(listKeyType).Elem()
 
-	if util.IsTypeStruct(listKeyType) {
+	if util.IsTypeStruct(listKeyType) && !util.IsTypeGenericUnion(listKeyType) {
 		// For struct key type, copy the key fields from the new list entry
 		// struct newVal into the key struct.
 		for i := 0; i < newKey.NumField(); i++ {
@@ -675,6 +675,166 @@ func TestUnmarshalKeyedList(t *testing.T) {
 	}
 }
 
+type orderedListElem struct {
+	Key       *string `path:"key"`
+	LeafField *int32  `path:"leaf-field"`
+}
+
+func (*orderedListElem) IsYANGGoStruct() {}
+
+// orderedListElemOrderedMap is a minimal hand-written equivalent of a
+// generated ordered map, whose entries are orderedListElem structs keyed by
+// their Key.
+type orderedListElemOrderedMap struct {
+	keys     []string
+	valueMap map[string]*orderedListElem
+}
+
+func (*orderedListElemOrderedMap) IsYANGOrderedList() {}
+
+func (o *orderedListElemOrderedMap) Keys() []string {
+	if o == nil {
+		return nil
+	}
+	return append([]string{}, o.keys...)
+}
+
+func (o *orderedListElemOrderedMap) Values() []*orderedListElem {
+	if o == nil {
+		return nil
+	}
+	var vs []*orderedListElem
+	for _, k := range o.keys {
+		vs = append(vs, o.valueMap[k])
+	}
+	return vs
+}
+
+func (o *orderedListElemOrderedMap) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.keys)
+}
+
+func (o *orderedListElemOrderedMap) Get(key string) *orderedListElem {
+	if o == nil {
+		return nil
+	}
+	return o.valueMap[key]
+}
+
+func (o *orderedListElemOrderedMap) Delete(key string) bool {
+	if _, ok := o.valueMap[key]; !ok {
+		return false
+	}
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+	delete(o.valueMap, key)
+	return true
+}
+
+func (o *orderedListElemOrderedMap) Append(v *orderedListElem) error {
+	if v == nil || v.Key == nil {
+		return fmt.Errorf("nil value or key")
+	}
+	if o.valueMap == nil {
+		o.valueMap = map[string]*orderedListElem{}
+	}
+	if _, ok := o.valueMap[*v.Key]; ok {
+		return fmt.Errorf("duplicate key %s", *v.Key)
+	}
+	o.keys = append(o.keys, *v.Key)
+	o.valueMap[*v.Key] = v
+	return nil
+}
+
+func TestUnmarshalOrderedList(t *testing.T) {
+	listAttr := yang.NewDefaultListAttr()
+	listAttr.OrderedBy = &yang.Value{Name: "user"}
+	containerSchema := &yang.Entry{
+		Name: "container",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"ordered-list": {
+				Name:     "ordered-list",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: listAttr,
+				Key:      "key",
+				Config:   yang.TSTrue,
+				Dir: map[string]*yang.Entry{
+					"key": {
+						Kind: yang.LeafEntry,
+						Name: "key",
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"leaf-field": {
+						Kind: yang.LeafEntry,
+						Name: "leaf-field",
+						Type: &yang.YangType{Kind: yang.Yint32},
+					},
+				},
+			},
+		},
+	}
+	addParents(containerSchema)
+
+	type ContainerStruct struct {
+		OrderedList *orderedListElemOrderedMap `path:"ordered-list"`
+	}
+
+	tests := []struct {
+		desc     string
+		json     string
+		parent   *ContainerStruct
+		wantKeys []string
+		wantErr  string
+	}{{
+		desc:     "entries unmarshalled in order",
+		json:     `{ "ordered-list" : [ { "key" : "z", "leaf-field" : 1}, { "key" : "a", "leaf-field" : 2}, { "key" : "m"} ] }`,
+		parent:   &ContainerStruct{},
+		wantKeys: []string{"z", "a", "m"},
+	}, {
+		desc:     "entries appended to existing list",
+		json:     `{ "ordered-list" : [ { "key" : "b"}, { "key" : "a"} ] }`,
+		parent:   &ContainerStruct{OrderedList: &orderedListElemOrderedMap{}},
+		wantKeys: []string{"b", "a"},
+	}, {
+		desc:    "bad field",
+		json:    `{ "ordered-list" : [ { "key" : "forty-two", "bad-field" : 42} ] }`,
+		parent:  &ContainerStruct{},
+		wantErr: `parent container ordered-list (type *ytypes.orderedListElem): JSON contains unexpected field bad-field`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var jsonTree interface{}
+			if err := json.Unmarshal([]byte(tt.json), &jsonTree); err != nil {
+				t.Fatalf("%s : %s", tt.desc, err)
+			}
+
+			err := Unmarshal(containerSchema, tt.parent, jsonTree)
+			if got, want := errToString(err), tt.wantErr; got != want {
+				t.Errorf("%s: Unmarshal got error: %v, want error: %v", tt.desc, got, want)
+			}
+			testErrLog(t, tt.desc, err)
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.wantKeys, tt.parent.OrderedList.Keys()); diff != "" {
+				t.Errorf("%s: Unmarshal did not preserve order (-want, +got):\n%s", tt.desc, diff)
+			}
+			if got, want := *tt.parent.OrderedList.Get(tt.wantKeys[0]).Key, tt.wantKeys[0]; got != want {
+				t.Errorf("%s: Unmarshal got key %s for first entry, want %s", tt.desc, got, want)
+			}
+		})
+	}
+}
+
 func TestUnmarshalStructKeyedList(t *testing.T) {
 	containerWithLeafListSchema := &yang.Entry{
 		Name: "container",
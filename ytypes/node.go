@@ -51,6 +51,10 @@ type retrieveNodeArgs struct {
 	// If val is set to a non-nil value, leaf/leaflist node corresponding
 	// to the given path is updated with this value.
 	val interface{}
+	// If goVal is set to true, val is the Go value of the leaf/leaflist
+	// node, which is assigned to the field of the GoStruct rather than
+	// being unmarshalled.
+	goVal bool
 	// tolerateJSONInconsistenciesForVal means to tolerate inconsistencies
 	// for val as if it were converted from JSON. As of right now, this is
 	// specifically to deal with uint values being streamed as positive int
@@ -94,7 +98,7 @@ func retrieveNode(schema *yang.Entry, root interface{}, path, traversedPath *gpb
 
 	switch {
 	// Check if the schema is a container, or the schema is a list and the parent provided is a member of that list.
-	case schema.IsContainer() || (schema.IsList() && util.IsTypeStructPtr(reflect.TypeOf(root))):
+	case schema.IsContainer() || (schema.IsList() && util.IsTypeStructPtr(reflect.TypeOf(root)) && !util.IsTypeOrderedMap(reflect.TypeOf(root))):
 		return retrieveNodeContainer(schema, root, path, traversedPath, args)
 	case schema.IsList():
 		return retrieveNodeList(schema, root, path, traversedPath, args)
@@ -133,7 +137,7 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 
 		checkPath := func(p []string, args retrieveNodeArgs, shadowLeaf bool) ([]*TreeNode, error) {
 			to := len(p)
-			if util.IsTypeMap(ft.Type) {
+			if util.IsTypeKeyedList(ft.Type) {
 				to--
 			}
 			np := &gpb.Path{}
@@ -185,6 +189,10 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 					if err := util.UpdateField(root, ft.Name, args.val); err != nil {
 						return nil, status.Errorf(codes.Unknown, "failed to update struct field %s in %T with value %v, because of %v", ft.Name, root, args.val, err)
 					}
+				case args.goVal && (cschema.IsLeaf() || cschema.IsLeafList()):
+					if err := setLeafValue(fv, args.val); err != nil {
+						return nil, status.Errorf(codes.InvalidArgument, "failed to update struct field %s in %T with value %v; %v", ft.Name, root, args.val, err)
+					}
 				case cschema.IsLeaf() || cschema.IsLeafList():
 					// With GNMIEncoding, unmarshalGeneric can only unmarshal leaf or leaf list
 					// nodes. Schema provided must be the schema of the leaf or leaf list node.
@@ -251,11 +259,28 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 	return nil, status.Errorf(codes.InvalidArgument, "no match found in %T, for path %v", root, path)
 }
 
-// retrieveNodeList is an internal function and operates on a map. It returns the nodes matching
-// with keys corresponding to the key supplied in path.
+// retrieveNodeList is an internal function and operates on a map or an ordered map. It returns
+// the nodes matching with keys corresponding to the key supplied in path.
 // Function returns list of nodes, list of schemas and error.
 func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath *gpb.Path, args retrieveNodeArgs) ([]*TreeNode, error) {
 	rv := reflect.ValueOf(root)
+	// An ordered map is traversed as a map, in the order of its keys. Entries
+	// that are deleted from, or inserted into, the map are also deleted from,
+	// or appended to, the ordered map.
+	om := reflect.Value{}
+	var mapKeys []reflect.Value
+	if util.IsValueOrderedMap(rv) {
+		om, mapKeys = rv, util.OrderedMapKeys(rv)
+		rv = util.OrderedMapAsMap(rv)
+		root = rv.Interface()
+	}
+	deleteKey := func(k reflect.Value) {
+		rv.SetMapIndex(k, reflect.Value{})
+		if om.IsValid() {
+			util.OrderedMapDelete(om, k)
+		}
+	}
+
 	switch {
 	case schema.Key == "":
 		return nil, status.Errorf(codes.InvalidArgument, "unkeyed list can't be traversed, type %T, path %v", root, path)
@@ -269,11 +294,14 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 
 	listKeyT := rv.Type().Key()
 	listElemT := rv.Type().Elem()
-	for _, k := range rv.MapKeys() {
+	if mapKeys == nil {
+		mapKeys = rv.MapKeys()
+	}
+	for _, k := range mapKeys {
 		listElemV := rv.MapIndex(k)
 
 		// Handle lists with a single key.
-		if !util.IsValueStruct(k) {
+		if !util.IsValueStruct(k) || util.IsValueGenericUnion(k) {
 			// Handle the special case that we have zero keys specified only when we are handling lists
 			// with partial keys specified.
 			if len(path.GetElem()[0].GetKey()) == 0 && args.partialKeyMatch || (args.handleWildcards && path.GetElem()[0].GetKey()[schema.Key] == "*") {
@@ -309,7 +337,7 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 			if keyAsString == pathKey {
 				remainingPath := util.PopGNMIPath(path)
 				if args.delete && len(remainingPath.GetElem()) == 0 {
-					rv.SetMapIndex(k, reflect.Value{})
+					deleteKey(k)
 					return nil, nil
 				}
 				return retrieveNode(schema, listElemV.Interface(), remainingPath, appendElem(traversedPath, path.GetElem()[0]), args)
@@ -363,7 +391,7 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 			}
 			remainingPath := util.PopGNMIPath(path)
 			if args.delete && len(remainingPath.GetElem()) == 0 {
-				rv.SetMapIndex(k, reflect.Value{})
+				deleteKey(k)
 				return nil, nil
 			}
 			nodes, err := retrieveNode(schema, listElemV.Interface(), remainingPath, appendElem(traversedPath, &gpb.PathElem{Name: path.GetElem()[0].Name, Key: keys}), args)
@@ -382,6 +410,11 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 		if err != nil {
 			return nil, err
 		}
+		if om.IsValid() {
+			if err := util.OrderedMapAppend(om, rv.MapIndex(reflect.ValueOf(key))); err != nil {
+				return nil, status.Errorf(codes.Unknown, "could not append new entry to %T: %v", om.Interface(), err)
+			}
+		}
 		nodes, err := retrieveNode(schema, rv.MapIndex(reflect.ValueOf(key)).Interface(), util.PopGNMIPath(path), appendElem(traversedPath, path.GetElem()[0]), args)
 		if err != nil {
 			return nil, err
@@ -436,13 +469,116 @@ type TreeNode struct {
 // also be supplied. It takes a set of options which can be used to specify get behaviours, such as
 // allowing partial match. If there are no matches for the path, an error is returned.
 func GetNode(schema *yang.Entry, root interface{}, path *gpb.Path, opts ...GetNodeOpt) ([]*TreeNode, error) {
-	return retrieveNode(schema, root, path, nil, retrieveNodeArgs{
+	args := retrieveNodeArgs{
 		// We never want to modify the input root, so we specify modifyRoot.
 		modifyRoot:       false,
 		partialKeyMatch:  hasPartialKeyMatch(opts),
 		handleWildcards:  hasHandleWildcards(opts),
 		preferShadowPath: hasGetNodePreferShadowPath(opts),
+	}
+	if args.handleWildcards && util.PathHasMultiLevelWildcard(path) {
+		return retrieveNodeMultiLevelWildcard(schema, root, path, args)
+	}
+	return retrieveNode(schema, root, path, nil, args)
+}
+
+// retrieveNodeMultiLevelWildcard returns the nodes within the tree at root,
+// which must have the schema supplied, whose paths match path, which
+// contains multilevel wildcards ("..."). Since such a path may match nodes at
+// any depth, the entire tree is traversed, and only the nodes that exist in
+// the GoStruct are returned, i.e., the containers that are compressed out of
+// the GoStruct never match. Keys that are not specified in path are treated
+// as wildcards. A NotFound error is returned if no node matches path.
+func retrieveNodeMultiLevelWildcard(schema *yang.Entry, root interface{}, path *gpb.Path, args retrieveNodeArgs) ([]*TreeNode, error) {
+	query := &gpb.Path{Elem: path.GetElem()}
+	var matches []*TreeNode
+	err := walkDescendantNodes(schema, root, &gpb.Path{}, args, func(n *TreeNode) {
+		if util.PathMatchesQueryExact(n.Path, query) {
+			matches = append(matches, n)
+		}
 	})
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, status.Errorf(codes.NotFound, "could not find any nodes matching path %v", path)
+	}
+	return matches, nil
+}
+
+// walkDescendantNodes calls fn for the node at root, whose schema and path
+// are supplied, and for each of its populated descendants. Unkeyed lists are
+// not traversed, since their entries cannot be addressed by a path.
+func walkDescendantNodes(schema *yang.Entry, root interface{}, path *gpb.Path, args retrieveNodeArgs, fn func(*TreeNode)) error {
+	if util.IsValueNil(root) {
+		return nil
+	}
+	fn(&TreeNode{Schema: schema, Data: root, Path: path})
+
+	rv := reflect.ValueOf(root)
+	if schema == nil || !(schema.IsContainer() || schema.IsList()) || !util.IsTypeStructPtr(rv.Type()) {
+		return nil
+	}
+	v := rv.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		fv, ft := v.Field(i), v.Type().Field(i)
+		if util.IsYgotAnnotation(ft) || util.IsNilOrInvalidValue(fv) {
+			continue
+		}
+		cschema, err := util.ChildSchema(schema, ft)
+		switch {
+		case err != nil:
+			return status.Errorf(codes.Unknown, "failed to get child schema for %T, field %s: %s", root, ft.Name, err)
+		case cschema == nil:
+			return status.Errorf(codes.InvalidArgument, "could not find schema for type %T, field %s", root, ft.Name)
+		case cschema.IsList() && !util.IsTypeKeyedList(ft.Type):
+			continue
+		}
+
+		schPaths := util.ShadowSchemaPaths(ft)
+		if !args.preferShadowPath || len(schPaths) == 0 {
+			if schPaths, err = util.SchemaPaths(ft); err != nil {
+				return status.Errorf(codes.Unknown, "failed to get schema paths for %T, field %s: %s", root, ft.Name, err)
+			}
+		}
+		for _, p := range schPaths {
+			np := proto.Clone(path).(*gpb.Path)
+			for _, e := range p {
+				if e != "" {
+					np.Elem = append(np.Elem, &gpb.PathElem{Name: e})
+				}
+			}
+			if !util.IsTypeKeyedList(ft.Type) {
+				if err := walkDescendantNodes(cschema, fv.Interface(), np, args, fn); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// The last element of the path of a keyed list is that of its
+			// entries, which is qualified by the keys of each entry.
+			listElem := np.Elem[len(np.Elem)-1]
+			np.Elem = np.Elem[:len(np.Elem)-1]
+			mv := fv
+			var mapKeys []reflect.Value
+			if util.IsValueOrderedMap(fv) {
+				mapKeys, mv = util.OrderedMapKeys(fv), util.OrderedMapAsMap(fv)
+			} else {
+				mapKeys = mv.MapKeys()
+			}
+			for _, k := range mapKeys {
+				listElemV := mv.MapIndex(k)
+				keys, err := ygot.PathKeyFromStruct(listElemV)
+				if err != nil {
+					return status.Errorf(codes.Unknown, "could not get path keys at %v: %v", np, err)
+				}
+				if err := walkDescendantNodes(cschema, listElemV.Interface(), appendElem(np, &gpb.PathElem{Name: listElem.Name, Key: keys}), args, fn); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
 }
 
 // GetNodeOpt defines an interface that can be used to supply arguments to functions using GetNode.
@@ -471,6 +607,8 @@ func hasPartialKeyMatch(opts []GetNodeOpt) bool {
 }
 
 // GetHandleWildcards specifies that a match within GetNode should be allowed to use wildekarts.
+// Besides wildcard keys ("*"), multilevel wildcards ("..."), which match any
+// number of path elements, are handled.
 type GetHandleWildcards struct{}
 
 // IsGetNodeOpt implements the GetNodeOpt interface.
@@ -24,6 +24,8 @@ import (
 	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
 
@@ -1087,6 +1089,12 @@ type childList struct {
 	ChildContainer *listChildContainer `path:"child-container"`
 }
 
+func (l *childList) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"key": *l.Key}, nil
+}
+
+func (*childList) IsYANGGoStruct() {}
+
 type childContainer struct {
 	Container *grandchildContainer `path:"grandchild"`
 }
@@ -1290,6 +1298,7 @@ func TestGetNode(t *testing.T) {
 		inArgs           []GetNodeOpt
 		wantTreeNodes    []*TreeNode
 		wantErrSubstring string
+		wantErrCode      codes.Code
 	}{{
 		desc:     "simple get leaf",
 		inSchema: rootSchema,
@@ -1806,6 +1815,71 @@ func TestGetNode(t *testing.T) {
 		inPath:           mustPath("/state/childlist[key=one]/child-container/valeur"),
 		inArgs:           []GetNodeOpt{&PreferShadowPath{}},
 		wantErrSubstring: "no match found in *ytypes.listChildContainer",
+	}, {
+		desc:     "multilevel wildcard, leaf at any depth",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			Leaf:      ygot.String("leaf"),
+			Container: &childContainer{Container: &grandchildContainer{Val: ygot.String("val")}},
+		},
+		inPath: mustPath("/.../val"),
+		inArgs: []GetNodeOpt{&GetHandleWildcards{}},
+		wantTreeNodes: []*TreeNode{{
+			Data:   ygot.String("val"),
+			Schema: valSchema,
+			Path:   mustPath("/container/grandchild/val"),
+		}},
+	}, {
+		desc:     "multilevel wildcard, subtree of list entry",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			List: map[string]*listEntry{
+				"one": {Key: ygot.String("one")},
+				"two": {Key: ygot.String("two")},
+			},
+		},
+		inPath: mustPath("/list[key=one]/..."),
+		inArgs: []GetNodeOpt{&GetHandleWildcards{}},
+		wantTreeNodes: []*TreeNode{{
+			Data:   &listEntry{Key: ygot.String("one")},
+			Schema: simpleListSchema,
+			Path:   mustPath("/list[key=one]"),
+		}, {
+			Data:   ygot.String("one"),
+			Schema: keyLeafSchema,
+			Path:   mustPath("/list[key=one]/key"),
+		}},
+	}, {
+		desc:     "multilevel wildcard, wildcard list keys and names",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			ChildList: map[string]*childList{
+				"one": {
+					Key:            ygot.String("one"),
+					ChildContainer: &listChildContainer{Value: ygot.String("1")},
+				},
+				"two": {
+					Key: ygot.String("two"),
+				},
+			},
+		},
+		inPath: mustPath("/.../childlist[key=*]/*/value"),
+		inArgs: []GetNodeOpt{&GetHandleWildcards{}},
+		wantTreeNodes: []*TreeNode{{
+			Data:   ygot.String("1"),
+			Schema: rootSchema.Dir["state"].Dir["childlist"].Dir["child-container"].Dir["value"],
+			Path:   mustPath("/state/childlist[key=one]/child-container/value"),
+		}},
+	}, {
+		desc:     "multilevel wildcard, no match",
+		inSchema: rootSchema,
+		inData: &rootStruct{
+			Leaf: ygot.String("leaf"),
+		},
+		inPath:           mustPath("/.../val"),
+		inArgs:           []GetNodeOpt{&GetHandleWildcards{}},
+		wantErrSubstring: "could not find any nodes matching path",
+		wantErrCode:      codes.NotFound,
 	}}
 
 	for _, tt := range tests {
@@ -1814,6 +1888,9 @@ func TestGetNode(t *testing.T) {
 			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
 				t.Fatalf("did not get expected error, %s", diff)
 			}
+			if err != nil && tt.wantErrCode != codes.OK && status.Code(err) != tt.wantErrCode {
+				t.Fatalf("did not get expected error code, got: %v, want: %v", status.Code(err), tt.wantErrCode)
+			}
 
 			if err := treeNodesEqual(got, tt.wantTreeNodes); err != nil {
 				fmt.Println(got[0].Schema)
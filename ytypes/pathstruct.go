@@ -0,0 +1,598 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// This file contains functions that read and write the nodes of a GoStruct
+// that are referenced by the generated path structs. The root and its schema
+// must be those of the root of the path structs, i.e., the fake root of the
+// generated code.
+//
+// The type arguments of the functions that handle typed leaf path structs
+// cannot be inferred from a path struct, so they must be specified, e.g.,
+// GetLeaf[string, *Device](schema, root, DeviceRoot("dev").Name()).
+
+// resolvePathStruct returns the *gpb.Path that the path struct p refers to.
+func resolvePathStruct(p ygot.PathStruct) (*gpb.Path, error) {
+	path, _, errs := ygot.ResolvePath(p)
+	if errs != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot resolve path struct %T: %v", p, util.Errors(errs))
+	}
+	// The target of the resolved path identifies the device, and is not
+	// part of the data tree of the root.
+	path.Target = ""
+	return path, nil
+}
+
+// ResolvePathWithSchema returns the resolved *gpb.Path of the path struct n,
+// as well as the root node's customData, as ygot.ResolvePath does. In
+// addition, the values of the list keys within the path are validated against
+// the YANG types of the key leaves, including their ranges, lengths and
+// patterns, such that a path that would be rejected by a target because of an
+// invalid key is not returned. schema must be the schema of the root of the
+// path structs. Wildcard keys are not validated.
+func ResolvePathWithSchema(schema *yang.Entry, n ygot.PathStruct) (*gpb.Path, map[string]interface{}, []error) {
+	path, customData, errs := ygot.ResolvePath(n)
+	if errs != nil {
+		return nil, nil, errs
+	}
+	if _, errs := validatePathKeys(schema, path); errs != nil {
+		return nil, nil, errs
+	}
+	return path, customData, nil
+}
+
+// validatePathKeys validates the values of the list keys within path against
+// the schemas of the key leaves, starting from the supplied schema of the
+// root of the path. The path elements following a multilevel wildcard
+// ("...") are not validated, since their schemas are unknown. The schema of
+// the node at path is returned if the keys are valid, or nil if path contains
+// a multilevel wildcard.
+func validatePathKeys(schema *yang.Entry, path *gpb.Path) (*yang.Entry, util.Errors) {
+	var errs util.Errors
+	for i, e := range path.GetElem() {
+		if e.GetName() == "..." {
+			return nil, errs
+		}
+		child := schemaChild(schema, e.GetName())
+		if child == nil {
+			return nil, util.AppendErr(errs, fmt.Errorf("path %v: schema %s does not have a child %s", path, schema.Path(), e.GetName()))
+		}
+		schema = child
+		if len(e.GetKey()) == 0 {
+			continue
+		}
+		if !schema.IsList() {
+			return nil, util.AppendErr(errs, fmt.Errorf("path %v: keys specified for schema %s, which is not a list", path, schema.Path()))
+		}
+		// Keys are validated in a deterministic order.
+		names := make([]string, 0, len(e.GetKey()))
+		for name := range e.GetKey() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			v := e.GetKey()[name]
+			if v == "*" {
+				continue
+			}
+			keySchema, ok := schema.Dir[name]
+			if !ok {
+				errs = util.AppendErr(errs, fmt.Errorf("path element %d of %v: %s is not a key of list %s", i, path, name, schema.Path()))
+				continue
+			}
+			if err := validateKeyString(keySchema, v); err != nil {
+				errs = util.AppendErr(errs, fmt.Errorf("path element %d of %v: invalid value %q for key %s: %v", i, path, v, name, err))
+			}
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return schema, nil
+}
+
+// schemaChild returns the child of schema with the specified name, descending
+// through any choice and case nodes, or nil if there is no such child.
+func schemaChild(schema *yang.Entry, name string) *yang.Entry {
+	if c, ok := schema.Dir[name]; ok && !util.IsChoiceOrCase(c) {
+		return c
+	}
+	for _, c := range util.FindFirstNonChoiceOrCase(schema) {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// validateKeyString validates the string representation of the value of a
+// list key within a gNMI path against the schema of the key leaf.
+func validateKeyString(schema *yang.Entry, value string) error {
+	schema, err := util.ResolveIfLeafRef(schema)
+	if err != nil {
+		return err
+	}
+	if schema.Type == nil {
+		return fmt.Errorf("schema %s does not have a type", schema.Name)
+	}
+
+	ykind := schema.Type.Kind
+	switch ykind {
+	case yang.Ystring:
+		return validateString(schema, value)
+	case yang.Ybool:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%q is not a bool", value)
+		}
+		return nil
+	case yang.Ydecimal64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a decimal64", value)
+		}
+		return validateDecimal(schema, f)
+	case yang.Ybinary:
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("%q is not base64-encoded binary: %v", value, err)
+		}
+		return validateBinary(schema, b)
+	case yang.Yenum:
+		if schema.Type.Enum == nil || !schema.Type.Enum.IsDefined(value) {
+			return fmt.Errorf("%q is not a value of the enumeration", value)
+		}
+		return nil
+	case yang.Yidentityref:
+		if schema.Type.IdentityBase == nil {
+			return fmt.Errorf("identityref schema %s does not have a base", schema.Name)
+		}
+		name := util.StripModulePrefix(value)
+		for _, id := range schema.Type.IdentityBase.Values {
+			if id.Name == name {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not an identity derived from %s", value, schema.Type.IdentityBase.Name)
+	case yang.Yunion:
+		for _, t := range schema.Type.Type {
+			// Leafrefs within unions cannot be resolved without the
+			// context of the leaf, so they are considered valid.
+			if t.Kind == yang.Yleafref || validateKeyString(yangTypeToLeafEntry(t), value) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q does not match any type of the union", value)
+	}
+
+	if isIntegerType(ykind) {
+		var v interface{}
+		bits, err := util.YangIntTypeBits(ykind)
+		if err != nil {
+			return err
+		}
+		if isSigned(ykind) {
+			v, err = strconv.ParseInt(value, 10, bits)
+		} else {
+			v, err = strconv.ParseUint(value, 10, bits)
+		}
+		if err != nil {
+			return fmt.Errorf("%q is not a valid %v", value, ykind)
+		}
+		return validateInt(schema, reflect.ValueOf(v).Convert(reflect.TypeOf(yangBuiltinTypeToGoType(ykind))).Interface())
+	}
+	// Other types, such as bits, are not validated.
+	return nil
+}
+
+// GetPathStruct retrieves the nodes referenced by the path struct p from the
+// specified root, whose schema must also be supplied. Wildcard keys within
+// the path struct match all entries of the corresponding list, and the Path of
+// each returned TreeNode contains the concrete keys of the matched entries. If
+// there are no matches for the path, an error is returned.
+func GetPathStruct(schema *yang.Entry, root ygot.GoStruct, p ygot.PathStruct, opts ...GetNodeOpt) ([]*TreeNode, error) {
+	path, err := resolvePathStruct(p)
+	if err != nil {
+		return nil, err
+	}
+	return GetNode(schema, root, path, append(opts, &GetHandleWildcards{})...)
+}
+
+// GetOrCreatePathStruct retrieves the node referenced by the path struct p
+// from the specified root, whose schema must also be supplied, initialising
+// the nodes along the path if they do not exist. The path struct must not
+// contain wildcards.
+func GetOrCreatePathStruct(schema *yang.Entry, root ygot.GoStruct, p ygot.PathStruct, opts ...GetOrCreateNodeOpt) (interface{}, *yang.Entry, error) {
+	path, err := resolvePathStruct(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	return GetOrCreateNode(schema, root, path, opts...)
+}
+
+// GetOrCreateTypedPathStruct is GetOrCreatePathStruct for a node whose Go
+// type is T, such as the GoStruct of a container or list entry, which is
+// returned without a type assertion by the caller. An error is returned if
+// the node is not of type T.
+func GetOrCreateTypedPathStruct[T any](schema *yang.Entry, root ygot.GoStruct, p ygot.PathStruct, opts ...GetOrCreateNodeOpt) (T, error) {
+	var t T
+	n, _, err := GetOrCreatePathStruct(schema, root, p, opts...)
+	if err != nil {
+		return t, err
+	}
+	t, ok := n.(T)
+	if !ok {
+		return t, status.Errorf(codes.InvalidArgument, "node referenced by path struct %T is of type %T, not %v", p, n, reflect.TypeOf((*T)(nil)).Elem())
+	}
+	return t, nil
+}
+
+// SetPathStruct sets the value of the leaf or leaf-list referenced by the path
+// struct p within the specified root, whose schema must also be supplied. The
+// value is specified as in SetNode.
+func SetPathStruct(schema *yang.Entry, root ygot.GoStruct, p ygot.PathStruct, val interface{}, opts ...SetNodeOpt) error {
+	path, err := resolvePathStruct(p)
+	if err != nil {
+		return err
+	}
+	return SetNode(schema, root, path, val, opts...)
+}
+
+// DeletePathStruct deletes the node referenced by the path struct p from the
+// specified root, whose schema must also be supplied.
+func DeletePathStruct(schema *yang.Entry, root ygot.GoStruct, p ygot.PathStruct, opts ...DelNodeOpt) error {
+	path, err := resolvePathStruct(p)
+	if err != nil {
+		return err
+	}
+	return DeleteNode(schema, root, path, opts...)
+}
+
+// TypedTreeNode is a TreeNode whose data is a leaf value of type V.
+type TypedTreeNode[V any] struct {
+	// Schema is the schema entry for the leaf.
+	Schema *yang.Entry
+	// Data is the value of the leaf.
+	Data V
+	// Path is the path of the leaf that is being returned.
+	Path *gpb.Path
+}
+
+// GetLeaf retrieves the values of the leaves referenced by the typed leaf path
+// struct p from the specified root, whose schema must also be supplied. Leaves
+// that are not set are not returned, nor are leaves within containers or list
+// entries that do not exist, such that an empty slice is returned if the leaf
+// referenced by a path struct without wildcards is not set.
+func GetLeaf[V any, S ygot.GoStruct](schema *yang.Entry, root ygot.GoStruct, p ygot.TypedLeafPathStruct[V, S], opts ...GetNodeOpt) ([]*TypedTreeNode[V], error) {
+	nodes, err := GetPathStruct(schema, root, p, opts...)
+	switch {
+	case status.Code(err) == codes.NotFound:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	var typed []*TypedTreeNode[V]
+	for _, n := range nodes {
+		v, ok, err := leafValue[V](n.Data)
+		switch {
+		case err != nil:
+			return nil, status.Errorf(codes.Internal, "path %v: %v", n.Path, err)
+		case !ok:
+			continue
+		}
+		typed = append(typed, &TypedTreeNode[V]{Schema: n.Schema, Data: v, Path: n.Path})
+	}
+	return typed, nil
+}
+
+// SetLeaf sets the value of the leaf referenced by the typed leaf path struct
+// p within the specified root, whose schema must also be supplied, to v. The
+// Go value v is assigned to the field of the GoStruct that holds the leaf.
+func SetLeaf[V any, S ygot.GoStruct](schema *yang.Entry, root ygot.GoStruct, p ygot.TypedLeafPathStruct[V, S], v V, opts ...SetNodeOpt) error {
+	if util.IsValueNil(v) {
+		return status.Errorf(codes.InvalidArgument, "cannot set path struct %T to a nil value", p)
+	}
+	path, err := resolvePathStruct(p)
+	if err != nil {
+		return err
+	}
+	nodes, err := retrieveNode(schema, root, path, nil, retrieveNodeArgs{
+		modifyRoot:       hasInitMissingElements(opts),
+		val:              v,
+		goVal:            true,
+		preferShadowPath: hasSetNodePreferShadowPath(opts),
+	})
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return status.Errorf(codes.NotFound, "unable to find any nodes for the given path %v", path)
+	}
+	return nil
+}
+
+// leafValue returns the value of type V that is stored in the field of a
+// GoStruct with value data, dereferencing it if it is a pointer. It returns
+// false if the field is not set.
+func leafValue[V any](data interface{}) (V, bool, error) {
+	var v V
+	if data == nil {
+		return v, false, nil
+	}
+	dv := reflect.ValueOf(data)
+	// The zero value of a leaf field within a GoStruct is its unset value.
+	if dv.IsZero() {
+		return v, false, nil
+	}
+	vt := reflect.TypeOf(&v).Elem()
+	switch {
+	case dv.Type().AssignableTo(vt):
+	case dv.Kind() == reflect.Ptr && dv.Type().Elem().AssignableTo(vt):
+		dv = dv.Elem()
+	default:
+		return v, false, fmt.Errorf("leaf of type %T cannot be returned as %v", data, vt)
+	}
+	reflect.ValueOf(&v).Elem().Set(dv)
+	return v, true, nil
+}
+
+// setLeafValue sets the field of a GoStruct with value fv to the leaf value
+// val, allocating a pointer to val if the field is a pointer. It is the
+// inverse of leafValue.
+func setLeafValue(fv reflect.Value, val interface{}) error {
+	vv := reflect.ValueOf(val)
+	switch {
+	case vv.Type().AssignableTo(fv.Type()):
+		fv.Set(vv)
+	case fv.Kind() == reflect.Ptr && vv.Type().AssignableTo(fv.Type().Elem()):
+		pv := reflect.New(fv.Type().Elem())
+		pv.Elem().Set(vv)
+		fv.Set(pv)
+	default:
+		return fmt.Errorf("value of type %T cannot be assigned to a field of type %v", val, fv.Type())
+	}
+	return nil
+}
+
+// PathStructKey describes a key of a list whose path struct is described by
+// a PathStructChild.
+type PathStructKey struct {
+	// Name is the name of the key in the schema.
+	Name string
+	// Zero is the zero value of the Go type of the key, or nil if the key
+	// is of a union type.
+	Zero interface{}
+	// UnionZeros are the zero values of the Go types of the members of the
+	// union type of the key, if the key is of a union type.
+	UnionZeros []interface{}
+	// ToUnion converts a value of one of the types of UnionZeros to the
+	// union type of the key, using the union conversion method of the
+	// GoStruct of the list. It is nil if the key is not of a union type.
+	ToUnion func(interface{}) (interface{}, error)
+}
+
+// PathStructChild describes a child of a generated path struct, and is used by
+// ParsePathStruct to construct the path struct of a gNMI path.
+type PathStructChild struct {
+	// Path is the schema path of the child relative to its parent.
+	Path []string
+	// Keys are the keys of the child if it is a list, in the order of the
+	// arguments of New.
+	Keys []PathStructKey
+	// New returns the path struct of the child with the specified key
+	// values. It is nil if the path struct of the child can only be
+	// obtained from NewWildcard.
+	New func(keys []interface{}) ygot.PathStruct
+	// NewWildcard returns the path struct of the child with wildcards for
+	// all of its keys. It is nil if the child is not a list, or if
+	// wildcard path structs are not generated.
+	NewWildcard func() ygot.PathStruct
+}
+
+// pathStructParent is the interface implemented by the generated path structs
+// of non-leaf schema nodes when ParsePath is generated.
+type pathStructParent interface {
+	// ΛChildren returns the descriptions of the children of the path struct.
+	ΛChildren() []*PathStructChild
+}
+
+// ParsePathStruct returns the path struct of the node at the specified path,
+// which is constructed from the supplied root path struct. The keys of the
+// path are converted to the Go types of the keys of the path structs, and
+// wildcard or unspecified keys result in the wildcard version of the path
+// struct of a list. A path ending with the multilevel wildcard "..." results
+// in the path struct that refers to all descendants of the preceding node.
+func ParsePathStruct(root ygot.PathStruct, path *gpb.Path) (ygot.PathStruct, error) {
+	n := root
+	for elems := path.GetElem(); len(elems) != 0; {
+		if elems[0].GetName() == "..." {
+			if len(elems) != 1 || len(elems[0].GetKey()) != 0 {
+				return nil, fmt.Errorf("path %v: the multilevel wildcard ... must be the last element of the path, and cannot have keys", path)
+			}
+			return ygot.NewDescendantsPath(n), nil
+		}
+		p, ok := n.(pathStructParent)
+		if !ok {
+			return nil, fmt.Errorf("path %v: path struct %T does not have any children", path, n)
+		}
+		var child *PathStructChild
+		for _, c := range p.ΛChildren() {
+			if pathElemsMatch(elems, c.Path) {
+				child = c
+				break
+			}
+		}
+		if child == nil {
+			return nil, fmt.Errorf("path %v: no child of path struct %T matches %v", path, n, elems[0])
+		}
+		elem := elems[len(child.Path)-1]
+		elems = elems[len(child.Path):]
+
+		var err error
+		if n, err = newChildPathStruct(child, elem.GetKey()); err != nil {
+			return nil, fmt.Errorf("path %v: %v", path, err)
+		}
+	}
+	return n, nil
+}
+
+// pathElemsMatch returns true if the names of the first elements of elems are
+// those of the relative schema path path. Only the last of the matched
+// elements may have keys.
+func pathElemsMatch(elems []*gpb.PathElem, path []string) bool {
+	if len(path) == 0 || len(path) > len(elems) {
+		return false
+	}
+	for i, name := range path {
+		if elems[i].GetName() != name || (i != len(path)-1 && len(elems[i].GetKey()) != 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// newChildPathStruct returns the path struct of the child described by c with
+// the specified keys, converting the values of the keys to their Go types.
+func newChildPathStruct(c *PathStructChild, keys map[string]string) (ygot.PathStruct, error) {
+	if len(c.Keys) == 0 {
+		if len(keys) != 0 {
+			return nil, fmt.Errorf("keys %v specified for a node that is not a keyed list", keys)
+		}
+		return c.New(nil), nil
+	}
+
+	vals := make([]interface{}, len(c.Keys))
+	wildcard := false
+	for i, k := range c.Keys {
+		s, ok := keys[k.Name]
+		if !ok || s == "*" {
+			wildcard = true
+			continue
+		}
+		v, err := pathStructKeyValue(k, s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for key %s: %v", k.Name, err)
+		}
+		vals[i] = v
+	}
+	for name := range keys {
+		known := false
+		for _, k := range c.Keys {
+			known = known || k.Name == name
+		}
+		if !known {
+			return nil, fmt.Errorf("%s is not a key of the list", name)
+		}
+	}
+
+	if !wildcard && c.New != nil {
+		return c.New(vals), nil
+	}
+	if c.NewWildcard == nil {
+		return nil, fmt.Errorf("keys %v require wildcard path structs, which are not generated", keys)
+	}
+	n := c.NewWildcard()
+	np, err := embeddedNodePath(n)
+	if err != nil {
+		return nil, err
+	}
+	for i, k := range c.Keys {
+		if vals[i] != nil {
+			ygot.ModifyKey(np, k.Name, vals[i])
+		}
+	}
+	return n, nil
+}
+
+// pathStructKeyValue converts the string value s of the key k to the Go type
+// of the key. The value of a key of a union type is converted to the first of
+// the member types of the union that it is a valid value of, trying
+// enumerated types first, as when a union is unmarshalled.
+func pathStructKeyValue(k PathStructKey, s string) (interface{}, error) {
+	if k.ToUnion == nil {
+		if k.Zero == nil {
+			return nil, fmt.Errorf("cannot convert value %q of key %s of an unknown type", s, k.Name)
+		}
+		v, err := StringToType(reflect.TypeOf(k.Zero), s)
+		if err != nil {
+			return nil, err
+		}
+		return v.Interface(), nil
+	}
+
+	var ets, others []reflect.Type
+	for _, z := range k.UnionZeros {
+		t := reflect.TypeOf(z)
+		if t.Implements(reflect.TypeOf((*ygot.GoEnum)(nil)).Elem()) {
+			ets = append(ets, t)
+		} else {
+			others = append(others, t)
+		}
+	}
+	ev, err := castToOneEnumValue(ets, s)
+	if err != nil {
+		return nil, err
+	}
+	if ev != nil {
+		return k.ToUnion(ev)
+	}
+	for _, t := range others {
+		var v reflect.Value
+		if t.Kind() == reflect.Float64 {
+			f, ferr := strconv.ParseFloat(s, 64)
+			v, err = reflect.ValueOf(f), ferr
+		} else {
+			v, err = StringToType(t, s)
+		}
+		if err == nil {
+			return k.ToUnion(v.Interface())
+		}
+	}
+	return nil, fmt.Errorf("%q does not match any type of the union", s)
+}
+
+// embeddedNodePath returns the ygot.NodePath embedded within the generated
+// path struct n.
+func embeddedNodePath(n ygot.PathStruct) (*ygot.NodePath, error) {
+	v := reflect.ValueOf(n)
+	if !util.IsValueStructPtr(v) {
+		return nil, fmt.Errorf("path struct %T is not a struct pointer", n)
+	}
+	f := v.Elem().FieldByName("NodePath")
+	if !f.IsValid() {
+		return nil, fmt.Errorf("path struct %T does not embed a ygot.NodePath", n)
+	}
+	np, ok := f.Interface().(*ygot.NodePath)
+	if !ok {
+		return nil, fmt.Errorf("path struct %T does not embed a ygot.NodePath", n)
+	}
+	return np, nil
+}
@@ -0,0 +1,530 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+type pathStructRoot struct {
+	Name *string                             `path:"name"`
+	List map[string]*pathStructRoot_ListElem `path:"list"`
+}
+
+func (*pathStructRoot) IsYANGGoStruct() {}
+
+type pathStructRoot_ListElem struct {
+	Key   *string `path:"key"`
+	Value *int32  `path:"value"`
+}
+
+func (*pathStructRoot_ListElem) IsYANGGoStruct() {}
+
+func (l *pathStructRoot_ListElem) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"key": *l.Key}, nil
+}
+
+func pathStructRootSchema() *yang.Entry {
+	s := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"name": {
+				Name: "name",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+			"list": {
+				Name:     "list",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Key:      "key",
+				Config:   yang.TSTrue,
+				Dir: map[string]*yang.Entry{
+					"key": {
+						Name: "key",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"value": {
+						Name: "value",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Yint32},
+					},
+				},
+			},
+		},
+	}
+	addParents(s)
+	return s
+}
+
+// namePath returns the path struct of the name leaf.
+func namePath() *ygot.LeafPath[string, *pathStructRoot] {
+	return ygot.NewLeafPath[string, *pathStructRoot]([]string{"name"}, nil, ygot.NewDeviceRootBase("dev"))
+}
+
+// listValuePath returns the path struct of the value leaf of the entry of the
+// list with the specified key.
+func listValuePath(key string) *ygot.LeafPath[int32, *pathStructRoot_ListElem] {
+	list := ygot.NewNodePath([]string{"list"}, map[string]interface{}{"key": key}, ygot.NewDeviceRootBase("dev"))
+	return ygot.NewLeafPath[int32, *pathStructRoot_ListElem]([]string{"value"}, nil, list)
+}
+
+func listPath(key string) *gpb.Path {
+	return &gpb.Path{Elem: []*gpb.PathElem{{Name: "list", Key: map[string]string{"key": key}}, {Name: "value"}}}
+}
+
+func TestGetLeaf(t *testing.T) {
+	root := &pathStructRoot{
+		Name: ygot.String("device-one"),
+		List: map[string]*pathStructRoot_ListElem{
+			"a": {Key: ygot.String("a"), Value: ygot.Int32(1)},
+			"b": {Key: ygot.String("b")},
+			"c": {Key: ygot.String("c"), Value: ygot.Int32(3)},
+		},
+	}
+
+	tests := []struct {
+		desc             string
+		inRoot           *pathStructRoot
+		inPath           ygot.TypedLeafPathStruct[int32, *pathStructRoot_ListElem]
+		want             []*TypedTreeNode[int32]
+		wantErrSubstring string
+	}{{
+		desc:   "leaf within list entry",
+		inRoot: root,
+		inPath: listValuePath("a"),
+		want:   []*TypedTreeNode[int32]{{Data: 1, Path: listPath("a")}},
+	}, {
+		desc:   "unset leaf",
+		inRoot: root,
+		inPath: listValuePath("b"),
+	}, {
+		desc:   "missing list entry",
+		inRoot: root,
+		inPath: listValuePath("d"),
+	}, {
+		desc:   "wildcard list entries",
+		inRoot: root,
+		inPath: listValuePath("*"),
+		want: []*TypedTreeNode[int32]{
+			{Data: 1, Path: listPath("a")},
+			{Data: 3, Path: listPath("c")},
+		},
+	}, {
+		desc:   "missing list",
+		inRoot: &pathStructRoot{},
+		inPath: listValuePath("a"),
+	}, {
+		desc:             "unresolvable path struct",
+		inRoot:           root,
+		inPath:           ygot.NewLeafPath[int32, *pathStructRoot_ListElem]([]string{"value"}, nil, ygot.NewNodePath([]string{"list"}, map[string]interface{}{"key": struct{}{}}, ygot.NewDeviceRootBase("dev"))),
+		wantErrSubstring: "cannot resolve path struct",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := GetLeaf(pathStructRootSchema(), tt.inRoot, tt.inPath)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("GetLeaf: %s", diff)
+			}
+			// Wildcard matches are returned in the order of the map iteration.
+			sort.Slice(got, func(i, j int) bool { return got[i].Path.String() < got[j].Path.String() })
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform(), cmpopts.IgnoreFields(TypedTreeNode[int32]{}, "Schema")); diff != "" {
+				t.Errorf("GetLeaf: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetGetOrCreateDeletePathStruct(t *testing.T) {
+	schema := pathStructRootSchema()
+	root := &pathStructRoot{}
+
+	if err := SetLeaf[string, *pathStructRoot](schema, root, namePath(), "device-one"); err != nil {
+		t.Fatalf("SetLeaf: unexpected error: %v", err)
+	}
+	if err := SetLeaf[int32, *pathStructRoot_ListElem](schema, root, listValuePath("a"), 42, &InitMissingElements{}); err != nil {
+		t.Fatalf("SetLeaf: unexpected error: %v", err)
+	}
+	want := &pathStructRoot{
+		Name: ygot.String("device-one"),
+		List: map[string]*pathStructRoot_ListElem{
+			"a": {Key: ygot.String("a"), Value: ygot.Int32(42)},
+		},
+	}
+	if diff := cmp.Diff(want, root); diff != "" {
+		t.Fatalf("SetLeaf: (-want, +got):\n%s", diff)
+	}
+
+	got, err := GetLeaf[string, *pathStructRoot](schema, root, namePath())
+	if err != nil {
+		t.Fatalf("GetLeaf: unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Data != "device-one" {
+		t.Errorf("GetLeaf: got %v, want a single node with value device-one", got)
+	}
+
+	list := ygot.NewNodePath([]string{"list"}, map[string]interface{}{"key": "b"}, ygot.NewDeviceRootBase("dev"))
+	node, _, err := GetOrCreatePathStruct(schema, root, list)
+	if err != nil {
+		t.Fatalf("GetOrCreatePathStruct: unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(&pathStructRoot_ListElem{Key: ygot.String("b")}, node); diff != "" {
+		t.Errorf("GetOrCreatePathStruct: (-want, +got):\n%s", diff)
+	}
+	elem, err := GetOrCreateTypedPathStruct[*pathStructRoot_ListElem](schema, root, list)
+	if err != nil {
+		t.Fatalf("GetOrCreateTypedPathStruct: unexpected error: %v", err)
+	}
+	if elem != node {
+		t.Errorf("GetOrCreateTypedPathStruct: got %p, want the list entry %p", elem, node)
+	}
+	_, err = GetOrCreateTypedPathStruct[*pathStructRoot](schema, root, list)
+	if diff := errdiff.Substring(err, "is of type *ytypes.pathStructRoot_ListElem, not *ytypes.pathStructRoot"); diff != "" {
+		t.Errorf("GetOrCreateTypedPathStruct: %s", diff)
+	}
+
+	if err := DeletePathStruct(schema, root, listValuePath("a")); err != nil {
+		t.Fatalf("DeletePathStruct: unexpected error: %v", err)
+	}
+	if err := DeletePathStruct(schema, root, namePath()); err != nil {
+		t.Fatalf("DeletePathStruct: unexpected error: %v", err)
+	}
+	want = &pathStructRoot{
+		List: map[string]*pathStructRoot_ListElem{
+			"a": {Key: ygot.String("a")},
+			"b": {Key: ygot.String("b")},
+		},
+	}
+	if diff := cmp.Diff(want, root); diff != "" {
+		t.Errorf("DeletePathStruct: (-want, +got):\n%s", diff)
+	}
+}
+
+// psRootPath, psListPath and psListPathAny are path structs of the schema of
+// pathStructRoot of the form generated when ParsePath is generated.
+type psRootPath struct {
+	*ygot.DeviceRootBase
+}
+
+type psListPath struct {
+	*ygot.NodePath
+}
+
+type psListPathAny struct {
+	*ygot.NodePath
+}
+
+func (n *psRootPath) ΛChildren() []*PathStructChild {
+	return []*PathStructChild{
+		{
+			Path: []string{"name"},
+			New: func(keys []interface{}) ygot.PathStruct {
+				return ygot.NewLeafPath[string, *pathStructRoot]([]string{"name"}, map[string]interface{}{}, n)
+			},
+		},
+		{
+			Path: []string{"list"},
+			Keys: []PathStructKey{
+				{Name: "key", Zero: *new(string)},
+			},
+			New: func(keys []interface{}) ygot.PathStruct {
+				return &psListPath{ygot.NewNodePath([]string{"list"}, map[string]interface{}{"key": keys[0].(string)}, n)}
+			},
+			NewWildcard: func() ygot.PathStruct {
+				return &psListPathAny{ygot.NewNodePath([]string{"list"}, map[string]interface{}{"key": "*"}, n)}
+			},
+		},
+		{
+			Path: []string{"union-list"},
+			Keys: []PathStructKey{
+				{Name: "key", UnionZeros: []interface{}{*new(int16), *new(float64), *new(EnumType)}, ToUnion: psUnionKey},
+			},
+			New: func(keys []interface{}) ygot.PathStruct {
+				return ygot.NewNodePath([]string{"union-list"}, map[string]interface{}{"key": keys[0]}, n)
+			},
+		},
+	}
+}
+
+// psUnionKey converts v to the union type of the key of the union-list list
+// described by psRootPath, as the union conversion method of a GoStruct does.
+func psUnionKey(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case int16:
+		return testutil.UnionInt16(v), nil
+	case float64:
+		return testutil.UnionFloat64(v), nil
+	case EnumType:
+		return v, nil
+	}
+	return nil, fmt.Errorf("cannot convert %v of type %T to the union", v, v)
+}
+
+// ΛChildren of psListPath describes the value leaf as if it were the state
+// leaf of a compressed schema, whose config leaf is shadowed.
+func (n *psListPath) ΛChildren() []*PathStructChild {
+	return []*PathStructChild{
+		{
+			Path: []string{"state", "value"},
+			New: func(keys []interface{}) ygot.PathStruct {
+				return ygot.NewLeafPath[int32, *pathStructRoot_ListElem]([]string{"state", "value"}, map[string]interface{}{}, n)
+			},
+		},
+		{
+			Path: []string{"config", "value"},
+			New: func(keys []interface{}) ygot.PathStruct {
+				return ygot.NewLeafPath[int32, *pathStructRoot_ListElem]([]string{"config", "value"}, map[string]interface{}{}, n)
+			},
+		},
+	}
+}
+
+func TestParsePathStruct(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inPath           *gpb.Path
+		wantType         ygot.PathStruct
+		wantPath         *gpb.Path
+		wantErrSubstring string
+	}{{
+		desc:     "root",
+		inPath:   &gpb.Path{Target: "dev"},
+		wantType: &psRootPath{},
+		wantPath: &gpb.Path{Target: "dev"},
+	}, {
+		desc:     "leaf",
+		inPath:   &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "name"}}},
+		wantType: &ygot.LeafPath[string, *pathStructRoot]{},
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "name"}}},
+	}, {
+		desc:     "list with key",
+		inPath:   &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "list", Key: map[string]string{"key": "a"}}}},
+		wantType: &psListPath{},
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "list", Key: map[string]string{"key": "a"}}}},
+	}, {
+		desc:     "list with wildcard key",
+		inPath:   &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "list", Key: map[string]string{"key": "*"}}}},
+		wantType: &psListPathAny{},
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "list", Key: map[string]string{"key": "*"}}}},
+	}, {
+		desc:     "list with unspecified key",
+		inPath:   &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "list"}}},
+		wantType: &psListPathAny{},
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "list", Key: map[string]string{"key": "*"}}}},
+	}, {
+		desc:     "state leaf",
+		inPath:   &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "list", Key: map[string]string{"key": "a"}}, {Name: "state"}, {Name: "value"}}},
+		wantType: &ygot.LeafPath[int32, *pathStructRoot_ListElem]{},
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "list", Key: map[string]string{"key": "a"}}, {Name: "state"}, {Name: "value"}}},
+	}, {
+		desc:     "shadowed config leaf",
+		inPath:   &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "list", Key: map[string]string{"key": "a"}}, {Name: "config"}, {Name: "value"}}},
+		wantType: &ygot.LeafPath[int32, *pathStructRoot_ListElem]{},
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "list", Key: map[string]string{"key": "a"}}, {Name: "config"}, {Name: "value"}}},
+	}, {
+		desc:     "descendants of root",
+		inPath:   &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "..."}}},
+		wantType: &ygot.NodePath{},
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "..."}}},
+	}, {
+		desc:     "descendants of list",
+		inPath:   &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "list"}, {Name: "..."}}},
+		wantType: &ygot.NodePath{},
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "list", Key: map[string]string{"key": "*"}}, {Name: "..."}}},
+	}, {
+		desc:     "union key of enumerated type",
+		inPath:   &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "union-list", Key: map[string]string{"key": "E_VALUE_FORTY_TWO"}}}},
+		wantType: &ygot.NodePath{},
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "union-list", Key: map[string]string{"key": "E_VALUE_FORTY_TWO"}}}},
+	}, {
+		desc:     "union key of integer type",
+		inPath:   &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "union-list", Key: map[string]string{"key": "-42"}}}},
+		wantType: &ygot.NodePath{},
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "union-list", Key: map[string]string{"key": "-42"}}}},
+	}, {
+		desc:     "union key of decimal type",
+		inPath:   &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "union-list", Key: map[string]string{"key": "10.5"}}}},
+		wantType: &ygot.NodePath{},
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "union-list", Key: map[string]string{"key": "10.5"}}}},
+	}, {
+		desc:             "union key not matching any type",
+		inPath:           &gpb.Path{Elem: []*gpb.PathElem{{Name: "union-list", Key: map[string]string{"key": "forty-two"}}}},
+		wantErrSubstring: "does not match any type of the union",
+	}, {
+		desc:             "multilevel wildcard followed by elements",
+		inPath:           &gpb.Path{Elem: []*gpb.PathElem{{Name: "..."}, {Name: "name"}}},
+		wantErrSubstring: "must be the last element",
+	}, {
+		desc:             "unknown key",
+		inPath:           &gpb.Path{Elem: []*gpb.PathElem{{Name: "list", Key: map[string]string{"key": "a", "other": "b"}}}},
+		wantErrSubstring: "other is not a key of the list",
+	}, {
+		desc:             "keys for a node that is not a list",
+		inPath:           &gpb.Path{Elem: []*gpb.PathElem{{Name: "name", Key: map[string]string{"key": "a"}}}},
+		wantErrSubstring: "not a keyed list",
+	}, {
+		desc:             "unknown child",
+		inPath:           &gpb.Path{Elem: []*gpb.PathElem{{Name: "unknown"}}},
+		wantErrSubstring: "no child of path struct",
+	}, {
+		desc:             "child of leaf",
+		inPath:           &gpb.Path{Elem: []*gpb.PathElem{{Name: "name"}, {Name: "child"}}},
+		wantErrSubstring: "does not have any children",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParsePathStruct(&psRootPath{ygot.NewDeviceRootBase(tt.inPath.GetTarget())}, tt.inPath)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ParsePathStruct: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if gotT, wantT := reflect.TypeOf(got), reflect.TypeOf(tt.wantType); gotT != wantT {
+				t.Errorf("ParsePathStruct: got path struct of type %v, want %v", gotT, wantT)
+			}
+			gotPath, _, errs := ygot.ResolvePath(got)
+			if errs != nil {
+				t.Fatalf("ResolvePath: unexpected errors: %v", errs)
+			}
+			if diff := cmp.Diff(tt.wantPath, gotPath, protocmp.Transform()); diff != "" {
+				t.Errorf("ParsePathStruct: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolvePathWithSchema(t *testing.T) {
+	schema := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"vlan": {
+				Name:     "vlan",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Key:      "id",
+				Dir: map[string]*yang.Entry{
+					"id": {
+						Name: "id",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Yuint16, Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(4094)}}},
+					},
+				},
+			},
+			"interface": {
+				Name:     "interface",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Key:      "name",
+				Dir: map[string]*yang.Entry{
+					"name": {
+						Name: "name",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring, Pattern: []string{"eth[0-9]+"}},
+					},
+					"mtu": {
+						Name: "mtu",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Yuint16},
+					},
+				},
+			},
+		},
+	}
+	addParents(schema)
+
+	root := ygot.NewDeviceRootBase("dev")
+	vlan := func(id interface{}) ygot.PathStruct {
+		return ygot.NewNodePath([]string{"vlan"}, map[string]interface{}{"id": id}, root)
+	}
+	mtu := func(name interface{}) ygot.PathStruct {
+		intf := ygot.NewNodePath([]string{"interface"}, map[string]interface{}{"name": name}, root)
+		return ygot.NewNodePath([]string{"mtu"}, nil, intf)
+	}
+
+	tests := []struct {
+		desc             string
+		inPath           ygot.PathStruct
+		wantPath         *gpb.Path
+		wantErrSubstring string
+	}{{
+		desc:     "valid integer key",
+		inPath:   vlan(uint16(100)),
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "vlan", Key: map[string]string{"id": "100"}}}},
+	}, {
+		desc:     "multilevel wildcard after valid key",
+		inPath:   ygot.NewDescendantsPath(vlan(uint16(100))),
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "vlan", Key: map[string]string{"id": "100"}}, {Name: "..."}}},
+	}, {
+		desc:             "integer key out of range",
+		inPath:           vlan(uint16(4095)),
+		wantErrSubstring: `invalid value "4095" for key id`,
+	}, {
+		desc:     "wildcard key",
+		inPath:   vlan("*"),
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "vlan", Key: map[string]string{"id": "*"}}}},
+	}, {
+		desc:     "valid string key below the list",
+		inPath:   mtu("eth0"),
+		wantPath: &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "interface", Key: map[string]string{"name": "eth0"}}, {Name: "mtu"}}},
+	}, {
+		desc:             "string key not matching pattern",
+		inPath:           mtu("Ethernet0"),
+		wantErrSubstring: `invalid value "Ethernet0" for key name`,
+	}, {
+		desc:             "key of the wrong type",
+		inPath:           vlan("one"),
+		wantErrSubstring: `"one" is not a valid uint16`,
+	}, {
+		desc:             "path not in schema",
+		inPath:           ygot.NewNodePath([]string{"unknown"}, nil, root),
+		wantErrSubstring: "does not have a child unknown",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, _, errs := ResolvePathWithSchema(schema, tt.inPath)
+			var err error
+			if errs != nil {
+				err = util.Errors(errs)
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ResolvePathWithSchema: %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantPath, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ResolvePathWithSchema: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"time"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// DefaultCounterSampleInterval is the sample interval that is used for the
+// subscriptions to nodes that cannot be subscribed to in ON_CHANGE mode, when
+// no sample interval is specified. It can be overridden using the
+// CounterSampleInterval option.
+const DefaultCounterSampleInterval = 10 * time.Second
+
+// CounterSampleInterval is a ygot.SubscriptionOpt that specifies the sample
+// interval used by NewSubscriptionWithSchema and
+// NewSubscriptionListWithSchema for the subscriptions to nodes that cannot be
+// subscribed to in ON_CHANGE mode, when no sample interval is specified. It is
+// ignored by ygot.NewSubscription and ygot.NewSubscriptionList.
+type CounterSampleInterval struct {
+	Interval time.Duration
+}
+
+// IsSubscriptionOpt marks CounterSampleInterval as a ygot.SubscriptionOpt.
+func (*CounterSampleInterval) IsSubscriptionOpt() {}
+
+// counterTypeNames are the names of the YANG types of leaves that hold
+// counters, which change too often to be subscribed to in ON_CHANGE mode.
+var counterTypeNames = map[string]bool{
+	"counter32":            true,
+	"counter64":            true,
+	"zero-based-counter32": true,
+	"zero-based-counter64": true,
+}
+
+// NewSubscriptionWithSchema returns a gNMI Subscription to the path referred
+// to by the path struct p, as ygot.NewSubscription does. In addition, the
+// list keys within the path are validated as ResolvePathWithSchema does, and
+// a TARGET_DEFINED subscription to a node that cannot be subscribed to in
+// ON_CHANGE mode, such as a counter, is changed to a SAMPLE subscription with
+// DefaultCounterSampleInterval, or the interval of the CounterSampleInterval
+// option, unless a sample interval is specified. An ON_CHANGE subscription to
+// such a node is rejected with an InvalidArgument error. schema must be the
+// schema of the root of the path structs.
+func NewSubscriptionWithSchema(schema *yang.Entry, p ygot.PathStruct, opts ...ygot.SubscriptionOpt) (*gpb.Subscription, error) {
+	sub, err := ygot.NewSubscription(p, opts...)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := adjustSubscription(schema, p, sub, opts); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// NewSubscriptionListWithSchema returns a gNMI SubscriptionList subscribing
+// to the paths referred to by the path structs ps, as
+// ygot.NewSubscriptionList does. Each of the Subscriptions is validated and
+// adjusted as by NewSubscriptionWithSchema.
+func NewSubscriptionListWithSchema(schema *yang.Entry, ps []ygot.PathStruct, opts ...ygot.SubscriptionOpt) (*gpb.SubscriptionList, error) {
+	sl, err := ygot.NewSubscriptionList(ps, opts...)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	for i, sub := range sl.Subscription {
+		if err := adjustSubscription(schema, ps[i], sub, opts); err != nil {
+			return nil, err
+		}
+	}
+	return sl, nil
+}
+
+// adjustSubscription validates the list keys within the path of sub, which
+// refers to the path struct p, and changes sub to a SAMPLE subscription if it
+// is a TARGET_DEFINED subscription to a node that cannot be subscribed to in
+// ON_CHANGE mode. An ON_CHANGE subscription to such a node is an error. schema
+// is the schema of the root of the path of sub, and opts are the options sub
+// was created with.
+func adjustSubscription(schema *yang.Entry, p ygot.PathStruct, sub *gpb.Subscription, opts []ygot.SubscriptionOpt) error {
+	e, errs := validatePathKeys(schema, sub.GetPath())
+	if errs != nil {
+		return status.Errorf(codes.InvalidArgument, "cannot resolve path struct %T: %v", p, errs)
+	}
+	// The nodes matched by a multilevel wildcard are unknown, so the mode
+	// requested is used.
+	if e == nil || onChangeCapable(e) {
+		return nil
+	}
+	switch sub.Mode {
+	case gpb.SubscriptionMode_ON_CHANGE:
+		return status.Errorf(codes.InvalidArgument, "path struct %T refers to %s, which cannot be subscribed to in ON_CHANGE mode", p, e.Path())
+	case gpb.SubscriptionMode_TARGET_DEFINED:
+	default:
+		return nil
+	}
+	sub.Mode = gpb.SubscriptionMode_SAMPLE
+	if sub.SampleInterval == 0 {
+		interval := DefaultCounterSampleInterval
+		for _, o := range opts {
+			if o, ok := o.(*CounterSampleInterval); ok {
+				interval = o.Interval
+			}
+		}
+		sub.SampleInterval = uint64(interval.Nanoseconds())
+	}
+	return nil
+}
+
+// onChangeCapable reports whether the node with schema e can be subscribed
+// to in ON_CHANGE mode. Read-only counter leaves, whose types are, or are
+// derived from, counter types, and read-only containers named counters, along
+// with their descendants, cannot.
+func onChangeCapable(e *yang.Entry) bool {
+	if util.IsConfig(e) {
+		return true
+	}
+	if (e.IsLeaf() || e.IsLeafList()) && isCounterType(e.Type) {
+		return false
+	}
+	for ; e != nil; e = e.Parent {
+		if e.IsContainer() && e.Name == "counters" {
+			return false
+		}
+	}
+	return true
+}
+
+// isCounterType reports whether t is a counter type, or a type derived from a
+// counter type through a chain of typedefs.
+func isCounterType(t *yang.YangType) bool {
+	for t != nil {
+		if counterTypeNames[t.Name] {
+			return true
+		}
+		if t.Base == nil || t.Base.YangType == t {
+			return false
+		}
+		t = t.Base.YangType
+	}
+	return false
+}
@@ -0,0 +1,241 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestNewSubscriptionWithSchema(t *testing.T) {
+	schema := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"interface": {
+				Name:     "interface",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Key:      "name",
+				Dir: map[string]*yang.Entry{
+					"name": {
+						Name: "name",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring, Pattern: []string{"eth[0-9]+"}},
+					},
+					"oper-status": {
+						Name:   "oper-status",
+						Kind:   yang.LeafEntry,
+						Config: yang.TSFalse,
+						Type:   &yang.YangType{Kind: yang.Ystring},
+					},
+					"in-octets": {
+						Name:   "in-octets",
+						Kind:   yang.LeafEntry,
+						Config: yang.TSFalse,
+						Type:   &yang.YangType{Name: "counter64", Kind: yang.Yuint64},
+					},
+					"in-pkts": {
+						Name:   "in-pkts",
+						Kind:   yang.LeafEntry,
+						Config: yang.TSFalse,
+						Type: &yang.YangType{
+							Name: "pkt-counter",
+							Kind: yang.Yuint64,
+							Base: &yang.Type{
+								Name:     "yang:counter64",
+								YangType: &yang.YangType{Name: "counter64", Kind: yang.Yuint64},
+							},
+						},
+					},
+					"counters": {
+						Name:   "counters",
+						Kind:   yang.DirectoryEntry,
+						Config: yang.TSFalse,
+						Dir: map[string]*yang.Entry{
+							"in-errors": {
+								Name: "in-errors",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yuint64},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(schema)
+
+	root := ygot.NewDeviceRootBase("dev")
+	intfPath := func(name string, rel ...string) ygot.PathStruct {
+		intf := ygot.NewNodePath([]string{"interface"}, map[string]interface{}{"name": name}, root)
+		return ygot.NewNodePath(rel, nil, intf)
+	}
+	wantPath := func(rel ...string) *gpb.Path {
+		p := &gpb.Path{Target: "dev", Elem: []*gpb.PathElem{{Name: "interface", Key: map[string]string{"name": "eth0"}}}}
+		for _, r := range rel {
+			p.Elem = append(p.Elem, &gpb.PathElem{Name: r})
+		}
+		return p
+	}
+	onChange := &ygot.SubscriptionMode{Mode: gpb.SubscriptionMode_ON_CHANGE}
+
+	tests := []struct {
+		desc             string
+		inPath           ygot.PathStruct
+		inOpts           []ygot.SubscriptionOpt
+		want             *gpb.Subscription
+		wantErrSubstring string
+	}{{
+		desc:   "on change state leaf",
+		inPath: intfPath("eth0", "oper-status"),
+		inOpts: []ygot.SubscriptionOpt{onChange},
+		want:   &gpb.Subscription{Path: wantPath("oper-status"), Mode: gpb.SubscriptionMode_ON_CHANGE},
+	}, {
+		desc:             "on change counter leaf",
+		inPath:           intfPath("eth0", "in-octets"),
+		inOpts:           []ygot.SubscriptionOpt{onChange},
+		wantErrSubstring: "cannot be subscribed to in ON_CHANGE mode",
+	}, {
+		desc:             "on change leaf within counters container",
+		inPath:           intfPath("eth0", "counters", "in-errors"),
+		inOpts:           []ygot.SubscriptionOpt{onChange},
+		wantErrSubstring: "cannot be subscribed to in ON_CHANGE mode",
+	}, {
+		desc:   "target defined leaf within counters container with heartbeat",
+		inPath: intfPath("eth0", "counters", "in-errors"),
+		inOpts: []ygot.SubscriptionOpt{&ygot.HeartbeatInterval{Interval: time.Minute}},
+		want: &gpb.Subscription{
+			Path:              wantPath("counters", "in-errors"),
+			Mode:              gpb.SubscriptionMode_SAMPLE,
+			SampleInterval:    uint64(DefaultCounterSampleInterval),
+			HeartbeatInterval: uint64(time.Minute),
+		},
+	}, {
+		desc:   "sample counter leaf",
+		inPath: intfPath("eth0", "in-octets"),
+		inOpts: []ygot.SubscriptionOpt{&ygot.SubscriptionMode{Mode: gpb.SubscriptionMode_SAMPLE}, &ygot.SampleInterval{Interval: time.Second}},
+		want: &gpb.Subscription{
+			Path:           wantPath("in-octets"),
+			Mode:           gpb.SubscriptionMode_SAMPLE,
+			SampleInterval: uint64(time.Second),
+		},
+	}, {
+		desc:   "target defined counter leaf with counter sample interval",
+		inPath: intfPath("eth0", "in-octets"),
+		inOpts: []ygot.SubscriptionOpt{&CounterSampleInterval{Interval: time.Minute}},
+		want: &gpb.Subscription{
+			Path:           wantPath("in-octets"),
+			Mode:           gpb.SubscriptionMode_SAMPLE,
+			SampleInterval: uint64(time.Minute),
+		},
+	}, {
+		desc:   "target defined state leaf",
+		inPath: intfPath("eth0", "oper-status"),
+		want:   &gpb.Subscription{Path: wantPath("oper-status"), Mode: gpb.SubscriptionMode_TARGET_DEFINED},
+	}, {
+		desc:   "target defined counter leaf",
+		inPath: intfPath("eth0", "in-octets"),
+		want: &gpb.Subscription{
+			Path:           wantPath("in-octets"),
+			Mode:           gpb.SubscriptionMode_SAMPLE,
+			SampleInterval: uint64(DefaultCounterSampleInterval),
+		},
+	}, {
+		desc:   "target defined leaf of type derived from counter",
+		inPath: intfPath("eth0", "in-pkts"),
+		want: &gpb.Subscription{
+			Path:           wantPath("in-pkts"),
+			Mode:           gpb.SubscriptionMode_SAMPLE,
+			SampleInterval: uint64(DefaultCounterSampleInterval),
+		},
+	}, {
+		desc:             "on change leaf of type derived from counter",
+		inPath:           intfPath("eth0", "in-pkts"),
+		inOpts:           []ygot.SubscriptionOpt{onChange},
+		wantErrSubstring: "cannot be subscribed to in ON_CHANGE mode",
+	}, {
+		desc:   "target defined counter leaf with sample interval",
+		inPath: intfPath("eth0", "in-octets"),
+		inOpts: []ygot.SubscriptionOpt{&ygot.SampleInterval{Interval: time.Second}},
+		want: &gpb.Subscription{
+			Path:           wantPath("in-octets"),
+			Mode:           gpb.SubscriptionMode_SAMPLE,
+			SampleInterval: uint64(time.Second),
+		},
+	}, {
+		desc:   "on change descendants of counters container",
+		inPath: intfPath("eth0", "counters", "..."),
+		inOpts: []ygot.SubscriptionOpt{onChange},
+		want:   &gpb.Subscription{Path: wantPath("counters", "..."), Mode: gpb.SubscriptionMode_ON_CHANGE},
+	}, {
+		desc:             "path not in schema",
+		inPath:           intfPath("eth0", "unknown"),
+		wantErrSubstring: "does not have a child unknown",
+	}, {
+		desc:             "invalid key",
+		inPath:           intfPath("Ethernet0", "oper-status"),
+		inOpts:           []ygot.SubscriptionOpt{onChange},
+		wantErrSubstring: `invalid value "Ethernet0" for key name`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := NewSubscriptionWithSchema(schema, tt.inPath, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("NewSubscriptionWithSchema returned diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("subscription list", func(t *testing.T) {
+		got, err := NewSubscriptionListWithSchema(schema, []ygot.PathStruct{intfPath("eth0", "oper-status"), intfPath("eth0", "in-octets")}, &CounterSampleInterval{Interval: time.Minute})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &gpb.SubscriptionList{
+			Prefix: &gpb.Path{Target: "dev"},
+			Subscription: []*gpb.Subscription{{
+				Path: &gpb.Path{Elem: wantPath("oper-status").Elem},
+				Mode: gpb.SubscriptionMode_TARGET_DEFINED,
+			}, {
+				Path:           &gpb.Path{Elem: wantPath("in-octets").Elem},
+				Mode:           gpb.SubscriptionMode_SAMPLE,
+				SampleInterval: uint64(time.Minute),
+			}},
+		}
+		if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("NewSubscriptionListWithSchema returned diff (-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("on change subscription list with counter", func(t *testing.T) {
+		_, err := NewSubscriptionListWithSchema(schema, []ygot.PathStruct{intfPath("eth0", "oper-status"), intfPath("eth0", "in-octets")}, onChange)
+		if diff := errdiff.Substring(err, "cannot be subscribed to in ON_CHANGE mode"); diff != "" {
+			t.Errorf("did not get expected error, %s", diff)
+		}
+	})
+}
@@ -78,7 +78,7 @@ func enumAndNonEnumTypesForUnion(schema *yang.Entry, parentT reflect.Type) ([]re
 // is indicated with the boolean return value.
 func getLoneUnionType(schema *yang.Entry, unionT reflect.Type, ets []reflect.Type, sks []yang.TypeKind) (yang.TypeKind, bool, error) {
 	// Single type union -- GoStruct field is that type rather than a union Interface type.
-	if !util.IsTypeInterface(unionT) && !util.IsTypeSliceOfInterface(unionT) {
+	if !util.IsTypeInterface(unionT) && !util.IsTypeSliceOfInterface(unionT) && !isTypeGenericUnionField(unionT) {
 		// Is not an interface, we must have exactly one type in the union.
 		var yk yang.TypeKind
 		var isEnum bool
@@ -97,6 +97,15 @@ func getLoneUnionType(schema *yang.Entry, unionT reflect.Type, ets []reflect.Typ
 	return yang.Ynone, false, nil
 }
 
+// isTypeGenericUnionField reports whether t is the type of a field that
+// stores a generic union, i.e., a union pointer or a slice of unions.
+func isTypeGenericUnionField(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return util.IsTypeGenericUnion(t)
+}
+
 // castToOneEnumValue loops through the given enum types in order in converting
 // the string value, and returns upon success. If the string value can't be
 // casted to any, nil is returned (without error).